@@ -1,34 +1,125 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"inventario-api/docs"
 	"inventario-api/internal/database"
 	"inventario-api/internal/handlers"
 	"inventario-api/internal/middleware"
 	"inventario-api/internal/repository"
 	"inventario-api/internal/service"
+	"inventario-api/internal/storage"
 )
 
+// @title API de Inventário - Go + Gin
+// @version 1.0.0
+// @description API REST para gerenciamento de inventário/estoque
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Token financeiro (INVENTARIO_TOKEN_FINANCEIRO) que libera custos e margens nas respostas
 func main() {
 	// Inicializa banco de dados em memória
 	db := database.NewInMemoryDatabase()
-	
-	// Inicializa repository
+
+	// Inicializa o armazenamento local de imagens e documentos
+	uploadDir := os.Getenv("INVENTARIO_UPLOAD_DIR")
+	if uploadDir == "" {
+		uploadDir = "uploads"
+	}
+	files, err := storage.NewLocalStorage(uploadDir)
+	if err != nil {
+		log.Fatal("Falha ao preparar armazenamento de arquivos:", err)
+	}
+	log.Printf("🖼️  Anexos gravados em %s", files.Root())
+
+	// Carrega a tabela de frete configurada ou a padrão
+	rateTable, err := service.LoadRateTable(os.Getenv("INVENTARIO_TABELA_FRETE"))
+	if err != nil {
+		log.Fatal("Falha ao carregar tabela de frete:", err)
+	}
+
+	// Carrega a tabela de tributos configurada ou a padrão
+	taxTable, err := service.LoadTaxTable(os.Getenv("INVENTARIO_TABELA_TRIBUTOS"))
+	if err != nil {
+		log.Fatal("Falha ao carregar tabela de tributos:", err)
+	}
+
+	// Carrega a tabela NCM completa, se configurada
+	if err := service.LoadNCMTable(os.Getenv("INVENTARIO_TABELA_NCM")); err != nil {
+		log.Fatal("Falha ao carregar tabela NCM:", err)
+	}
+
+	// Inicializa repositories
 	repo := repository.NewInMemoryProductRepository(db)
-	
-	// Inicializa service
-	productService := service.NewProductService(repo)
-	
-	// Inicializa handler
+	reservationRepo := repository.NewInMemoryReservationRepository(db)
+	lotRepo := repository.NewInMemoryLotRepository(db)
+	serialRepo := repository.NewInMemorySerialRepository(db)
+	priceRepo := repository.NewInMemoryPriceRepository(db)
+	promotionRepo := repository.NewInMemoryPromotionRepository(db)
+	supplierRepo := repository.NewInMemorySupplierRepository(db)
+	purchaseOrderRepo := repository.NewInMemoryPurchaseOrderRepository(db)
+	salesOrderRepo := repository.NewInMemorySalesOrderRepository(db)
+	returnRepo := repository.NewInMemoryReturnRepository(db)
+	inventoryCountRepo := repository.NewInMemoryInventoryCountRepository(db)
+	attachmentRepo := repository.NewInMemoryAttachmentRepository(db)
+	invoiceImportRepo := repository.NewInMemoryInvoiceImportRepository(db)
+
+	// Inicializa services
+	productService := service.NewProductService(repo, files)
+	reservationService := service.NewReservationService(reservationRepo)
+	lotService := service.NewLotService(lotRepo, repo)
+	serialService := service.NewSerialService(serialRepo, repo)
+	priceService := service.NewPriceService(priceRepo, repo)
+	promotionService := service.NewPromotionService(promotionRepo)
+	supplierService := service.NewSupplierService(supplierRepo)
+	purchaseOrderService := service.NewPurchaseOrderService(purchaseOrderRepo, supplierRepo, repo)
+	salesOrderService := service.NewSalesOrderService(salesOrderRepo)
+	returnService := service.NewReturnService(returnRepo)
+	inventoryCountService := service.NewInventoryCountService(inventoryCountRepo)
+	invoiceImportService := service.NewInvoiceImportService(invoiceImportRepo)
+	attachmentService := service.NewAttachmentService(attachmentRepo, repo, files)
+	shippingService := service.NewShippingService(repo, rateTable)
+	fiscalService := service.NewFiscalService(repo, taxTable)
+
+	// Expira reservas vencidas em segundo plano
+	reservationService.StartExpirationSweeper(context.Background(), 30*time.Second)
+
+	// Aplica preços agendados que entraram em vigor
+	priceService.StartScheduler(context.Background(), 10*time.Second)
+
+	// Inicializa handlers
 	productHandler := handlers.NewProductHandler(productService)
-	
+	productV2Handler := handlers.NewProductV2Handler(productService)
+	reservationHandler := handlers.NewReservationHandler(reservationService)
+	lotHandler := handlers.NewLotHandler(lotService)
+	serialHandler := handlers.NewSerialHandler(serialService)
+	priceHandler := handlers.NewPriceHandler(priceService)
+	promotionHandler := handlers.NewPromotionHandler(promotionService)
+	supplierHandler := handlers.NewSupplierHandler(supplierService)
+	purchaseOrderHandler := handlers.NewPurchaseOrderHandler(purchaseOrderService)
+	salesOrderHandler := handlers.NewSalesOrderHandler(salesOrderService)
+	returnHandler := handlers.NewReturnHandler(returnService)
+	inventoryCountHandler := handlers.NewInventoryCountHandler(inventoryCountService)
+	invoiceImportHandler := handlers.NewInvoiceImportHandler(invoiceImportService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+	shippingHandler := handlers.NewShippingHandler(shippingService)
+	fiscalHandler := handlers.NewFiscalHandler(fiscalService)
+	systemHandler, err := handlers.NewSystemHandler(docs.OpenAPI)
+	if err != nil {
+		log.Fatal("Falha ao carregar documentação da API:", err)
+	}
+
 	// Configura Gin
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
-	
+
 	// Middlewares globais
 	router.Use(middleware.Logger())
 	router.Use(middleware.Recovery())
@@ -36,19 +127,37 @@ func main() {
 	router.Use(middleware.Security())
 	router.Use(middleware.RequestID())
 	router.Use(middleware.RateLimiter())
-	
+
+	// Custos e margens só são exibidos para quem apresenta o token financeiro
+	tokenFinanceiro := os.Getenv("INVENTARIO_TOKEN_FINANCEIRO")
+	if tokenFinanceiro == "" {
+		log.Println("⚠️  INVENTARIO_TOKEN_FINANCEIRO não definido: custos e margens ficam ocultos")
+	}
+	router.Use(middleware.FinancialAccess(tokenFinanceiro))
+
+	// A versão vem do caminho ou do Accept; a v1 anuncia a descontinuação
+	// quando as datas estão configuradas
+	versions := middleware.NewVersionRouter(router)
+	deprecacaoV1, err := middleware.ParseVersionDate(os.Getenv("INVENTARIO_V1_DEPRECACAO"))
+	if err != nil {
+		log.Fatal("INVENTARIO_V1_DEPRECACAO inválida:", err)
+	}
+	sunsetV1, err := middleware.ParseVersionDate(os.Getenv("INVENTARIO_V1_SUNSET"))
+	if err != nil {
+		log.Fatal("INVENTARIO_V1_SUNSET inválida:", err)
+	}
+	if !deprecacaoV1.IsZero() || !sunsetV1.IsZero() {
+		log.Println("⚠️  API v1 marcada como descontinuada: respostas com Deprecation/Sunset")
+	}
+
 	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":    "OK",
-			"service":   "Inventário API",
-			"version":   "1.0.0",
-			"timestamp": "2023-01-15T10:30:00Z",
-		})
-	})
-	
+	router.GET("/health", systemHandler.HealthCheck)
+
+	// Arquivos enviados (imagens, miniaturas e documentos)
+	router.Static(service.AttachmentURLPrefix, files.Root())
+
 	// API routes
-	api := router.Group("/api")
+	api := router.Group("/api", versions.Deprecation(deprecacaoV1, sunsetV1))
 	{
 		produtos := api.Group("/produtos")
 		{
@@ -57,54 +166,202 @@ func main() {
 			produtos.GET("", productHandler.GetAllProducts)
 			produtos.GET("/:id", productHandler.GetProduct)
 			produtos.PUT("/:id", productHandler.UpdateProduct)
+			produtos.PATCH("/:id", productHandler.PatchProduct)
+			produtos.POST("/lote", productHandler.BulkProducts)
 			produtos.DELETE("/:id", productHandler.DeleteProduct)
-			
+
 			// Endpoints especializados
 			produtos.GET("/filtros", productHandler.GetProductsFiltered)
 			produtos.GET("/categoria/:categoria", productHandler.GetProductsByCategory)
 			produtos.GET("/ativos", productHandler.GetActiveProducts)
 			produtos.GET("/estoque", productHandler.GetInStockProducts)
 			produtos.PATCH("/:id/estoque", productHandler.UpdateStock)
+			produtos.PATCH("/:id/estoque/ajuste", productHandler.AdjustStock)
+			produtos.POST("/estoque/ajustes", productHandler.AdjustStockBatch)
 			produtos.GET("/estatisticas", productHandler.GetStatistics)
+			produtos.GET("/reposicao", productHandler.GetReorderList)
+			produtos.GET("/reposicao/alertas", productHandler.GetStockAlerts)
+
+			// Custos e valoração (acesso financeiro)
+			produtos.GET("/valoracao", middleware.RequireFinancialAccess(), productHandler.GetInventoryValuation)
+			produtos.GET("/:id/custos", middleware.RequireFinancialAccess(), productHandler.GetCostLayers)
+
+			// Preços: histórico, agendamento e reajuste
+			produtos.GET("/:id/precos", priceHandler.GetPriceHistory)
+			produtos.POST("/:id/precos/agendamentos", priceHandler.SchedulePrice)
+			produtos.DELETE("/:id/precos/agendamentos/:agendamento", priceHandler.CancelScheduledPrice)
+			produtos.POST("/precos/reajuste/simulacao", priceHandler.PreviewPriceAdjustment)
+			produtos.POST("/precos/reajuste", priceHandler.ApplyPriceAdjustment)
+
+			// Lotes e validade
+			produtos.POST("/:id/lotes", lotHandler.ReceiveLot)
+			produtos.GET("/:id/lotes", lotHandler.GetProductLots)
+			produtos.GET("/lotes/vencendo", lotHandler.GetExpiringLots)
+
+			// Kits
+			produtos.GET("/:id/componentes", productHandler.GetKitComposition)
+			produtos.PUT("/:id/componentes", productHandler.SetKitComponents)
+			produtos.GET("/:id/status", productHandler.GetProductStatus)
+			produtos.POST("/:id/status", productHandler.ChangeProductStatus)
+
+			// Tags
+			produtos.GET("/tags", productHandler.GetTagCloud)
+			produtos.POST("/tags/lote", productHandler.UpdateTagsBatch)
+			produtos.POST("/:id/tags", productHandler.AddProductTags)
+			produtos.DELETE("/:id/tags/:tag", productHandler.RemoveProductTag)
+
+			// Traduções
+			produtos.GET("/:id/traducoes", productHandler.GetProductTranslations)
+			produtos.PUT("/:id/traducoes/:idioma", productHandler.SetProductTranslation)
+			produtos.DELETE("/:id/traducoes/:idioma", productHandler.DeleteProductTranslation)
+
+			// Produtos relacionados
+			produtos.POST("/:id/relacionados", productHandler.CreateProductRelation)
+			produtos.GET("/:id/relacionados", productHandler.GetProductRelations)
+			produtos.DELETE("/:id/relacionados/:relacionado", productHandler.DeleteProductRelation)
+			produtos.GET("/:id/alternativas", productHandler.GetProductAlternatives)
+
+			// Números de série
+			produtos.GET("/:id/seriais", serialHandler.GetProductSerials)
+			produtos.POST("/:id/seriais/entrada", serialHandler.ReceiveSerials)
+			produtos.POST("/:id/seriais/saida", serialHandler.ShipSerials)
+
+			// Fornecedores do produto
+			produtos.GET("/:id/fornecedores", supplierHandler.GetProductSuppliers)
+
+			// Imagens e documentos
+			produtos.POST("/:id/anexos", attachmentHandler.UploadAttachment)
+			produtos.GET("/:id/anexos", attachmentHandler.GetProductAttachments)
+			produtos.DELETE("/:id/anexos/:anexo", attachmentHandler.DeleteAttachment)
+		}
+
+		api.GET("/seriais/:numero", serialHandler.GetSerial)
+
+		frete := api.Group("/frete")
+		{
+			frete.POST("/cotacao", shippingHandler.QuoteShipping)
+			frete.GET("/tabela", shippingHandler.GetRateTable)
+		}
+
+		tributos := api.Group("/tributos")
+		{
+			tributos.POST("/simulacao", fiscalHandler.SimulateTaxes)
+			tributos.GET("/ncm", fiscalHandler.SearchNCM)
+			tributos.GET("/tabela", fiscalHandler.GetTaxTable)
+		}
+
+		fornecedores := api.Group("/fornecedores")
+		{
+			fornecedores.POST("", supplierHandler.CreateSupplier)
+			fornecedores.GET("", supplierHandler.ListSuppliers)
+			fornecedores.GET("/:id", supplierHandler.GetSupplier)
+			fornecedores.PUT("/:id", supplierHandler.UpdateSupplier)
+			fornecedores.DELETE("/:id", supplierHandler.DeleteSupplier)
+			fornecedores.GET("/:id/produtos", supplierHandler.GetSupplierProducts)
+			fornecedores.PUT("/:id/produtos/:produto", supplierHandler.LinkProduct)
+			fornecedores.DELETE("/:id/produtos/:produto", supplierHandler.UnlinkProduct)
+		}
+
+		pedidosCompra := api.Group("/pedidos-compra")
+		{
+			pedidosCompra.POST("", purchaseOrderHandler.CreatePurchaseOrder)
+			pedidosCompra.GET("", purchaseOrderHandler.ListPurchaseOrders)
+			pedidosCompra.GET("/:id", purchaseOrderHandler.GetPurchaseOrder)
+			pedidosCompra.PUT("/:id", purchaseOrderHandler.UpdatePurchaseOrder)
+			pedidosCompra.POST("/:id/enviar", purchaseOrderHandler.SendPurchaseOrder)
+			pedidosCompra.POST("/:id/cancelar", purchaseOrderHandler.CancelPurchaseOrder)
+			pedidosCompra.POST("/:id/recebimentos", purchaseOrderHandler.ReceivePurchaseOrder)
+		}
+
+		notasFiscais := api.Group("/notas-fiscais")
+		{
+			notasFiscais.POST("/importacoes", invoiceImportHandler.ImportNFe)
+			notasFiscais.GET("/importacoes", invoiceImportHandler.ListInvoiceImports)
+			notasFiscais.GET("/importacoes/:id", invoiceImportHandler.GetInvoiceImport)
+			notasFiscais.PUT("/importacoes/:id/itens/:item", invoiceImportHandler.MapInvoiceLine)
+			notasFiscais.POST("/importacoes/:id/confirmar", invoiceImportHandler.ConfirmInvoiceImport)
+			notasFiscais.POST("/importacoes/:id/descartar", invoiceImportHandler.DiscardInvoiceImport)
+		}
+
+		pedidosVenda := api.Group("/pedidos-venda")
+		{
+			pedidosVenda.POST("", salesOrderHandler.CreateSalesOrder)
+			pedidosVenda.GET("", salesOrderHandler.ListSalesOrders)
+			pedidosVenda.GET("/:id", salesOrderHandler.GetSalesOrder)
+			pedidosVenda.POST("/:id/cancelar", salesOrderHandler.CancelSalesOrder)
+		}
+
+		devolucoes := api.Group("/devolucoes")
+		{
+			devolucoes.POST("", returnHandler.CreateReturn)
+			devolucoes.GET("", returnHandler.ListReturns)
+			devolucoes.GET("/relatorio", returnHandler.GetReturnReport)
+			devolucoes.GET("/:id", returnHandler.GetReturn)
+			devolucoes.POST("/:id/inspecao", returnHandler.InspectReturn)
+		}
+
+		inventarios := api.Group("/inventarios")
+		{
+			inventarios.POST("", inventoryCountHandler.CreateInventoryCount)
+			inventarios.GET("", inventoryCountHandler.ListInventoryCounts)
+			inventarios.GET("/:id", inventoryCountHandler.GetInventoryCount)
+			inventarios.POST("/:id/contagens", inventoryCountHandler.RecordCounts)
+			inventarios.POST("/:id/encerrar", inventoryCountHandler.CloseInventoryCount)
+			inventarios.POST("/:id/reabrir", inventoryCountHandler.ReopenInventoryCount)
+			inventarios.GET("/:id/divergencias", inventoryCountHandler.GetVarianceReport)
+			inventarios.POST("/:id/postar", inventoryCountHandler.PostInventoryCount)
+			inventarios.POST("/:id/cancelar", inventoryCountHandler.CancelInventoryCount)
+		}
+
+		promocoes := api.Group("/promocoes")
+		{
+			promocoes.POST("", promotionHandler.CreatePromotion)
+			promocoes.GET("", promotionHandler.ListPromotions)
+			promocoes.POST("/simulacao", promotionHandler.SimulatePromotion)
+			promocoes.GET("/:id", promotionHandler.GetPromotion)
+			promocoes.PUT("/:id", promotionHandler.UpdatePromotion)
+			promocoes.DELETE("/:id", promotionHandler.DeletePromotion)
+		}
+
+		reservas := api.Group("/reservas")
+		{
+			reservas.POST("", reservationHandler.CreateReservation)
+			reservas.GET("", reservationHandler.ListReservations)
+			reservas.GET("/:id", reservationHandler.GetReservation)
+			reservas.POST("/:id/confirmar", reservationHandler.ConfirmReservation)
+			reservas.POST("/:id/liberar", reservationHandler.ReleaseReservation)
 		}
 	}
-	
-	// Endpoint para documentação da API
-	router.GET("/", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"message":     "API de Inventário - Go + Gin",
-			"version":     "1.0.0",
-			"description": "API REST para gerenciamento de inventário/estoque",
-			"endpoints": gin.H{
-				"health":              "GET /health",
-				"produtos":            "GET /api/produtos",
-				"criar_produto":       "POST /api/produtos",
-				"buscar_produto":      "GET /api/produtos/{id}",
-				"atualizar_produto":   "PUT /api/produtos/{id}",
-				"deletar_produto":     "DELETE /api/produtos/{id}",
-				"filtrar_produtos":    "GET /api/produtos/filtros",
-				"produtos_categoria":  "GET /api/produtos/categoria/{categoria}",
-				"produtos_ativos":     "GET /api/produtos/ativos",
-				"produtos_estoque":    "GET /api/produtos/estoque",
-				"atualizar_estoque":   "PATCH /api/produtos/{id}/estoque",
-				"estatisticas":        "GET /api/produtos/estatisticas",
-			},
-			"categories": []string{
-				"eletronicos", "roupas", "casa", "livros", 
-				"esportes", "beleza", "brinquedos", 
-				"automotivo", "alimentos", "outros",
-			},
-		})
-	})
-	
+
+	// API v2: envelope de resposta próprio sobre os mesmos services
+	v2 := router.Group("/api/v2")
+	{
+		produtosV2 := v2.Group("/produtos")
+		{
+			produtosV2.GET("", productV2Handler.ListProducts)
+			produtosV2.POST("", productV2Handler.CreateProduct)
+			produtosV2.GET("/:id", productV2Handler.GetProduct)
+			produtosV2.PUT("/:id", productV2Handler.UpdateProduct)
+			produtosV2.PATCH("/:id", productV2Handler.PatchProduct)
+			produtosV2.DELETE("/:id", productV2Handler.DeleteProduct)
+		}
+	}
+
+	// Página inicial, gerada a partir da especificação OpenAPI
+	router.GET("/", systemHandler.GetIndex)
+
+	// Documentação da API
+	router.GET("/openapi.json", systemHandler.GetOpenAPISpec)
+	router.GET("/docs/*arquivo", systemHandler.SwaggerUI)
+
 	// Inicia o servidor
 	port := ":8000"
 	log.Printf("🚀 Servidor iniciado na porta %s", port)
-	log.Printf("📖 Documentação da API: http://localhost%s", port)
+	log.Printf("📖 Documentação da API: http://localhost%s/docs/", port)
 	log.Printf("🏥 Health Check: http://localhost%s/health", port)
-	log.Printf("📦 Endpoint de Produtos: http://localhost%s/api/produtos", port)
-	
-	if err := router.Run(port); err != nil {
+	log.Printf("📦 Endpoint de Produtos: http://localhost%s/api/produtos (v2: /api/v2/produtos)", port)
+
+	if err := http.ListenAndServe(port, versions); err != nil {
 		log.Fatal("Falha ao iniciar servidor:", err)
 	}
-}
\ No newline at end of file
+}
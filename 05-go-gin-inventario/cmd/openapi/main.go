@@ -0,0 +1,259 @@
+// Comando openapi gera a especificação OpenAPI 3 da API a partir das
+// anotações no estilo swag dos handlers (@Summary, @Param, @Success,
+// @Router...) e dos tipos dos DTOs e modelos.
+//
+// Antes de gravar, o gerador confere as rotas registradas em cmd/api com as
+// operações anotadas e falha se alguma rota não estiver documentada, se
+// alguma anotação não tiver rota ou se método e caminho divergirem.
+//
+// Uso:
+//
+//	go run ./cmd/openapi          # grava docs/openapi.json
+//	go run ./cmd/openapi -check   # falha se docs/openapi.json estiver desatualizado
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// generator monta a especificação e acumula os problemas encontrados
+type generator struct {
+	mod     *module
+	schemas map[string]*Schema
+	errors  []string
+}
+
+func main() {
+	out := flag.String("out", "docs/openapi.json", "arquivo da especificação, relativo à raiz do módulo")
+	check := flag.Bool("check", false, "apenas confere se o arquivo está atualizado e se as rotas batem com as anotações")
+	flag.Parse()
+
+	mod, err := loadModule(".")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "openapi:", err)
+		os.Exit(1)
+	}
+
+	spec, operations, err := generate(mod)
+	if err != nil {
+		var found problems
+		if errors.As(err, &found) {
+			for _, msg := range found {
+				fmt.Fprintln(os.Stderr, msg)
+			}
+		}
+		fmt.Fprintln(os.Stderr, "openapi:", err)
+		os.Exit(1)
+	}
+
+	path := filepath.Join(mod.root, filepath.FromSlash(*out))
+	if *check {
+		current, err := os.ReadFile(path)
+		if err != nil || !bytes.Equal(current, spec) {
+			fmt.Fprintf(os.Stderr, "openapi: %s desatualizado; execute go generate ./docs\n", *out)
+			os.Exit(1)
+		}
+		fmt.Printf("openapi: %s em dia (%d operações)\n", *out, operations)
+		return
+	}
+
+	if err := os.WriteFile(path, spec, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "openapi:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("openapi: %d operações gravadas em %s\n", operations, *out)
+}
+
+// problems reúne as divergências entre rotas e anotações
+type problems []string
+
+// Error implementa a interface error
+func (p problems) Error() string {
+	return fmt.Sprintf("%d problema(s) entre rotas e anotações", len(p))
+}
+
+// generate monta a especificação do módulo e retorna o JSON no formato
+// gravado em docs/openapi.json, com a quantidade de operações
+func generate(mod *module) ([]byte, int, error) {
+	g := &generator{mod: mod, schemas: make(map[string]*Schema)}
+	doc := g.build()
+	if len(g.errors) > 0 {
+		sort.Strings(g.errors)
+		return nil, 0, problems(g.errors)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, 0, err
+	}
+	return buf.Bytes(), countOperations(doc), nil
+}
+
+// build lê as anotações, confere as rotas e monta o documento
+func (g *generator) build() *Document {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			Schemas: g.schemas,
+		},
+	}
+
+	serverPkg := g.mod.path + "/cmd/api"
+	g.parseGeneralInfo(serverPkg, doc)
+
+	handlerPkgs, err := g.mod.subpackages("internal")
+	if err != nil {
+		g.errors = append(g.errors, err.Error())
+		return doc
+	}
+	operations := g.collectOperations(handlerPkgs)
+	g.matchRoutes(g.collectRoutes(serverPkg), operations)
+
+	operationIDs := make(map[string]handlerRef)
+	usedTags := make(map[string]bool)
+	for _, annotated := range operations {
+		item := doc.Paths[annotated.path]
+		if item == nil {
+			item = &PathItem{}
+			doc.Paths[annotated.path] = item
+		}
+		slot := item.operation(annotated.method)
+		switch {
+		case slot == nil:
+			g.errorf(annotated.pos, "%s: método %s não suportado", annotated.handler, annotated.method)
+			continue
+		case *slot != nil:
+			g.errorf(annotated.pos, "%s documentado por mais de um handler", formatRoute(annotated.method, annotated.path))
+			continue
+		}
+		*slot = annotated.operation
+
+		id := annotated.operation.OperationID
+		if other, exists := operationIDs[id]; exists {
+			g.errorf(annotated.pos, "operationId %s repetido em %s e %s", id, other, annotated.handler)
+		}
+		operationIDs[id] = annotated.handler
+		for _, tag := range annotated.operation.Tags {
+			usedTags[tag] = true
+		}
+		for _, requirement := range annotated.operation.Security {
+			for name := range requirement {
+				if doc.Components.SecuritySchemes[name] == nil {
+					g.errorf(annotated.pos, "%s usa o esquema de segurança %s, que não foi declarado", annotated.handler, name)
+				}
+			}
+		}
+	}
+
+	// Tags declaradas no pacote do servidor vêm primeiro, na ordem declarada
+	declared := make(map[string]bool, len(doc.Tags))
+	for _, tag := range doc.Tags {
+		declared[tag.Name] = true
+	}
+	for _, tag := range sortedKeys(usedTags) {
+		if !declared[tag] {
+			doc.Tags = append(doc.Tags, Tag{Name: tag})
+		}
+	}
+
+	return doc
+}
+
+// parseGeneralInfo lê as anotações gerais (@title, @version, @description,
+// @tag.name, @securityDefinitions.apikey) do comentário da função main
+func (g *generator) parseGeneralInfo(pkgPath string, doc *Document) {
+	pkg, err := g.mod.load(pkgPath)
+	if err != nil {
+		g.errors = append(g.errors, err.Error())
+		return
+	}
+
+	var security *SecurityScheme
+	for _, file := range pkg.files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Name.Name != "main" || fn.Recv != nil || fn.Doc == nil {
+				continue
+			}
+			for _, comment := range fn.Doc.List {
+				line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+				keyword, value, _ := strings.Cut(line, " ")
+				value = strings.TrimSpace(value)
+				switch strings.ToLower(keyword) {
+				case "@title":
+					doc.Info.Title = value
+				case "@version":
+					doc.Info.Version = value
+				case "@description":
+					if security != nil {
+						security.Description = value
+					} else {
+						doc.Info.Description = strings.TrimSpace(doc.Info.Description + "\n" + value)
+					}
+				case "@tag.name":
+					security = nil
+					doc.Tags = append(doc.Tags, Tag{Name: value})
+				case "@tag.description":
+					if n := len(doc.Tags); n > 0 {
+						doc.Tags[n-1].Description = value
+					}
+				case "@server":
+					doc.Servers = append(doc.Servers, Server{URL: value})
+				case "@securitydefinitions.apikey":
+					security = &SecurityScheme{Type: "apiKey"}
+					if doc.Components.SecuritySchemes == nil {
+						doc.Components.SecuritySchemes = make(map[string]*SecurityScheme)
+					}
+					doc.Components.SecuritySchemes[value] = security
+				case "@in":
+					if security != nil {
+						security.In = value
+					}
+				case "@name":
+					if security != nil {
+						security.Name = value
+					}
+				}
+			}
+		}
+	}
+
+	if doc.Info.Title == "" || doc.Info.Version == "" {
+		g.errors = append(g.errors, fmt.Sprintf("%s: a função main precisa das anotações @title e @version", pkgPath))
+	}
+}
+
+// errorf registra um problema com a posição relativa à raiz do módulo
+func (g *generator) errorf(pos token.Pos, format string, args ...interface{}) {
+	position := g.mod.fset.Position(pos)
+	if rel, err := filepath.Rel(g.mod.root, position.Filename); err == nil {
+		position.Filename = filepath.ToSlash(rel)
+	}
+	g.errors = append(g.errors, fmt.Sprintf("%s:%d: %s", position.Filename, position.Line, fmt.Sprintf(format, args...)))
+}
+
+func countOperations(doc *Document) int {
+	total := 0
+	for _, item := range doc.Paths {
+		for _, method := range []string{"GET", "PUT", "POST", "DELETE", "PATCH"} {
+			if *item.operation(method) != nil {
+				total++
+			}
+		}
+	}
+	return total
+}
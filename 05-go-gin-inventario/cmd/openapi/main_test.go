@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"inventario-api/docs"
+)
+
+// TestSpecUpToDate falha quando docs/openapi.json não corresponde às
+// anotações e rotas atuais
+func TestSpecUpToDate(t *testing.T) {
+	mod, err := loadModule(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec, _, err := generate(mod)
+	if err != nil {
+		var found problems
+		if errors.As(err, &found) {
+			for _, msg := range found {
+				t.Log(msg)
+			}
+		}
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(spec, docs.OpenAPI) {
+		t.Fatal("docs/openapi.json desatualizado; execute go generate ./docs")
+	}
+}
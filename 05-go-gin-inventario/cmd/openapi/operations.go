@@ -0,0 +1,514 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// handlerRef identifica a função registrada em uma rota
+type handlerRef struct {
+	pkg  string
+	recv string
+	name string
+}
+
+// String retorna o nome qualificado da função, como handlers.ProductHandler.CreateProduct
+func (h handlerRef) String() string {
+	name := h.pkg[strings.LastIndex(h.pkg, "/")+1:]
+	if h.recv != "" {
+		name += "." + h.recv
+	}
+	return name + "." + h.name
+}
+
+// annotatedOperation é uma operação documentada no comentário de um handler
+type annotatedOperation struct {
+	handler   handlerRef
+	method    string
+	path      string
+	operation *Operation
+	pos       token.Pos
+}
+
+var (
+	paramAttribute = regexp.MustCompile(`(\w+)\(([^)]*)\)`)
+	pathParameter  = regexp.MustCompile(`\{([^}]+)\}`)
+)
+
+// mimeAliases traduz os apelidos de @Accept e @Produce usados pelo swag
+var mimeAliases = map[string]string{
+	"json":                  "application/json",
+	"xml":                   "application/xml",
+	"plain":                 "text/plain",
+	"html":                  "text/html",
+	"mpfd":                  "multipart/form-data",
+	"x-www-form-urlencoded": "application/x-www-form-urlencoded",
+	"octet-stream":          "application/octet-stream",
+	"json-patch":            "application/json-patch+json",
+	"merge-patch":           "application/merge-patch+json",
+}
+
+// statusDescriptions descreve as respostas sem texto na anotação
+var statusDescriptions = map[int]string{
+	http.StatusOK:                    "Sucesso",
+	http.StatusCreated:               "Criado",
+	http.StatusAccepted:              "Aceito",
+	http.StatusNoContent:             "Sem conteúdo",
+	http.StatusMultiStatus:           "Resultado por item",
+	http.StatusBadRequest:            "Requisição inválida",
+	http.StatusUnauthorized:          "Não autenticado",
+	http.StatusForbidden:             "Acesso negado",
+	http.StatusNotFound:              "Não encontrado",
+	http.StatusNotAcceptable:         "Formato não suportado",
+	http.StatusConflict:              "Conflito com o estado atual",
+	http.StatusPreconditionFailed:    "Pré-condição falhou",
+	http.StatusRequestEntityTooLarge: "Conteúdo grande demais",
+	http.StatusUnsupportedMediaType:  "Tipo de conteúdo não suportado",
+	http.StatusUnprocessableEntity:   "Dados inválidos",
+	http.StatusTooManyRequests:       "Limite de requisições excedido",
+	http.StatusInternalServerError:   "Erro interno",
+}
+
+// collectOperations lê as anotações @Router das funções dos pacotes
+func (g *generator) collectOperations(pkgPaths []string) []*annotatedOperation {
+	var operations []*annotatedOperation
+	for _, path := range pkgPaths {
+		pkg, err := g.mod.load(path)
+		if err != nil {
+			g.errors = append(g.errors, err.Error())
+			continue
+		}
+		for _, file := range pkg.files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Doc == nil || !strings.Contains(fn.Doc.Text(), "@Router") {
+					continue
+				}
+				if op := g.parseOperation(fn, pkg, file); op != nil {
+					operations = append(operations, op)
+				}
+			}
+		}
+	}
+	return operations
+}
+
+// parseOperation interpreta as anotações no estilo swag do comentário da função
+func (g *generator) parseOperation(fn *ast.FuncDecl, pkg *sourcePackage, file *ast.File) *annotatedOperation {
+	ref := handlerRef{pkg: pkg.path, name: fn.Name.Name}
+	if fn.Recv != nil && len(fn.Recv.List) == 1 {
+		ref.recv = embeddedName(fn.Recv.List[0].Type)
+	}
+
+	annotated := &annotatedOperation{
+		handler: ref,
+		pos:     fn.Pos(),
+		operation: &Operation{
+			OperationID: lowerFirst(fn.Name.Name),
+			Responses:   make(map[string]*Response),
+		},
+	}
+	op := annotated.operation
+
+	var accept, produce []string
+	var descriptions []string
+	bodies := make(map[string]*Schema)
+	var bodyDescription string
+	var bodyRequired bool
+	form := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	responses := make(map[int][]*Schema)
+	responseTexts := make(map[int]string)
+	headers := make(map[string]map[string]*Header)
+
+	for _, comment := range fn.Doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		keyword, rest, _ := strings.Cut(line, " ")
+		rest = strings.TrimSpace(rest)
+
+		switch strings.ToLower(keyword) {
+		case "@summary":
+			op.Summary = rest
+		case "@description":
+			descriptions = append(descriptions, rest)
+		case "@tags":
+			for _, tag := range strings.Split(rest, ",") {
+				op.Tags = append(op.Tags, strings.TrimSpace(tag))
+			}
+		case "@id":
+			op.OperationID = rest
+		case "@accept":
+			accept = append(accept, g.mimeTypes(rest, comment.Pos())...)
+		case "@produce":
+			produce = append(produce, g.mimeTypes(rest, comment.Pos())...)
+		case "@security":
+			op.Security = append(op.Security, map[string][]string{rest: {}})
+		case "@deprecated":
+			op.Deprecated = true
+		case "@router":
+			path, method, ok := strings.Cut(rest, " ")
+			method = strings.Trim(strings.TrimSpace(method), "[]")
+			if !ok || !strings.HasPrefix(path, "/") || method == "" {
+				g.errorf(comment.Pos(), "@Router inválido: %q", rest)
+				return nil
+			}
+			annotated.path = path
+			annotated.method = strings.ToUpper(method)
+		case "@param":
+			param, in, schema, required, ok := g.parseParam(rest, pkg, file, comment.Pos())
+			if !ok {
+				continue
+			}
+			switch in {
+			case "body":
+				bodies[param.mediaType] = schema
+				if bodyDescription == "" {
+					bodyDescription = param.Description
+				}
+				bodyRequired = bodyRequired || required
+			case "formData":
+				schema.Description = param.Description
+				form.Properties[param.Name] = schema
+				if required {
+					form.Required = append(form.Required, param.Name)
+				}
+			default:
+				op.Parameters = append(op.Parameters, param)
+			}
+		case "@success", "@failure":
+			code, schema, text, ok := g.parseResponse(rest, pkg, file, comment.Pos())
+			if !ok {
+				continue
+			}
+			if schema != nil {
+				responses[code] = append(responses[code], schema)
+			} else if _, exists := responses[code]; !exists {
+				responses[code] = nil
+			}
+			if text != "" {
+				responseTexts[code] = text
+			}
+		case "@header":
+			code, name, header, ok := g.parseHeader(rest, pkg, file, comment.Pos())
+			if !ok {
+				continue
+			}
+			if headers[code] == nil {
+				headers[code] = make(map[string]*Header)
+			}
+			headers[code][name] = header
+		default:
+			g.errorf(comment.Pos(), "anotação desconhecida: %s", keyword)
+		}
+	}
+
+	if annotated.path == "" {
+		return nil
+	}
+	if op.Summary == "" {
+		g.errorf(fn.Pos(), "%s sem @Summary", ref)
+	}
+	if len(responses) == 0 {
+		g.errorf(fn.Pos(), "%s sem @Success", ref)
+	}
+	op.Description = strings.Join(descriptions, "\n")
+	if len(produce) == 0 {
+		produce = []string{"application/json"}
+	}
+
+	for mime := range bodies {
+		if mime != "" && !contains(accept, mime) {
+			g.errorf(fn.Pos(), "%s: corpo para %s, que não está em @Accept", ref, mime)
+		}
+	}
+	op.RequestBody = requestBody(accept, bodies, bodyDescription, bodyRequired, form)
+
+	for code, schemas := range responses {
+		response := &Response{Description: responseTexts[code]}
+		if response.Description == "" {
+			response.Description = statusDescriptions[code]
+		}
+		if response.Description == "" {
+			response.Description = http.StatusText(code)
+		}
+		if len(schemas) > 0 {
+			schema := schemas[0]
+			if len(schemas) > 1 {
+				schema = &Schema{OneOf: schemas}
+			}
+			response.Content = make(map[string]*MediaType, len(produce))
+			for _, mime := range produce {
+				response.Content[mime] = &MediaType{Schema: schema}
+			}
+		}
+		op.Responses[strconv.Itoa(code)] = response
+	}
+	for code, codeHeaders := range headers {
+		for _, status := range sortedKeys(op.Responses) {
+			if code == "all" || code == status {
+				if op.Responses[status].Headers == nil {
+					op.Responses[status].Headers = make(map[string]*Header)
+				}
+				for name, header := range codeHeaders {
+					op.Responses[status].Headers[name] = header
+				}
+			}
+		}
+	}
+
+	g.checkPathParameters(annotated)
+	return annotated
+}
+
+// parseParam interpreta "nome local tipo obrigatório "descrição" atributos(...)"
+func (g *generator) parseParam(text string, pkg *sourcePackage, file *ast.File, pos token.Pos) (*Parameter, string, *Schema, bool, bool) {
+	head, description, attributes, ok := splitQuoted(text)
+	fields := strings.Fields(head)
+	if !ok || len(fields) != 4 {
+		g.errorf(pos, "@Param inválido: %q", text)
+		return nil, "", nil, false, false
+	}
+	name, in, typeName := fields[0], fields[1], fields[2]
+	required, err := strconv.ParseBool(fields[3])
+	if err != nil {
+		g.errorf(pos, "@Param %s: obrigatório deve ser true ou false", name)
+		return nil, "", nil, false, false
+	}
+
+	var schema *Schema
+	switch in {
+	case "path", "query", "header", "formData":
+		schema = paramSchema(typeName)
+		if schema == nil {
+			schema = g.typeSchema(typeName, pkg, file, pos)
+		}
+	case "body":
+		schema = g.typeSchema(typeName, pkg, file, pos)
+	default:
+		g.errorf(pos, "@Param %s: local %q desconhecido", name, in)
+		return nil, "", nil, false, false
+	}
+
+	var mediaType string
+	base := g.baseType(schema)
+	for _, match := range paramAttribute.FindAllStringSubmatch(attributes, -1) {
+		value := strings.TrimSpace(match[2])
+		switch strings.ToLower(match[1]) {
+		case "enums":
+			for _, option := range strings.Split(value, ",") {
+				schema.Enum = append(schema.Enum, typedValue(base, strings.TrimSpace(option)))
+			}
+		case "default":
+			schema.Default = typedValue(base, value)
+		case "example":
+			schema.Example = typedValue(base, value)
+		case "format":
+			schema.Format = value
+		case "minimum":
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				schema.Minimum = &number
+			}
+		case "maximum":
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				schema.Maximum = &number
+			}
+		case "mime":
+			if in != "body" {
+				g.errorf(pos, "@Param %s: mime só vale para o corpo", name)
+			} else if mimes := g.mimeTypes(value, pos); len(mimes) == 1 {
+				mediaType = mimes[0]
+			}
+		default:
+			g.errorf(pos, "@Param %s: atributo %s desconhecido", name, match[1])
+		}
+	}
+
+	param := &Parameter{
+		Name:        name,
+		In:          in,
+		Description: description,
+		Required:    required || in == "path",
+		Schema:      schema,
+		mediaType:   mediaType,
+	}
+	return param, in, schema, required, true
+}
+
+// parseResponse interpreta "código {object} tipo "descrição"" ou "código "descrição""
+func (g *generator) parseResponse(text string, pkg *sourcePackage, file *ast.File, pos token.Pos) (int, *Schema, string, bool) {
+	head, description, _, _ := splitQuoted(text)
+	fields := strings.Fields(head)
+	if len(fields) == 0 {
+		g.errorf(pos, "resposta inválida: %q", text)
+		return 0, nil, "", false
+	}
+	code, err := strconv.Atoi(fields[0])
+	if err != nil {
+		g.errorf(pos, "código de resposta inválido: %q", fields[0])
+		return 0, nil, "", false
+	}
+
+	switch len(fields) {
+	case 1:
+		return code, nil, description, true
+	case 3:
+		schema := g.typeSchema(fields[2], pkg, file, pos)
+		switch fields[1] {
+		case "{object}":
+			return code, schema, description, true
+		case "{array}":
+			return code, &Schema{Type: "array", Items: schema}, description, true
+		}
+	}
+	g.errorf(pos, "resposta inválida: %q", text)
+	return 0, nil, "", false
+}
+
+// parseHeader interpreta "código {tipo} Nome "descrição"", em que o código pode ser "all"
+func (g *generator) parseHeader(text string, pkg *sourcePackage, file *ast.File, pos token.Pos) (string, string, *Header, bool) {
+	head, description, _, _ := splitQuoted(text)
+	fields := strings.Fields(head)
+	if len(fields) != 3 || !strings.HasPrefix(fields[1], "{") {
+		g.errorf(pos, "@Header inválido: %q", text)
+		return "", "", nil, false
+	}
+	typeName := strings.Trim(fields[1], "{}")
+	schema := paramSchema(typeName)
+	if schema == nil {
+		schema = g.typeSchema(typeName, pkg, file, pos)
+	}
+	return fields[0], fields[2], &Header{Description: description, Schema: schema}, true
+}
+
+// checkPathParameters confere os parâmetros {nome} do caminho com os @Param path
+func (g *generator) checkPathParameters(annotated *annotatedOperation) {
+	declared := make(map[string]bool)
+	for _, param := range annotated.operation.Parameters {
+		if param.In == "path" {
+			declared[param.Name] = true
+		}
+	}
+	for _, match := range pathParameter.FindAllStringSubmatch(annotated.path, -1) {
+		if !declared[match[1]] {
+			g.errorf(annotated.pos, "%s: parâmetro {%s} de %s sem @Param path", annotated.handler, match[1], annotated.path)
+		}
+		delete(declared, match[1])
+	}
+	for name := range declared {
+		g.errorf(annotated.pos, "%s: @Param path %s não aparece em %s", annotated.handler, name, annotated.path)
+	}
+}
+
+// mimeTypes traduz a lista de @Accept ou @Produce
+func (g *generator) mimeTypes(text string, pos token.Pos) []string {
+	var mimes []string
+	for _, value := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		if mime, ok := mimeAliases[value]; ok {
+			mimes = append(mimes, mime)
+		} else if strings.Contains(value, "/") {
+			mimes = append(mimes, value)
+		} else {
+			g.errorf(pos, "tipo de conteúdo desconhecido: %s", value)
+		}
+	}
+	return mimes
+}
+
+// requestBody monta o corpo a partir dos @Param body, dos campos formData e
+// dos tipos aceitos. Um @Param body com mime(...) vale só para aquele tipo,
+// como os formatos de patch; os demais valem para todos. Tipos que não são
+// JSON nem formulário, como o XML da NF-e, recebem o conteúdo bruto.
+func requestBody(accept []string, bodies map[string]*Schema, description string, required bool, form *Schema) *RequestBody {
+	request := &RequestBody{Description: description, Required: required, Content: make(map[string]*MediaType)}
+	for _, mime := range accept {
+		switch {
+		case mime == "multipart/form-data" || mime == "application/x-www-form-urlencoded":
+			if len(form.Properties) > 0 {
+				request.Content[mime] = &MediaType{Schema: form}
+				request.Required = request.Required || len(form.Required) > 0
+			}
+		case bodies[mime] != nil:
+			request.Content[mime] = &MediaType{Schema: bodies[mime]}
+		case bodies[""] != nil:
+			request.Content[mime] = &MediaType{Schema: bodies[""]}
+		case !strings.Contains(mime, "json"):
+			request.Content[mime] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+			request.Required = true
+		}
+	}
+	if len(request.Content) == 0 {
+		return nil
+	}
+	return request
+}
+
+// paramSchema representa os tipos simples aceitos em parâmetros
+func paramSchema(typeName string) *Schema {
+	switch typeName {
+	case "string":
+		return &Schema{Type: "string"}
+	case "int", "integer":
+		return &Schema{Type: "integer"}
+	case "number", "float":
+		return &Schema{Type: "number"}
+	case "bool", "boolean":
+		return &Schema{Type: "boolean"}
+	case "file":
+		return &Schema{Type: "string", Format: "binary"}
+	default:
+		return nil
+	}
+}
+
+// splitQuoted separa o texto antes da primeira string entre aspas, a string e o restante
+func splitQuoted(text string) (head, quoted, rest string, ok bool) {
+	start := strings.Index(text, `"`)
+	if start < 0 {
+		return text, "", "", false
+	}
+	end := strings.Index(text[start+1:], `"`)
+	if end < 0 {
+		return text, "", "", false
+	}
+	end += start + 1
+	return text[:start], text[start+1 : end], text[end+1:], true
+}
+
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatRoute padroniza método e caminho para as mensagens
+func formatRoute(method, path string) string {
+	return fmt.Sprintf("%s %s", method, path)
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// module dá acesso aos pacotes do módulo a partir do código-fonte, sem
+// compilá-los: o gerador só precisa das declarações e dos comentários
+type module struct {
+	root     string
+	path     string
+	fset     *token.FileSet
+	packages map[string]*sourcePackage
+}
+
+// sourcePackage é um pacote do módulo analisado
+type sourcePackage struct {
+	path   string
+	name   string
+	files  []*ast.File
+	types  map[string]*typeDecl
+	consts map[string][]interface{}
+}
+
+// typeDecl é a declaração de um tipo e o arquivo em que está
+type typeDecl struct {
+	spec *ast.TypeSpec
+	doc  string
+	file *ast.File
+}
+
+// loadModule localiza o go.mod a partir do diretório informado
+func loadModule(dir string) (*module, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if path, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					return &module{
+						root:     dir,
+						path:     strings.TrimSpace(path),
+						fset:     token.NewFileSet(),
+						packages: make(map[string]*sourcePackage),
+					}, nil
+				}
+			}
+			return nil, fmt.Errorf("go.mod sem diretiva module em %s", dir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, fmt.Errorf("go.mod não encontrado")
+		}
+		dir = parent
+	}
+}
+
+// load analisa o pacote do módulo com o caminho de importação informado
+func (m *module) load(importPath string) (*sourcePackage, error) {
+	if pkg, ok := m.packages[importPath]; ok {
+		return pkg, nil
+	}
+	rel, ok := strings.CutPrefix(importPath, m.path)
+	if !ok {
+		return nil, fmt.Errorf("pacote %s não pertence ao módulo %s", importPath, m.path)
+	}
+	dir := filepath.Join(m.root, filepath.FromSlash(strings.TrimPrefix(rel, "/")))
+
+	parsed, err := parser.ParseDir(m.fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao analisar %s: %v", importPath, err)
+	}
+	if len(parsed) != 1 {
+		return nil, fmt.Errorf("esperado um pacote em %s, encontrados %d", dir, len(parsed))
+	}
+
+	pkg := &sourcePackage{
+		path:   importPath,
+		types:  make(map[string]*typeDecl),
+		consts: make(map[string][]interface{}),
+	}
+	for name, astPkg := range parsed {
+		pkg.name = name
+		fileNames := make([]string, 0, len(astPkg.Files))
+		for fileName := range astPkg.Files {
+			fileNames = append(fileNames, fileName)
+		}
+		sort.Strings(fileNames)
+		for _, fileName := range fileNames {
+			pkg.files = append(pkg.files, astPkg.Files[fileName])
+		}
+	}
+	for _, file := range pkg.files {
+		pkg.collect(file)
+	}
+
+	m.packages[importPath] = pkg
+	return pkg, nil
+}
+
+// subpackages retorna os caminhos de importação dos pacotes abaixo do diretório
+func (m *module) subpackages(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(filepath.Join(m.root, dir), func(path string, entry os.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return err
+		}
+		matches, _ := filepath.Glob(filepath.Join(path, "*.go"))
+		if len(matches) == 0 {
+			return nil
+		}
+		rel, err := filepath.Rel(m.root, path)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, m.path+"/"+filepath.ToSlash(rel))
+		return nil
+	})
+	return paths, err
+}
+
+// collect registra os tipos e as constantes tipadas do arquivo
+func (p *sourcePackage) collect(file *ast.File) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		switch gen.Tok {
+		case token.TYPE:
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				doc := typeSpec.Doc
+				if doc == nil && len(gen.Specs) == 1 {
+					doc = gen.Doc
+				}
+				p.types[typeSpec.Name.Name] = &typeDecl{spec: typeSpec, doc: docText(doc), file: file}
+			}
+		case token.CONST:
+			p.collectConsts(gen)
+		}
+	}
+}
+
+// collectConsts guarda os valores das constantes de tipos nomeados, usados
+// como enum do schema. Constantes com iota repetem a expressão anterior.
+func (p *sourcePackage) collectConsts(gen *ast.GenDecl) {
+	var lastType string
+	var lastValue ast.Expr
+	for index, spec := range gen.Specs {
+		valueSpec := spec.(*ast.ValueSpec)
+		if valueSpec.Type != nil {
+			lastType = ""
+			if ident, ok := valueSpec.Type.(*ast.Ident); ok {
+				lastType = ident.Name
+			}
+			lastValue = nil
+		}
+		if len(valueSpec.Values) > 0 {
+			lastValue = valueSpec.Values[0]
+		}
+		if lastType == "" || lastValue == nil || len(valueSpec.Names) != 1 {
+			continue
+		}
+		if value, ok := constValue(lastValue, index); ok {
+			p.consts[lastType] = append(p.consts[lastType], value)
+		}
+	}
+}
+
+// constValue avalia literais e expressões simples com iota
+func constValue(expr ast.Expr, index int) (interface{}, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.STRING:
+			value, err := strconv.Unquote(e.Value)
+			return value, err == nil
+		case token.INT:
+			value, err := strconv.ParseInt(e.Value, 0, 64)
+			return value, err == nil
+		}
+	case *ast.Ident:
+		if e.Name == "iota" {
+			return int64(index), true
+		}
+	case *ast.BinaryExpr:
+		left, okLeft := constValue(e.X, index)
+		right, okRight := constValue(e.Y, index)
+		l, isIntLeft := left.(int64)
+		r, isIntRight := right.(int64)
+		if !okLeft || !okRight || !isIntLeft || !isIntRight {
+			return nil, false
+		}
+		switch e.Op {
+		case token.ADD:
+			return l + r, true
+		case token.SUB:
+			return l - r, true
+		case token.MUL:
+			return l * r, true
+		}
+	}
+	return nil, false
+}
+
+// fileImports retorna os pacotes importados pelo arquivo, pelo nome usado no
+// código. Pacotes do módulo usam o nome declarado; os externos, o último
+// elemento do caminho, que é suficiente para os tipos conhecidos pelo gerador.
+func (m *module) fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string, len(file.Imports))
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		switch {
+		case spec.Name != nil:
+			name = spec.Name.Name
+		case strings.HasPrefix(path, m.path+"/"):
+			if pkg, err := m.load(path); err == nil {
+				name = pkg.name
+			}
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+// docText junta as linhas do comentário em um parágrafo
+func docText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
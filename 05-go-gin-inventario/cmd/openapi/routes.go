@@ -0,0 +1,199 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// route é uma rota registrada no roteador do Gin
+type route struct {
+	method  string
+	path    string
+	handler handlerRef
+	pos     token.Pos
+}
+
+// routeMethods são os métodos de registro de rotas do Gin conferidos com a
+// especificação. Static e StaticFS servem arquivos e não são operações da API.
+var routeMethods = map[string]bool{
+	"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true,
+}
+
+// collectRoutes percorre as funções do pacote do servidor e reconstrói as
+// rotas registradas, somando os prefixos dos grupos. O handler de cada rota
+// é identificado pela variável criada com o construtor New<Tipo> do pacote.
+func (g *generator) collectRoutes(pkgPath string) []route {
+	pkg, err := g.mod.load(pkgPath)
+	if err != nil {
+		g.errors = append(g.errors, err.Error())
+		return nil
+	}
+
+	var routes []route
+	for _, file := range pkg.files {
+		imports := g.mod.fileImports(file)
+		groups := make(map[string]string)
+		handlerVars := make(map[string]handlerRef)
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.AssignStmt:
+				g.trackAssignment(n, imports, groups, handlerVars)
+			case *ast.CallExpr:
+				if r, ok := g.routeCall(n, imports, groups, handlerVars); ok {
+					routes = append(routes, r)
+				}
+			}
+			return true
+		})
+	}
+	return routes
+}
+
+// trackAssignment registra roteadores (gin.New, gin.Default), grupos e
+// variáveis de handlers
+func (g *generator) trackAssignment(assign *ast.AssignStmt, imports map[string]string, groups map[string]string, handlerVars map[string]handlerRef) {
+	if len(assign.Rhs) != 1 || len(assign.Lhs) == 0 {
+		return
+	}
+	target, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return
+	}
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	receiver, ok := selector.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+
+	if prefix, isGroup := groups[receiver.Name]; isGroup && selector.Sel.Name == "Group" && len(call.Args) > 0 {
+		if path, ok := stringLiteral(call.Args[0]); ok {
+			groups[target.Name] = prefix + path
+		} else {
+			g.errorf(call.Pos(), "grupo %s com prefixo que não é literal", target.Name)
+		}
+		return
+	}
+
+	pkgPath, isImport := imports[receiver.Name]
+	if !isImport {
+		return
+	}
+	switch {
+	case pkgPath == "github.com/gin-gonic/gin" && (selector.Sel.Name == "New" || selector.Sel.Name == "Default"):
+		groups[target.Name] = ""
+	case strings.HasPrefix(selector.Sel.Name, "New"):
+		handlerVars[target.Name] = handlerRef{pkg: pkgPath, recv: strings.TrimPrefix(selector.Sel.Name, "New")}
+	}
+}
+
+// routeCall reconhece chamadas como produtos.GET("/:id", productHandler.GetProduct)
+func (g *generator) routeCall(call *ast.CallExpr, imports map[string]string, groups map[string]string, handlerVars map[string]handlerRef) (route, bool) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !routeMethods[selector.Sel.Name] || len(call.Args) < 2 {
+		return route{}, false
+	}
+	receiver, ok := selector.X.(*ast.Ident)
+	if !ok {
+		return route{}, false
+	}
+	prefix, isGroup := groups[receiver.Name]
+	if !isGroup {
+		return route{}, false
+	}
+
+	path, ok := stringLiteral(call.Args[0])
+	if !ok {
+		g.errorf(call.Pos(), "rota com caminho que não é literal")
+		return route{}, false
+	}
+	r := route{
+		method: selector.Sel.Name,
+		path:   openAPIPath(prefix + path),
+		pos:    call.Pos(),
+	}
+
+	switch handler := call.Args[len(call.Args)-1].(type) {
+	case *ast.SelectorExpr:
+		owner, ok := handler.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+		if ref, isVar := handlerVars[owner.Name]; isVar {
+			ref.name = handler.Sel.Name
+			r.handler = ref
+			return r, true
+		}
+		if pkgPath, isImport := imports[owner.Name]; isImport {
+			r.handler = handlerRef{pkg: pkgPath, name: handler.Sel.Name}
+			return r, true
+		}
+	case *ast.FuncLit:
+		g.errorf(call.Pos(), "%s registrada com função anônima; mova o handler para um pacote documentado", formatRoute(r.method, r.path))
+		return route{}, false
+	}
+	g.errorf(call.Pos(), "handler de %s não identificado", formatRoute(r.method, r.path))
+	return route{}, false
+}
+
+// openAPIPath converte os parâmetros do Gin (:id, *arquivo) para {id} e {arquivo}
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	path = strings.Join(segments, "/")
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// matchRoutes confere as rotas registradas com as operações anotadas
+func (g *generator) matchRoutes(routes []route, operations []*annotatedOperation) {
+	byHandler := make(map[handlerRef]*annotatedOperation, len(operations))
+	registered := make(map[*annotatedOperation]bool, len(operations))
+	for _, op := range operations {
+		byHandler[op.handler] = op
+	}
+
+	for _, r := range routes {
+		op, ok := byHandler[r.handler]
+		if !ok {
+			g.errorf(r.pos, "%s usa %s, que não tem anotação @Router", formatRoute(r.method, r.path), r.handler)
+			continue
+		}
+		if op.method != r.method || op.path != r.path {
+			g.errorf(r.pos, "%s documenta %s, mas está registrado como %s",
+				r.handler, formatRoute(op.method, op.path), formatRoute(r.method, r.path))
+			continue
+		}
+		registered[op] = true
+	}
+
+	for _, op := range operations {
+		if !registered[op] {
+			g.errorf(op.pos, "%s documenta %s, mas a rota não está registrada", op.handler, formatRoute(op.method, op.path))
+		}
+	}
+}
+
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
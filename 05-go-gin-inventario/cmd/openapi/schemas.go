@@ -0,0 +1,485 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// typeSchema resolve um nome de tipo usado nas anotações, como
+// "dtos.ProductResponse" ou "string", no contexto do arquivo do handler
+func (g *generator) typeSchema(name string, pkg *sourcePackage, file *ast.File, pos token.Pos) *Schema {
+	if elem, ok := strings.CutPrefix(name, "[]"); ok {
+		return &Schema{Type: "array", Items: g.typeSchema(elem, pkg, file, pos)}
+	}
+	qualifier, typeName, qualified := strings.Cut(name, ".")
+	if !qualified {
+		if name == "object" {
+			return &Schema{Type: "object"}
+		}
+		if schema := builtinSchema(name); schema != nil {
+			return schema
+		}
+		return g.namedSchema(pkg, name, pos)
+	}
+	return g.selectorSchema(qualifier, typeName, file, pos)
+}
+
+// exprSchema converte a expressão de tipo de um campo ou declaração
+func (g *generator) exprSchema(expr ast.Expr, pkg *sourcePackage, file *ast.File) *Schema {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if schema := builtinSchema(t.Name); schema != nil {
+			return schema
+		}
+		return g.namedSchema(pkg, t.Name, t.Pos())
+	case *ast.SelectorExpr:
+		qualifier, ok := t.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+		return g.selectorSchema(qualifier.Name, t.Sel.Name, file, t.Pos())
+	case *ast.StarExpr:
+		return g.exprSchema(t.X, pkg, file)
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && ident.Name == "byte" && t.Len == nil {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.exprSchema(t.Elt, pkg, file)}
+	case *ast.MapType:
+		return &Schema{Type: "object", AdditionalProperties: g.exprSchema(t.Value, pkg, file)}
+	case *ast.InterfaceType:
+		return &Schema{}
+	case *ast.StructType:
+		return g.structSchema(t, pkg, file)
+	}
+	g.errorf(expr.Pos(), "tipo sem representação no schema: %T", expr)
+	return &Schema{}
+}
+
+// selectorSchema resolve um tipo de outro pacote, como models.Product ou uuid.UUID
+func (g *generator) selectorSchema(qualifier, name string, file *ast.File, pos token.Pos) *Schema {
+	path, ok := g.mod.fileImports(file)[qualifier]
+	if !ok {
+		// Como no swag, as anotações podem citar pacotes do módulo que o
+		// arquivo não importa
+		if path, ok = g.packageByName(qualifier); !ok {
+			g.errorf(pos, "pacote %s não encontrado no módulo", qualifier)
+			return &Schema{}
+		}
+	}
+	if schema := externalSchema(path, name); schema != nil {
+		return schema
+	}
+	other, err := g.mod.load(path)
+	if err != nil {
+		g.errorf(pos, "tipo %s.%s sem representação no schema: %v", qualifier, name, err)
+		return &Schema{}
+	}
+	return g.namedSchema(other, name, pos)
+}
+
+// packageByName procura em internal o pacote com o nome informado
+func (g *generator) packageByName(name string) (string, bool) {
+	paths, err := g.mod.subpackages("internal")
+	if err != nil {
+		return "", false
+	}
+	found := ""
+	for _, path := range paths {
+		if pkg, err := g.mod.load(path); err == nil && pkg.name == name {
+			if found != "" {
+				return "", false
+			}
+			found = path
+		}
+	}
+	return found, found != ""
+}
+
+// namedSchema retorna a referência para o schema de um tipo nomeado do
+// módulo, registrando-o em components na primeira vez. Tipos sem campos nem
+// enum, como aliases de string, são expandidos no local.
+func (g *generator) namedSchema(pkg *sourcePackage, name string, pos token.Pos) *Schema {
+	key := pkg.name + "." + name
+	if _, ok := g.schemas[key]; ok {
+		return refSchema(key)
+	}
+	decl, ok := pkg.types[name]
+	if !ok {
+		g.errorf(pos, "tipo %s não encontrado", key)
+		return &Schema{}
+	}
+
+	if structType, ok := decl.spec.Type.(*ast.StructType); ok {
+		// Registra antes de montar para que tipos recursivos usem a referência
+		g.schemas[key] = &Schema{}
+		schema := g.structSchema(structType, pkg, decl.file)
+		schema.Description = decl.doc
+		g.schemas[key] = schema
+		return refSchema(key)
+	}
+
+	schema := g.exprSchema(decl.spec.Type, pkg, decl.file)
+	values, isEnum := pkg.consts[name]
+	if !isEnum || schema.Ref != "" {
+		return schema
+	}
+	schema.Description = decl.doc
+	schema.Enum = values
+	g.schemas[key] = schema
+	return refSchema(key)
+}
+
+// structSchema monta o objeto com as propriedades serializadas pelo
+// encoding/json. Campos embutidos sem nome JSON são promovidos, e os campos
+// da própria struct têm prioridade sobre eles.
+func (g *generator) structSchema(structType *ast.StructType, pkg *sourcePackage, file *ast.File) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	var embedded []*Schema
+
+	for _, field := range structType.Fields.List {
+		tag := reflect.StructTag("")
+		if field.Tag != nil {
+			value, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(value)
+		}
+		jsonName, jsonOptions, _ := strings.Cut(tag.Get("json"), ",")
+		if jsonName == "-" && jsonOptions == "" {
+			continue
+		}
+
+		names := make([]string, 0, len(field.Names))
+		for _, ident := range field.Names {
+			if ident.IsExported() {
+				names = append(names, ident.Name)
+			}
+		}
+		if len(field.Names) == 0 {
+			if jsonName == "" {
+				embedded = append(embedded, g.exprSchema(field.Type, pkg, file))
+				continue
+			}
+			names = append(names, embeddedName(field.Type))
+		}
+
+		for _, name := range names {
+			propName := name
+			if jsonName != "" {
+				propName = jsonName
+			}
+			prop := g.fieldSchema(field, tag, strings.Contains(jsonOptions, "omitempty"), pkg, file)
+			schema.Properties[propName] = prop
+			if hasRule(tag.Get("binding"), "required") {
+				schema.Required = append(schema.Required, propName)
+			}
+		}
+	}
+
+	for _, ref := range embedded {
+		promoted := g.schemas[strings.TrimPrefix(ref.Ref, "#/components/schemas/")]
+		if promoted == nil || promoted.Properties == nil {
+			g.errorf(structType.Pos(), "campo embutido sem propriedades resolvidas")
+			continue
+		}
+		for name, prop := range promoted.Properties {
+			if _, shadowed := schema.Properties[name]; !shadowed {
+				schema.Properties[name] = prop
+			}
+		}
+		for _, name := range promoted.Required {
+			if !containsString(schema.Required, name) {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+
+	return schema
+}
+
+// fieldSchema monta o schema do campo com descrição, exemplo e as regras
+// de validação do binding
+func (g *generator) fieldSchema(field *ast.Field, tag reflect.StructTag, omitempty bool, pkg *sourcePackage, file *ast.File) *Schema {
+	prop := g.exprSchema(field.Type, pkg, file)
+	prop = g.constrain(prop, splitRules(tag.Get("binding")))
+
+	description := docText(field.Doc)
+	if description == "" {
+		description = docText(field.Comment)
+	}
+	var example interface{}
+	if raw, ok := tag.Lookup("example"); ok {
+		example = g.parseExample(prop, raw)
+	}
+	_, isPointer := field.Type.(*ast.StarExpr)
+	nullable := isPointer && !omitempty
+
+	if description == "" && example == nil && !nullable {
+		return prop
+	}
+	prop = wrapRef(prop)
+	prop.Description = description
+	prop.Example = example
+	prop.Nullable = nullable
+	return prop
+}
+
+// constrain aplica as regras do binding do Gin (go-playground/validator)
+// que têm equivalente no schema. Regras após "dive" valem para os itens.
+func (g *generator) constrain(schema *Schema, rules []string) *Schema {
+	if len(rules) == 0 {
+		return schema
+	}
+	base := g.baseType(schema)
+	target := &Schema{}
+
+	for i, rule := range rules {
+		key, value, _ := strings.Cut(rule, "=")
+		if key == "dive" {
+			if schema.Items != nil {
+				schema.Items = g.constrain(schema.Items, rules[i+1:])
+			}
+			break
+		}
+
+		number, err := strconv.ParseFloat(value, 64)
+		isNumber := err == nil
+		switch {
+		case key == "oneof":
+			for _, option := range strings.Fields(value) {
+				target.Enum = append(target.Enum, typedValue(base, option))
+			}
+		case key == "email":
+			target.Format = "email"
+		case key == "url" || key == "uri":
+			target.Format = "uri"
+		case key == "uuid" || key == "uuid4":
+			target.Format = "uuid"
+		case isNumber && base == "string":
+			size := int(number)
+			switch key {
+			case "min":
+				target.MinLength = &size
+			case "max":
+				target.MaxLength = &size
+			case "len":
+				target.MinLength, target.MaxLength = &size, &size
+			}
+		case isNumber && base == "array":
+			size := int(number)
+			switch key {
+			case "min":
+				target.MinItems = &size
+			case "max":
+				target.MaxItems = &size
+			case "len":
+				target.MinItems, target.MaxItems = &size, &size
+			}
+		case isNumber && (base == "integer" || base == "number"):
+			switch key {
+			case "min", "gte":
+				target.Minimum = &number
+			case "gt":
+				target.Minimum, target.ExclusiveMinimum = &number, true
+			case "max", "lte":
+				target.Maximum = &number
+			case "lt":
+				target.Maximum, target.ExclusiveMaximum = &number, true
+			}
+		}
+	}
+
+	if reflect.DeepEqual(*target, Schema{}) {
+		return schema
+	}
+	if schema.Ref != "" {
+		target.AllOf = []*Schema{schema}
+		return target
+	}
+	merged := *schema
+	mergeConstraints(&merged, target)
+	return &merged
+}
+
+// baseType retorna o tipo JSON do schema, seguindo referências
+func (g *generator) baseType(schema *Schema) string {
+	switch {
+	case schema.Ref != "":
+		if target := g.schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]; target != nil {
+			return g.baseType(target)
+		}
+	case len(schema.AllOf) == 1:
+		return g.baseType(schema.AllOf[0])
+	}
+	return schema.Type
+}
+
+// parseExample converte o exemplo da tag para o tipo do campo; listas usam
+// vírgula como separador
+func (g *generator) parseExample(schema *Schema, raw string) interface{} {
+	switch base := g.baseType(schema); base {
+	case "array":
+		items := schema.Items
+		if items == nil && len(schema.AllOf) == 1 {
+			items = schema.AllOf[0].Items
+		}
+		itemType := "string"
+		if items != nil {
+			itemType = g.baseType(items)
+		}
+		values := []interface{}{}
+		for _, part := range strings.Split(raw, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, typedValue(itemType, part))
+			}
+		}
+		return values
+	case "object", "":
+		return nil
+	default:
+		return typedValue(base, raw)
+	}
+}
+
+// typedValue converte o texto para o tipo JSON, mantendo o texto se não for possível
+func typedValue(base, raw string) interface{} {
+	switch base {
+	case "integer":
+		if value, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return value
+		}
+	case "number":
+		if value, err := strconv.ParseFloat(raw, 64); err == nil {
+			return value
+		}
+	case "boolean":
+		if value, err := strconv.ParseBool(raw); err == nil {
+			return value
+		}
+	}
+	return raw
+}
+
+// mergeConstraints copia para o schema as restrições preenchidas
+func mergeConstraints(schema, constraints *Schema) {
+	if constraints.Enum != nil {
+		schema.Enum = constraints.Enum
+	}
+	if constraints.Format != "" {
+		schema.Format = constraints.Format
+	}
+	if constraints.Minimum != nil {
+		schema.Minimum, schema.ExclusiveMinimum = constraints.Minimum, constraints.ExclusiveMinimum
+	}
+	if constraints.Maximum != nil {
+		schema.Maximum, schema.ExclusiveMaximum = constraints.Maximum, constraints.ExclusiveMaximum
+	}
+	if constraints.MinLength != nil {
+		schema.MinLength = constraints.MinLength
+	}
+	if constraints.MaxLength != nil {
+		schema.MaxLength = constraints.MaxLength
+	}
+	if constraints.MinItems != nil {
+		schema.MinItems = constraints.MinItems
+	}
+	if constraints.MaxItems != nil {
+		schema.MaxItems = constraints.MaxItems
+	}
+}
+
+// wrapRef envolve uma referência em allOf para que receba descrição e
+// exemplo, já que no OpenAPI 3.0 campos ao lado de $ref são ignorados
+func wrapRef(schema *Schema) *Schema {
+	if schema.Ref == "" {
+		return schema
+	}
+	return &Schema{AllOf: []*Schema{schema}}
+}
+
+// builtinSchema representa os tipos predeclarados do Go
+func builtinSchema(name string) *Schema {
+	switch name {
+	case "string":
+		return &Schema{Type: "string"}
+	case "bool":
+		return &Schema{Type: "boolean"}
+	case "int", "int8", "int16", "uint", "uint8", "uint16", "uint32", "uint64":
+		return &Schema{Type: "integer"}
+	case "int32", "rune":
+		return &Schema{Type: "integer", Format: "int32"}
+	case "int64":
+		return &Schema{Type: "integer", Format: "int64"}
+	case "float32":
+		return &Schema{Type: "number", Format: "float"}
+	case "float64":
+		return &Schema{Type: "number"}
+	case "any":
+		return &Schema{}
+	default:
+		return nil
+	}
+}
+
+// externalSchema representa os tipos de fora do módulo usados nos DTOs
+func externalSchema(path, name string) *Schema {
+	switch path + "." + name {
+	case "time.Time":
+		return &Schema{Type: "string", Format: "date-time"}
+	case "time.Duration":
+		return &Schema{Type: "integer", Format: "int64"}
+	case "github.com/google/uuid.UUID":
+		return &Schema{Type: "string", Format: "uuid"}
+	case "encoding/json.RawMessage":
+		return &Schema{}
+	case "mime/multipart.FileHeader":
+		return &Schema{Type: "string", Format: "binary"}
+	default:
+		return nil
+	}
+}
+
+// embeddedName retorna o nome do campo de um tipo embutido
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// splitRules separa as regras de uma tag binding
+func splitRules(binding string) []string {
+	if binding == "" {
+		return nil
+	}
+	return strings.Split(binding, ",")
+}
+
+// hasRule verifica se a tag binding contém a regra antes de um "dive"
+func hasRule(binding, rule string) bool {
+	for _, r := range splitRules(binding) {
+		if r == "dive" {
+			return false
+		}
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package main
+
+// Estruturas do documento OpenAPI 3.0, apenas com os campos que o gerador
+// preenche. Mapas são serializados com as chaves ordenadas, o que mantém o
+// arquivo gerado estável entre execuções.
+
+// Document é a raiz da especificação
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Tags       []Tag                `json:"tags,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Info descreve a API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server é um endereço base da API
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag agrupa operações na interface de documentação
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem reúne as operações de um caminho
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+	Patch  *Operation `json:"patch,omitempty"`
+}
+
+// operation retorna o ponteiro para a operação do método HTTP
+func (p *PathItem) operation(method string) **Operation {
+	switch method {
+	case "GET":
+		return &p.Get
+	case "PUT":
+		return &p.Put
+	case "POST":
+		return &p.Post
+	case "DELETE":
+		return &p.Delete
+	case "PATCH":
+		return &p.Patch
+	default:
+		return nil
+	}
+}
+
+// Operation é um endpoint documentado
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter é um parâmetro de caminho, query ou cabeçalho
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+
+	// mediaType restringe um @Param body a um tipo de conteúdo
+	mediaType string
+}
+
+// RequestBody é o corpo aceito pela operação
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// Response é uma resposta possível da operação
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// Header é um cabeçalho de resposta
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType associa um tipo de conteúdo ao seu schema
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components guarda os schemas reutilizados e os esquemas de segurança
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme descreve uma forma de autenticação
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name,omitempty"`
+	In          string `json:"in,omitempty"`
+}
+
+// Schema descreve um valor JSON
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Example              interface{}        `json:"example,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// refSchema aponta para um schema de components
+func refSchema(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
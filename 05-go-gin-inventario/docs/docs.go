@@ -0,0 +1,18 @@
+// Package docs guarda a especificação OpenAPI da API, gerada pelo comando
+// cmd/openapi a partir das anotações dos handlers, e o inicializador da
+// interface Swagger UI servida em /docs.
+package docs
+
+import _ "embed"
+
+//go:generate go run ../cmd/openapi
+
+// OpenAPI é a especificação OpenAPI 3 servida em /openapi.json
+//
+//go:embed openapi.json
+var OpenAPI []byte
+
+// SwaggerInitializer configura a Swagger UI para carregar /openapi.json
+//
+//go:embed swagger-initializer.js
+var SwaggerInitializer []byte
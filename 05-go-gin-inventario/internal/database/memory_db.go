@@ -1,6 +1,7 @@
 package database
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -11,23 +12,161 @@ import (
 	"inventario-api/internal/models"
 )
 
+// Erros conhecidos retornados pelo banco em memória
+var (
+	ErrProductNotFound   = errors.New("produto não encontrado")
+	ErrProductInactive   = errors.New("produto inativo")
+	ErrInsufficientStock = errors.New("estoque insuficiente")
+	ErrProductStatus     = errors.New("transição de situação do produto não permitida")
+	ErrRestockNotAllowed = errors.New("situação do produto não permite novas entradas de estoque")
+
+	ErrReservationNotFound  = errors.New("reserva não encontrada")
+	ErrReservationNotActive = errors.New("reserva não está ativa")
+
+	ErrLotRequired     = errors.New("produto controlado por lote")
+	ErrLotNotSupported = errors.New("produto não controla lote")
+	ErrLotDuplicate    = errors.New("lote já cadastrado")
+	ErrLotExpired      = errors.New("estoque insuficiente em lotes dentro da validade")
+
+	ErrSerialRequired     = errors.New("produto serializado")
+	ErrSerialNotSupported = errors.New("produto não é serializado")
+	ErrSerialNotFound     = errors.New("número de série não encontrado")
+	ErrSerialConflict     = errors.New("número de série em situação inválida")
+
+	ErrKitInvalid        = errors.New("composição de kit inválida")
+	ErrKitOperation      = errors.New("operação não permitida para kits")
+	ErrKitComponentInUse = errors.New("produto é componente de um kit")
+
+	ErrScheduledPriceNotFound   = errors.New("agendamento de preço não encontrado")
+	ErrScheduledPriceNotPending = errors.New("agendamento de preço não está pendente")
+
+	ErrPromotionNotFound = errors.New("promoção não encontrada")
+	ErrPromotionInvalid  = errors.New("promoção inválida")
+
+	ErrSupplierNotFound  = errors.New("fornecedor não encontrado")
+	ErrSupplierDuplicate = errors.New("fornecedor já cadastrado")
+	ErrSupplierInactive  = errors.New("fornecedor inativo")
+	ErrSupplierInUse     = errors.New("fornecedor possui pedidos de compra em aberto")
+
+	ErrPurchaseOrderNotFound = errors.New("pedido de compra não encontrado")
+	ErrPurchaseOrderInvalid  = errors.New("pedido de compra inválido")
+	ErrPurchaseOrderStatus   = errors.New("operação não permitida na situação atual do pedido de compra")
+	ErrProductInPurchase     = errors.New("produto possui pedidos de compra em aberto")
+
+	ErrSalesOrderNotFound  = errors.New("pedido de venda não encontrado")
+	ErrSalesOrderRejected  = errors.New("pedido de venda recusado")
+	ErrSalesOrderDuplicate = errors.New("pedido de venda já registrado")
+	ErrSalesOrderStatus    = errors.New("pedido de venda não está confirmado")
+	ErrSalesOrderReturned  = errors.New("pedido de venda possui devoluções")
+
+	ErrReturnNotFound = errors.New("devolução não encontrada")
+	ErrReturnInvalid  = errors.New("devolução inválida")
+	ErrReturnStatus   = errors.New("devolução já foi inspecionada")
+
+	ErrCountNotFound   = errors.New("contagem de inventário não encontrada")
+	ErrCountInvalid    = errors.New("contagem de inventário inválida")
+	ErrCountStatus     = errors.New("operação não permitida na situação atual da contagem")
+	ErrCountIncomplete = errors.New("contagem de inventário incompleta")
+	ErrCountConflict   = errors.New("produto já está em uma contagem em andamento")
+
+	ErrAttachmentNotFound    = errors.New("anexo não encontrado")
+	ErrAttachmentInvalid     = errors.New("anexo inválido")
+	ErrAttachmentTooLarge    = errors.New("anexo excede o tamanho máximo")
+	ErrAttachmentUnsupported = errors.New("tipo de arquivo não suportado")
+
+	ErrTagInvalid  = errors.New("tag inválida")
+	ErrTagNotFound = errors.New("tag não encontrada no produto")
+
+	ErrRelationInvalid   = errors.New("relação entre produtos inválida")
+	ErrRelationNotFound  = errors.New("relação entre produtos não encontrada")
+	ErrRelationDuplicate = errors.New("relação entre produtos já cadastrada")
+
+	ErrShippingInvalid     = errors.New("cotação de frete inválida")
+	ErrShippingUnavailable = errors.New("destino não atendido pela tabela de frete")
+	ErrDimensionsMissing   = errors.New("produto sem peso e medidas cadastrados")
+
+	ErrFiscalInvalid = errors.New("dados fiscais inválidos")
+	ErrFiscalMissing = errors.New("produto sem dados fiscais cadastrados")
+	ErrTaxInvalid    = errors.New("simulação de tributos inválida")
+
+	ErrBarcodeInvalid   = errors.New("código de barras inválido")
+	ErrBarcodeDuplicate = errors.New("código de barras já cadastrado em outro produto")
+
+	ErrInvoiceNotFound  = errors.New("importação de NF-e não encontrada")
+	ErrInvoiceInvalid   = errors.New("NF-e inválida")
+	ErrInvoiceDuplicate = errors.New("NF-e já importada")
+	ErrInvoiceStatus    = errors.New("importação de NF-e não está pendente")
+	ErrInvoiceUnmapped  = errors.New("itens da NF-e sem produto vinculado")
+
+	ErrTranslationInvalid  = errors.New("tradução inválida")
+	ErrTranslationNotFound = errors.New("tradução não encontrada")
+
+	ErrPatchInvalid       = errors.New("patch inválido")
+	ErrPatchUnprocessable = errors.New("patch não aplicável ao produto")
+	ErrPatchTestFailed    = errors.New("condição do patch não atendida")
+	ErrPatchUnsupported   = errors.New("formato de patch não suportado")
+
+	ErrBulkInvalid   = errors.New("lote inválido")
+	ErrBulkDuplicate = errors.New("produto repetido no lote")
+	ErrBulkAborted   = errors.New("item não aplicado: outro item do lote falhou")
+)
+
 // InMemoryDatabase implementa um banco de dados em memória thread-safe
 type InMemoryDatabase struct {
-	products map[uuid.UUID]*models.Product
-	mutex    sync.RWMutex
-	lastID   int
+	products          map[uuid.UUID]*models.Product
+	reservations      map[uuid.UUID]*models.Reservation
+	lots              map[uuid.UUID]*models.Lot
+	serials           map[string]*models.SerialNumber
+	costLayers        map[uuid.UUID][]*models.CostLayer
+	priceHistory      map[uuid.UUID][]models.PriceChange
+	statusHistory     map[uuid.UUID][]models.StatusChange
+	scheduledPrices   map[uuid.UUID]*models.ScheduledPrice
+	promotions        map[uuid.UUID]*models.Promotion
+	suppliers         map[uuid.UUID]*models.Supplier
+	supplierProducts  map[uuid.UUID]map[uuid.UUID]*models.SupplierProduct
+	purchaseOrders    map[uuid.UUID]*models.PurchaseOrder
+	purchaseOrderSeq  int
+	salesOrders       map[uuid.UUID]*models.SalesOrder
+	salesOrderSeq     int
+	returns           map[uuid.UUID]*models.Return
+	returnSeq         int
+	inventoryCounts   map[uuid.UUID]*models.InventoryCount
+	inventoryCountSeq int
+	attachments       map[uuid.UUID][]*models.Attachment
+	relations         map[uuid.UUID][]*models.ProductRelation
+	invoiceImports    map[uuid.UUID]*models.InvoiceImport
+	stockAlerts       []models.StockAlert
+	mutex             sync.RWMutex
+	lastID            int
 }
 
 // NewInMemoryDatabase cria uma nova instância do banco em memória
 func NewInMemoryDatabase() *InMemoryDatabase {
 	db := &InMemoryDatabase{
-		products: make(map[uuid.UUID]*models.Product),
-		lastID:   0,
+		products:         make(map[uuid.UUID]*models.Product),
+		reservations:     make(map[uuid.UUID]*models.Reservation),
+		lots:             make(map[uuid.UUID]*models.Lot),
+		serials:          make(map[string]*models.SerialNumber),
+		costLayers:       make(map[uuid.UUID][]*models.CostLayer),
+		priceHistory:     make(map[uuid.UUID][]models.PriceChange),
+		statusHistory:    make(map[uuid.UUID][]models.StatusChange),
+		scheduledPrices:  make(map[uuid.UUID]*models.ScheduledPrice),
+		promotions:       make(map[uuid.UUID]*models.Promotion),
+		suppliers:        make(map[uuid.UUID]*models.Supplier),
+		supplierProducts: make(map[uuid.UUID]map[uuid.UUID]*models.SupplierProduct),
+		purchaseOrders:   make(map[uuid.UUID]*models.PurchaseOrder),
+		salesOrders:      make(map[uuid.UUID]*models.SalesOrder),
+		returns:          make(map[uuid.UUID]*models.Return),
+		inventoryCounts:  make(map[uuid.UUID]*models.InventoryCount),
+		attachments:      make(map[uuid.UUID][]*models.Attachment),
+		relations:        make(map[uuid.UUID][]*models.ProductRelation),
+		invoiceImports:   make(map[uuid.UUID]*models.InvoiceImport),
+		lastID:           0,
 	}
-	
+
 	// Inicializa com dados de exemplo
 	db.seedData()
-	
+
 	return db
 }
 
@@ -36,6 +175,16 @@ func (db *InMemoryDatabase) Create(product *models.Product) error {
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
 
+	if err := db.prepareCreate(product); err != nil {
+		return err
+	}
+	db.commitCreate(product)
+	return nil
+}
+
+// prepareCreate valida um novo produto e completa o ID e a situação, sem
+// gravá-lo. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) prepareCreate(product *models.Product) error {
 	// Gera um novo UUID se não existir
 	if product.ID == uuid.Nil {
 		product.ID = uuid.New()
@@ -46,16 +195,58 @@ func (db *InMemoryDatabase) Create(product *models.Product) error {
 		return fmt.Errorf("produto com ID %s já existe", product.ID)
 	}
 
+	// Estoque de produtos controlados por lote entra apenas via lotes
+	if product.ControlaLote && product.Quantidade > 0 {
+		return fmt.Errorf("%w: cadastre o estoque inicial como um lote", ErrLotRequired)
+	}
+
+	// Estoque de produtos serializados entra apenas via números de série
+	if product.Serializado && product.ControlaLote {
+		return fmt.Errorf("%w: produto não pode ser serializado e controlado por lote ao mesmo tempo", ErrSerialRequired)
+	}
+	if product.Serializado && product.Quantidade > 0 {
+		return fmt.Errorf("%w: cadastre o estoque inicial pelos números de série", ErrSerialRequired)
+	}
+
+	if err := validateTags(product.Tags); err != nil {
+		return err
+	}
+
+	if err := db.checkBarcode(product.ID, product.CodigoBarras); err != nil {
+		return err
+	}
+
+	// Sem situação informada vale o antigo campo ativo; ativo é sempre derivado
+	if product.Status == "" {
+		product.Status = models.StatusFromActive(product.Ativo)
+	}
+	if !product.Status.IsInitial() {
+		return fmt.Errorf("%w: produtos não podem ser cadastrados como %s", ErrProductStatus, product.Status)
+	}
+	product.Ativo = product.Status.IsSellable()
+	return nil
+}
+
+// commitCreate grava um produto validado por prepareCreate.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) commitCreate(product *models.Product) {
 	// Define timestamps
 	now := time.Now()
 	product.DataCriacao = now
 	product.DataAtualizacao = now
 
-	// Copia o produto para evitar modificações externas
-	productCopy := *product
-	db.products[product.ID] = &productCopy
+	// Estoque inicial entra a preço de custo e abre a primeira camada FIFO
+	product.CustoMedio = 0
+	if product.Quantidade > 0 {
+		db.recordCostMovement(product, product.Quantidade, 0, "estoque inicial")
+	}
 
-	return nil
+	// Copia o produto para evitar modificações externas
+	stored := product.Clone()
+	db.products[product.ID] = stored
+	db.recordPriceChange(stored, 0, models.PriceOriginCadastro, "", now)
+	db.recordStatusChange(stored, "", "cadastro", "", now)
+	db.trackStockChange(models.Product{}, stored)
 }
 
 // GetByID busca um produto por ID
@@ -65,12 +256,11 @@ func (db *InMemoryDatabase) GetByID(id uuid.UUID) (*models.Product, error) {
 
 	product, exists := db.products[id]
 	if !exists {
-		return nil, fmt.Errorf("produto com ID %s não encontrado", id)
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, id)
 	}
 
 	// Retorna uma cópia para evitar modificações externas
-	productCopy := *product
-	return &productCopy, nil
+	return db.snapshot(product), nil
 }
 
 // GetAll retorna todos os produtos
@@ -80,8 +270,7 @@ func (db *InMemoryDatabase) GetAll() ([]*models.Product, error) {
 
 	products := make([]*models.Product, 0, len(db.products))
 	for _, product := range db.products {
-		productCopy := *product
-		products = append(products, &productCopy)
+		products = append(products, db.snapshot(product))
 	}
 
 	// Ordena por data de criação (mais recentes primeiro)
@@ -99,7 +288,11 @@ type FilterOptions struct {
 	PrecoMaximo   *float64
 	ApenasAtivos  *bool
 	ApenasEstoque *bool
+	Status        *models.ProductStatus
+	Tags          []string
+	TagsModo      models.TagMatch
 	Nome          *string
+	Idioma        models.Locale
 	Page          int
 	Size          int
 }
@@ -112,9 +305,9 @@ func (db *InMemoryDatabase) GetFiltered(options FilterOptions) ([]*models.Produc
 	var filtered []*models.Product
 
 	for _, product := range db.products {
-		if db.matchesFilter(product, options) {
-			productCopy := *product
-			filtered = append(filtered, &productCopy)
+		snapshot := db.snapshot(product)
+		if db.matchesFilter(snapshot, options) {
+			filtered = append(filtered, snapshot)
 		}
 	}
 
@@ -169,17 +362,28 @@ func (db *InMemoryDatabase) matchesFilter(product *models.Product, options Filte
 		return false
 	}
 
+	// Filtro por situação do ciclo de vida
+	if options.Status != nil && product.Status != *options.Status {
+		return false
+	}
+
+	// Filtro por tags (qualquer uma ou todas)
+	if !product.MatchesTags(options.Tags, options.TagsModo) {
+		return false
+	}
+
 	// Filtro por produtos em estoque
 	if options.ApenasEstoque != nil && *options.ApenasEstoque && !product.IsInStock() {
 		return false
 	}
 
-	// Filtro por nome (busca parcial, case-insensitive)
+	// Filtro por nome (busca parcial, case-insensitive) nos textos do idioma solicitado
 	if options.Nome != nil && *options.Nome != "" {
 		nome := strings.ToLower(*options.Nome)
-		produtoNome := strings.ToLower(product.Nome)
-		produtoDesc := strings.ToLower(product.Descricao)
-		
+		localNome, localDesc := product.LocalizedText(options.Idioma)
+		produtoNome := strings.ToLower(localNome)
+		produtoDesc := strings.ToLower(localDesc)
+
 		if !strings.Contains(produtoNome, nome) && !strings.Contains(produtoDesc, nome) {
 			return false
 		}
@@ -193,21 +397,122 @@ func (db *InMemoryDatabase) Update(id uuid.UUID, product *models.Product) error
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
 
+	existing, err := db.prepareUpdate(id, product)
+	if err != nil {
+		return err
+	}
+	db.commitUpdate(existing, product)
+	return nil
+}
+
+// prepareUpdate valida a nova versão do produto, preservando os campos que
+// não mudam por atualização, e retorna a versão gravada, sem alterá-la.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) prepareUpdate(id uuid.UUID, product *models.Product) (*models.Product, error) {
 	existing, exists := db.products[id]
 	if !exists {
-		return fmt.Errorf("produto com ID %s não encontrado", id)
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, id)
 	}
 
 	// Preserva campos que não devem ser alterados
 	product.ID = id
 	product.DataCriacao = existing.DataCriacao
+	product.QuantidadeReservada = existing.QuantidadeReservada
+	product.QuantidadeCaixaAberta = existing.QuantidadeCaixaAberta
+	product.CustoMedio = existing.CustoMedio
+
+	// A situação muda apenas por transições do ciclo de vida
+	product.Status = existing.Status
+	product.Ativo = existing.Ativo
+
+	// A composição de kits é mantida por endpoint próprio e kits não têm estoque
+	product.Componentes = existing.Componentes
+	product.PrecoDerivado = existing.PrecoDerivado
+	product.DescontoKit = existing.DescontoKit
+
+	// As traduções também têm endpoints próprios
+	product.Traducoes = existing.Traducoes
+	if existing.IsKit() {
+		if product.ControlaLote || product.Serializado {
+			return nil, fmt.Errorf("%w: kits não podem ser controlados por lote ou serializados", ErrKitOperation)
+		}
+		product.Quantidade = existing.Quantidade
+		if existing.PrecoDerivado {
+			product.Preco = existing.Preco
+		}
+	}
+
+	if product.Quantidade < product.QuantidadeReservada {
+		return nil, fmt.Errorf("%w: produto %s possui %d unidade(s) reservada(s)",
+			ErrInsufficientStock, id, product.QuantidadeReservada)
+	}
+
+	if err := validateTags(product.Tags); err != nil {
+		return nil, err
+	}
+
+	if err := db.checkBarcode(product.ID, product.CodigoBarras); err != nil {
+		return nil, err
+	}
+
+	// Produtos controlados por lote só têm o estoque alterado via lotes
+	if err := db.checkLotControlChange(existing, product); err != nil {
+		return nil, err
+	}
+
+	// Produtos serializados só têm o estoque alterado via números de série
+	if err := db.checkSerialControlChange(existing, product); err != nil {
+		return nil, err
+	}
+
+	// Produtos descontinuados ou bloqueados não recebem novas entradas
+	if product.Quantidade > existing.Quantidade {
+		if err := checkRestock(existing); err != nil {
+			return nil, err
+		}
+	}
+
+	return existing, nil
+}
+
+// commitUpdate grava a versão validada por prepareUpdate.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) commitUpdate(existing, product *models.Product) {
 	product.DataAtualizacao = time.Now()
 
+	// Alterações diretas de quantidade entram ou saem a preço de custo
+	if delta := product.Quantidade - existing.Quantidade; delta != 0 {
+		db.recordCostMovement(product, delta, 0, "ajuste de estoque")
+	}
+
 	// Atualiza o produto
-	productCopy := *product
-	db.products[id] = &productCopy
+	stored := product.Clone()
+	db.products[existing.ID] = stored
+	db.recordPriceChange(stored, existing.Preco, models.PriceOriginManual, "", product.DataAtualizacao)
+	db.trackStockChange(*existing, stored)
+}
 
-	return nil
+// ProductModifier monta a nova versão de um produto a partir da versão
+// gravada e, se a situação também muda, a transição correspondente
+type ProductModifier func(current *models.Product) (*models.Product, *StatusTransition, error)
+
+// ModifyProduct lê o produto, monta a nova versão com modify e a grava sob o
+// mesmo lock de escrita, de modo que nenhuma movimentação concorrente seja
+// sobrescrita entre a leitura e a gravação. modify não pode acessar o banco.
+func (db *InMemoryDatabase) ModifyProduct(id uuid.UUID, modify ProductModifier) (*models.Product, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	operation := ProductOperation{
+		Tipo:      models.BulkUpdate,
+		ProdutoID: id,
+		Modificar: modify,
+	}
+	existing, err := db.prepareProductOperation(&operation, make(map[uuid.UUID]bool), nil)
+	if err != nil {
+		return nil, err
+	}
+	return db.commitProductOperation(&operation, existing), nil
 }
 
 // Delete remove um produto do banco
@@ -215,14 +520,204 @@ func (db *InMemoryDatabase) Delete(id uuid.UUID) error {
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
 
+	if err := db.prepareDelete(id); err != nil {
+		return err
+	}
+	db.commitDelete(id)
+	return nil
+}
+
+// prepareDelete verifica se o produto pode ser removido.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) prepareDelete(id uuid.UUID) error {
 	if _, exists := db.products[id]; !exists {
-		return fmt.Errorf("produto com ID %s não encontrado", id)
+		return fmt.Errorf("%w: ID %s", ErrProductNotFound, id)
 	}
 
-	delete(db.products, id)
+	// Componentes de kits não podem ser removidos enquanto fizerem parte da composição
+	if kit := db.kitUsingComponent(id); kit != nil {
+		return fmt.Errorf("%w: remova-o do kit %s antes de excluí-lo", ErrKitComponentInUse, kit.Nome)
+	}
+
+	// Produtos com pedidos de compra em aberto aguardam o recebimento ou cancelamento
+	if order := db.openPurchaseOrderWithProduct(id); order != nil {
+		return fmt.Errorf("%w: pedido %s", ErrProductInPurchase, order.Numero)
+	}
+
+	// Produtos em contagem de inventário aguardam a postagem ou o cancelamento
+	if count := db.countInProgressWithProduct(id); count != nil {
+		return fmt.Errorf("%w: contagem %s", ErrCountConflict, count.Numero)
+	}
 	return nil
 }
 
+// commitDelete remove o produto validado por prepareDelete e seus vínculos.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) commitDelete(id uuid.UUID) {
+	// Libera reservas ativas do produto removido
+	for _, reservation := range db.reservations {
+		if reservation.ProdutoID == id && reservation.IsActive() {
+			reservation.SetStatus(models.ReservationLiberada)
+		}
+	}
+
+	// Cancela os preços agendados do produto removido
+	for _, scheduled := range db.scheduledPrices {
+		if scheduled.ProdutoID == id && scheduled.IsPending() {
+			scheduled.SetStatus(models.ScheduledPriceCancelado)
+		}
+	}
+
+	// Remove os vínculos do produto com fornecedores
+	for _, links := range db.supplierProducts {
+		delete(links, id)
+	}
+
+	// Remove os lotes e libera os números de série para outros produtos
+	for loteID, lot := range db.lots {
+		if lot.ProdutoID == id {
+			delete(db.lots, loteID)
+		}
+	}
+	for numero, serial := range db.serials {
+		if serial.ProdutoID == id {
+			delete(db.serials, numero)
+		}
+	}
+
+	delete(db.products, id)
+	delete(db.costLayers, id)
+	delete(db.priceHistory, id)
+	delete(db.statusHistory, id)
+	delete(db.attachments, id)
+	db.removeProductRelations(id)
+}
+
+// StockAdjustment define uma variação relativa de estoque para um produto.
+// CustoUnitario vale apenas para entradas; zero usa o preço de custo do produto.
+type StockAdjustment struct {
+	ProductID     uuid.UUID
+	Delta         int
+	CustoUnitario float64
+}
+
+// AdjustStock aplica uma variação relativa ao estoque de forma atômica
+func (db *InMemoryDatabase) AdjustStock(adjustment StockAdjustment) (*models.Product, error) {
+	products, err := db.AdjustStockBatch([]StockAdjustment{adjustment})
+	if err != nil {
+		return nil, err
+	}
+	return products[0], nil
+}
+
+// AdjustStockBatch aplica vários ajustes de estoque em uma única operação atômica.
+// Se qualquer ajuste deixar um produto com estoque negativo, nenhum é aplicado.
+func (db *InMemoryDatabase) AdjustStockBatch(adjustments []StockAdjustment) ([]*models.Product, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	// Kits não possuem estoque próprio: a baixa de um kit baixa seus componentes
+	expanded, err := db.expandKitAdjustments(adjustments)
+	if err != nil {
+		return nil, err
+	}
+
+	// Primeira passada: valida todos os ajustes acumulando por produto
+	resulting := make(map[uuid.UUID]int)
+	for _, adj := range expanded {
+		product, exists := db.products[adj.ProductID]
+		if !exists {
+			return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, adj.ProductID)
+		}
+
+		current, seen := resulting[adj.ProductID]
+		if !seen {
+			current = product.Quantidade
+		}
+
+		if product.Serializado {
+			return nil, fmt.Errorf("%w: movimentações do produto %s devem informar os números de série",
+				ErrSerialRequired, adj.ProductID)
+		}
+
+		if adj.Delta > 0 {
+			if err := checkRestock(product); err != nil {
+				return nil, err
+			}
+		}
+
+		if adj.Delta > 0 && product.ControlaLote {
+			return nil, fmt.Errorf("%w: entradas do produto %s devem ser registradas em um lote",
+				ErrLotRequired, adj.ProductID)
+		}
+
+		// Unidades reservadas não podem ser consumidas por ajustes
+		if current+adj.Delta < product.QuantidadeReservada {
+			return nil, fmt.Errorf("%w: produto %s possui %d unidade(s) disponível(is), ajuste de %d",
+				ErrInsufficientStock, adj.ProductID, current-product.QuantidadeReservada, adj.Delta)
+		}
+		resulting[adj.ProductID] = current + adj.Delta
+	}
+
+	// Segunda passada: aplica os ajustes já validados
+	for _, adj := range expanded {
+		product := db.products[adj.ProductID]
+		before := *product
+		if err := db.applyStockDelta(product, adj.Delta, true); err != nil {
+			return nil, err
+		}
+		db.recordCostMovement(product, adj.Delta, adj.CustoUnitario, "ajuste de estoque")
+		db.trackStockChange(before, product)
+	}
+
+	updated := make([]*models.Product, 0, len(adjustments))
+	for _, adj := range adjustments {
+		updated = append(updated, db.snapshot(db.products[adj.ProductID]))
+	}
+
+	return updated, nil
+}
+
+// snapshot retorna uma cópia do produto com todos os campos calculados na
+// leitura: composição de kits, preço promocional vigente e imagens.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) snapshot(product *models.Product) *models.Product {
+	resolved := db.resolve(product)
+	db.applyPromotions(resolved, time.Now())
+	db.attachImages(resolved)
+	return resolved
+}
+
+// applyStockDelta aplica uma variação ao estoque físico do produto, consumindo
+// lotes em ordem FEFO quando o produto é controlado por lote. Lotes vencidos
+// só são consumidos quando incluirVencidos é verdadeiro, como nos ajustes e
+// contagens que dão baixa em produtos descartados. Produtos serializados são
+// rejeitados, pois exigem os números de série movimentados.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) applyStockDelta(product *models.Product, delta int, incluirVencidos bool) error {
+	if product.Serializado {
+		return fmt.Errorf("%w: movimentações do produto %s devem informar os números de série",
+			ErrSerialRequired, product.ID)
+	}
+	if product.ControlaLote {
+		if delta > 0 {
+			return fmt.Errorf("%w: entradas do produto %s devem ser registradas em um lote",
+				ErrLotRequired, product.ID)
+		}
+		if product.Quantidade+delta < product.QuantidadeReservada {
+			return fmt.Errorf("%w: produto %s possui %d unidade(s) disponível(is), ajuste de %d",
+				ErrInsufficientStock, product.ID, product.AvailableQuantity(), delta)
+		}
+		if !incluirVencidos {
+			if err := db.checkValidLots(product, -delta); err != nil {
+				return err
+			}
+		}
+		db.consumeLotsFEFO(product.ID, -delta, incluirVencidos)
+	}
+	return product.AdjustStock(delta)
+}
+
 // GetByCategory retorna produtos de uma categoria específica
 func (db *InMemoryDatabase) GetByCategory(category models.ProductCategory) ([]*models.Product, error) {
 	options := FilterOptions{
@@ -259,39 +754,55 @@ func (db *InMemoryDatabase) GetStatistics() (map[string]interface{}, error) {
 
 	stats := make(map[string]interface{})
 	categoryStats := make(map[models.ProductCategory]*CategoryStats)
-	
+	statusStats := make(map[models.ProductStatus]*StatusStats)
+	tagStats := make(map[string]*TagStats)
+
 	var totalProdutos, produtosAtivos, produtosInativos, produtosEmEstoque, produtosSemEstoque int
+	var produtosReposicao int
 	var valorTotal, precoMinimo, precoMaximo float64
+	var valorCustoFIFO, valorCustoMedio float64
 	var quantidadeTotal int
-	
+
 	precoMinimo = -1 // Inicializa com -1 para detectar primeiro produto
-	
+
 	for _, product := range db.products {
 		totalProdutos++
-		
+
 		if product.Ativo {
 			produtosAtivos++
 		} else {
 			produtosInativos++
 		}
-		
-		if product.IsInStock() {
+
+		// Kits têm disponibilidade e preço calculados a partir dos componentes,
+		// mas não somam valor nem quantidade para não contar o estoque duas vezes
+		resolved := db.snapshot(product)
+
+		if resolved.IsInStock() {
 			produtosEmEstoque++
 		} else {
 			produtosSemEstoque++
 		}
-		
+
+		if product.Ativo && !product.IsKit() && product.NeedsReorder() {
+			produtosReposicao++
+		}
+
 		valorTotal += product.Preco * float64(product.Quantidade)
 		quantidadeTotal += product.Quantidade
-		
+
+		value := db.valuation(product)
+		valorCustoFIFO += value.ValorCustoFIFO
+		valorCustoMedio += value.ValorCustoMedio
+
 		// Atualiza preços mínimo e máximo
-		if precoMinimo == -1 || product.Preco < precoMinimo {
-			precoMinimo = product.Preco
+		if precoMinimo == -1 || resolved.Preco < precoMinimo {
+			precoMinimo = resolved.Preco
 		}
-		if product.Preco > precoMaximo {
-			precoMaximo = product.Preco
+		if resolved.Preco > precoMaximo {
+			precoMaximo = resolved.Preco
 		}
-		
+
 		// Estatísticas por categoria
 		if categoryStats[product.Categoria] == nil {
 			categoryStats[product.Categoria] = &CategoryStats{
@@ -304,34 +815,66 @@ func (db *InMemoryDatabase) GetStatistics() (map[string]interface{}, error) {
 			cat.ProdutosAtivos++
 		}
 		cat.ValorTotal += product.Preco * float64(product.Quantidade)
+		cat.ValorCustoFIFO += value.ValorCustoFIFO
+		cat.ValorCustoMedio += value.ValorCustoMedio
 		cat.QuantidadeTotal += product.Quantidade
+
+		// Estatísticas por situação do ciclo de vida
+		if statusStats[product.Status] == nil {
+			statusStats[product.Status] = &StatusStats{
+				Status: product.Status,
+			}
+		}
+		st := statusStats[product.Status]
+		st.TotalProdutos++
+		st.QuantidadeTotal += product.Quantidade
+		st.ValorTotal += product.Preco * float64(product.Quantidade)
+
+		// Estatísticas por tag; um produto conta em cada uma de suas tags
+		for _, tag := range product.Tags {
+			if tagStats[tag] == nil {
+				tagStats[tag] = &TagStats{Tag: tag}
+			}
+			tg := tagStats[tag]
+			tg.TotalProdutos++
+			if product.Ativo {
+				tg.ProdutosAtivos++
+			}
+			tg.QuantidadeTotal += product.Quantidade
+			tg.ValorTotal += product.Preco * float64(product.Quantidade)
+		}
 	}
-	
+
 	// Calcula preço médio
 	var precoMedio float64
 	if totalProdutos > 0 {
 		precoMedio = valorTotal / float64(quantidadeTotal)
 	}
-	
+
 	// Calcula preço médio por categoria
 	for _, cat := range categoryStats {
 		if cat.QuantidadeTotal > 0 {
 			cat.PrecoMedio = cat.ValorTotal / float64(cat.QuantidadeTotal)
 		}
 	}
-	
+
 	stats["total_produtos"] = totalProdutos
 	stats["produtos_ativos"] = produtosAtivos
 	stats["produtos_inativos"] = produtosInativos
 	stats["produtos_em_estoque"] = produtosEmEstoque
 	stats["produtos_sem_estoque"] = produtosSemEstoque
+	stats["produtos_reposicao"] = produtosReposicao
 	stats["valor_total_inventario"] = valorTotal
+	stats["valor_custo_fifo"] = valorCustoFIFO
+	stats["valor_custo_medio"] = valorCustoMedio
 	stats["preco_medio"] = precoMedio
 	stats["preco_minimo"] = precoMinimo
 	stats["preco_maximo"] = precoMaximo
 	stats["quantidade_total"] = quantidadeTotal
 	stats["por_categoria"] = categoryStats
-	
+	stats["por_status"] = statusStats
+	stats["por_tag"] = tagStats
+
 	return stats, nil
 }
 
@@ -341,75 +884,142 @@ type CategoryStats struct {
 	TotalProdutos   int                    `json:"total_produtos"`
 	ProdutosAtivos  int                    `json:"produtos_ativos"`
 	ValorTotal      float64                `json:"valor_total"`
+	ValorCustoFIFO  float64                `json:"valor_custo_fifo"`
+	ValorCustoMedio float64                `json:"valor_custo_medio"`
 	PrecoMedio      float64                `json:"preco_medio"`
 	QuantidadeTotal int                    `json:"quantidade_total"`
 }
 
+// StatusStats representa estatísticas de uma situação do ciclo de vida
+type StatusStats struct {
+	Status          models.ProductStatus `json:"status"`
+	TotalProdutos   int                  `json:"total_produtos"`
+	QuantidadeTotal int                  `json:"quantidade_total"`
+	ValorTotal      float64              `json:"valor_total"`
+}
+
+// TagStats representa estatísticas dos produtos com uma tag
+type TagStats struct {
+	Tag             string  `json:"tag"`
+	TotalProdutos   int     `json:"total_produtos"`
+	ProdutosAtivos  int     `json:"produtos_ativos"`
+	QuantidadeTotal int     `json:"quantidade_total"`
+	ValorTotal      float64 `json:"valor_total"`
+}
+
 // seedData inicializa o banco com dados de exemplo
 func (db *InMemoryDatabase) seedData() {
 	produtos := []*models.Product{
 		{
-			ID:          uuid.New(),
-			Nome:        "Smartphone Samsung Galaxy S24",
-			Descricao:   "Smartphone com tela de 6.1 polegadas, câmera de 50MP e 5G",
-			Preco:       2299.99,
-			Quantidade:  25,
-			Categoria:   models.CategoryEletronicos,
-			Ativo:       true,
+			ID:                  uuid.New(),
+			Nome:                "Smartphone Samsung Galaxy S24",
+			Descricao:           "Smartphone com tela de 6.1 polegadas, câmera de 50MP e 5G",
+			Preco:               2299.99,
+			PrecoCusto:          1580.00,
+			Quantidade:          25,
+			EstoqueMinimo:       10,
+			QuantidadeReposicao: 20,
+			Categoria:           models.CategoryEletronicos,
+			Tags:                []string{"5g", "lançamento"},
+			Traducoes: map[models.Locale]models.ProductTranslation{
+				models.LocaleSpanish: {Nome: "Teléfono inteligente Samsung Galaxy S24", Descricao: "Teléfono inteligente con pantalla de 6,1 pulgadas, cámara de 50MP y 5G"},
+				models.LocaleEnglish: {Nome: "Samsung Galaxy S24 Smartphone", Descricao: "Smartphone with a 6.1-inch display, 50MP camera and 5G"},
+			},
+			CodigoBarras: "7891000001011",
+			Dimensoes:    &models.Dimensions{PesoKg: 0.45, ComprimentoCm: 18, LarguraCm: 10, AlturaCm: 6, Embalagem: "caixa"},
+			Fiscal:       &models.FiscalData{NCM: "85171300", CEST: "2105300", CFOPEstadual: "5102", CFOPInterestadual: "6102", Origem: models.OrigemNacionalPPB},
+			Ativo:        true,
 		},
 		{
-			ID:          uuid.New(),
-			Nome:        "Notebook Dell Inspiron",
-			Descricao:   "Notebook com processador Intel i7, 16GB RAM e SSD 512GB",
-			Preco:       3499.99,
-			Quantidade:  10,
-			Categoria:   models.CategoryEletronicos,
-			Ativo:       true,
+			ID:                  uuid.New(),
+			Nome:                "Notebook Dell Inspiron",
+			Descricao:           "Notebook com processador Intel i7, 16GB RAM e SSD 512GB",
+			Preco:               3499.99,
+			PrecoCusto:          2650.00,
+			Quantidade:          10,
+			EstoqueMinimo:       12,
+			QuantidadeReposicao: 10,
+			Categoria:           models.CategoryEletronicos,
+			Tags:                []string{"frágil", "importado"},
+			CodigoBarras:        "7891000001028",
+			Dimensoes:           &models.Dimensions{PesoKg: 2.8, ComprimentoCm: 45, LarguraCm: 32, AlturaCm: 8, Embalagem: "caixa"},
+			Fiscal:              &models.FiscalData{NCM: "84713012", CFOPEstadual: "5102", CFOPInterestadual: "6102", Origem: models.OrigemEstrangeiraMercadoInterno},
+			Ativo:               true,
 		},
 		{
-			ID:          uuid.New(),
-			Nome:        "Camiseta Nike Dri-FIT",
-			Descricao:   "Camiseta esportiva com tecnologia que remove o suor",
-			Preco:       89.99,
-			Quantidade:  50,
-			Categoria:   models.CategoryRoupas,
-			Ativo:       true,
+			ID:           uuid.New(),
+			Nome:         "Camiseta Nike Dri-FIT",
+			Descricao:    "Camiseta esportiva com tecnologia que remove o suor",
+			Preco:        89.99,
+			PrecoCusto:   42.50,
+			Quantidade:   50,
+			Categoria:    models.CategoryRoupas,
+			Tags:         []string{"importado"},
+			CodigoBarras: "7891000001035",
+			Dimensoes:    &models.Dimensions{PesoKg: 0.25, ComprimentoCm: 30, LarguraCm: 25, AlturaCm: 3, Embalagem: "envelope"},
+			Fiscal:       &models.FiscalData{NCM: "61091000", CFOPEstadual: "5102", CFOPInterestadual: "6102", Origem: models.OrigemEstrangeiraMercadoInterno},
+			Ativo:        true,
 		},
 		{
-			ID:          uuid.New(),
-			Nome:        "Livro Clean Code",
-			Descricao:   "Manual de programação limpa por Robert C. Martin",
-			Preco:       65.90,
-			Quantidade:  30,
-			Categoria:   models.CategoryLivros,
-			Ativo:       true,
+			ID:        uuid.New(),
+			Nome:      "Livro Clean Code",
+			Descricao: "Manual de programação limpa por Robert C. Martin",
+			Traducoes: map[models.Locale]models.ProductTranslation{
+				models.LocaleSpanish: {Nome: "Libro Código Limpio", Descricao: "Manual de programación limpia de Robert C. Martin"},
+				models.LocaleEnglish: {Nome: "Clean Code Book"},
+			},
+			Preco:        65.90,
+			PrecoCusto:   38.00,
+			Quantidade:   30,
+			Categoria:    models.CategoryLivros,
+			CodigoBarras: "9780132350884",
+			Dimensoes:    &models.Dimensions{PesoKg: 0.8, ComprimentoCm: 24, LarguraCm: 17, AlturaCm: 4, Embalagem: "envelope"},
+			Fiscal:       &models.FiscalData{NCM: "49019900", CFOPEstadual: "5102", CFOPInterestadual: "6102", Origem: models.OrigemNacional},
+			Ativo:        true,
 		},
 		{
-			ID:          uuid.New(),
-			Nome:        "Bicicleta Mountain Bike",
-			Descricao:   "Bicicleta 21 marchas para trilhas e aventuras",
-			Preco:       1299.99,
-			Quantidade:  8,
-			Categoria:   models.CategoryEsportes,
-			Ativo:       true,
+			ID:           uuid.New(),
+			Nome:         "Bicicleta Mountain Bike",
+			Descricao:    "Bicicleta 21 marchas para trilhas e aventuras",
+			Preco:        1299.99,
+			PrecoCusto:   870.00,
+			Quantidade:   8,
+			Categoria:    models.CategoryEsportes,
+			Tags:         []string{"volumoso"},
+			CodigoBarras: "7891000001059",
+			Dimensoes:    &models.Dimensions{PesoKg: 16, ComprimentoCm: 140, LarguraCm: 25, AlturaCm: 80, Embalagem: "caixa"},
+			Fiscal:       &models.FiscalData{NCM: "87120010", CFOPEstadual: "5102", CFOPInterestadual: "6102", Origem: models.OrigemNacional},
+			Ativo:        true,
 		},
 		{
-			ID:          uuid.New(),
-			Nome:        "Perfume Masculino Hugo Boss",
-			Descricao:   "Fragrância sofisticada de 100ml",
-			Preco:       189.99,
-			Quantidade:  0, // Sem estoque
-			Categoria:   models.CategoryBeleza,
-			Ativo:       false, // Inativo
+			ID:           uuid.New(),
+			Nome:         "Perfume Masculino Hugo Boss",
+			Descricao:    "Fragrância sofisticada de 100ml",
+			Preco:        189.99,
+			PrecoCusto:   112.00,
+			Quantidade:   0, // Sem estoque
+			Categoria:    models.CategoryBeleza,
+			Tags:         []string{"frágil", "importado"},
+			CodigoBarras: "7891000001066",
+			Dimensoes:    &models.Dimensions{PesoKg: 0.5, ComprimentoCm: 12, LarguraCm: 8, AlturaCm: 18, Embalagem: "caixa"},
+			Fiscal:       &models.FiscalData{NCM: "33030010", CFOPEstadual: "5102", CFOPInterestadual: "6102", Origem: models.OrigemEstrangeiraImportacaoDireta},
+			Ativo:        false, // Inativo
 		},
 		{
-			ID:          uuid.New(),
-			Nome:        "Sofá 3 Lugares",
-			Descricao:   "Sofá confortável para sala de estar",
-			Preco:       899.99,
-			Quantidade:  5,
-			Categoria:   models.CategoryCasa,
-			Ativo:       true,
+			ID:                  uuid.New(),
+			Nome:                "Sofá 3 Lugares",
+			Descricao:           "Sofá confortável para sala de estar",
+			Preco:               899.99,
+			PrecoCusto:          610.00,
+			Quantidade:          5,
+			EstoqueMinimo:       5,
+			QuantidadeReposicao: 4,
+			Categoria:           models.CategoryCasa,
+			Tags:                []string{"volumoso"},
+			CodigoBarras:        "7891000001073",
+			Dimensoes:           &models.Dimensions{PesoKg: 55, ComprimentoCm: 210, LarguraCm: 90, AlturaCm: 85, Embalagem: "plástico bolha"},
+			Fiscal:              &models.FiscalData{NCM: "94016100", CFOPEstadual: "5102", CFOPInterestadual: "6102", Origem: models.OrigemNacional},
+			Ativo:               true,
 		},
 	}
 
@@ -417,6 +1027,12 @@ func (db *InMemoryDatabase) seedData() {
 		now := time.Now()
 		produto.DataCriacao = now
 		produto.DataAtualizacao = now
+		produto.Status = models.StatusFromActive(produto.Ativo)
+		if produto.Quantidade > 0 {
+			db.recordCostMovement(produto, produto.Quantidade, 0, "estoque inicial")
+		}
+		db.recordPriceChange(produto, 0, models.PriceOriginCadastro, "", now)
+		db.recordStatusChange(produto, "", "cadastro", "", now)
 		db.products[produto.ID] = produto
 	}
-}
\ No newline at end of file
+}
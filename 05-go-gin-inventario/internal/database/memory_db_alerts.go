@@ -0,0 +1,65 @@
+package database
+
+import (
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// maxStockAlerts limita quantos alertas ficam retidos em memória
+const maxStockAlerts = 500
+
+// GetStockAlerts retorna os alertas de estoque mais recentes primeiro.
+// Um limite menor ou igual a zero retorna todos os alertas retidos.
+func (db *InMemoryDatabase) GetStockAlerts(limit int) ([]models.StockAlert, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	total := len(db.stockAlerts)
+	if limit <= 0 || limit > total {
+		limit = total
+	}
+
+	alerts := make([]models.StockAlert, 0, limit)
+	for i := total - 1; i >= total-limit; i-- {
+		alerts = append(alerts, db.stockAlerts[i])
+	}
+
+	return alerts, nil
+}
+
+// trackStockChange compara o produto antes e depois de uma alteração de
+// estoque e emite um alerta quando o ponto de reposição é cruzado.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) trackStockChange(before models.Product, after *models.Product) {
+	var tipo models.StockAlertType
+	switch {
+	case !before.NeedsReorder() && after.NeedsReorder():
+		tipo = models.StockAlertAbaixoMinimo
+	case before.NeedsReorder() && !after.NeedsReorder():
+		tipo = models.StockAlertNormalizado
+	default:
+		return
+	}
+
+	alert := models.StockAlert{
+		ID:                   uuid.New(),
+		ProdutoID:            after.ID,
+		NomeProduto:          after.Nome,
+		Tipo:                 tipo,
+		QuantidadeDisponivel: after.AvailableQuantity(),
+		EstoqueMinimo:        after.EstoqueMinimo,
+		QuantidadeSugerida:   after.SuggestedReorderQuantity(),
+		DataCriacao:          time.Now(),
+	}
+
+	db.stockAlerts = append(db.stockAlerts, alert)
+	if len(db.stockAlerts) > maxStockAlerts {
+		db.stockAlerts = db.stockAlerts[len(db.stockAlerts)-maxStockAlerts:]
+	}
+
+	log.Printf("🔔 Alerta de estoque [%s]: %s (disponível %d, mínimo %d)",
+		alert.Tipo, alert.NomeProduto, alert.QuantidadeDisponivel, alert.EstoqueMinimo)
+}
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// CreateAttachment registra os metadados de um anexo já gravado no
+// armazenamento de arquivos. A primeira imagem do produto, ou uma imagem
+// enviada como principal, passa a ser a imagem principal.
+func (db *InMemoryDatabase) CreateAttachment(attachment *models.Attachment) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if _, exists := db.products[attachment.ProdutoID]; !exists {
+		return fmt.Errorf("%w: ID %s", ErrProductNotFound, attachment.ProdutoID)
+	}
+	if !attachment.Tipo.IsValid() {
+		return fmt.Errorf("%w: tipo %q desconhecido", ErrAttachmentInvalid, attachment.Tipo)
+	}
+
+	if attachment.ID == uuid.Nil {
+		attachment.ID = uuid.New()
+	}
+	attachment.DataCriacao = time.Now()
+
+	attachments := db.attachments[attachment.ProdutoID]
+	if attachment.IsImage() {
+		if attachment.Principal {
+			for _, existing := range attachments {
+				existing.Principal = false
+			}
+		} else {
+			attachment.Principal = principalImage(attachments) == nil
+		}
+	} else {
+		attachment.Principal = false
+	}
+
+	db.attachments[attachment.ProdutoID] = append(attachments, attachment.Clone())
+	return nil
+}
+
+// GetAttachments retorna os anexos do produto, opcionalmente de um só tipo.
+// Imagens vêm primeiro, com a principal à frente, e depois por data de envio.
+func (db *InMemoryDatabase) GetAttachments(productID uuid.UUID, tipo *models.AttachmentKind) ([]*models.Attachment, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if _, exists := db.products[productID]; !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	attachments := make([]*models.Attachment, 0)
+	for _, attachment := range db.attachments[productID] {
+		if tipo != nil && attachment.Tipo != *tipo {
+			continue
+		}
+		attachments = append(attachments, attachment.Clone())
+	}
+	sortAttachments(attachments)
+
+	return attachments, nil
+}
+
+// DeleteAttachment remove os metadados de um anexo e retorna o registro
+// removido para que os arquivos sejam apagados. Ao remover a imagem
+// principal, a imagem mais antiga restante assume o lugar.
+func (db *InMemoryDatabase) DeleteAttachment(productID, attachmentID uuid.UUID) (*models.Attachment, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if _, exists := db.products[productID]; !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	attachments := db.attachments[productID]
+	for i, attachment := range attachments {
+		if attachment.ID != attachmentID {
+			continue
+		}
+
+		remaining := append(attachments[:i:i], attachments[i+1:]...)
+		if attachment.Principal {
+			sortAttachments(remaining)
+			for _, candidate := range remaining {
+				if candidate.IsImage() {
+					candidate.Principal = true
+					break
+				}
+			}
+		}
+		db.attachments[productID] = remaining
+
+		return attachment.Clone(), nil
+	}
+
+	return nil, fmt.Errorf("%w: ID %s", ErrAttachmentNotFound, attachmentID)
+}
+
+// attachImages preenche as imagens do produto na leitura.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) attachImages(product *models.Product) {
+	product.Imagens = nil
+	images := make([]*models.Attachment, 0)
+	for _, attachment := range db.attachments[product.ID] {
+		if attachment.IsImage() {
+			images = append(images, attachment)
+		}
+	}
+	if len(images) == 0 {
+		return
+	}
+
+	sortAttachments(images)
+	product.Imagens = make([]models.Attachment, len(images))
+	for i, image := range images {
+		product.Imagens[i] = *image.Clone()
+	}
+}
+
+// principalImage retorna a imagem principal entre os anexos, se houver
+func principalImage(attachments []*models.Attachment) *models.Attachment {
+	for _, attachment := range attachments {
+		if attachment.IsImage() && attachment.Principal {
+			return attachment
+		}
+	}
+	return nil
+}
+
+// sortAttachments ordena imagens antes de documentos, a imagem principal à
+// frente e, em seguida, por data de envio
+func sortAttachments(attachments []*models.Attachment) {
+	sort.SliceStable(attachments, func(i, j int) bool {
+		a, b := attachments[i], attachments[j]
+		if a.IsImage() != b.IsImage() {
+			return a.IsImage()
+		}
+		if a.Principal != b.Principal {
+			return a.Principal
+		}
+		return a.DataCriacao.Before(b.DataCriacao)
+	})
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// ProductOperation é um item de um lote de produtos. Produto traz o novo
+// cadastro; nas atualizações, Modificar monta a nova versão e a transição de
+// situação a partir do produto gravado, já sob o lock do lote, e não pode
+// acessar o banco.
+type ProductOperation struct {
+	Tipo      models.BulkOperation
+	ProdutoID uuid.UUID
+	Produto   *models.Product
+	Modificar ProductModifier
+	Transicao *StatusTransition
+}
+
+// ProductOperationResult traz o produto gravado (nil nas remoções) ou o erro
+// que impediu o item
+type ProductOperationResult struct {
+	Produto *models.Product
+	Err     error
+}
+
+// ApplyProductBatch executa um lote de cadastros, atualizações e remoções sob
+// um único lock. No modo atômico todos os itens são validados contra o estado
+// anterior ao lote e, se algum falhar, nenhum é aplicado; os demais recebem
+// ErrBulkAborted. Caso contrário cada item é aplicado na ordem, se válido.
+// Um mesmo produto só pode aparecer uma vez no lote.
+func (db *InMemoryDatabase) ApplyProductBatch(operations []ProductOperation, atomic bool) []ProductOperationResult {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	results := make([]ProductOperationResult, len(operations))
+	seen := make(map[uuid.UUID]bool)
+
+	if !atomic {
+		for i := range operations {
+			existing, err := db.prepareProductOperation(&operations[i], seen, nil)
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			results[i].Produto = db.commitProductOperation(&operations[i], existing)
+		}
+		return results
+	}
+
+	// Itens ainda não gravados não são vistos por checkBarcode
+	barcodes := make(map[string]uuid.UUID)
+	existing := make([]*models.Product, len(operations))
+	failed := false
+	for i := range operations {
+		existing[i], results[i].Err = db.prepareProductOperation(&operations[i], seen, barcodes)
+		failed = failed || results[i].Err != nil
+	}
+
+	if failed {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = ErrBulkAborted
+			}
+		}
+		return results
+	}
+
+	for i := range operations {
+		results[i].Produto = db.commitProductOperation(&operations[i], existing[i])
+	}
+	return results
+}
+
+// prepareProductOperation valida um item do lote e retorna a versão gravada
+// dos produtos atualizados. barcodes, quando informado, reserva os códigos de
+// barras dos itens validados. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) prepareProductOperation(operation *ProductOperation, seen map[uuid.UUID]bool, barcodes map[string]uuid.UUID) (*models.Product, error) {
+	if operation.Tipo != models.BulkCreate {
+		if seen[operation.ProdutoID] {
+			return nil, fmt.Errorf("%w: ID %s", ErrBulkDuplicate, operation.ProdutoID)
+		}
+		seen[operation.ProdutoID] = true
+	}
+
+	var existing *models.Product
+	var err error
+	switch operation.Tipo {
+	case models.BulkCreate:
+		err = db.prepareCreate(operation.Produto)
+	case models.BulkUpdate:
+		if err = db.modifyOperation(operation); err != nil {
+			break
+		}
+		existing, err = db.prepareUpdate(operation.ProdutoID, operation.Produto)
+		if err == nil && operation.Transicao != nil {
+			err = checkStatusTransition(operation.Produto, *operation.Transicao)
+		}
+	case models.BulkDelete:
+		err = db.prepareDelete(operation.ProdutoID)
+	default:
+		err = fmt.Errorf("%w: operação %q desconhecida", ErrBulkInvalid, operation.Tipo)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if barcodes != nil && operation.Produto != nil && operation.Produto.CodigoBarras != "" {
+		codigo := operation.Produto.CodigoBarras
+		if other, taken := barcodes[codigo]; taken && other != operation.Produto.ID {
+			return nil, fmt.Errorf("%w: %s repetido no lote", ErrBarcodeDuplicate, codigo)
+		}
+		barcodes[codigo] = operation.Produto.ID
+	}
+	return existing, nil
+}
+
+// modifyOperation monta a nova versão de uma atualização a partir do produto
+// gravado, para que movimentações feitas desde a requisição não sejam
+// sobrescritas. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) modifyOperation(operation *ProductOperation) error {
+	product, exists := db.products[operation.ProdutoID]
+	if !exists {
+		return fmt.Errorf("%w: ID %s", ErrProductNotFound, operation.ProdutoID)
+	}
+
+	updated, transition, err := operation.Modificar(db.snapshot(product))
+	if err != nil {
+		return err
+	}
+	operation.Produto = updated
+	operation.Transicao = transition
+	return nil
+}
+
+// commitProductOperation aplica um item validado por prepareProductOperation
+// e retorna o produto gravado. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) commitProductOperation(operation *ProductOperation, existing *models.Product) *models.Product {
+	switch operation.Tipo {
+	case models.BulkCreate:
+		db.commitCreate(operation.Produto)
+		return db.snapshot(db.products[operation.Produto.ID])
+	case models.BulkUpdate:
+		db.commitUpdate(existing, operation.Produto)
+		stored := db.products[operation.ProdutoID]
+		if operation.Transicao != nil {
+			db.applyStatusTransition(stored, *operation.Transicao)
+		}
+		return db.snapshot(stored)
+	default:
+		db.commitDelete(operation.ProdutoID)
+		return nil
+	}
+}
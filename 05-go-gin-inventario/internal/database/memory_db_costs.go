@@ -0,0 +1,140 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// maxExhaustedLayers limita quantas camadas de custo esgotadas ficam retidas por produto
+const maxExhaustedLayers = 20
+
+// InventoryValuation representa o valor em estoque de um produto a custo
+// (FIFO e média ponderada) e a preço de venda
+type InventoryValuation struct {
+	Produto         *models.Product
+	ValorCustoFIFO  float64
+	ValorCustoMedio float64
+	ValorVenda      float64
+}
+
+// GetCostLayers retorna as camadas de custo de um produto na ordem de consumo (FIFO)
+func (db *InMemoryDatabase) GetCostLayers(productID uuid.UUID, apenasComSaldo bool) ([]models.CostLayer, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if _, exists := db.products[productID]; !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	layers := make([]models.CostLayer, 0, len(db.costLayers[productID]))
+	for _, layer := range db.costLayers[productID] {
+		if apenasComSaldo && layer.Quantidade == 0 {
+			continue
+		}
+		layers = append(layers, *layer)
+	}
+
+	return layers, nil
+}
+
+// GetInventoryValuation valora o estoque de todos os produtos que possuem
+// estoque próprio. Kits ficam de fora para não contar os componentes duas vezes.
+func (db *InMemoryDatabase) GetInventoryValuation() ([]InventoryValuation, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	valuations := make([]InventoryValuation, 0, len(db.products))
+	for _, product := range db.products {
+		if product.IsKit() {
+			continue
+		}
+		valuations = append(valuations, db.valuation(product))
+	}
+
+	return valuations, nil
+}
+
+// valuation calcula o valor em estoque de um produto.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) valuation(product *models.Product) InventoryValuation {
+	return InventoryValuation{
+		Produto:         db.snapshot(product),
+		ValorCustoFIFO:  db.fifoValue(product.ID),
+		ValorCustoMedio: product.CustoMedio * float64(product.Quantidade),
+		ValorVenda:      product.Preco * float64(product.Quantidade),
+	}
+}
+
+// fifoValue soma o saldo das camadas de custo do produto.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) fifoValue(productID uuid.UUID) float64 {
+	total := 0.0
+	for _, layer := range db.costLayers[productID] {
+		total += layer.CustoUnitario * float64(layer.Quantidade)
+	}
+	return total
+}
+
+// recordCostMovement registra o efeito de uma variação de estoque já aplicada
+// ao produto: entradas criam uma camada de custo e recalculam o custo médio;
+// saídas consomem as camadas mais antigas. Um custo unitário zero usa o preço
+// de custo cadastrado; um custo informado passa a ser o último preço de custo.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) recordCostMovement(product *models.Product, delta int, custoUnitario float64, referencia string) {
+	switch {
+	case delta > 0:
+		if custoUnitario > 0 {
+			product.PrecoCusto = custoUnitario
+		} else {
+			custoUnitario = product.PrecoCusto
+		}
+		product.ReceiveCost(product.Quantidade-delta, delta, custoUnitario)
+
+		db.costLayers[product.ID] = append(db.costLayers[product.ID], &models.CostLayer{
+			ID:                uuid.New(),
+			ProdutoID:         product.ID,
+			CustoUnitario:     custoUnitario,
+			QuantidadeInicial: delta,
+			Quantidade:        delta,
+			Referencia:        referencia,
+			DataEntrada:       time.Now(),
+		})
+	case delta < 0:
+		quantidade := -delta
+		for _, layer := range db.costLayers[product.ID] {
+			if quantidade == 0 {
+				break
+			}
+			quantidade -= layer.Consume(quantidade)
+		}
+		db.compactCostLayers(product.ID)
+	}
+}
+
+// compactCostLayers descarta as camadas esgotadas mais antigas, mantendo as
+// mais recentes para consulta. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) compactCostLayers(productID uuid.UUID) {
+	layers := db.costLayers[productID]
+	exhausted := 0
+	for exhausted < len(layers) && layers[exhausted].Quantidade == 0 {
+		exhausted++
+	}
+	if exhausted > maxExhaustedLayers {
+		db.costLayers[productID] = layers[exhausted-maxExhaustedLayers:]
+	}
+}
+
+// kitCost soma o custo unitário dos componentes de um kit multiplicado pelas
+// quantidades da composição. Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) kitCost(kit *models.Product) float64 {
+	total := 0.0
+	for _, component := range kit.Componentes {
+		if product, exists := db.products[component.ProdutoID]; exists {
+			total += product.UnitCost() * float64(component.Quantidade)
+		}
+	}
+	return total
+}
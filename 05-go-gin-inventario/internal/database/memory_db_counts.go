@@ -0,0 +1,302 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// InventoryCountFilter define os filtros da listagem de contagens de inventário
+type InventoryCountFilter struct {
+	Status    *models.InventoryCountStatus
+	ProdutoID *uuid.UUID
+}
+
+// CountEntry representa a quantidade contada de um produto
+type CountEntry struct {
+	ProdutoID  uuid.UUID
+	Quantidade int
+	ContadoPor string
+}
+
+// CreateInventoryCount abre uma contagem congelando a quantidade esperada e o
+// custo unitário de cada produto do escopo: produtos da categoria e/ou com
+// localização iniciada pelo prefixo informado. Kits, que não têm estoque
+// próprio, e produtos serializados, conferidos pelos números de série, ficam
+// de fora. Um produto só pode estar em uma contagem em andamento por vez.
+func (db *InMemoryDatabase) CreateInventoryCount(count *models.InventoryCount) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	emAndamento := make(map[uuid.UUID]string)
+	for _, existing := range db.inventoryCounts {
+		if !existing.IsInProgress() {
+			continue
+		}
+		for _, item := range existing.Itens {
+			emAndamento[item.ProdutoID] = existing.Numero
+		}
+	}
+
+	itens := make([]models.InventoryCountItem, 0)
+	for _, product := range db.products {
+		if product.IsKit() || product.Serializado {
+			continue
+		}
+		if count.Categoria != nil && product.Categoria != *count.Categoria {
+			continue
+		}
+		if count.Localizacao != "" && !strings.HasPrefix(product.Localizacao, count.Localizacao) {
+			continue
+		}
+		if numero, exists := emAndamento[product.ID]; exists {
+			return fmt.Errorf("%w: %s está na contagem %s", ErrCountConflict, product.Nome, numero)
+		}
+		itens = append(itens, models.InventoryCountItem{
+			ProdutoID:          product.ID,
+			Nome:               product.Nome,
+			Categoria:          product.Categoria,
+			Localizacao:        product.Localizacao,
+			QuantidadeEsperada: product.Quantidade,
+			CustoUnitario:      product.UnitCost(),
+		})
+	}
+
+	if len(itens) == 0 {
+		return fmt.Errorf("%w: nenhum produto no escopo informado", ErrCountInvalid)
+	}
+
+	// Ordem de percurso no depósito: localização e depois nome
+	sort.Slice(itens, func(i, j int) bool {
+		if itens[i].Localizacao != itens[j].Localizacao {
+			return itens[i].Localizacao < itens[j].Localizacao
+		}
+		return itens[i].Nome < itens[j].Nome
+	})
+
+	db.inventoryCountSeq++
+	if count.ID == uuid.Nil {
+		count.ID = uuid.New()
+	}
+	now := time.Now()
+	count.Numero = fmt.Sprintf("INV-%06d", db.inventoryCountSeq)
+	count.Status = models.CountAberta
+	count.Itens = itens
+	count.Ajustes = 0
+	count.DataAbertura = now
+	count.DataAtualizacao = now
+
+	db.inventoryCounts[count.ID] = count.Clone()
+	return nil
+}
+
+// GetInventoryCount busca uma contagem por ID
+func (db *InMemoryDatabase) GetInventoryCount(id uuid.UUID) (*models.InventoryCount, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	count, exists := db.inventoryCounts[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrCountNotFound, id)
+	}
+
+	return count.Clone(), nil
+}
+
+// ListInventoryCounts retorna as contagens filtradas, das mais recentes para as mais antigas
+func (db *InMemoryDatabase) ListInventoryCounts(filter InventoryCountFilter) ([]*models.InventoryCount, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	counts := make([]*models.InventoryCount, 0)
+	for _, count := range db.inventoryCounts {
+		if filter.Status != nil && count.Status != *filter.Status {
+			continue
+		}
+		if filter.ProdutoID != nil && count.Item(*filter.ProdutoID) == nil {
+			continue
+		}
+		counts = append(counts, count.Clone())
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i].Numero > counts[j].Numero
+	})
+
+	return counts, nil
+}
+
+// RecordCounts registra quantidades contadas em uma contagem aberta. Contagens
+// de um produto já contado substituem a anterior (recontagem). Todos os
+// produtos são validados antes de qualquer registro.
+func (db *InMemoryDatabase) RecordCounts(id uuid.UUID, entries []CountEntry) (*models.InventoryCount, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	count, exists := db.inventoryCounts[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrCountNotFound, id)
+	}
+	if count.Status != models.CountAberta {
+		return nil, fmt.Errorf("%w: contagem %s está %s", ErrCountStatus, count.Numero, count.Status)
+	}
+
+	vistos := make(map[uuid.UUID]bool, len(entries))
+	for _, entry := range entries {
+		if count.Item(entry.ProdutoID) == nil {
+			return nil, fmt.Errorf("%w: produto %s não faz parte da contagem %s", ErrCountInvalid, entry.ProdutoID, count.Numero)
+		}
+		if entry.Quantidade < 0 {
+			return nil, fmt.Errorf("%w: quantidade contada não pode ser negativa", ErrCountInvalid)
+		}
+		if vistos[entry.ProdutoID] {
+			return nil, fmt.Errorf("%w: produto %s informado mais de uma vez", ErrCountInvalid, entry.ProdutoID)
+		}
+		vistos[entry.ProdutoID] = true
+	}
+
+	for _, entry := range entries {
+		count.Item(entry.ProdutoID).Count(entry.Quantidade, entry.ContadoPor)
+	}
+	count.DataAtualizacao = time.Now()
+
+	return count.Clone(), nil
+}
+
+// CloseInventoryCount encerra a contagem para revisão das divergências. Todos
+// os itens devem ter sido contados.
+func (db *InMemoryDatabase) CloseInventoryCount(id uuid.UUID) (*models.InventoryCount, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	count, exists := db.inventoryCounts[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrCountNotFound, id)
+	}
+	if count.Status != models.CountAberta {
+		return nil, fmt.Errorf("%w: contagem %s está %s", ErrCountStatus, count.Numero, count.Status)
+	}
+	if pendentes := count.Pending(); pendentes > 0 {
+		return nil, fmt.Errorf("%w: %d item(ns) da contagem %s ainda não foram contados",
+			ErrCountIncomplete, pendentes, count.Numero)
+	}
+
+	count.SetStatus(models.CountEncerrada)
+	return count.Clone(), nil
+}
+
+// ReopenInventoryCount reabre uma contagem encerrada para recontagens
+func (db *InMemoryDatabase) ReopenInventoryCount(id uuid.UUID) (*models.InventoryCount, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	count, exists := db.inventoryCounts[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrCountNotFound, id)
+	}
+	if count.Status != models.CountEncerrada {
+		return nil, fmt.Errorf("%w: contagem %s está %s", ErrCountStatus, count.Numero, count.Status)
+	}
+
+	count.SetStatus(models.CountAberta)
+	return count.Clone(), nil
+}
+
+// PostInventoryCount aprova uma contagem encerrada e lança todos os ajustes
+// de uma só vez. Cada ajuste é a divergência entre o contado e o esperado
+// congelado na abertura, aplicada sobre o estoque atual, de modo que as
+// movimentações ocorridas durante a contagem são preservadas. Sobras de
+// produtos controlados por lote precisam ser registradas em um lote e impedem
+// a postagem; faltas consomem os lotes em ordem FEFO.
+func (db *InMemoryDatabase) PostInventoryCount(id uuid.UUID, aprovadoPor string) (*models.InventoryCount, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	count, exists := db.inventoryCounts[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrCountNotFound, id)
+	}
+	if count.Status != models.CountEncerrada {
+		return nil, fmt.Errorf("%w: contagem %s está %s", ErrCountStatus, count.Numero, count.Status)
+	}
+
+	// Primeira passada: valida todos os ajustes
+	for _, item := range count.Itens {
+		delta := item.Variance()
+		if delta == 0 {
+			continue
+		}
+		product, exists := db.products[item.ProdutoID]
+		if !exists {
+			return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, item.ProdutoID)
+		}
+		if product.Serializado {
+			return nil, fmt.Errorf("%w: ajustes do produto %s devem informar os números de série",
+				ErrSerialRequired, product.Nome)
+		}
+		if product.ControlaLote && delta > 0 {
+			return nil, fmt.Errorf("%w: a sobra de %d unidade(s) de %s deve ser registrada em um lote",
+				ErrLotRequired, delta, product.Nome)
+		}
+		if product.Quantidade+delta < product.QuantidadeReservada {
+			return nil, fmt.Errorf("%w: %s possui %d unidade(s) disponível(is), ajuste de %d",
+				ErrInsufficientStock, product.Nome, product.AvailableQuantity(), delta)
+		}
+	}
+
+	// Segunda passada: aplica os ajustes já validados
+	referencia := "inventário " + count.Numero
+	ajustes := 0
+	for _, item := range count.Itens {
+		delta := item.Variance()
+		if delta == 0 {
+			continue
+		}
+		product := db.products[item.ProdutoID]
+		before := *product
+		if err := db.applyStockDelta(product, delta, true); err != nil {
+			return nil, err
+		}
+		db.recordCostMovement(product, delta, 0, referencia)
+		db.trackStockChange(before, product)
+		ajustes++
+	}
+
+	count.Ajustes = ajustes
+	count.AprovadoPor = aprovadoPor
+	count.SetStatus(models.CountPostada)
+
+	return count.Clone(), nil
+}
+
+// CancelInventoryCount cancela uma contagem em andamento sem alterar o estoque
+func (db *InMemoryDatabase) CancelInventoryCount(id uuid.UUID) (*models.InventoryCount, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	count, exists := db.inventoryCounts[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrCountNotFound, id)
+	}
+	if !count.IsInProgress() {
+		return nil, fmt.Errorf("%w: contagem %s está %s", ErrCountStatus, count.Numero, count.Status)
+	}
+
+	count.SetStatus(models.CountCancelada)
+	return count.Clone(), nil
+}
+
+// countInProgressWithProduct retorna a contagem em andamento que contém o
+// produto, se houver. Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) countInProgressWithProduct(productID uuid.UUID) *models.InventoryCount {
+	for _, count := range db.inventoryCounts {
+		if count.IsInProgress() && count.Item(productID) != nil {
+			return count
+		}
+	}
+	return nil
+}
@@ -0,0 +1,344 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// InvoiceImportFilter define os filtros da listagem de importações de NF-e
+type InvoiceImportFilter struct {
+	Status       *models.InvoiceImportStatus
+	FornecedorID *uuid.UUID
+}
+
+// CreateInvoiceImport registra a importação de uma NF-e como pendente e
+// vincula automaticamente os itens: primeiro pelo código de barras do produto
+// e depois pelo código do fornecedor, quando o CNPJ do emitente pertence a um
+// fornecedor cadastrado. Uma nota só pode ser importada novamente depois de
+// descartada.
+func (db *InMemoryDatabase) CreateInvoiceImport(invoice *models.InvoiceImport) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	for _, existing := range db.invoiceImports {
+		if existing.ChaveAcesso == invoice.ChaveAcesso && existing.Status != models.InvoiceImportDescartada {
+			return fmt.Errorf("%w: chave %s está %s na importação %s",
+				ErrInvoiceDuplicate, invoice.ChaveAcesso, existing.Status, existing.ID)
+		}
+	}
+
+	invoice.FornecedorID = nil
+	for _, supplier := range db.suppliers {
+		if supplier.CNPJ != "" && supplier.CNPJ == invoice.EmitenteCNPJ {
+			fornecedorID := supplier.ID
+			invoice.FornecedorID = &fornecedorID
+			break
+		}
+	}
+
+	for i := range invoice.Itens {
+		line := &invoice.Itens[i]
+		line.Unlink()
+		line.Ignorado = false
+		if product := db.matchInvoiceLine(invoice.FornecedorID, line); product != nil {
+			line.Link(product, line.Vinculo)
+		}
+	}
+
+	if invoice.ID == uuid.Nil {
+		invoice.ID = uuid.New()
+	}
+	now := time.Now()
+	invoice.Status = models.InvoiceImportPendente
+	invoice.DataImportacao = now
+	invoice.DataAtualizacao = now
+	invoice.DataConclusao = nil
+
+	db.invoiceImports[invoice.ID] = invoice.Clone()
+	return nil
+}
+
+// GetInvoiceImport busca uma importação de NF-e por ID
+func (db *InMemoryDatabase) GetInvoiceImport(id uuid.UUID) (*models.InvoiceImport, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	invoice, exists := db.invoiceImports[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrInvoiceNotFound, id)
+	}
+
+	return invoice.Clone(), nil
+}
+
+// ListInvoiceImports retorna as importações filtradas, das mais recentes para as mais antigas
+func (db *InMemoryDatabase) ListInvoiceImports(filter InvoiceImportFilter) ([]*models.InvoiceImport, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	invoices := make([]*models.InvoiceImport, 0)
+	for _, invoice := range db.invoiceImports {
+		if filter.Status != nil && invoice.Status != *filter.Status {
+			continue
+		}
+		if filter.FornecedorID != nil && (invoice.FornecedorID == nil || *invoice.FornecedorID != *filter.FornecedorID) {
+			continue
+		}
+		invoices = append(invoices, invoice.Clone())
+	}
+
+	sort.Slice(invoices, func(i, j int) bool {
+		return invoices[i].DataImportacao.After(invoices[j].DataImportacao)
+	})
+
+	return invoices, nil
+}
+
+// MapInvoiceLine vincula manualmente um item de uma importação pendente a um
+// produto, ou marca o item como ignorado quando produtoID é nulo
+func (db *InMemoryDatabase) MapInvoiceLine(id uuid.UUID, item int, produtoID *uuid.UUID) (*models.InvoiceImport, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	invoice, exists := db.invoiceImports[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrInvoiceNotFound, id)
+	}
+	if !invoice.IsPending() {
+		return nil, fmt.Errorf("%w: importação %s está %s", ErrInvoiceStatus, id, invoice.Status)
+	}
+
+	line := invoice.Line(item)
+	if line == nil {
+		return nil, fmt.Errorf("%w: item %d não pertence à nota %s", ErrInvoiceInvalid, item, invoice.Numero)
+	}
+
+	if produtoID == nil {
+		line.Unlink()
+		line.Ignorado = true
+	} else {
+		product, exists := db.products[*produtoID]
+		if !exists {
+			return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, *produtoID)
+		}
+		if product.IsKit() {
+			return nil, fmt.Errorf("%w: kits são comprados pelos componentes", ErrKitOperation)
+		}
+		line.Link(product, models.InvoiceMatchManual)
+	}
+
+	invoice.DataAtualizacao = time.Now()
+	return invoice.Clone(), nil
+}
+
+// ConfirmInvoiceImport lança a entrada de uma importação pendente: soma as
+// quantidades ao estoque, registra o custo unitário da nota em cada produto e
+// atualiza o último custo e o código do fornecedor no vínculo com o emitente,
+// de modo que vínculos manuais sejam reconhecidos nas próximas notas. Todos os
+// itens precisam estar vinculados ou ignorados; produtos controlados por lote
+// exigem os lotes no grupo de rastreabilidade da nota e produtos serializados
+// devem entrar pelos números de série. A operação é atômica.
+func (db *InMemoryDatabase) ConfirmInvoiceImport(id uuid.UUID, observacao string) (*models.InvoiceImport, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	invoice, exists := db.invoiceImports[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrInvoiceNotFound, id)
+	}
+	if !invoice.IsPending() {
+		return nil, fmt.Errorf("%w: importação %s está %s", ErrInvoiceStatus, id, invoice.Status)
+	}
+
+	if pendentes := invoice.Unresolved(); len(pendentes) > 0 {
+		itens := make([]string, len(pendentes))
+		for i, item := range pendentes {
+			itens[i] = fmt.Sprint(item)
+		}
+		return nil, fmt.Errorf("%w: vincule ou ignore os itens %s", ErrInvoiceUnmapped, strings.Join(itens, ", "))
+	}
+
+	// Primeira passada: valida todos os itens sem alterar nada
+	quantidades := make([]int, len(invoice.Itens))
+	lotes := make(map[uuid.UUID]map[string]bool)
+	for i, line := range invoice.Itens {
+		if line.Ignorado {
+			continue
+		}
+
+		quantidade, ok := models.WholeQuantity(line.Quantidade)
+		if !ok {
+			return nil, fmt.Errorf("%w: item %d tem quantidade fracionada (%g %s)",
+				ErrInvoiceInvalid, line.Item, line.Quantidade, line.Unidade)
+		}
+		quantidades[i] = quantidade
+
+		product, exists := db.products[*line.ProdutoID]
+		if !exists {
+			return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, *line.ProdutoID)
+		}
+		if product.IsKit() {
+			return nil, fmt.Errorf("%w: kits são comprados pelos componentes", ErrKitOperation)
+		}
+		if err := checkRestock(product); err != nil {
+			return nil, err
+		}
+		if product.Serializado {
+			return nil, fmt.Errorf("%w: o item %d (%s) deve entrar pelos números de série",
+				ErrSerialRequired, line.Item, product.Nome)
+		}
+
+		if product.ControlaLote {
+			if err := db.validateInvoiceLots(product, line, quantidade, lotes); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Segunda passada: aplica as entradas já validadas
+	now := time.Now()
+	referencia := fmt.Sprintf("NF-e %s série %s", invoice.Numero, invoice.Serie)
+	for i, line := range invoice.Itens {
+		if line.Ignorado {
+			continue
+		}
+		product := db.products[*line.ProdutoID]
+
+		if product.ControlaLote {
+			for _, lote := range line.Lotes {
+				db.receiveLot(product, &models.Lot{
+					ProdutoID:      product.ID,
+					Codigo:         lote.Codigo,
+					DataFabricacao: lote.DataFabricacao,
+					DataValidade:   lote.DataValidade,
+					Quantidade:     int(lote.Quantidade),
+					CustoUnitario:  line.ValorUnitario,
+				}, referencia)
+			}
+		} else {
+			before := *product
+			product.Quantidade += quantidades[i]
+			product.DataAtualizacao = now
+			db.recordCostMovement(product, quantidades[i], line.ValorUnitario, referencia)
+			db.trackStockChange(before, product)
+		}
+
+		if invoice.FornecedorID != nil {
+			db.recordSupplierPurchase(*invoice.FornecedorID, product.ID, line.CodigoProduto, line.ValorUnitario, now)
+		}
+	}
+
+	invoice.Observacao = observacao
+	invoice.SetStatus(models.InvoiceImportConfirmada)
+
+	return invoice.Clone(), nil
+}
+
+// DiscardInvoiceImport descarta uma importação pendente sem alterar o estoque
+func (db *InMemoryDatabase) DiscardInvoiceImport(id uuid.UUID, observacao string) (*models.InvoiceImport, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	invoice, exists := db.invoiceImports[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrInvoiceNotFound, id)
+	}
+	if !invoice.IsPending() {
+		return nil, fmt.Errorf("%w: importação %s está %s", ErrInvoiceStatus, id, invoice.Status)
+	}
+
+	invoice.Observacao = observacao
+	invoice.SetStatus(models.InvoiceImportDescartada)
+
+	return invoice.Clone(), nil
+}
+
+// matchInvoiceLine procura o produto do item pelo código de barras e depois
+// pelo código do fornecedor, registrando no item o critério usado. Deve ser
+// chamado com o lock adquirido.
+func (db *InMemoryDatabase) matchInvoiceLine(fornecedorID *uuid.UUID, line *models.InvoiceLine) *models.Product {
+	if line.CodigoBarras != "" {
+		if product := db.productByBarcode(line.CodigoBarras); product != nil && !product.IsKit() {
+			line.Vinculo = models.InvoiceMatchCodigoBarras
+			return product
+		}
+	}
+
+	if fornecedorID != nil {
+		for productID, link := range db.supplierProducts[*fornecedorID] {
+			product, exists := db.products[productID]
+			if exists && !product.IsKit() && strings.EqualFold(link.CodigoFornecedor, line.CodigoProduto) {
+				line.Vinculo = models.InvoiceMatchCodigoFornecedor
+				return product
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateInvoiceLots verifica se os lotes informados na nota cobrem a
+// quantidade do item e ainda não existem para o produto. Deve ser chamado com
+// o lock adquirido.
+func (db *InMemoryDatabase) validateInvoiceLots(product *models.Product, line models.InvoiceLine, quantidade int, vistos map[uuid.UUID]map[string]bool) error {
+	if len(line.Lotes) == 0 {
+		return fmt.Errorf("%w: o item %d (%s) não informa lotes na nota", ErrLotRequired, line.Item, product.Nome)
+	}
+	if vistos[product.ID] == nil {
+		vistos[product.ID] = make(map[string]bool)
+	}
+
+	total := 0
+	for _, lote := range line.Lotes {
+		quantidadeLote, ok := models.WholeQuantity(lote.Quantidade)
+		if !ok || quantidadeLote <= 0 || lote.Codigo == "" {
+			return fmt.Errorf("%w: item %d tem lote %q inválido", ErrInvoiceInvalid, line.Item, lote.Codigo)
+		}
+		codigo := strings.ToUpper(lote.Codigo)
+		if vistos[product.ID][codigo] {
+			return fmt.Errorf("%w: lote %s do produto %s informado mais de uma vez", ErrLotDuplicate, lote.Codigo, product.Nome)
+		}
+		vistos[product.ID][codigo] = true
+		if err := db.validateLot(product, &models.Lot{Codigo: lote.Codigo}); err != nil {
+			return err
+		}
+		total += quantidadeLote
+	}
+
+	if total != quantidade {
+		return fmt.Errorf("%w: lotes do item %d somam %d unidade(s), nota informa %d",
+			ErrInvoiceInvalid, line.Item, total, quantidade)
+	}
+	return nil
+}
+
+// productByBarcode busca o produto pelo código de barras. Deve ser chamado
+// com o lock adquirido.
+func (db *InMemoryDatabase) productByBarcode(codigo string) *models.Product {
+	for _, product := range db.products {
+		if product.CodigoBarras == codigo {
+			return product
+		}
+	}
+	return nil
+}
+
+// checkBarcode valida o código de barras e garante que ele não pertence a
+// outro produto. Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) checkBarcode(id uuid.UUID, codigo string) error {
+	if codigo == "" {
+		return nil
+	}
+	if !models.IsValidGTIN(codigo) {
+		return fmt.Errorf("%w: %q não é um GTIN-8, 12, 13 ou 14 válido", ErrBarcodeInvalid, codigo)
+	}
+	if existing := db.productByBarcode(codigo); existing != nil && existing.ID != id {
+		return fmt.Errorf("%w: %s pertence a %s", ErrBarcodeDuplicate, codigo, existing.Nome)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// KitDefinition define a composição de um kit e a política de preço
+type KitDefinition struct {
+	KitID         uuid.UUID
+	Componentes   []models.KitComponent
+	PrecoDerivado bool
+	DescontoKit   float64
+}
+
+// SetKitComponents define (ou remove, com lista vazia) a composição de um kit
+func (db *InMemoryDatabase) SetKitComponents(definition KitDefinition) (*models.Product, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	kit, exists := db.products[definition.KitID]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, definition.KitID)
+	}
+
+	if len(definition.Componentes) > 0 {
+		if err := db.validateKitDefinition(kit, definition); err != nil {
+			return nil, err
+		}
+	}
+
+	kit.Componentes = append([]models.KitComponent(nil), definition.Componentes...)
+	kit.PrecoDerivado = definition.PrecoDerivado && kit.IsKit()
+	kit.DescontoKit = definition.DescontoKit
+	if !kit.IsKit() {
+		kit.DescontoKit = 0
+	}
+	kit.DataAtualizacao = time.Now()
+
+	return db.snapshot(kit), nil
+}
+
+// GetKitComponents retorna os produtos que compõem um kit, na ordem da composição
+func (db *InMemoryDatabase) GetKitComponents(kitID uuid.UUID) (*models.Product, []*models.Product, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	kit, exists := db.products[kitID]
+	if !exists {
+		return nil, nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, kitID)
+	}
+
+	if !kit.IsKit() {
+		return nil, nil, fmt.Errorf("%w: produto %s não possui componentes", ErrKitInvalid, kitID)
+	}
+
+	components := make([]*models.Product, 0, len(kit.Componentes))
+	for _, component := range kit.Componentes {
+		if product, exists := db.products[component.ProdutoID]; exists {
+			components = append(components, db.snapshot(product))
+		}
+	}
+
+	return db.snapshot(kit), components, nil
+}
+
+// resolve retorna uma cópia do produto com os campos de kit resolvidos: a
+// disponibilidade e o custo vêm dos componentes e o preço pode ser derivado
+// deles. Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) resolve(product *models.Product) *models.Product {
+	resolved := product.Clone()
+	if product.IsKit() {
+		resolved.Quantidade = db.kitAvailability(product)
+		resolved.QuantidadeReservada = 0
+		resolved.PrecoCusto = db.kitCost(product)
+		resolved.CustoMedio = resolved.PrecoCusto
+		if product.PrecoDerivado {
+			resolved.Preco = product.KitPrice(db.componentsTotal(product))
+		}
+	}
+	return resolved
+}
+
+// kitAvailability calcula quantos kits podem ser montados com o disponível
+// dos componentes. Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) kitAvailability(kit *models.Product) int {
+	disponivel := -1
+	for _, component := range kit.Componentes {
+		product, exists := db.products[component.ProdutoID]
+		if !exists || !product.Ativo {
+			return 0
+		}
+		kits := product.AvailableQuantity() / component.Quantidade
+		if disponivel == -1 || kits < disponivel {
+			disponivel = kits
+		}
+	}
+	if disponivel < 0 {
+		return 0
+	}
+	return disponivel
+}
+
+// componentsTotal soma o preço dos componentes de um kit multiplicado pelas
+// quantidades da composição. Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) componentsTotal(kit *models.Product) float64 {
+	total := 0.0
+	for _, component := range kit.Componentes {
+		if product, exists := db.products[component.ProdutoID]; exists {
+			total += product.Preco * float64(component.Quantidade)
+		}
+	}
+	return total
+}
+
+// expandKitAdjustments substitui baixas de kits pelas baixas correspondentes dos
+// componentes. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) expandKitAdjustments(adjustments []StockAdjustment) ([]StockAdjustment, error) {
+	expanded := make([]StockAdjustment, 0, len(adjustments))
+	for _, adj := range adjustments {
+		product, exists := db.products[adj.ProductID]
+		if !exists {
+			return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, adj.ProductID)
+		}
+
+		if !product.IsKit() {
+			expanded = append(expanded, adj)
+			continue
+		}
+
+		if adj.Delta > 0 {
+			return nil, fmt.Errorf("%w: kit %s não possui estoque próprio, dê entrada nos componentes",
+				ErrKitOperation, product.ID)
+		}
+
+		for _, component := range product.Componentes {
+			expanded = append(expanded, StockAdjustment{
+				ProductID: component.ProdutoID,
+				Delta:     adj.Delta * component.Quantidade,
+			})
+		}
+	}
+	return expanded, nil
+}
+
+// kitUsingComponent retorna o primeiro kit que usa o produto como componente.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) kitUsingComponent(productID uuid.UUID) *models.Product {
+	for _, product := range db.products {
+		for _, component := range product.Componentes {
+			if component.ProdutoID == productID {
+				return product
+			}
+		}
+	}
+	return nil
+}
+
+// validateKitDefinition valida a composição informada para um kit.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) validateKitDefinition(kit *models.Product, definition KitDefinition) error {
+	if kit.ControlaLote || kit.Serializado {
+		return fmt.Errorf("%w: produtos controlados por lote ou serializados não podem ser kits", ErrKitInvalid)
+	}
+
+	if !kit.IsKit() && (kit.Quantidade > 0 || kit.QuantidadeReservada > 0) {
+		return fmt.Errorf("%w: zere o estoque do produto %s antes de transformá-lo em kit", ErrKitInvalid, kit.ID)
+	}
+
+	if other := db.kitUsingComponent(kit.ID); other != nil {
+		return fmt.Errorf("%w: produto %s já é componente do kit %s", ErrKitInvalid, kit.ID, other.Nome)
+	}
+
+	if definition.DescontoKit < 0 || definition.DescontoKit > 100 {
+		return fmt.Errorf("%w: desconto deve estar entre 0 e 100", ErrKitInvalid)
+	}
+
+	vistos := make(map[uuid.UUID]bool, len(definition.Componentes))
+	for _, component := range definition.Componentes {
+		if component.Quantidade <= 0 {
+			return fmt.Errorf("%w: quantidade do componente %s deve ser maior que zero", ErrKitInvalid, component.ProdutoID)
+		}
+		if component.ProdutoID == kit.ID {
+			return fmt.Errorf("%w: kit não pode ser componente de si mesmo", ErrKitInvalid)
+		}
+		if vistos[component.ProdutoID] {
+			return fmt.Errorf("%w: componente %s informado mais de uma vez", ErrKitInvalid, component.ProdutoID)
+		}
+		vistos[component.ProdutoID] = true
+
+		product, exists := db.products[component.ProdutoID]
+		if !exists {
+			return fmt.Errorf("%w: ID %s", ErrProductNotFound, component.ProdutoID)
+		}
+		if product.IsKit() {
+			return fmt.Errorf("%w: componente %s também é um kit", ErrKitInvalid, product.Nome)
+		}
+		if product.Serializado {
+			return fmt.Errorf("%w: componente %s é serializado", ErrKitInvalid, product.Nome)
+		}
+	}
+
+	return nil
+}
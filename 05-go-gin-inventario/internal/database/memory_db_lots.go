@@ -0,0 +1,219 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// CreateLot registra a entrada de um lote, somando sua quantidade ao estoque do produto
+func (db *InMemoryDatabase) CreateLot(lot *models.Lot) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	product, exists := db.products[lot.ProdutoID]
+	if !exists {
+		return fmt.Errorf("%w: ID %s", ErrProductNotFound, lot.ProdutoID)
+	}
+
+	if err := db.validateLot(product, lot); err != nil {
+		return err
+	}
+	if err := checkRestock(product); err != nil {
+		return err
+	}
+
+	db.receiveLot(product, lot, "lote "+lot.Codigo)
+	return nil
+}
+
+// validateLot verifica se o produto aceita o lote informado.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) validateLot(product *models.Product, lot *models.Lot) error {
+	if !product.ControlaLote {
+		return fmt.Errorf("%w: produto %s", ErrLotNotSupported, product.ID)
+	}
+
+	for _, existing := range db.lots {
+		if existing.ProdutoID == product.ID && strings.EqualFold(existing.Codigo, lot.Codigo) {
+			return fmt.Errorf("%w: código %s", ErrLotDuplicate, lot.Codigo)
+		}
+	}
+
+	return nil
+}
+
+// receiveLot registra um lote já validado, somando sua quantidade ao estoque
+// do produto. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) receiveLot(product *models.Product, lot *models.Lot, referencia string) {
+	if lot.ID == uuid.Nil {
+		lot.ID = uuid.New()
+	}
+
+	now := time.Now()
+	lot.QuantidadeInicial = lot.Quantidade
+	lot.DataCriacao = now
+	lot.DataAtualizacao = now
+
+	before := *product
+	product.Quantidade += lot.Quantidade
+	product.DataAtualizacao = now
+	db.recordCostMovement(product, lot.Quantidade, lot.CustoUnitario, referencia)
+	lot.CustoUnitario = product.PrecoCusto
+	db.trackStockChange(before, product)
+
+	lotCopy := *lot
+	db.lots[lot.ID] = &lotCopy
+}
+
+// GetLotsByProduct retorna os lotes de um produto na ordem de consumo (FEFO)
+func (db *InMemoryDatabase) GetLotsByProduct(productID uuid.UUID, apenasComSaldo bool) ([]*models.Lot, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if _, exists := db.products[productID]; !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	lots := make([]*models.Lot, 0)
+	for _, lot := range db.productLotsFEFO(productID) {
+		if apenasComSaldo && lot.Quantidade == 0 {
+			continue
+		}
+		lotCopy := *lot
+		lots = append(lots, &lotCopy)
+	}
+
+	return lots, nil
+}
+
+// GetExpiringLots retorna os lotes com saldo que vencem até o instante informado,
+// incluindo os já vencidos, ordenados pela data de validade
+func (db *InMemoryDatabase) GetExpiringLots(until time.Time) ([]*models.Lot, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	lots := make([]*models.Lot, 0)
+	for _, lot := range db.lots {
+		if lot.Quantidade > 0 && !lot.DataValidade.After(until) {
+			lotCopy := *lot
+			lots = append(lots, &lotCopy)
+		}
+	}
+
+	sort.Slice(lots, func(i, j int) bool {
+		return lots[i].DataValidade.Before(lots[j].DataValidade)
+	})
+
+	return lots, nil
+}
+
+// productLotsFEFO retorna os lotes do produto do primeiro a vencer ao último.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) productLotsFEFO(productID uuid.UUID) []*models.Lot {
+	lots := make([]*models.Lot, 0)
+	for _, lot := range db.lots {
+		if lot.ProdutoID == productID {
+			lots = append(lots, lot)
+		}
+	}
+
+	sort.Slice(lots, func(i, j int) bool {
+		if lots[i].DataValidade.Equal(lots[j].DataValidade) {
+			return lots[i].DataCriacao.Before(lots[j].DataCriacao)
+		}
+		return lots[i].DataValidade.Before(lots[j].DataValidade)
+	})
+
+	return lots
+}
+
+// consumeLotsFEFO baixa a quantidade dos lotes do produto, primeiro os que
+// vencem antes, e retorna quanto saiu de cada lote. Lotes vencidos são
+// ignorados, a menos que incluirVencidos seja verdadeiro.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) consumeLotsFEFO(productID uuid.UUID, quantidade int, incluirVencidos bool) []models.LotConsumption {
+	now := time.Now()
+	consumed := make([]models.LotConsumption, 0)
+	for _, lot := range db.productLotsFEFO(productID) {
+		if quantidade == 0 {
+			break
+		}
+		if !incluirVencidos && lot.IsExpired(now) {
+			continue
+		}
+		if retirado := lot.Consume(quantidade); retirado > 0 {
+			consumed = append(consumed, models.LotConsumption{
+				LoteID:     lot.ID,
+				Codigo:     lot.Codigo,
+				Quantidade: retirado,
+			})
+			quantidade -= retirado
+		}
+	}
+	return consumed
+}
+
+// lotBalance soma o saldo de todos os lotes do produto.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) lotBalance(productID uuid.UUID) int {
+	total := 0
+	for _, lot := range db.lots {
+		if lot.ProdutoID == productID {
+			total += lot.Quantidade
+		}
+	}
+	return total
+}
+
+// validLotBalance retorna as unidades do produto em lotes dentro da validade
+// que não estão comprometidas com reservas.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) validLotBalance(product *models.Product) int {
+	now := time.Now()
+	total := 0
+	for _, lot := range db.lots {
+		if lot.ProdutoID == product.ID && !lot.IsExpired(now) {
+			total += lot.Quantidade
+		}
+	}
+	if total -= product.QuantidadeReservada; total < 0 {
+		return 0
+	}
+	return total
+}
+
+// checkValidLots verifica se os lotes dentro da validade atendem uma saída do
+// produto. Produtos sem controle de lote não são verificados.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) checkValidLots(product *models.Product, quantidade int) error {
+	if !product.ControlaLote {
+		return nil
+	}
+	if valido := db.validLotBalance(product); valido < quantidade {
+		return fmt.Errorf("%w: produto %s possui %d unidade(s) em lotes válidos, saída de %d",
+			ErrLotExpired, product.ID, valido, quantidade)
+	}
+	return nil
+}
+
+// checkLotControlChange valida alterações diretas de um produto frente ao
+// controle de lote. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) checkLotControlChange(existing, updated *models.Product) error {
+	switch {
+	case !existing.ControlaLote && updated.ControlaLote && existing.Quantidade > 0:
+		return fmt.Errorf("%w: zere o estoque do produto %s antes de ativar o controle por lote",
+			ErrLotRequired, existing.ID)
+	case existing.ControlaLote && !updated.ControlaLote && db.lotBalance(existing.ID) > 0:
+		return fmt.Errorf("%w: produto %s ainda possui lotes com saldo",
+			ErrLotRequired, existing.ID)
+	case existing.ControlaLote && updated.ControlaLote && updated.Quantidade != existing.Quantidade:
+		return fmt.Errorf("%w: o estoque do produto %s é a soma dos seus lotes",
+			ErrLotRequired, existing.ID)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package database
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// PriceAdjustment define um reajuste percentual de preços em massa para os
+// produtos que atendem ao filtro. Em simulação nada é alterado.
+type PriceAdjustment struct {
+	Filtro     FilterOptions
+	Percentual float64
+	Motivo     string
+	Simular    bool
+}
+
+// PriceAdjustmentResult representa o efeito de um reajuste em um produto
+type PriceAdjustmentResult struct {
+	Produto       *models.Product
+	PrecoAnterior float64
+	PrecoNovo     float64
+}
+
+// SchedulePrice agenda um preço futuro para um produto
+func (db *InMemoryDatabase) SchedulePrice(scheduled *models.ScheduledPrice) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	product, exists := db.products[scheduled.ProdutoID]
+	if !exists {
+		return fmt.Errorf("%w: ID %s", ErrProductNotFound, scheduled.ProdutoID)
+	}
+
+	if product.PrecoDerivado {
+		return fmt.Errorf("%w: o preço deste kit é derivado dos componentes", ErrKitOperation)
+	}
+
+	if scheduled.ID == uuid.Nil {
+		scheduled.ID = uuid.New()
+	}
+
+	now := time.Now()
+	scheduled.Status = models.ScheduledPricePendente
+	scheduled.DataCriacao = now
+	scheduled.DataAtualizacao = now
+
+	scheduledCopy := *scheduled
+	db.scheduledPrices[scheduled.ID] = &scheduledCopy
+
+	return nil
+}
+
+// CancelScheduledPrice cancela um preço agendado ainda pendente
+func (db *InMemoryDatabase) CancelScheduledPrice(productID, id uuid.UUID) (*models.ScheduledPrice, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	scheduled, exists := db.scheduledPrices[id]
+	if !exists || scheduled.ProdutoID != productID {
+		return nil, fmt.Errorf("%w: ID %s", ErrScheduledPriceNotFound, id)
+	}
+
+	if !scheduled.IsPending() {
+		return nil, fmt.Errorf("%w: agendamento %s está %s", ErrScheduledPriceNotPending, id, scheduled.Status)
+	}
+
+	scheduled.SetStatus(models.ScheduledPriceCancelado)
+
+	scheduledCopy := *scheduled
+	return &scheduledCopy, nil
+}
+
+// ListScheduledPrices retorna os preços agendados de um produto por data de vigência
+func (db *InMemoryDatabase) ListScheduledPrices(productID uuid.UUID, apenasPendentes bool) ([]*models.ScheduledPrice, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if _, exists := db.products[productID]; !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	scheduled := make([]*models.ScheduledPrice, 0)
+	for _, item := range db.scheduledPrices {
+		if item.ProdutoID != productID || (apenasPendentes && !item.IsPending()) {
+			continue
+		}
+		itemCopy := *item
+		scheduled = append(scheduled, &itemCopy)
+	}
+
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].VigenteEm.Before(scheduled[j].VigenteEm)
+	})
+
+	return scheduled, nil
+}
+
+// ApplyDuePrices aplica, na ordem de vigência, os preços agendados que já
+// deveriam estar em vigor no instante informado e retorna quantos foram aplicados
+func (db *InMemoryDatabase) ApplyDuePrices(now time.Time) int {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	due := make([]*models.ScheduledPrice, 0)
+	for _, scheduled := range db.scheduledPrices {
+		if scheduled.IsDue(now) {
+			due = append(due, scheduled)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].VigenteEm.Before(due[j].VigenteEm)
+	})
+
+	applied := 0
+	for _, scheduled := range due {
+		product, exists := db.products[scheduled.ProdutoID]
+		if !exists || product.PrecoDerivado {
+			scheduled.SetStatus(models.ScheduledPriceCancelado)
+			continue
+		}
+
+		db.changePrice(product, scheduled.Preco, models.PriceOriginAgendada, scheduled.Motivo, scheduled.VigenteEm)
+		scheduled.SetStatus(models.ScheduledPriceAplicado)
+		applied++
+	}
+
+	return applied
+}
+
+// GetPriceHistory retorna o histórico de preços de um produto, do mais recente ao mais antigo
+func (db *InMemoryDatabase) GetPriceHistory(productID uuid.UUID) ([]models.PriceChange, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if _, exists := db.products[productID]; !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	history := db.priceHistory[productID]
+	changes := make([]models.PriceChange, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		changes = append(changes, history[i])
+	}
+
+	return changes, nil
+}
+
+// AdjustPrices aplica um reajuste percentual aos produtos que atendem ao filtro
+// em uma única operação atômica. Kits com preço derivado acompanham os
+// componentes e ficam de fora. Em simulação apenas calcula os novos preços.
+func (db *InMemoryDatabase) AdjustPrices(adjustment PriceAdjustment) ([]PriceAdjustmentResult, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if adjustment.Percentual <= -100 {
+		return nil, fmt.Errorf("percentual de reajuste deve ser maior que -100")
+	}
+
+	fator := 1 + adjustment.Percentual/100
+	now := time.Now()
+	results := make([]PriceAdjustmentResult, 0)
+
+	for _, product := range db.products {
+		if product.PrecoDerivado || !db.matchesFilter(db.snapshot(product), adjustment.Filtro) {
+			continue
+		}
+
+		result := PriceAdjustmentResult{
+			PrecoAnterior: product.Preco,
+			PrecoNovo:     math.Round(product.Preco*fator*100) / 100,
+		}
+
+		if !adjustment.Simular {
+			db.changePrice(product, result.PrecoNovo, models.PriceOriginReajuste, adjustment.Motivo, now)
+		}
+
+		result.Produto = db.snapshot(product)
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Produto.Nome < results[j].Produto.Nome
+	})
+
+	return results, nil
+}
+
+// changePrice altera o preço do produto registrando a alteração no histórico.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) changePrice(product *models.Product, preco float64, origem models.PriceChangeOrigin, motivo string, vigenteDesde time.Time) {
+	anterior := product.Preco
+	product.Preco = preco
+	product.DataAtualizacao = time.Now()
+	db.recordPriceChange(product, anterior, origem, motivo, vigenteDesde)
+}
+
+// recordPriceChange registra no histórico o preço atual do produto quando ele
+// difere do anterior. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) recordPriceChange(product *models.Product, anterior float64, origem models.PriceChangeOrigin, motivo string, vigenteDesde time.Time) {
+	if product.Preco == anterior && origem != models.PriceOriginCadastro {
+		return
+	}
+
+	db.priceHistory[product.ID] = append(db.priceHistory[product.ID], models.PriceChange{
+		ID:            uuid.New(),
+		ProdutoID:     product.ID,
+		PrecoAnterior: anterior,
+		PrecoNovo:     product.Preco,
+		Origem:        origem,
+		Motivo:        motivo,
+		VigenteDesde:  vigenteDesde,
+	})
+}
@@ -0,0 +1,188 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// PromotionSimulation representa o efeito de uma promoção sobre um produto
+type PromotionSimulation struct {
+	Produto            *models.Product
+	PrecoAtual         float64
+	PrecoFinal         float64
+	PromocoesAplicadas []models.AppliedPromotion
+}
+
+// CreatePromotion cadastra uma nova promoção
+func (db *InMemoryDatabase) CreatePromotion(promotion *models.Promotion) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.validatePromotionTarget(promotion); err != nil {
+		return err
+	}
+
+	if promotion.ID == uuid.Nil {
+		promotion.ID = uuid.New()
+	}
+
+	now := time.Now()
+	promotion.DataCriacao = now
+	promotion.DataAtualizacao = now
+
+	db.promotions[promotion.ID] = promotion.Clone()
+	return nil
+}
+
+// GetPromotion busca uma promoção por ID
+func (db *InMemoryDatabase) GetPromotion(id uuid.UUID) (*models.Promotion, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	promotion, exists := db.promotions[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrPromotionNotFound, id)
+	}
+
+	return promotion.Clone(), nil
+}
+
+// ListPromotions retorna as promoções por início de vigência, opcionalmente
+// apenas as vigentes no instante informado
+func (db *InMemoryDatabase) ListPromotions(apenasVigentes bool, now time.Time) ([]*models.Promotion, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	promotions := make([]*models.Promotion, 0, len(db.promotions))
+	for _, promotion := range db.promotions {
+		if apenasVigentes && !promotion.IsActiveAt(now) {
+			continue
+		}
+		promotions = append(promotions, promotion.Clone())
+	}
+
+	sort.Slice(promotions, func(i, j int) bool {
+		return promotions[i].Inicio.Before(promotions[j].Inicio)
+	})
+
+	return promotions, nil
+}
+
+// UpdatePromotion substitui os dados de uma promoção existente
+func (db *InMemoryDatabase) UpdatePromotion(id uuid.UUID, promotion *models.Promotion) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	existing, exists := db.promotions[id]
+	if !exists {
+		return fmt.Errorf("%w: ID %s", ErrPromotionNotFound, id)
+	}
+
+	if err := db.validatePromotionTarget(promotion); err != nil {
+		return err
+	}
+
+	promotion.ID = id
+	promotion.DataCriacao = existing.DataCriacao
+	promotion.DataAtualizacao = time.Now()
+
+	db.promotions[id] = promotion.Clone()
+	return nil
+}
+
+// DeletePromotion remove uma promoção
+func (db *InMemoryDatabase) DeletePromotion(id uuid.UUID) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if _, exists := db.promotions[id]; !exists {
+		return fmt.Errorf("%w: ID %s", ErrPromotionNotFound, id)
+	}
+
+	delete(db.promotions, id)
+	return nil
+}
+
+// SimulatePromotion calcula, sem cadastrar a promoção, o preço final de cada
+// produto alcançado por ela no instante informado, considerando as demais
+// promoções cadastradas e as regras de prioridade e acúmulo
+func (db *InMemoryDatabase) SimulatePromotion(candidate *models.Promotion, at time.Time) ([]PromotionSimulation, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if err := db.validatePromotionTarget(candidate); err != nil {
+		return nil, err
+	}
+
+	simulated := candidate.Clone()
+	if simulated.ID == uuid.Nil {
+		simulated.ID = uuid.New()
+	}
+
+	others := make([]*models.Promotion, 0, len(db.promotions))
+	for _, promotion := range db.promotions {
+		if promotion.ID != simulated.ID {
+			others = append(others, promotion)
+		}
+	}
+	withCandidate := append(append([]*models.Promotion(nil), others...), simulated)
+
+	simulations := make([]PromotionSimulation, 0)
+	for _, product := range db.products {
+		resolved := db.resolve(product)
+		if !simulated.Matches(resolved) {
+			continue
+		}
+
+		precoAtual, _ := models.ApplyPromotions(resolved, others, at)
+		precoFinal, applied := models.ApplyPromotions(resolved, withCandidate, at)
+		simulations = append(simulations, PromotionSimulation{
+			Produto:            resolved,
+			PrecoAtual:         precoAtual,
+			PrecoFinal:         precoFinal,
+			PromocoesAplicadas: applied,
+		})
+	}
+
+	sort.Slice(simulations, func(i, j int) bool {
+		return simulations[i].Produto.Nome < simulations[j].Produto.Nome
+	})
+
+	return simulations, nil
+}
+
+// applyPromotions resolve o preço promocional do produto com as promoções
+// vigentes. Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) applyPromotions(product *models.Product, now time.Time) {
+	product.PrecoPromocional = 0
+	product.Promocoes = nil
+	if len(db.promotions) == 0 {
+		return
+	}
+
+	promotions := make([]*models.Promotion, 0, len(db.promotions))
+	for _, promotion := range db.promotions {
+		promotions = append(promotions, promotion)
+	}
+
+	preco, applied := models.ApplyPromotions(product, promotions, now)
+	if len(applied) > 0 {
+		product.PrecoPromocional = preco
+		product.Promocoes = applied
+	}
+}
+
+// validatePromotionTarget garante que os produtos do alvo existem.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) validatePromotionTarget(promotion *models.Promotion) error {
+	for _, id := range promotion.Alvo.Produtos {
+		if _, exists := db.products[id]; !exists {
+			return fmt.Errorf("%w: produto %s do alvo não encontrado", ErrPromotionInvalid, id)
+		}
+	}
+	return nil
+}
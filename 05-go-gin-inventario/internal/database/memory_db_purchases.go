@@ -0,0 +1,401 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// PurchaseOrderFilter define os filtros da listagem de pedidos de compra
+type PurchaseOrderFilter struct {
+	FornecedorID *uuid.UUID
+	ProdutoID    *uuid.UUID
+	Status       *models.PurchaseOrderStatus
+}
+
+// CreatePurchaseOrder cadastra um pedido de compra em rascunho
+func (db *InMemoryDatabase) CreatePurchaseOrder(order *models.PurchaseOrder) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.preparePurchaseOrder(order); err != nil {
+		return err
+	}
+
+	if order.ID == uuid.Nil {
+		order.ID = uuid.New()
+	}
+
+	db.purchaseOrderSeq++
+	now := time.Now()
+	order.Numero = fmt.Sprintf("PC-%06d", db.purchaseOrderSeq)
+	order.Status = models.PurchaseOrderRascunho
+	order.Recebimentos = []models.PurchaseReceipt{}
+	order.DataCriacao = now
+	order.DataAtualizacao = now
+
+	db.purchaseOrders[order.ID] = order.Clone()
+	return nil
+}
+
+// GetPurchaseOrder busca um pedido de compra por ID
+func (db *InMemoryDatabase) GetPurchaseOrder(id uuid.UUID) (*models.PurchaseOrder, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	order, exists := db.purchaseOrders[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrPurchaseOrderNotFound, id)
+	}
+
+	return order.Clone(), nil
+}
+
+// ListPurchaseOrders retorna os pedidos de compra filtrados, dos mais recentes para os mais antigos
+func (db *InMemoryDatabase) ListPurchaseOrders(filter PurchaseOrderFilter) ([]*models.PurchaseOrder, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	orders := make([]*models.PurchaseOrder, 0)
+	for _, order := range db.purchaseOrders {
+		if filter.FornecedorID != nil && order.FornecedorID != *filter.FornecedorID {
+			continue
+		}
+		if filter.Status != nil && order.Status != *filter.Status {
+			continue
+		}
+		if filter.ProdutoID != nil && !purchaseOrderHasProduct(order, *filter.ProdutoID) {
+			continue
+		}
+		orders = append(orders, order.Clone())
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].Numero > orders[j].Numero
+	})
+
+	return orders, nil
+}
+
+// UpdatePurchaseOrder substitui fornecedor, itens e observações de um pedido em rascunho
+func (db *InMemoryDatabase) UpdatePurchaseOrder(id uuid.UUID, order *models.PurchaseOrder) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	existing, exists := db.purchaseOrders[id]
+	if !exists {
+		return fmt.Errorf("%w: ID %s", ErrPurchaseOrderNotFound, id)
+	}
+
+	if !existing.IsEditable() {
+		return fmt.Errorf("%w: pedido %s está %s", ErrPurchaseOrderStatus, existing.Numero, existing.Status)
+	}
+
+	if err := db.preparePurchaseOrder(order); err != nil {
+		return err
+	}
+
+	order.ID = id
+	order.Numero = existing.Numero
+	order.Status = existing.Status
+	order.Recebimentos = []models.PurchaseReceipt{}
+	order.DataCriacao = existing.DataCriacao
+	order.DataAtualizacao = time.Now()
+
+	db.purchaseOrders[id] = order.Clone()
+	return nil
+}
+
+// SendPurchaseOrder marca um pedido em rascunho como enviado ao fornecedor. Sem
+// previsão de entrega informada, ela é calculada pelo maior prazo de entrega
+// entre os produtos do pedido.
+func (db *InMemoryDatabase) SendPurchaseOrder(id uuid.UUID) (*models.PurchaseOrder, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	order, exists := db.purchaseOrders[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrPurchaseOrderNotFound, id)
+	}
+
+	if !order.IsEditable() {
+		return nil, fmt.Errorf("%w: pedido %s está %s", ErrPurchaseOrderStatus, order.Numero, order.Status)
+	}
+
+	supplier, exists := db.suppliers[order.FornecedorID]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrSupplierNotFound, order.FornecedorID)
+	}
+	if !supplier.Ativo {
+		return nil, fmt.Errorf("%w: %s", ErrSupplierInactive, supplier.Nome)
+	}
+
+	order.SetStatus(models.PurchaseOrderEnviado)
+	if order.PrevisaoEntrega == nil {
+		prazo := 0
+		for _, line := range order.Itens {
+			if link := db.supplierProducts[order.FornecedorID][line.ProdutoID]; link != nil && link.PrazoEntregaDias > prazo {
+				prazo = link.PrazoEntregaDias
+			}
+		}
+		previsao := order.DataEnvio.AddDate(0, 0, prazo)
+		order.PrevisaoEntrega = &previsao
+	}
+
+	return order.Clone(), nil
+}
+
+// CancelPurchaseOrder cancela um pedido ainda não concluído. Quantidades já
+// recebidas permanecem no estoque; apenas o saldo pendente é cancelado.
+func (db *InMemoryDatabase) CancelPurchaseOrder(id uuid.UUID, motivo string) (*models.PurchaseOrder, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	order, exists := db.purchaseOrders[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrPurchaseOrderNotFound, id)
+	}
+
+	if !order.CanCancel() {
+		return nil, fmt.Errorf("%w: pedido %s está %s", ErrPurchaseOrderStatus, order.Numero, order.Status)
+	}
+
+	order.MotivoCancelamento = motivo
+	order.SetStatus(models.PurchaseOrderCancelado)
+
+	return order.Clone(), nil
+}
+
+// ReceivePurchaseOrder registra o recebimento de itens de um pedido enviado,
+// somando as quantidades ao estoque e registrando o custo de cada item. Itens
+// de produtos controlados por lote exigem o lote; itens de produtos
+// serializados exigem os números de série. A operação é atômica: se qualquer
+// item for inválido nada é recebido.
+func (db *InMemoryDatabase) ReceivePurchaseOrder(id uuid.UUID, receipt *models.PurchaseReceipt) (*models.PurchaseOrder, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	order, exists := db.purchaseOrders[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrPurchaseOrderNotFound, id)
+	}
+
+	if !order.IsOpen() {
+		return nil, fmt.Errorf("%w: pedido %s está %s", ErrPurchaseOrderStatus, order.Numero, order.Status)
+	}
+
+	// Primeira passada: valida todos os itens sem alterar nada
+	serials := make([][]string, len(receipt.Itens))
+	vistos := make(map[uuid.UUID]bool, len(receipt.Itens))
+	for i := range receipt.Itens {
+		item := &receipt.Itens[i]
+		line := order.Line(item.LinhaID)
+		if line == nil {
+			return nil, fmt.Errorf("%w: item %s não pertence ao pedido %s", ErrPurchaseOrderInvalid, item.LinhaID, order.Numero)
+		}
+		if vistos[line.ID] {
+			return nil, fmt.Errorf("%w: item %s informado mais de uma vez", ErrPurchaseOrderInvalid, line.ID)
+		}
+		vistos[line.ID] = true
+
+		if item.Quantidade <= 0 || item.Quantidade > line.Pending() {
+			return nil, fmt.Errorf("%w: item %s possui %d unidade(s) pendente(s), recebimento de %d",
+				ErrPurchaseOrderInvalid, line.ID, line.Pending(), item.Quantidade)
+		}
+
+		product, exists := db.products[line.ProdutoID]
+		if !exists {
+			return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, line.ProdutoID)
+		}
+		if product.IsKit() {
+			return nil, fmt.Errorf("%w: kits são comprados pelos componentes", ErrKitOperation)
+		}
+		if err := checkRestock(product); err != nil {
+			return nil, err
+		}
+
+		item.ProdutoID = product.ID
+		if item.CustoUnitario == 0 {
+			item.CustoUnitario = line.CustoUnitario
+		}
+
+		switch {
+		case product.ControlaLote:
+			if item.Lote == nil {
+				return nil, fmt.Errorf("%w: informe o lote do item do produto %s", ErrLotRequired, product.ID)
+			}
+			if err := db.validateLot(product, &models.Lot{Codigo: item.Lote.Codigo}); err != nil {
+				return nil, err
+			}
+		case item.Lote != nil:
+			return nil, fmt.Errorf("%w: produto %s", ErrLotNotSupported, product.ID)
+		}
+
+		switch {
+		case product.Serializado:
+			if len(item.NumerosSerie) != item.Quantidade {
+				return nil, fmt.Errorf("%w: informe %d número(s) de série para o produto %s",
+					ErrSerialRequired, item.Quantidade, product.ID)
+			}
+			_, numeros, err := db.prepareSerialMovement(SerialMovement{ProductID: product.ID, Numeros: item.NumerosSerie})
+			if err != nil {
+				return nil, err
+			}
+			if err := db.validateSerialReceipt(product, numeros); err != nil {
+				return nil, err
+			}
+			serials[i] = numeros
+			item.NumerosSerie = numeros
+		case len(item.NumerosSerie) > 0:
+			return nil, fmt.Errorf("%w: produto %s", ErrSerialNotSupported, product.ID)
+		}
+	}
+
+	// Segunda passada: aplica os recebimentos já validados
+	now := time.Now()
+	referencia := "pedido de compra " + order.Numero
+	for i, item := range receipt.Itens {
+		product := db.products[item.ProdutoID]
+		line := order.Line(item.LinhaID)
+
+		switch {
+		case item.Lote != nil:
+			db.receiveLot(product, &models.Lot{
+				ProdutoID:      product.ID,
+				Codigo:         item.Lote.Codigo,
+				DataFabricacao: item.Lote.DataFabricacao,
+				DataValidade:   item.Lote.DataValidade,
+				Quantidade:     item.Quantidade,
+				CustoUnitario:  item.CustoUnitario,
+			}, referencia)
+		case serials[i] != nil:
+			db.receiveSerials(product, serials[i], referencia, item.CustoUnitario)
+		default:
+			before := *product
+			product.Quantidade += item.Quantidade
+			product.DataAtualizacao = now
+			db.recordCostMovement(product, item.Quantidade, item.CustoUnitario, referencia)
+			db.trackStockChange(before, product)
+		}
+
+		line.QuantidadeRecebida += item.Quantidade
+		db.recordSupplierPurchase(order.FornecedorID, line.ProdutoID, line.CodigoFornecedor, item.CustoUnitario, now)
+	}
+
+	receipt.ID = uuid.New()
+	receipt.DataRecebimento = now
+	order.Recebimentos = append(order.Recebimentos, *receipt)
+
+	if order.IsFullyReceived() {
+		order.SetStatus(models.PurchaseOrderRecebido)
+	} else {
+		order.SetStatus(models.PurchaseOrderParcialmenteRecebido)
+	}
+
+	return order.Clone(), nil
+}
+
+// preparePurchaseOrder valida fornecedor e itens de um pedido e completa código
+// do fornecedor e custo dos itens a partir do vínculo ou do preço de custo do
+// produto. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) preparePurchaseOrder(order *models.PurchaseOrder) error {
+	supplier, exists := db.suppliers[order.FornecedorID]
+	if !exists {
+		return fmt.Errorf("%w: ID %s", ErrSupplierNotFound, order.FornecedorID)
+	}
+	if !supplier.Ativo {
+		return fmt.Errorf("%w: %s", ErrSupplierInactive, supplier.Nome)
+	}
+
+	if len(order.Itens) == 0 {
+		return fmt.Errorf("%w: informe ao menos um item", ErrPurchaseOrderInvalid)
+	}
+
+	vistos := make(map[uuid.UUID]bool, len(order.Itens))
+	for i := range order.Itens {
+		line := &order.Itens[i]
+		product, exists := db.products[line.ProdutoID]
+		if !exists {
+			return fmt.Errorf("%w: ID %s", ErrProductNotFound, line.ProdutoID)
+		}
+		if product.IsKit() {
+			return fmt.Errorf("%w: kits são comprados pelos componentes", ErrKitOperation)
+		}
+		if err := checkRestock(product); err != nil {
+			return err
+		}
+		if vistos[product.ID] {
+			return fmt.Errorf("%w: produto %s informado mais de uma vez", ErrPurchaseOrderInvalid, product.ID)
+		}
+		vistos[product.ID] = true
+
+		if line.Quantidade <= 0 {
+			return fmt.Errorf("%w: quantidade do produto %s deve ser maior que zero", ErrPurchaseOrderInvalid, product.ID)
+		}
+
+		link := db.supplierProducts[supplier.ID][product.ID]
+		if strings.TrimSpace(line.CodigoFornecedor) == "" && link != nil {
+			line.CodigoFornecedor = link.CodigoFornecedor
+		}
+		if line.CustoUnitario == 0 {
+			if link != nil && link.UltimoCusto > 0 {
+				line.CustoUnitario = link.UltimoCusto
+			} else {
+				line.CustoUnitario = product.PrecoCusto
+			}
+		}
+
+		line.ID = uuid.New()
+		line.QuantidadeRecebida = 0
+	}
+
+	return nil
+}
+
+// recordSupplierPurchase atualiza o último custo do vínculo entre fornecedor e
+// produto, criando o vínculo na primeira compra. O código do fornecedor só é
+// preenchido quando o vínculo ainda não tem um. Deve ser chamado com o lock
+// de escrita adquirido.
+func (db *InMemoryDatabase) recordSupplierPurchase(supplierID, productID uuid.UUID, codigoFornecedor string, custoUnitario float64, now time.Time) {
+	if db.supplierProducts[supplierID] == nil {
+		db.supplierProducts[supplierID] = make(map[uuid.UUID]*models.SupplierProduct)
+	}
+
+	link := db.supplierProducts[supplierID][productID]
+	if link == nil {
+		link = &models.SupplierProduct{
+			FornecedorID: supplierID,
+			ProdutoID:    productID,
+		}
+		db.supplierProducts[supplierID][productID] = link
+	}
+	if strings.TrimSpace(link.CodigoFornecedor) == "" {
+		link.CodigoFornecedor = codigoFornecedor
+	}
+	link.RecordPurchase(custoUnitario, now)
+}
+
+// openPurchaseOrderWithProduct retorna um pedido em rascunho ou aguardando
+// recebimento que contenha o produto. Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) openPurchaseOrderWithProduct(productID uuid.UUID) *models.PurchaseOrder {
+	for _, order := range db.purchaseOrders {
+		if (order.IsEditable() || order.IsOpen()) && purchaseOrderHasProduct(order, productID) {
+			return order
+		}
+	}
+	return nil
+}
+
+// purchaseOrderHasProduct verifica se o pedido possui item do produto
+func purchaseOrderHasProduct(order *models.PurchaseOrder, productID uuid.UUID) bool {
+	for _, line := range order.Itens {
+		if line.ProdutoID == productID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package database
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// RelatedProduct combina uma relação com o produto relacionado
+type RelatedProduct struct {
+	Relacao models.ProductRelation
+	Produto *models.Product
+}
+
+// CreateRelation liga um produto a outro. Relações de substituto são
+// registradas nos dois sentidos.
+func (db *InMemoryDatabase) CreateRelation(relation *models.ProductRelation) (*models.ProductRelation, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if !relation.Tipo.IsValid() {
+		return nil, fmt.Errorf("%w: tipo %q desconhecido", ErrRelationInvalid, relation.Tipo)
+	}
+	if relation.ProdutoID == relation.RelacionadoID {
+		return nil, fmt.Errorf("%w: um produto não pode ser relacionado a si mesmo", ErrRelationInvalid)
+	}
+	if _, exists := db.products[relation.ProdutoID]; !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, relation.ProdutoID)
+	}
+	if _, exists := db.products[relation.RelacionadoID]; !exists {
+		return nil, fmt.Errorf("%w: produto relacionado %s", ErrProductNotFound, relation.RelacionadoID)
+	}
+	if db.findRelation(relation.ProdutoID, relation.RelacionadoID, relation.Tipo) >= 0 {
+		return nil, fmt.Errorf("%w: %s já é %s do produto", ErrRelationDuplicate, relation.RelacionadoID, relation.Tipo)
+	}
+
+	relation.DataCriacao = time.Now()
+	stored := relation.Clone()
+	db.relations[stored.ProdutoID] = append(db.relations[stored.ProdutoID], stored)
+
+	if stored.Tipo.IsSymmetric() && db.findRelation(stored.RelacionadoID, stored.ProdutoID, stored.Tipo) < 0 {
+		reverse := stored.Reverse()
+		db.relations[reverse.ProdutoID] = append(db.relations[reverse.ProdutoID], reverse)
+	}
+
+	return stored.Clone(), nil
+}
+
+// DeleteRelation desfaz as relações de um produto com outro. Sem tipo, todas
+// as relações entre os dois são removidas; relações de substituto são
+// removidas nos dois sentidos.
+func (db *InMemoryDatabase) DeleteRelation(productID, relatedID uuid.UUID, tipo *models.RelationType) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if _, exists := db.products[productID]; !exists {
+		return fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	removed := 0
+	kept := make([]*models.ProductRelation, 0, len(db.relations[productID]))
+	for _, relation := range db.relations[productID] {
+		if relation.RelacionadoID == relatedID && (tipo == nil || relation.Tipo == *tipo) {
+			removed++
+			if relation.Tipo.IsSymmetric() {
+				db.removeRelation(relatedID, productID, relation.Tipo)
+			}
+			continue
+		}
+		kept = append(kept, relation)
+	}
+
+	if removed == 0 {
+		return fmt.Errorf("%w: produto %s não está relacionado a %s", ErrRelationNotFound, productID, relatedID)
+	}
+	db.relations[productID] = kept
+	return nil
+}
+
+// GetRelations retorna as relações do produto com os produtos relacionados,
+// agrupadas por tipo e ordenadas pelo nome
+func (db *InMemoryDatabase) GetRelations(productID uuid.UUID, tipo *models.RelationType) ([]RelatedProduct, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if _, exists := db.products[productID]; !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	related := make([]RelatedProduct, 0, len(db.relations[productID]))
+	for _, relation := range db.relations[productID] {
+		if tipo != nil && relation.Tipo != *tipo {
+			continue
+		}
+		related = append(related, RelatedProduct{
+			Relacao: *relation,
+			Produto: db.snapshot(db.products[relation.RelacionadoID]),
+		})
+	}
+
+	sort.Slice(related, func(i, j int) bool {
+		if related[i].Relacao.Tipo != related[j].Relacao.Tipo {
+			return related[i].Relacao.Tipo < related[j].Relacao.Tipo
+		}
+		return related[i].Produto.Nome < related[j].Produto.Nome
+	})
+
+	return related, nil
+}
+
+// GetAlternatives retorna o produto e seus substitutos vendáveis com
+// disponível em estoque, do preço mais próximo ao mais distante
+func (db *InMemoryDatabase) GetAlternatives(productID uuid.UUID) (*models.Product, []RelatedProduct, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	product, exists := db.products[productID]
+	if !exists {
+		return nil, nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+	base := db.snapshot(product)
+
+	alternatives := make([]RelatedProduct, 0)
+	for _, relation := range db.relations[productID] {
+		if relation.Tipo != models.RelationSubstituto {
+			continue
+		}
+		candidate := db.snapshot(db.products[relation.RelacionadoID])
+		if !candidate.Ativo || candidate.AvailableQuantity() == 0 {
+			continue
+		}
+		alternatives = append(alternatives, RelatedProduct{
+			Relacao: *relation,
+			Produto: candidate,
+		})
+	}
+
+	sort.Slice(alternatives, func(i, j int) bool {
+		di := math.Abs(alternatives[i].Produto.Preco - base.Preco)
+		dj := math.Abs(alternatives[j].Produto.Preco - base.Preco)
+		if di != dj {
+			return di < dj
+		}
+		return alternatives[i].Produto.AvailableQuantity() > alternatives[j].Produto.AvailableQuantity()
+	})
+
+	return base, alternatives, nil
+}
+
+// findRelation retorna a posição da relação na lista do produto ou -1.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) findRelation(productID, relatedID uuid.UUID, tipo models.RelationType) int {
+	for i, relation := range db.relations[productID] {
+		if relation.RelacionadoID == relatedID && relation.Tipo == tipo {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeRelation remove uma relação da lista do produto, se existir.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) removeRelation(productID, relatedID uuid.UUID, tipo models.RelationType) {
+	if i := db.findRelation(productID, relatedID, tipo); i >= 0 {
+		relations := db.relations[productID]
+		db.relations[productID] = append(relations[:i], relations[i+1:]...)
+	}
+}
+
+// removeProductRelations remove as relações do produto e as que apontam para
+// ele. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) removeProductRelations(productID uuid.UUID) {
+	delete(db.relations, productID)
+	for id, relations := range db.relations {
+		kept := relations[:0]
+		for _, relation := range relations {
+			if relation.RelacionadoID != productID {
+				kept = append(kept, relation)
+			}
+		}
+		db.relations[id] = kept
+	}
+}
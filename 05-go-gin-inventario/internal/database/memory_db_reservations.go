@@ -0,0 +1,202 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// ReservationFilter define opções de filtro para listagem de reservas
+type ReservationFilter struct {
+	ProdutoID  *uuid.UUID
+	Status     *models.ReservationStatus
+	Referencia *string
+}
+
+// CreateReservation retém unidades de um produto sem alterar o estoque físico.
+// A reserva só é criada se houver quantidade disponível suficiente.
+func (db *InMemoryDatabase) CreateReservation(reservation *models.Reservation) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	product, exists := db.products[reservation.ProdutoID]
+	if !exists {
+		return fmt.Errorf("%w: ID %s", ErrProductNotFound, reservation.ProdutoID)
+	}
+
+	if product.IsKit() {
+		return fmt.Errorf("%w: reserve os componentes do kit %s", ErrKitOperation, product.ID)
+	}
+
+	if !product.Ativo {
+		return fmt.Errorf("%w: %s está %s", ErrProductInactive, product.Nome, product.Status)
+	}
+
+	if product.AvailableQuantity() < reservation.Quantidade {
+		return fmt.Errorf("%w: produto %s possui %d unidade(s) disponível(is), reserva de %d",
+			ErrInsufficientStock, product.ID, product.AvailableQuantity(), reservation.Quantidade)
+	}
+	if err := db.checkValidLots(product, reservation.Quantidade); err != nil {
+		return err
+	}
+
+	if reservation.ID == uuid.Nil {
+		reservation.ID = uuid.New()
+	}
+
+	now := time.Now()
+	reservation.Status = models.ReservationAtiva
+	reservation.DataCriacao = now
+	reservation.DataAtualizacao = now
+
+	before := *product
+	product.QuantidadeReservada += reservation.Quantidade
+	product.DataAtualizacao = now
+	db.trackStockChange(before, product)
+
+	reservationCopy := *reservation
+	db.reservations[reservation.ID] = &reservationCopy
+
+	return nil
+}
+
+// GetReservation busca uma reserva por ID
+func (db *InMemoryDatabase) GetReservation(id uuid.UUID) (*models.Reservation, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	reservation, exists := db.reservations[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrReservationNotFound, id)
+	}
+
+	reservationCopy := *reservation
+	return &reservationCopy, nil
+}
+
+// ListReservations retorna as reservas que atendem ao filtro, mais recentes primeiro
+func (db *InMemoryDatabase) ListReservations(filter ReservationFilter) ([]*models.Reservation, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	reservations := make([]*models.Reservation, 0)
+	for _, reservation := range db.reservations {
+		if filter.ProdutoID != nil && reservation.ProdutoID != *filter.ProdutoID {
+			continue
+		}
+		if filter.Status != nil && reservation.Status != *filter.Status {
+			continue
+		}
+		if filter.Referencia != nil && reservation.Referencia != *filter.Referencia {
+			continue
+		}
+		reservationCopy := *reservation
+		reservations = append(reservations, &reservationCopy)
+	}
+
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].DataCriacao.After(reservations[j].DataCriacao)
+	})
+
+	return reservations, nil
+}
+
+// ConfirmReservation efetiva a reserva, baixando as unidades do estoque físico
+func (db *InMemoryDatabase) ConfirmReservation(id uuid.UUID) (*models.Reservation, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	reservation, err := db.activeReservation(id)
+	if err != nil {
+		return nil, err
+	}
+
+	product, exists := db.products[reservation.ProdutoID]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, reservation.ProdutoID)
+	}
+
+	// As unidades deixam de estar reservadas e saem do estoque físico
+	before := *product
+	product.QuantidadeReservada -= reservation.Quantidade
+	if err := db.applyStockDelta(product, -reservation.Quantidade, false); err != nil {
+		product.QuantidadeReservada = before.QuantidadeReservada
+		return nil, err
+	}
+	db.recordCostMovement(product, -reservation.Quantidade, 0, "reserva "+reservation.ID.String())
+	db.trackStockChange(before, product)
+	reservation.SetStatus(models.ReservationConfirmada)
+
+	reservationCopy := *reservation
+	return &reservationCopy, nil
+}
+
+// ReleaseReservation cancela a reserva, devolvendo as unidades ao disponível
+func (db *InMemoryDatabase) ReleaseReservation(id uuid.UUID) (*models.Reservation, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	reservation, err := db.activeReservation(id)
+	if err != nil {
+		return nil, err
+	}
+
+	db.releaseReservation(reservation, models.ReservationLiberada)
+
+	reservationCopy := *reservation
+	return &reservationCopy, nil
+}
+
+// ExpireReservations marca como expiradas todas as reservas ativas vencidas
+// até o instante informado e retorna quantas foram expiradas
+func (db *InMemoryDatabase) ExpireReservations(now time.Time) int {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	expired := 0
+	for _, reservation := range db.reservations {
+		if reservation.IsExpired(now) {
+			db.releaseReservation(reservation, models.ReservationExpirada)
+			expired++
+		}
+	}
+
+	return expired
+}
+
+// activeReservation busca uma reserva ativa; reservas vencidas são expiradas
+// no momento da consulta. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) activeReservation(id uuid.UUID) (*models.Reservation, error) {
+	reservation, exists := db.reservations[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrReservationNotFound, id)
+	}
+
+	if reservation.IsExpired(time.Now()) {
+		db.releaseReservation(reservation, models.ReservationExpirada)
+	}
+
+	if !reservation.IsActive() {
+		return nil, fmt.Errorf("%w: reserva %s está %s", ErrReservationNotActive, id, reservation.Status)
+	}
+
+	return reservation, nil
+}
+
+// releaseReservation devolve as unidades retidas ao disponível do produto.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) releaseReservation(reservation *models.Reservation, status models.ReservationStatus) {
+	if product, exists := db.products[reservation.ProdutoID]; exists {
+		before := *product
+		product.QuantidadeReservada -= reservation.Quantidade
+		if product.QuantidadeReservada < 0 {
+			product.QuantidadeReservada = 0
+		}
+		product.DataAtualizacao = time.Now()
+		db.trackStockChange(before, product)
+	}
+	reservation.SetStatus(status)
+}
@@ -0,0 +1,426 @@
+package database
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// ReturnFilter define os filtros da listagem de devoluções
+type ReturnFilter struct {
+	PedidoVendaID *uuid.UUID
+	ProdutoID     *uuid.UUID
+	Status        *models.ReturnStatus
+	Motivo        *models.ReturnReason
+	Destino       *models.ReturnOutcome
+	Inicio        *time.Time
+	Fim           *time.Time
+}
+
+// ReturnInspection representa o destino decidido na inspeção de um item devolvido
+type ReturnInspection struct {
+	ItemID       uuid.UUID
+	Destino      models.ReturnOutcome
+	FornecedorID *uuid.UUID
+	Parecer      string
+}
+
+// CreateReturn registra a devolução de itens de um pedido de venda confirmado.
+// Cada item não pode exceder o saldo ainda não devolvido do item do pedido e,
+// em produtos serializados, deve informar quais números de série voltaram. Os
+// itens ficam em quarentena, sem efeito no estoque, até a inspeção.
+func (db *InMemoryDatabase) CreateReturn(ret *models.Return) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	order, exists := db.salesOrders[ret.PedidoVendaID]
+	if !exists {
+		return fmt.Errorf("%w: ID %s", ErrSalesOrderNotFound, ret.PedidoVendaID)
+	}
+	if !order.IsConfirmed() {
+		return fmt.Errorf("%w: pedido %s está %s", ErrSalesOrderStatus, order.Numero, order.Status)
+	}
+	if len(ret.Itens) == 0 {
+		return fmt.Errorf("%w: informe ao menos um item", ErrReturnInvalid)
+	}
+
+	devolvidos, seriesDevolvidas := db.returnedFromOrder(order.ID)
+	saleLines := make([]*models.SalesOrderLine, len(ret.Itens))
+	for i := range ret.Itens {
+		line := &ret.Itens[i]
+		saleLine := order.Line(line.ItemPedidoID)
+		if saleLine == nil {
+			return fmt.Errorf("%w: item %s não pertence ao pedido %s", ErrReturnInvalid, line.ItemPedidoID, order.Numero)
+		}
+		if !line.Motivo.IsValid() {
+			return fmt.Errorf("%w: motivo %q desconhecido", ErrReturnInvalid, line.Motivo)
+		}
+		if line.Quantidade <= 0 {
+			return fmt.Errorf("%w: quantidade deve ser maior que zero", ErrReturnInvalid)
+		}
+
+		saldo := saleLine.Quantidade - devolvidos[saleLine.ID]
+		if line.Quantidade > saldo {
+			return fmt.Errorf("%w: item %s possui %d unidade(s) a devolver, informado %d",
+				ErrReturnInvalid, saleLine.Nome, saldo, line.Quantidade)
+		}
+		devolvidos[saleLine.ID] += line.Quantidade
+
+		switch {
+		case len(saleLine.NumerosSerie) > 0:
+			numeros, err := returnSerials(saleLine, line.NumerosSerie, line.Quantidade, seriesDevolvidas)
+			if err != nil {
+				return err
+			}
+			line.NumerosSerie = numeros
+		case len(line.NumerosSerie) > 0:
+			return fmt.Errorf("%w: item %s não foi vendido com números de série", ErrSerialNotSupported, saleLine.Nome)
+		}
+		saleLines[i] = saleLine
+	}
+
+	db.returnSeq++
+	if ret.ID == uuid.Nil {
+		ret.ID = uuid.New()
+	}
+	now := time.Now()
+	ret.Numero = fmt.Sprintf("DV-%06d", db.returnSeq)
+	ret.PedidoVendaNumero = order.Numero
+	ret.Status = models.ReturnStatusAguardandoInspecao
+	ret.DataInspecao = nil
+	ret.DataCriacao = now
+	ret.DataAtualizacao = now
+
+	for i := range ret.Itens {
+		line := &ret.Itens[i]
+		saleLine := saleLines[i]
+		line.ID = uuid.New()
+		line.ProdutoID = saleLine.ProdutoID
+		line.Nome = saleLine.Nome
+		if product, exists := db.products[saleLine.ProdutoID]; exists {
+			line.Categoria = product.Categoria
+		}
+		line.ValorReembolso = math.Round(saleLine.PrecoUnitario*float64(line.Quantidade)*100) / 100
+		line.Destino = ""
+		line.FornecedorID = nil
+		line.ValorCusto = 0
+		line.Entradas = nil
+	}
+	ret.CalculateTotals()
+
+	db.returns[ret.ID] = ret.Clone()
+	return nil
+}
+
+// GetReturn busca uma devolução por ID
+func (db *InMemoryDatabase) GetReturn(id uuid.UUID) (*models.Return, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	ret, exists := db.returns[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrReturnNotFound, id)
+	}
+
+	return ret.Clone(), nil
+}
+
+// ListReturns retorna as devoluções filtradas, das mais recentes para as mais antigas
+func (db *InMemoryDatabase) ListReturns(filter ReturnFilter) ([]*models.Return, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	returns := make([]*models.Return, 0)
+	for _, ret := range db.returns {
+		if filter.PedidoVendaID != nil && ret.PedidoVendaID != *filter.PedidoVendaID {
+			continue
+		}
+		if filter.Status != nil && ret.Status != *filter.Status {
+			continue
+		}
+		if filter.ProdutoID != nil && !ret.HasProduct(*filter.ProdutoID) {
+			continue
+		}
+		if filter.Inicio != nil && ret.DataCriacao.Before(*filter.Inicio) {
+			continue
+		}
+		if filter.Fim != nil && !ret.DataCriacao.Before(*filter.Fim) {
+			continue
+		}
+		if !returnMatchesLine(ret, filter) {
+			continue
+		}
+		returns = append(returns, ret.Clone())
+	}
+
+	sort.Slice(returns, func(i, j int) bool {
+		return returns[i].Numero > returns[j].Numero
+	})
+
+	return returns, nil
+}
+
+// InspectReturn registra o destino de cada item de uma devolução e aplica o
+// efeito correspondente no estoque, em uma única operação: reestoque como novo
+// devolve as unidades aos lotes e números de série de origem, reestoque como
+// caixa aberta alimenta o estoque de caixa aberta, e envio ao fornecedor ou
+// baixa apenas registram a saída definitiva das unidades.
+func (db *InMemoryDatabase) InspectReturn(id uuid.UUID, inspections []ReturnInspection) (*models.Return, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	ret, exists := db.returns[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrReturnNotFound, id)
+	}
+	if !ret.IsPending() {
+		return nil, fmt.Errorf("%w: devolução %s está %s", ErrReturnStatus, ret.Numero, ret.Status)
+	}
+	order := db.salesOrders[ret.PedidoVendaID]
+
+	// Cada item deve receber exatamente um destino
+	decisions := make(map[uuid.UUID]ReturnInspection, len(inspections))
+	for _, inspection := range inspections {
+		if ret.Line(inspection.ItemID) == nil {
+			return nil, fmt.Errorf("%w: item %s não pertence à devolução %s", ErrReturnInvalid, inspection.ItemID, ret.Numero)
+		}
+		if _, repeated := decisions[inspection.ItemID]; repeated {
+			return nil, fmt.Errorf("%w: item %s informado mais de uma vez", ErrReturnInvalid, inspection.ItemID)
+		}
+		decisions[inspection.ItemID] = inspection
+	}
+	if len(decisions) != len(ret.Itens) {
+		return nil, fmt.Errorf("%w: informe o destino de todos os %d item(ns)", ErrReturnInvalid, len(ret.Itens))
+	}
+
+	for _, line := range ret.Itens {
+		inspection := decisions[line.ID]
+		saleLine := order.Line(line.ItemPedidoID)
+		if err := db.validateInspection(line, saleLine, inspection); err != nil {
+			return nil, err
+		}
+	}
+
+	referencia := "devolução " + ret.Numero
+	for i := range ret.Itens {
+		line := &ret.Itens[i]
+		inspection := decisions[line.ID]
+		saleLine := order.Line(line.ItemPedidoID)
+
+		entradas := db.returnIssues(order, saleLine, line, inspection.Destino == models.ReturnOutcomeRestockNew)
+		line.ValorCusto = 0
+		for _, entrada := range entradas {
+			if product, exists := db.products[entrada.ProdutoID]; exists {
+				line.ValorCusto += product.UnitCost() * float64(entrada.Quantidade)
+			}
+		}
+		line.ValorCusto = math.Round(line.ValorCusto*100) / 100
+
+		switch inspection.Destino {
+		case models.ReturnOutcomeRestockNew:
+			for _, entrada := range entradas {
+				db.restoreStock(entrada, referencia)
+			}
+		case models.ReturnOutcomeRestockOpenBox:
+			for j := range entradas {
+				entradas[j].CaixaAberta = true
+				db.restockOpenBox(db.products[entradas[j].ProdutoID], entradas[j], models.SerialEventDevolucao, referencia)
+			}
+		case models.ReturnOutcomeSupplier:
+			db.recordReturnSerials(line.NumerosSerie, models.SerialDevolvidoFornecedor, referencia)
+		case models.ReturnOutcomeWriteOff:
+			db.recordReturnSerials(line.NumerosSerie, models.SerialBaixado, referencia)
+		}
+
+		line.Destino = inspection.Destino
+		line.FornecedorID = inspection.FornecedorID
+		line.ParecerInspecao = inspection.Parecer
+		line.Entradas = nil
+		if inspection.Destino.IsRestock() {
+			line.Entradas = entradas
+		}
+	}
+	ret.Complete()
+
+	return ret.Clone(), nil
+}
+
+// validateInspection valida o destino escolhido para um item devolvido.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) validateInspection(line models.ReturnLine, saleLine *models.SalesOrderLine, inspection ReturnInspection) error {
+	if !inspection.Destino.IsValid() {
+		return fmt.Errorf("%w: destino %q desconhecido", ErrReturnInvalid, inspection.Destino)
+	}
+
+	if inspection.FornecedorID != nil {
+		if inspection.Destino != models.ReturnOutcomeSupplier {
+			return fmt.Errorf("%w: fornecedor só é informado no envio ao fornecedor", ErrReturnInvalid)
+		}
+		if _, exists := db.suppliers[*inspection.FornecedorID]; !exists {
+			return fmt.Errorf("%w: ID %s", ErrSupplierNotFound, *inspection.FornecedorID)
+		}
+	}
+
+	if inspection.Destino.IsRestock() {
+		for _, issue := range saleLine.Baixas {
+			if _, exists := db.products[issue.ProdutoID]; !exists {
+				return fmt.Errorf("%w: ID %s", ErrProductNotFound, issue.ProdutoID)
+			}
+			if issue.CaixaAberta && inspection.Destino == models.ReturnOutcomeRestockNew {
+				return fmt.Errorf("%w: %s foi vendido como caixa aberta e não volta ao estoque como novo",
+					ErrReturnInvalid, line.Nome)
+			}
+		}
+	}
+
+	// Os números de série devem continuar vendidos até a inspeção
+	for _, numero := range line.NumerosSerie {
+		serial, exists := db.serials[numero]
+		if !exists || serial.ProdutoID != line.ProdutoID || serial.Status != models.SerialVendido {
+			return fmt.Errorf("%w: %s não está vendido para o produto", ErrSerialConflict, numero)
+		}
+	}
+
+	return nil
+}
+
+// returnIssues calcula as unidades que um item devolvido representa em cada
+// produto baixado pelo item do pedido (o próprio produto ou os componentes do
+// kit). No reestoque como novo, as unidades são distribuídas pelos lotes de
+// origem, descontando o que devoluções anteriores já repuseram em cada lote.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) returnIssues(order *models.SalesOrder, saleLine *models.SalesOrderLine, line *models.ReturnLine, comLotes bool) []models.StockIssue {
+	repostos := make(map[uuid.UUID]int)
+	if comLotes {
+		for _, ret := range db.returns {
+			if ret.PedidoVendaID != order.ID {
+				continue
+			}
+			for _, other := range ret.Itens {
+				if other.ItemPedidoID != saleLine.ID {
+					continue
+				}
+				for _, entrada := range other.Entradas {
+					for _, lote := range entrada.Lotes {
+						repostos[lote.LoteID] += lote.Quantidade
+					}
+				}
+			}
+		}
+	}
+
+	entradas := make([]models.StockIssue, 0, len(saleLine.Baixas))
+	for _, issue := range saleLine.Baixas {
+		entrada := models.StockIssue{
+			ProdutoID:    issue.ProdutoID,
+			Quantidade:   issue.Quantidade / saleLine.Quantidade * line.Quantidade,
+			NumerosSerie: append([]string(nil), line.NumerosSerie...),
+		}
+
+		if comLotes {
+			restante := entrada.Quantidade
+			for _, consumed := range issue.Lotes {
+				if restante == 0 {
+					break
+				}
+				quantidade := consumed.Quantidade - repostos[consumed.LoteID]
+				if quantidade > restante {
+					quantidade = restante
+				}
+				if quantidade <= 0 {
+					continue
+				}
+				entrada.Lotes = append(entrada.Lotes, models.LotConsumption{
+					LoteID:     consumed.LoteID,
+					Codigo:     consumed.Codigo,
+					Quantidade: quantidade,
+				})
+				repostos[consumed.LoteID] += quantidade
+				restante -= quantidade
+			}
+		}
+
+		entradas = append(entradas, entrada)
+	}
+
+	return entradas
+}
+
+// recordReturnSerials registra a saída definitiva de números de série
+// devolvidos. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) recordReturnSerials(numeros []string, status models.SerialStatus, referencia string) {
+	for _, numero := range numeros {
+		db.serials[numero].RecordAs(models.SerialEventDevolucao, status, referencia)
+	}
+}
+
+// returnedFromOrder soma as unidades já devolvidas de cada item de um pedido
+// e reúne os números de série já devolvidos. Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) returnedFromOrder(orderID uuid.UUID) (map[uuid.UUID]int, map[string]bool) {
+	quantidades := make(map[uuid.UUID]int)
+	numeros := make(map[string]bool)
+	for _, ret := range db.returns {
+		if ret.PedidoVendaID != orderID {
+			continue
+		}
+		for _, line := range ret.Itens {
+			quantidades[line.ItemPedidoID] += line.Quantidade
+			for _, numero := range line.NumerosSerie {
+				numeros[numero] = true
+			}
+		}
+	}
+	return quantidades, numeros
+}
+
+// returnSerials valida os números de série devolvidos em um item: a quantidade
+// deve corresponder e cada número deve ter sido vendido no item do pedido e
+// ainda não ter sido devolvido.
+func returnSerials(saleLine *models.SalesOrderLine, numeros []string, quantidade int, devolvidos map[string]bool) ([]string, error) {
+	if len(numeros) != quantidade {
+		return nil, fmt.Errorf("%w: informe %d número(s) de série para o item %s",
+			ErrSerialRequired, quantidade, saleLine.Nome)
+	}
+
+	vendidos := make(map[string]bool, len(saleLine.NumerosSerie))
+	for _, numero := range saleLine.NumerosSerie {
+		vendidos[numero] = true
+	}
+
+	normalizados := make([]string, 0, len(numeros))
+	for _, numero := range numeros {
+		normalizado := NormalizeSerial(numero)
+		if !vendidos[normalizado] {
+			return nil, fmt.Errorf("%w: %s não foi vendido no item %s", ErrSerialConflict, normalizado, saleLine.Nome)
+		}
+		if devolvidos[normalizado] {
+			return nil, fmt.Errorf("%w: %s já foi devolvido", ErrSerialConflict, normalizado)
+		}
+		devolvidos[normalizado] = true
+		normalizados = append(normalizados, normalizado)
+	}
+
+	return normalizados, nil
+}
+
+// returnMatchesLine verifica os filtros de motivo e destino, atendidos se algum
+// item da devolução os satisfizer
+func returnMatchesLine(ret *models.Return, filter ReturnFilter) bool {
+	if filter.Motivo == nil && filter.Destino == nil {
+		return true
+	}
+	for _, line := range ret.Itens {
+		if filter.Motivo != nil && line.Motivo != *filter.Motivo {
+			continue
+		}
+		if filter.Destino != nil && line.Destino != *filter.Destino {
+			continue
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,433 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// SalesOrderFilter define os filtros da listagem de pedidos de venda
+type SalesOrderFilter struct {
+	ProdutoID  *uuid.UUID
+	Status     *models.SalesOrderStatus
+	Canal      *string
+	Referencia *string
+}
+
+// SalesOrderLineError descreve o problema de um item de um pedido de venda recusado
+type SalesOrderLineError struct {
+	Indice     int
+	ProdutoID  uuid.UUID
+	Err        error
+	Disponivel int
+	Solicitado int
+}
+
+// stockBucket identifica de qual estoque de um produto sai uma venda: o
+// estoque regular ou o de caixa aberta
+type stockBucket struct {
+	ProdutoID   uuid.UUID
+	CaixaAberta bool
+}
+
+// SalesOrderRejection reúne os erros de todos os itens de um pedido de venda
+// recusado. Nenhuma baixa é feita quando o pedido é recusado.
+type SalesOrderRejection struct {
+	Itens []SalesOrderLineError
+}
+
+// Error implementa a interface error
+func (e *SalesOrderRejection) Error() string {
+	return fmt.Sprintf("%s: %d erro(s) nos itens", ErrSalesOrderRejected, len(e.Itens))
+}
+
+// Unwrap permite identificar a recusa com errors.Is(err, ErrSalesOrderRejected)
+func (e *SalesOrderRejection) Unwrap() error {
+	return ErrSalesOrderRejected
+}
+
+// add registra o erro de um item
+func (e *SalesOrderRejection) add(indice int, productID uuid.UUID, err error) {
+	e.Itens = append(e.Itens, SalesOrderLineError{
+		Indice:    indice,
+		ProdutoID: productID,
+		Err:       err,
+	})
+}
+
+// CreateSalesOrder registra uma venda e baixa o estoque de todos os itens em
+// uma única operação atômica. Kits baixam seus componentes, produtos
+// controlados por lote consomem os lotes em ordem FEFO e produtos serializados
+// exigem os números de série vendidos. Itens marcados como caixa aberta saem do
+// estoque de caixa aberta, alimentado por devoluções. Se qualquer item não
+// puder ser atendido, nada é baixado e o erro retornado é um
+// *SalesOrderRejection com o problema de cada item.
+func (db *InMemoryDatabase) CreateSalesOrder(order *models.SalesOrder) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	// A referência identifica a venda no canal de origem e evita baixas em dobro
+	if order.Referencia != "" {
+		for _, existing := range db.salesOrders {
+			if existing.Canal == order.Canal && existing.Referencia == order.Referencia {
+				return fmt.Errorf("%w: referência %s corresponde ao pedido %s",
+					ErrSalesOrderDuplicate, order.Referencia, existing.Numero)
+			}
+		}
+	}
+
+	if len(order.Itens) == 0 {
+		return fmt.Errorf("%w: informe ao menos um item", ErrSalesOrderRejected)
+	}
+
+	// Primeira passada: valida os itens e acumula a demanda por produto
+	rejection := &SalesOrderRejection{}
+	demanda := make(map[stockBucket]int)
+	demandaItens := make(map[stockBucket][]int)
+	serials := make([][]string, len(order.Itens))
+	usados := make(map[string]bool)
+	for i, line := range order.Itens {
+		product, exists := db.products[line.ProdutoID]
+		if !exists {
+			rejection.add(i, line.ProdutoID, fmt.Errorf("%w: ID %s", ErrProductNotFound, line.ProdutoID))
+			continue
+		}
+		if !product.Ativo {
+			rejection.add(i, line.ProdutoID, fmt.Errorf("%w: %s", ErrProductInactive, product.Nome))
+			continue
+		}
+		if line.Quantidade <= 0 {
+			rejection.add(i, line.ProdutoID, fmt.Errorf("%w: quantidade deve ser maior que zero", ErrSalesOrderRejected))
+			continue
+		}
+		if line.CaixaAberta && product.IsKit() {
+			rejection.add(i, line.ProdutoID, fmt.Errorf("%w: kits não são vendidos como caixa aberta", ErrKitOperation))
+			continue
+		}
+
+		switch {
+		case product.Serializado:
+			status := models.SerialEmEstoque
+			if line.CaixaAberta {
+				status = models.SerialCaixaAberta
+			}
+			numeros, err := db.saleSerials(product, line.NumerosSerie, line.Quantidade, status, usados)
+			if err != nil {
+				rejection.add(i, line.ProdutoID, err)
+				continue
+			}
+			serials[i] = numeros
+		case len(line.NumerosSerie) > 0:
+			rejection.add(i, line.ProdutoID, fmt.Errorf("%w: produto %s", ErrSerialNotSupported, product.Nome))
+			continue
+		}
+
+		if product.IsKit() {
+			for _, component := range product.Componentes {
+				bucket := stockBucket{ProdutoID: component.ProdutoID}
+				demanda[bucket] += line.Quantidade * component.Quantidade
+				demandaItens[bucket] = append(demandaItens[bucket], i)
+			}
+		} else {
+			bucket := stockBucket{ProdutoID: product.ID, CaixaAberta: line.CaixaAberta}
+			demanda[bucket] += line.Quantidade
+			demandaItens[bucket] = append(demandaItens[bucket], i)
+		}
+	}
+
+	// A falta de um produto é apontada em todos os itens que o consomem
+	for bucket, quantidade := range demanda {
+		product := db.products[bucket.ProdutoID]
+		disponivel := product.AvailableQuantity()
+		estoque := ""
+		if bucket.CaixaAberta {
+			disponivel = product.QuantidadeCaixaAberta
+			estoque = " de caixa aberta"
+		}
+		if !product.Ativo {
+			disponivel = 0
+		}
+
+		var err error
+		switch {
+		case quantidade > disponivel:
+			err = fmt.Errorf("%w: %s possui %d unidade(s)%s disponível(is), pedido de %d",
+				ErrInsufficientStock, product.Nome, disponivel, estoque, quantidade)
+		case product.ControlaLote && !bucket.CaixaAberta && quantidade > db.validLotBalance(product):
+			// Unidades em lotes vencidos não são vendidas
+			disponivel = db.validLotBalance(product)
+			err = fmt.Errorf("%w: %s possui %d unidade(s) disponível(is) em lotes válidos, pedido de %d",
+				ErrLotExpired, product.Nome, disponivel, quantidade)
+		default:
+			continue
+		}
+		for _, i := range demandaItens[bucket] {
+			rejection.Itens = append(rejection.Itens, SalesOrderLineError{
+				Indice:     i,
+				ProdutoID:  order.Itens[i].ProdutoID,
+				Err:        err,
+				Disponivel: disponivel,
+				Solicitado: quantidade,
+			})
+		}
+	}
+
+	if len(rejection.Itens) > 0 {
+		sort.SliceStable(rejection.Itens, func(i, j int) bool {
+			if rejection.Itens[i].Indice != rejection.Itens[j].Indice {
+				return rejection.Itens[i].Indice < rejection.Itens[j].Indice
+			}
+			return rejection.Itens[i].Err.Error() < rejection.Itens[j].Err.Error()
+		})
+		return rejection
+	}
+
+	// Segunda passada: registra os preços vigentes e baixa o estoque
+	db.salesOrderSeq++
+	if order.ID == uuid.Nil {
+		order.ID = uuid.New()
+	}
+	now := time.Now()
+	order.Numero = fmt.Sprintf("PV-%06d", db.salesOrderSeq)
+	order.Status = models.SalesOrderConfirmado
+	order.DataCriacao = now
+	order.DataAtualizacao = now
+
+	referencia := "pedido de venda " + order.Numero
+	for i := range order.Itens {
+		line := &order.Itens[i]
+		product := db.products[line.ProdutoID]
+
+		resolved := db.snapshot(product)
+		line.ID = uuid.New()
+		line.Nome = product.Nome
+		line.PrecoUnitario = resolved.Preco
+		if resolved.HasPromotion() {
+			line.PrecoUnitario = resolved.PrecoPromocional
+		}
+		line.NumerosSerie = serials[i]
+
+		line.Baixas = make([]models.StockIssue, 0, 1)
+		if product.IsKit() {
+			for _, component := range product.Componentes {
+				line.Baixas = append(line.Baixas, db.issueStock(db.products[component.ProdutoID],
+					line.Quantidade*component.Quantidade, nil, false, referencia))
+			}
+		} else {
+			line.Baixas = append(line.Baixas, db.issueStock(product, line.Quantidade, serials[i], line.CaixaAberta, referencia))
+		}
+	}
+	order.CalculateTotals()
+
+	db.salesOrders[order.ID] = order.Clone()
+	return nil
+}
+
+// GetSalesOrder busca um pedido de venda por ID
+func (db *InMemoryDatabase) GetSalesOrder(id uuid.UUID) (*models.SalesOrder, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	order, exists := db.salesOrders[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrSalesOrderNotFound, id)
+	}
+
+	return order.Clone(), nil
+}
+
+// ListSalesOrders retorna os pedidos de venda filtrados, dos mais recentes para os mais antigos
+func (db *InMemoryDatabase) ListSalesOrders(filter SalesOrderFilter) ([]*models.SalesOrder, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	orders := make([]*models.SalesOrder, 0)
+	for _, order := range db.salesOrders {
+		if filter.Status != nil && order.Status != *filter.Status {
+			continue
+		}
+		if filter.Canal != nil && order.Canal != *filter.Canal {
+			continue
+		}
+		if filter.Referencia != nil && order.Referencia != *filter.Referencia {
+			continue
+		}
+		if filter.ProdutoID != nil && !order.HasProduct(*filter.ProdutoID) {
+			continue
+		}
+		orders = append(orders, order.Clone())
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].Numero > orders[j].Numero
+	})
+
+	return orders, nil
+}
+
+// CancelSalesOrder cancela um pedido confirmado e devolve ao estoque tudo o que
+// saiu: as mesmas quantidades, nos mesmos lotes e com os mesmos números de
+// série. Produtos excluídos após a venda são ignorados.
+func (db *InMemoryDatabase) CancelSalesOrder(id uuid.UUID, motivo string) (*models.SalesOrder, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	order, exists := db.salesOrders[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrSalesOrderNotFound, id)
+	}
+
+	if !order.IsConfirmed() {
+		return nil, fmt.Errorf("%w: pedido %s está %s", ErrSalesOrderStatus, order.Numero, order.Status)
+	}
+
+	// Itens devolvidos já tiveram o destino decidido na devolução
+	for _, ret := range db.returns {
+		if ret.PedidoVendaID == order.ID {
+			return nil, fmt.Errorf("%w: pedido %s possui a devolução %s", ErrSalesOrderReturned, order.Numero, ret.Numero)
+		}
+	}
+
+	// Números de série só retornam se ainda estiverem vendidos para o produto;
+	// produtos excluídos não recebem o estoque de volta
+	for _, line := range order.Itens {
+		for _, issue := range line.Baixas {
+			if _, exists := db.products[issue.ProdutoID]; !exists {
+				continue
+			}
+			for _, numero := range issue.NumerosSerie {
+				serial, exists := db.serials[numero]
+				if !exists || serial.ProdutoID != issue.ProdutoID || serial.Status != models.SerialVendido {
+					return nil, fmt.Errorf("%w: %s não está vendido para o produto", ErrSerialConflict, numero)
+				}
+			}
+		}
+	}
+
+	referencia := "cancelamento do pedido de venda " + order.Numero
+	for _, line := range order.Itens {
+		for _, issue := range line.Baixas {
+			db.restoreStock(issue, referencia)
+		}
+	}
+	order.Cancel(motivo)
+
+	return order.Clone(), nil
+}
+
+// saleSerials valida os números de série vendidos em um item: a quantidade deve
+// corresponder e cada número deve estar na situação esperada (em estoque ou
+// caixa aberta) para o produto e não aparecer em outro item do pedido. Deve
+// ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) saleSerials(product *models.Product, numeros []string, quantidade int, status models.SerialStatus, usados map[string]bool) ([]string, error) {
+	if len(numeros) != quantidade {
+		return nil, fmt.Errorf("%w: informe %d número(s) de série para o produto %s",
+			ErrSerialRequired, quantidade, product.Nome)
+	}
+
+	_, normalizados, err := db.prepareSerialMovement(SerialMovement{ProductID: product.ID, Numeros: numeros})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, numero := range normalizados {
+		if usados[numero] {
+			return nil, fmt.Errorf("%w: %s informado em mais de um item", ErrSerialConflict, numero)
+		}
+		serial, exists := db.serials[numero]
+		if !exists {
+			return nil, fmt.Errorf("%w: %s", ErrSerialNotFound, numero)
+		}
+		if serial.ProdutoID != product.ID {
+			return nil, fmt.Errorf("%w: %s pertence a outro produto", ErrSerialConflict, numero)
+		}
+		if serial.Status != status {
+			return nil, fmt.Errorf("%w: %s está %s, esperado %s", ErrSerialConflict, numero, serial.Status, status)
+		}
+	}
+
+	for _, numero := range normalizados {
+		usados[numero] = true
+	}
+	return normalizados, nil
+}
+
+// issueStock baixa uma quantidade já validada do estoque do produto e retorna
+// o que saiu. Saídas de caixa aberta não têm lote nem camada de custo. Deve
+// ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) issueStock(product *models.Product, quantidade int, numeros []string, caixaAberta bool, referencia string) models.StockIssue {
+	issue := models.StockIssue{
+		ProdutoID:    product.ID,
+		Quantidade:   quantidade,
+		CaixaAberta:  caixaAberta,
+		NumerosSerie: numeros,
+	}
+
+	if caixaAberta {
+		for _, numero := range numeros {
+			db.serials[numero].Record(models.SerialEventSaida, referencia)
+		}
+		product.QuantidadeCaixaAberta -= quantidade
+		product.DataAtualizacao = time.Now()
+		return issue
+	}
+
+	switch {
+	case product.Serializado:
+		for _, numero := range numeros {
+			db.serials[numero].Record(models.SerialEventSaida, referencia)
+		}
+	case product.ControlaLote:
+		issue.Lotes = db.consumeLotsFEFO(product.ID, quantidade, false)
+	}
+
+	before := *product
+	product.Quantidade -= quantidade
+	product.DataAtualizacao = time.Now()
+	db.recordCostMovement(product, -quantidade, 0, referencia)
+	db.trackStockChange(before, product)
+
+	return issue
+}
+
+// restoreStock devolve ao estoque uma saída registrada por issueStock. As
+// unidades voltam a preço de custo. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) restoreStock(issue models.StockIssue, referencia string) {
+	product, exists := db.products[issue.ProdutoID]
+	if !exists {
+		return
+	}
+
+	if issue.CaixaAberta {
+		db.restockOpenBox(product, issue, models.SerialEventEntrada, referencia)
+		return
+	}
+
+	for _, consumed := range issue.Lotes {
+		if lot, exists := db.lots[consumed.LoteID]; exists {
+			lot.Restore(consumed.Quantidade)
+		}
+	}
+	for _, numero := range issue.NumerosSerie {
+		db.serials[numero].Record(models.SerialEventEntrada, referencia)
+	}
+
+	before := *product
+	product.Quantidade += issue.Quantidade
+	product.DataAtualizacao = time.Now()
+	db.recordCostMovement(product, issue.Quantidade, 0, referencia)
+	db.trackStockChange(before, product)
+}
+
+// restockOpenBox devolve unidades ao estoque de caixa aberta do produto. Deve
+// ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) restockOpenBox(product *models.Product, issue models.StockIssue, tipo models.SerialEventType, referencia string) {
+	for _, numero := range issue.NumerosSerie {
+		db.serials[numero].RecordAs(tipo, models.SerialCaixaAberta, referencia)
+	}
+	product.QuantidadeCaixaAberta += issue.Quantidade
+	product.DataAtualizacao = time.Now()
+}
@@ -0,0 +1,261 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// SerialMovement define uma entrada ou saída de unidades serializadas.
+// CustoUnitario vale apenas para entradas; zero usa o preço de custo do produto.
+type SerialMovement struct {
+	ProductID     uuid.UUID
+	Numeros       []string
+	Referencia    string
+	ReservationID *uuid.UUID
+	CustoUnitario float64
+}
+
+// NormalizeSerial padroniza um número de série para comparação e armazenamento
+func NormalizeSerial(numero string) string {
+	return strings.ToUpper(strings.TrimSpace(numero))
+}
+
+// ReceiveSerials registra a entrada de unidades serializadas. Números novos são
+// cadastrados; números já vendidos do mesmo produto retornam ao estoque.
+// A operação é atômica: se qualquer número for inválido nada é registrado.
+func (db *InMemoryDatabase) ReceiveSerials(movement SerialMovement) ([]*models.SerialNumber, *models.Product, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	product, numeros, err := db.prepareSerialMovement(movement)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := db.validateSerialReceipt(product, numeros); err != nil {
+		return nil, nil, err
+	}
+	if err := checkRestock(product); err != nil {
+		return nil, nil, err
+	}
+
+	processed := db.receiveSerials(product, numeros, movement.Referencia, movement.CustoUnitario)
+	return processed, db.snapshot(product), nil
+}
+
+// validateSerialReceipt verifica se os números podem entrar no estoque do
+// produto. Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) validateSerialReceipt(product *models.Product, numeros []string) error {
+	for _, numero := range numeros {
+		serial, exists := db.serials[numero]
+		if !exists {
+			continue
+		}
+		if serial.ProdutoID != product.ID {
+			return fmt.Errorf("%w: %s pertence a outro produto", ErrSerialConflict, numero)
+		}
+		if serial.Status == models.SerialEmEstoque {
+			return fmt.Errorf("%w: %s já está em estoque", ErrSerialConflict, numero)
+		}
+	}
+	return nil
+}
+
+// receiveSerials registra a entrada de números de série já validados e soma as
+// unidades ao estoque do produto. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) receiveSerials(product *models.Product, numeros []string, referencia string, custoUnitario float64) []*models.SerialNumber {
+	now := time.Now()
+	processed := make([]*models.SerialNumber, 0, len(numeros))
+	for _, numero := range numeros {
+		serial, exists := db.serials[numero]
+		if !exists {
+			serial = &models.SerialNumber{
+				Numero:      numero,
+				ProdutoID:   product.ID,
+				DataCriacao: now,
+			}
+			db.serials[numero] = serial
+		}
+		serial.Record(models.SerialEventEntrada, referencia)
+		processed = append(processed, serial.Clone())
+	}
+
+	before := *product
+	product.Quantidade += len(numeros)
+	product.DataAtualizacao = now
+	db.recordCostMovement(product, len(numeros), custoUnitario, referencia)
+	db.trackStockChange(before, product)
+
+	return processed
+}
+
+// ShipSerials registra a saída (venda) de unidades serializadas. Todos os números
+// devem estar em estoque para o produto. Quando uma reserva é informada, ela é
+// efetivada com os números de série movimentados.
+func (db *InMemoryDatabase) ShipSerials(movement SerialMovement) ([]*models.SerialNumber, *models.Product, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	product, numeros, err := db.prepareSerialMovement(movement)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, numero := range numeros {
+		serial, exists := db.serials[numero]
+		if !exists {
+			return nil, nil, fmt.Errorf("%w: %s", ErrSerialNotFound, numero)
+		}
+		if serial.ProdutoID != product.ID {
+			return nil, nil, fmt.Errorf("%w: %s pertence a outro produto", ErrSerialConflict, numero)
+		}
+		if serial.Status != models.SerialEmEstoque {
+			return nil, nil, fmt.Errorf("%w: %s não está em estoque", ErrSerialConflict, numero)
+		}
+	}
+
+	// Unidades reservadas só podem sair efetivando a reserva correspondente
+	var reservation *models.Reservation
+	reservado := 0
+	if movement.ReservationID != nil {
+		reservation, err = db.activeReservation(*movement.ReservationID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if reservation.ProdutoID != product.ID || reservation.Quantidade != len(numeros) {
+			return nil, nil, fmt.Errorf("%w: reserva %s não corresponde ao produto e à quantidade informados",
+				ErrReservationNotActive, reservation.ID)
+		}
+		reservado = reservation.Quantidade
+	}
+
+	if product.Quantidade-len(numeros) < product.QuantidadeReservada-reservado {
+		return nil, nil, fmt.Errorf("%w: produto %s possui %d unidade(s) disponível(is), saída de %d",
+			ErrInsufficientStock, product.ID, product.AvailableQuantity(), len(numeros))
+	}
+
+	processed := make([]*models.SerialNumber, 0, len(numeros))
+	for _, numero := range numeros {
+		serial := db.serials[numero]
+		serial.Record(models.SerialEventSaida, movement.Referencia)
+		processed = append(processed, serial.Clone())
+	}
+
+	before := *product
+	product.Quantidade -= len(numeros)
+	product.QuantidadeReservada -= reservado
+	product.DataAtualizacao = time.Now()
+	if reservation != nil {
+		reservation.SetStatus(models.ReservationConfirmada)
+	}
+	db.recordCostMovement(product, -len(numeros), 0, movement.Referencia)
+	db.trackStockChange(before, product)
+
+	return processed, db.snapshot(product), nil
+}
+
+// GetSerial busca um número de série com seu histórico
+func (db *InMemoryDatabase) GetSerial(numero string) (*models.SerialNumber, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	serial, exists := db.serials[NormalizeSerial(numero)]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSerialNotFound, numero)
+	}
+
+	return serial.Clone(), nil
+}
+
+// GetSerialsByProduct retorna os números de série de um produto, opcionalmente por status
+func (db *InMemoryDatabase) GetSerialsByProduct(productID uuid.UUID, status *models.SerialStatus) ([]*models.SerialNumber, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if _, exists := db.products[productID]; !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	serials := make([]*models.SerialNumber, 0)
+	for _, serial := range db.serials {
+		if serial.ProdutoID != productID {
+			continue
+		}
+		if status != nil && serial.Status != *status {
+			continue
+		}
+		serials = append(serials, serial.Clone())
+	}
+
+	sort.Slice(serials, func(i, j int) bool {
+		return serials[i].Numero < serials[j].Numero
+	})
+
+	return serials, nil
+}
+
+// prepareSerialMovement valida o produto e normaliza os números informados,
+// rejeitando duplicados. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) prepareSerialMovement(movement SerialMovement) (*models.Product, []string, error) {
+	product, exists := db.products[movement.ProductID]
+	if !exists {
+		return nil, nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, movement.ProductID)
+	}
+
+	if !product.Serializado {
+		return nil, nil, fmt.Errorf("%w: produto %s", ErrSerialNotSupported, product.ID)
+	}
+
+	vistos := make(map[string]bool, len(movement.Numeros))
+	numeros := make([]string, 0, len(movement.Numeros))
+	for _, numero := range movement.Numeros {
+		normalizado := NormalizeSerial(numero)
+		if normalizado == "" {
+			return nil, nil, fmt.Errorf("%w: número de série vazio", ErrSerialConflict)
+		}
+		if vistos[normalizado] {
+			return nil, nil, fmt.Errorf("%w: %s informado mais de uma vez", ErrSerialConflict, normalizado)
+		}
+		vistos[normalizado] = true
+		numeros = append(numeros, normalizado)
+	}
+
+	return product, numeros, nil
+}
+
+// serialsInStock conta os números de série em estoque de um produto.
+// Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) serialsInStock(productID uuid.UUID) int {
+	total := 0
+	for _, serial := range db.serials {
+		if serial.ProdutoID == productID && serial.Status == models.SerialEmEstoque {
+			total++
+		}
+	}
+	return total
+}
+
+// checkSerialControlChange valida alterações diretas de um produto frente ao
+// controle por número de série. Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) checkSerialControlChange(existing, updated *models.Product) error {
+	switch {
+	case updated.Serializado && updated.ControlaLote:
+		return fmt.Errorf("%w: produto %s não pode ser serializado e controlado por lote ao mesmo tempo",
+			ErrSerialRequired, existing.ID)
+	case !existing.Serializado && updated.Serializado && existing.Quantidade > 0:
+		return fmt.Errorf("%w: zere o estoque do produto %s antes de ativar o controle por número de série",
+			ErrSerialRequired, existing.ID)
+	case existing.Serializado && !updated.Serializado && db.serialsInStock(existing.ID) > 0:
+		return fmt.Errorf("%w: produto %s ainda possui números de série em estoque",
+			ErrSerialRequired, existing.ID)
+	case existing.Serializado && updated.Serializado && updated.Quantidade != existing.Quantidade:
+		return fmt.Errorf("%w: o estoque do produto %s é a quantidade de números de série em estoque",
+			ErrSerialRequired, existing.ID)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// StatusTransition define a mudança de situação do ciclo de vida de um produto
+type StatusTransition struct {
+	ProdutoID uuid.UUID
+	Para      models.ProductStatus
+	Motivo    string
+	Usuario   string
+}
+
+// StatusTransitionResult retorna o produto após a transição e as reservas
+// ativas liberadas por ele ter deixado de ser vendável
+type StatusTransitionResult struct {
+	Produto           *models.Product
+	Transicao         models.StatusChange
+	ReservasLiberadas int
+}
+
+// ChangeProductStatus aplica uma transição do ciclo de vida do produto. Só
+// transições permitidas pela máquina de estados são aceitas. Ao sair de uma
+// situação vendável, as reservas ativas do produto são liberadas.
+func (db *InMemoryDatabase) ChangeProductStatus(transition StatusTransition) (*StatusTransitionResult, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	product, exists := db.products[transition.ProdutoID]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, transition.ProdutoID)
+	}
+	if err := checkStatusTransition(product, transition); err != nil {
+		return nil, err
+	}
+
+	change, liberadas := db.applyStatusTransition(product, transition)
+
+	return &StatusTransitionResult{
+		Produto:           db.snapshot(product),
+		Transicao:         change,
+		ReservasLiberadas: liberadas,
+	}, nil
+}
+
+// checkStatusTransition verifica se a máquina de estados permite a transição
+func checkStatusTransition(product *models.Product, transition StatusTransition) error {
+	if !transition.Para.IsValid() {
+		return fmt.Errorf("%w: situação %q desconhecida", ErrProductStatus, transition.Para)
+	}
+	if !product.Status.CanTransitionTo(transition.Para) {
+		return fmt.Errorf("%w: %s não pode passar de %s para %s",
+			ErrProductStatus, product.Nome, product.Status, transition.Para)
+	}
+
+	// Produtos em aprovação precisam de preço de venda para entrar no catálogo
+	if transition.Para == models.StatusAtivo && product.Preco <= 0 {
+		return fmt.Errorf("%w: %s precisa de preço de venda para ser ativado", ErrProductStatus, product.Nome)
+	}
+	return nil
+}
+
+// applyStatusTransition aplica a transição validada por checkStatusTransition
+// e retorna o registro no histórico e quantas reservas foram liberadas.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) applyStatusTransition(product *models.Product, transition StatusTransition) (models.StatusChange, int) {
+	de := product.Status
+	liberadas := 0
+	if !transition.Para.IsSellable() {
+		for _, reservation := range db.reservations {
+			if reservation.ProdutoID == product.ID && reservation.IsActive() {
+				db.releaseReservation(reservation, models.ReservationLiberada)
+				liberadas++
+			}
+		}
+	}
+
+	product.SetStatus(transition.Para)
+	change := db.recordStatusChange(product, de, transition.Motivo, transition.Usuario, product.DataAtualizacao)
+	return change, liberadas
+}
+
+// GetStatusHistory retorna as transições do produto, das mais recentes para as mais antigas
+func (db *InMemoryDatabase) GetStatusHistory(productID uuid.UUID) ([]models.StatusChange, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if _, exists := db.products[productID]; !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	history := db.statusHistory[productID]
+	changes := make([]models.StatusChange, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		changes = append(changes, history[i])
+	}
+
+	return changes, nil
+}
+
+// recordStatusChange registra no histórico a situação atual do produto.
+// Deve ser chamado com o lock de escrita adquirido.
+func (db *InMemoryDatabase) recordStatusChange(product *models.Product, de models.ProductStatus, motivo, usuario string, data time.Time) models.StatusChange {
+	change := models.StatusChange{
+		ProdutoID: product.ID,
+		De:        de,
+		Para:      product.Status,
+		Motivo:    motivo,
+		Usuario:   usuario,
+		Data:      data,
+	}
+	db.statusHistory[product.ID] = append(db.statusHistory[product.ID], change)
+	return change
+}
+
+// checkRestock verifica se a situação do produto aceita novas entradas de estoque
+func checkRestock(product *models.Product) error {
+	if !product.Status.AllowsRestock() {
+		return fmt.Errorf("%w: %s está %s", ErrRestockNotAllowed, product.Nome, product.Status)
+	}
+	return nil
+}
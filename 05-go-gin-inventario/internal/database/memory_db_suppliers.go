@@ -0,0 +1,244 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// SupplierProductLink representa o vínculo de um fornecedor com um produto,
+// acompanhado dos dois cadastros para exibição
+type SupplierProductLink struct {
+	Vinculo    *models.SupplierProduct
+	Fornecedor *models.Supplier
+	Produto    *models.Product
+}
+
+// CreateSupplier cadastra um novo fornecedor
+func (db *InMemoryDatabase) CreateSupplier(supplier *models.Supplier) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.checkSupplierCNPJ(uuid.Nil, supplier.CNPJ); err != nil {
+		return err
+	}
+
+	if supplier.ID == uuid.Nil {
+		supplier.ID = uuid.New()
+	}
+
+	now := time.Now()
+	supplier.DataCriacao = now
+	supplier.DataAtualizacao = now
+
+	stored := *supplier
+	db.suppliers[supplier.ID] = &stored
+	return nil
+}
+
+// GetSupplier busca um fornecedor por ID
+func (db *InMemoryDatabase) GetSupplier(id uuid.UUID) (*models.Supplier, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	supplier, exists := db.suppliers[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrSupplierNotFound, id)
+	}
+
+	supplierCopy := *supplier
+	return &supplierCopy, nil
+}
+
+// ListSuppliers retorna os fornecedores por nome, opcionalmente apenas os ativos
+func (db *InMemoryDatabase) ListSuppliers(apenasAtivos bool) ([]*models.Supplier, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	suppliers := make([]*models.Supplier, 0, len(db.suppliers))
+	for _, supplier := range db.suppliers {
+		if apenasAtivos && !supplier.Ativo {
+			continue
+		}
+		supplierCopy := *supplier
+		suppliers = append(suppliers, &supplierCopy)
+	}
+
+	sort.Slice(suppliers, func(i, j int) bool {
+		return strings.ToLower(suppliers[i].Nome) < strings.ToLower(suppliers[j].Nome)
+	})
+
+	return suppliers, nil
+}
+
+// UpdateSupplier substitui os dados cadastrais de um fornecedor
+func (db *InMemoryDatabase) UpdateSupplier(id uuid.UUID, supplier *models.Supplier) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	existing, exists := db.suppliers[id]
+	if !exists {
+		return fmt.Errorf("%w: ID %s", ErrSupplierNotFound, id)
+	}
+
+	if err := db.checkSupplierCNPJ(id, supplier.CNPJ); err != nil {
+		return err
+	}
+
+	supplier.ID = id
+	supplier.DataCriacao = existing.DataCriacao
+	supplier.DataAtualizacao = time.Now()
+
+	stored := *supplier
+	db.suppliers[id] = &stored
+	return nil
+}
+
+// DeleteSupplier remove um fornecedor e seus vínculos com produtos. Fornecedores
+// com pedidos de compra em aberto não podem ser removidos.
+func (db *InMemoryDatabase) DeleteSupplier(id uuid.UUID) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if _, exists := db.suppliers[id]; !exists {
+		return fmt.Errorf("%w: ID %s", ErrSupplierNotFound, id)
+	}
+
+	for _, order := range db.purchaseOrders {
+		if order.FornecedorID == id && (order.IsEditable() || order.IsOpen()) {
+			return fmt.Errorf("%w: pedido %s", ErrSupplierInUse, order.Numero)
+		}
+	}
+
+	delete(db.suppliers, id)
+	delete(db.supplierProducts, id)
+	return nil
+}
+
+// LinkSupplierProduct cria ou atualiza o vínculo de um produto com um fornecedor
+func (db *InMemoryDatabase) LinkSupplierProduct(link *models.SupplierProduct) (*SupplierProductLink, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	supplier, exists := db.suppliers[link.FornecedorID]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrSupplierNotFound, link.FornecedorID)
+	}
+
+	product, exists := db.products[link.ProdutoID]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, link.ProdutoID)
+	}
+
+	if product.IsKit() {
+		return nil, fmt.Errorf("%w: kits são comprados pelos componentes", ErrKitOperation)
+	}
+
+	// A data da última compra é mantida pelos recebimentos
+	if existing := db.supplierProducts[supplier.ID][product.ID]; existing != nil {
+		link.DataUltimaCompra = existing.DataUltimaCompra
+	}
+	link.DataAtualizacao = time.Now()
+
+	if db.supplierProducts[supplier.ID] == nil {
+		db.supplierProducts[supplier.ID] = make(map[uuid.UUID]*models.SupplierProduct)
+	}
+	stored := link.Clone()
+	db.supplierProducts[supplier.ID][product.ID] = stored
+
+	return db.supplierProductLink(stored), nil
+}
+
+// UnlinkSupplierProduct remove o vínculo de um produto com um fornecedor
+func (db *InMemoryDatabase) UnlinkSupplierProduct(supplierID, productID uuid.UUID) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if _, exists := db.suppliers[supplierID]; !exists {
+		return fmt.Errorf("%w: ID %s", ErrSupplierNotFound, supplierID)
+	}
+
+	if _, exists := db.supplierProducts[supplierID][productID]; !exists {
+		return fmt.Errorf("%w: produto %s não vinculado ao fornecedor", ErrProductNotFound, productID)
+	}
+
+	delete(db.supplierProducts[supplierID], productID)
+	return nil
+}
+
+// GetSupplierProducts retorna os produtos vinculados a um fornecedor, por nome
+func (db *InMemoryDatabase) GetSupplierProducts(supplierID uuid.UUID) ([]*SupplierProductLink, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if _, exists := db.suppliers[supplierID]; !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrSupplierNotFound, supplierID)
+	}
+
+	links := make([]*SupplierProductLink, 0, len(db.supplierProducts[supplierID]))
+	for _, link := range db.supplierProducts[supplierID] {
+		links = append(links, db.supplierProductLink(link))
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].Produto.Nome < links[j].Produto.Nome
+	})
+
+	return links, nil
+}
+
+// GetProductSuppliers retorna os fornecedores de um produto, do menor prazo de
+// entrega para o maior
+func (db *InMemoryDatabase) GetProductSuppliers(productID uuid.UUID) ([]*SupplierProductLink, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if _, exists := db.products[productID]; !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	links := make([]*SupplierProductLink, 0)
+	for _, products := range db.supplierProducts {
+		if link, exists := products[productID]; exists {
+			links = append(links, db.supplierProductLink(link))
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].Vinculo.PrazoEntregaDias != links[j].Vinculo.PrazoEntregaDias {
+			return links[i].Vinculo.PrazoEntregaDias < links[j].Vinculo.PrazoEntregaDias
+		}
+		return links[i].Fornecedor.Nome < links[j].Fornecedor.Nome
+	})
+
+	return links, nil
+}
+
+// supplierProductLink monta a visão de um vínculo com cópias do fornecedor e
+// do produto. Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) supplierProductLink(link *models.SupplierProduct) *SupplierProductLink {
+	supplierCopy := *db.suppliers[link.FornecedorID]
+	return &SupplierProductLink{
+		Vinculo:    link.Clone(),
+		Fornecedor: &supplierCopy,
+		Produto:    db.snapshot(db.products[link.ProdutoID]),
+	}
+}
+
+// checkSupplierCNPJ garante que o CNPJ informado não pertence a outro
+// fornecedor. Deve ser chamado com o lock adquirido.
+func (db *InMemoryDatabase) checkSupplierCNPJ(id uuid.UUID, cnpj string) error {
+	if cnpj == "" {
+		return nil
+	}
+	for _, existing := range db.suppliers {
+		if existing.ID != id && existing.CNPJ == cnpj {
+			return fmt.Errorf("%w: CNPJ %s pertence a %s", ErrSupplierDuplicate, cnpj, existing.Nome)
+		}
+	}
+	return nil
+}
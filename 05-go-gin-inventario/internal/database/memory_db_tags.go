@@ -0,0 +1,158 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// TagChange define tags a adicionar e remover em um ou mais produtos
+type TagChange struct {
+	ProdutoIDs []uuid.UUID
+	Adicionar  []string
+	Remover    []string
+}
+
+// UpdateProductTags adiciona e remove tags dos produtos de forma atômica:
+// se algum produto não existir ou exceder o limite de tags, nenhum é alterado.
+// Remover uma tag que o produto não possui não é erro.
+func (db *InMemoryDatabase) UpdateProductTags(change TagChange) ([]*models.Product, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	adicionar := models.NormalizeTags(change.Adicionar)
+	remover := models.NormalizeTags(change.Remover)
+	if len(adicionar) == 0 && len(remover) == 0 {
+		return nil, fmt.Errorf("%w: informe tags para adicionar ou remover", ErrTagInvalid)
+	}
+	if err := validateTags(adicionar); err != nil {
+		return nil, err
+	}
+
+	// Primeira passada: valida todos os produtos e calcula as novas tags
+	products := make([]*models.Product, 0, len(change.ProdutoIDs))
+	newTags := make([][]string, 0, len(change.ProdutoIDs))
+	seen := make(map[uuid.UUID]bool, len(change.ProdutoIDs))
+	for _, id := range change.ProdutoIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		product, exists := db.products[id]
+		if !exists {
+			return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, id)
+		}
+
+		tags := applyTagChange(product.Tags, adicionar, remover)
+		if len(tags) > models.MaxTagsPerProduct {
+			return nil, fmt.Errorf("%w: %s ficaria com %d tags (máximo %d)",
+				ErrTagInvalid, product.Nome, len(tags), models.MaxTagsPerProduct)
+		}
+		products = append(products, product)
+		newTags = append(newTags, tags)
+	}
+
+	// Segunda passada: aplica as alterações
+	now := time.Now()
+	updated := make([]*models.Product, len(products))
+	for i, product := range products {
+		product.Tags = newTags[i]
+		product.DataAtualizacao = now
+		updated[i] = db.snapshot(product)
+	}
+
+	return updated, nil
+}
+
+// RemoveProductTag remove uma tag de um produto
+func (db *InMemoryDatabase) RemoveProductTag(productID uuid.UUID, tag string) (*models.Product, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	product, exists := db.products[productID]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	tag = models.NormalizeTag(tag)
+	if !product.HasTag(tag) {
+		return nil, fmt.Errorf("%w: %s não possui a tag %q", ErrTagNotFound, product.Nome, tag)
+	}
+
+	product.Tags = applyTagChange(product.Tags, nil, []string{tag})
+	product.DataAtualizacao = time.Now()
+
+	return db.snapshot(product), nil
+}
+
+// GetTagCloud retorna as tags em uso com a quantidade de produtos de cada uma,
+// das mais usadas para as menos usadas
+func (db *InMemoryDatabase) GetTagCloud() ([]models.TagCount, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	counts := make(map[string]*models.TagCount)
+	for _, product := range db.products {
+		for _, tag := range product.Tags {
+			if counts[tag] == nil {
+				counts[tag] = &models.TagCount{Tag: tag}
+			}
+			counts[tag].TotalProdutos++
+			if product.Ativo {
+				counts[tag].ProdutosAtivos++
+			}
+		}
+	}
+
+	cloud := make([]models.TagCount, 0, len(counts))
+	for _, count := range counts {
+		cloud = append(cloud, *count)
+	}
+	sort.Slice(cloud, func(i, j int) bool {
+		if cloud[i].TotalProdutos != cloud[j].TotalProdutos {
+			return cloud[i].TotalProdutos > cloud[j].TotalProdutos
+		}
+		return cloud[i].Tag < cloud[j].Tag
+	})
+
+	return cloud, nil
+}
+
+// applyTagChange retorna as tags resultantes, ordenadas e sem repetição
+func applyTagChange(current, adicionar, remover []string) []string {
+	removed := make(map[string]bool, len(remover))
+	for _, tag := range remover {
+		removed[tag] = true
+	}
+
+	tags := make([]string, 0, len(current)+len(adicionar))
+	for _, tag := range append(append([]string(nil), current...), adicionar...) {
+		if !removed[tag] {
+			tags = append(tags, tag)
+		}
+	}
+	return models.NormalizeTags(tags)
+}
+
+// validateTags verifica tamanho, caracteres e quantidade de tags já normalizadas
+func validateTags(tags []string) error {
+	if len(tags) > models.MaxTagsPerProduct {
+		return fmt.Errorf("%w: máximo de %d tags por produto", ErrTagInvalid, models.MaxTagsPerProduct)
+	}
+	for _, tag := range tags {
+		if tag == "" || utf8.RuneCountInString(tag) > models.MaxTagLength {
+			return fmt.Errorf("%w: %q deve ter de 1 a %d caracteres", ErrTagInvalid, tag, models.MaxTagLength)
+		}
+		// A vírgula separa tags no filtro de produtos
+		if strings.Contains(tag, ",") {
+			return fmt.Errorf("%w: %q não pode conter vírgula", ErrTagInvalid, tag)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// SetProductTranslation cria ou substitui a tradução do produto para o idioma.
+// O português é o idioma de cadastro e é alterado pela atualização do produto.
+func (db *InMemoryDatabase) SetProductTranslation(productID uuid.UUID, locale models.Locale, translation models.ProductTranslation) (*models.Product, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	product, exists := db.products[productID]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	translation.Nome = strings.TrimSpace(translation.Nome)
+	translation.Descricao = strings.TrimSpace(translation.Descricao)
+	if err := validateTranslation(locale, translation); err != nil {
+		return nil, err
+	}
+
+	if product.Traducoes == nil {
+		product.Traducoes = make(map[models.Locale]models.ProductTranslation)
+	}
+	product.Traducoes[locale] = translation
+	product.DataAtualizacao = time.Now()
+
+	return db.snapshot(product), nil
+}
+
+// DeleteProductTranslation remove a tradução do produto para o idioma
+func (db *InMemoryDatabase) DeleteProductTranslation(productID uuid.UUID, locale models.Locale) (*models.Product, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	product, exists := db.products[productID]
+	if !exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrProductNotFound, productID)
+	}
+
+	if _, ok := product.Translation(locale); !ok {
+		return nil, fmt.Errorf("%w: %s não possui tradução para %s", ErrTranslationNotFound, product.Nome, locale)
+	}
+
+	delete(product.Traducoes, locale)
+	if len(product.Traducoes) == 0 {
+		product.Traducoes = nil
+	}
+	product.DataAtualizacao = time.Now()
+
+	return db.snapshot(product), nil
+}
+
+// validateTranslation aplica à tradução os mesmos limites do nome e da
+// descrição do produto
+func validateTranslation(locale models.Locale, translation models.ProductTranslation) error {
+	if locale.IsDefault() {
+		return fmt.Errorf("%w: %s é o idioma de cadastro; altere o nome e a descrição do produto", ErrTranslationInvalid, locale)
+	}
+	if translation.IsEmpty() {
+		return fmt.Errorf("%w: informe o nome ou a descrição traduzidos", ErrTranslationInvalid)
+	}
+	if n := utf8.RuneCountInString(translation.Nome); translation.Nome != "" && (n < 2 || n > 100) {
+		return fmt.Errorf("%w: o nome deve ter entre 2 e 100 caracteres", ErrTranslationInvalid)
+	}
+	if utf8.RuneCountInString(translation.Descricao) > 500 {
+		return fmt.Errorf("%w: a descrição deve ter no máximo 500 caracteres", ErrTranslationInvalid)
+	}
+	return nil
+}
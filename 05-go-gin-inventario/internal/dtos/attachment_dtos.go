@@ -0,0 +1,51 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// UploadAttachmentRequest representa o envio de um anexo. Os campos de texto
+// vêm do formulário multipart; o conteúdo vem do campo de arquivo "arquivo".
+type UploadAttachmentRequest struct {
+	Tipo        models.AttachmentKind `form:"tipo" example:"imagem"`
+	Descricao   string                `form:"descricao" binding:"max=200" example:"Vista frontal"`
+	Principal   bool                  `form:"principal" example:"false"`
+	NomeArquivo string                `form:"-"`
+	Dados       []byte                `form:"-"`
+}
+
+// AttachmentResponse representa a resposta de um anexo com as URLs públicas
+type AttachmentResponse struct {
+	ID          uuid.UUID             `json:"id" example:"5b7e2c1a-3d4f-4a6b-8c9d-0e1f2a3b4c5d"`
+	ProdutoID   uuid.UUID             `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Tipo        models.AttachmentKind `json:"tipo" example:"imagem"`
+	NomeArquivo string                `json:"nome_arquivo" example:"galaxy-frente.jpg"`
+	Descricao   string                `json:"descricao,omitempty" example:"Vista frontal"`
+	ContentType string                `json:"content_type" example:"image/jpeg"`
+	Tamanho     int64                 `json:"tamanho" example:"284311"`
+	URL         string                `json:"url" example:"/arquivos/123e4567-e89b-12d3-a456-426614174000/5b7e2c1a-3d4f-4a6b-8c9d-0e1f2a3b4c5d.jpg"`
+	Largura     int                   `json:"largura,omitempty" example:"1200"`
+	Altura      int                   `json:"altura,omitempty" example:"900"`
+	Miniaturas  map[string]string     `json:"miniaturas,omitempty"`
+	Principal   bool                  `json:"principal" example:"true"`
+	DataCriacao time.Time             `json:"data_criacao" example:"2024-03-20T10:30:00Z"`
+}
+
+// AttachmentListResponse representa os anexos de um produto
+type AttachmentListResponse struct {
+	ProdutoID uuid.UUID            `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Anexos    []AttachmentResponse `json:"anexos"`
+	Total     int                  `json:"total" example:"3"`
+}
+
+// ProductImageResponse representa uma imagem incluída na resposta do produto
+type ProductImageResponse struct {
+	ID         uuid.UUID         `json:"id" example:"5b7e2c1a-3d4f-4a6b-8c9d-0e1f2a3b4c5d"`
+	URL        string            `json:"url" example:"/arquivos/123e4567-e89b-12d3-a456-426614174000/5b7e2c1a-3d4f-4a6b-8c9d-0e1f2a3b4c5d.jpg"`
+	Miniaturas map[string]string `json:"miniaturas"`
+	Descricao  string            `json:"descricao,omitempty" example:"Vista frontal"`
+	Principal  bool              `json:"principal" example:"true"`
+}
@@ -0,0 +1,47 @@
+package dtos
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// BulkProductRequest representa um lote de cadastros, atualizações e remoções
+// de produtos. Cada item segue as regras da requisição individual e é
+// validado separadamente.
+type BulkProductRequest struct {
+	Modo      models.BulkMode        `json:"modo,omitempty" binding:"omitempty,oneof=tudo_ou_nada melhor_esforco" example:"melhor_esforco"`
+	Criar     []CreateProductRequest `json:"criar,omitempty"`
+	Atualizar []BulkUpdateItem       `json:"atualizar,omitempty"`
+	Remover   []uuid.UUID            `json:"remover,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+// BulkUpdateItem representa a atualização parcial de um produto dentro do lote
+type BulkUpdateItem struct {
+	ID uuid.UUID `json:"id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UpdateProductRequest
+}
+
+// BulkItemResult representa o resultado de um item do lote. Indice é a
+// posição do item na lista da sua operação.
+type BulkItemResult struct {
+	Operacao  models.BulkOperation `json:"operacao" example:"criar"`
+	Indice    int                  `json:"indice" example:"0"`
+	ProdutoID *uuid.UUID           `json:"produto_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Sucesso   bool                 `json:"sucesso" example:"false"`
+	Status    int                  `json:"status" example:"422"`
+	Codigo    string               `json:"codigo,omitempty" example:"VALIDATION_ERROR"`
+	Erro      string               `json:"erro,omitempty" example:"Dados inválidos"`
+	Campos    []ValidationError    `json:"campos,omitempty"`
+
+	// Err é a falha do item, traduzida em status e código pelo handler
+	Err error `json:"-"`
+}
+
+// BulkProductResponse representa o relatório de um lote de produtos
+type BulkProductResponse struct {
+	Modo     models.BulkMode  `json:"modo" example:"melhor_esforco"`
+	Total    int              `json:"total" example:"3"`
+	Sucessos int              `json:"sucessos" example:"2"`
+	Falhas   int              `json:"falhas" example:"1"`
+	Itens    []BulkItemResult `json:"itens"`
+}
@@ -0,0 +1,135 @@
+package dtos
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// ProductCostInfo representa os dados de custo e margem de um produto,
+// visíveis apenas para usuários com acesso financeiro
+type ProductCostInfo struct {
+	PrecoCusto       float64 `json:"preco_custo" example:"850.00"`
+	CustoMedio       float64 `json:"custo_medio" example:"872.40"`
+	MargemPercentual float64 `json:"margem_percentual" example:"32.89"`
+	MarkupPercentual float64 `json:"markup_percentual" example:"49.01"`
+}
+
+// CategoryCostStatistics representa o valor em estoque a custo de uma categoria
+type CategoryCostStatistics struct {
+	ValorCustoFIFO  float64 `json:"valor_custo_fifo" example:"30250.00"`
+	ValorCustoMedio float64 `json:"valor_custo_medio" example:"30410.75"`
+}
+
+// InventoryValuationSummary representa o valor do inventário a custo e a preço de venda
+type InventoryValuationSummary struct {
+	ValorVenda          float64 `json:"valor_venda" example:"125000.50"`
+	ValorCustoFIFO      float64 `json:"valor_custo_fifo" example:"80120.00"`
+	ValorCustoMedio     float64 `json:"valor_custo_medio" example:"80544.30"`
+	LucroBrutoPotencial float64 `json:"lucro_bruto_potencial" example:"44456.20"`
+}
+
+// CostLayerListResponse representa as camadas de custo de um produto na ordem FIFO
+type CostLayerListResponse struct {
+	ProdutoID       uuid.UUID          `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Camadas         []models.CostLayer `json:"camadas"`
+	Quantidade      int                `json:"quantidade" example:"25"`
+	ValorCustoFIFO  float64            `json:"valor_custo_fifo" example:"21300.00"`
+	CustoMedio      float64            `json:"custo_medio" example:"852.00"`
+	ValorCustoMedio float64            `json:"valor_custo_medio" example:"21300.00"`
+}
+
+// InventoryValuationItem representa o valor em estoque de um produto pelo método escolhido
+type InventoryValuationItem struct {
+	ProdutoID        uuid.UUID              `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome             string                 `json:"nome" example:"Notebook Dell Inspiron"`
+	Categoria        models.ProductCategory `json:"categoria" example:"eletronicos"`
+	Quantidade       int                    `json:"quantidade" example:"10"`
+	CustoUnitario    float64                `json:"custo_unitario" example:"2650.00"`
+	ValorCusto       float64                `json:"valor_custo" example:"26500.00"`
+	ValorVenda       float64                `json:"valor_venda" example:"34999.90"`
+	MargemPercentual float64                `json:"margem_percentual" example:"24.28"`
+}
+
+// InventoryValuationResponse representa a valoração do inventário a custo
+type InventoryValuationResponse struct {
+	Metodo              models.ValuationMethod   `json:"metodo" example:"fifo"`
+	Itens               []InventoryValuationItem `json:"itens"`
+	Total               int                      `json:"total" example:"7"`
+	ValorCusto          float64                  `json:"valor_custo" example:"80120.00"`
+	ValorVenda          float64                  `json:"valor_venda" example:"125000.50"`
+	LucroBrutoPotencial float64                  `json:"lucro_bruto_potencial" example:"44880.50"`
+}
+
+// HideCosts remove os dados de custo da resposta
+func (r *ProductResponse) HideCosts() {
+	r.Custos = nil
+}
+
+// HideCosts remove os dados de custo de todos os produtos da lista
+func (r *ProductListResponse) HideCosts() {
+	for i := range r.Produtos {
+		r.Produtos[i].HideCosts()
+	}
+}
+
+// HideCosts remove os dados de custo dos produtos ajustados
+func (r *StockAdjustmentBatchResponse) HideCosts() {
+	for i := range r.Produtos {
+		r.Produtos[i].HideCosts()
+	}
+}
+
+// HideCosts remove a valoração a custo e os custos dos rankings
+func (r *ProductStatistics) HideCosts() {
+	r.Valoracao = nil
+	for i := range r.PorCategoria {
+		r.PorCategoria[i].Custos = nil
+	}
+	for _, ranking := range [][]ProductResponse{r.Top5MaisCaros, r.Top5MaisBaratos, r.Top5MaisEstoque} {
+		for i := range ranking {
+			ranking[i].HideCosts()
+		}
+	}
+}
+
+// HideCosts remove os dados de custo do kit
+func (r *KitCompositionResponse) HideCosts() {
+	r.Kit.HideCosts()
+}
+
+// HideCosts remove os valores a custo das divergências da contagem
+func (r *InventoryCountResponse) HideCosts() {
+	for i := range r.Itens {
+		r.Itens[i].ValorDivergencia = nil
+	}
+}
+
+// HideCosts remove os valores a custo de todas as contagens da lista
+func (r *InventoryCountListResponse) HideCosts() {
+	for i := range r.Contagens {
+		r.Contagens[i].HideCosts()
+	}
+}
+
+// HideCosts remove o custo unitário e os valores das divergências
+func (r *CountVarianceReportResponse) HideCosts() {
+	r.ValorSobras = nil
+	r.ValorFaltas = nil
+	r.ValorLiquido = nil
+	for i := range r.Itens {
+		r.Itens[i].CustoUnitario = nil
+		r.Itens[i].ValorDivergencia = nil
+	}
+}
+
+// HideCosts remove o custo da última compra do vínculo
+func (r *SupplierProductResponse) HideCosts() {
+	r.UltimoCusto = 0
+}
+
+// HideCosts remove o custo da última compra de todos os vínculos da lista
+func (r *SupplierProductListResponse) HideCosts() {
+	for i := range r.Itens {
+		r.Itens[i].HideCosts()
+	}
+}
@@ -0,0 +1,82 @@
+package dtos
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// FiscalRequest representa os dados fiscais do produto. CFOPs não informados
+// assumem 5102 (estadual) e 6102 (interestadual); alíquotas não informadas
+// vêm da tabela NCM (IPI) e da tabela de tributos (PIS e COFINS)
+type FiscalRequest struct {
+	NCM               string               `json:"ncm" binding:"required" example:"8517.13.00"`
+	CEST              string               `json:"cest,omitempty" example:"21.053.00"`
+	CFOPEstadual      string               `json:"cfop_estadual,omitempty" example:"5102"`
+	CFOPInterestadual string               `json:"cfop_interestadual,omitempty" example:"6102"`
+	Origem            models.ProductOrigin `json:"origem" binding:"min=0,max=8" example:"0"`
+	AliquotaIPI       *float64             `json:"aliquota_ipi,omitempty" binding:"omitempty,min=0,max=100" example:"9.75"`
+	AliquotaPIS       *float64             `json:"aliquota_pis,omitempty" binding:"omitempty,min=0,max=100" example:"1.65"`
+	AliquotaCOFINS    *float64             `json:"aliquota_cofins,omitempty" binding:"omitempty,min=0,max=100" example:"7.6"`
+}
+
+// FiscalResponse representa os dados fiscais do produto com as descrições do
+// NCM e da origem
+type FiscalResponse struct {
+	NCM               string               `json:"ncm" example:"85171300"`
+	DescricaoNCM      string               `json:"descricao_ncm" example:"Telefones inteligentes (smartphones)"`
+	CEST              string               `json:"cest,omitempty" example:"2105300"`
+	CFOPEstadual      string               `json:"cfop_estadual" example:"5102"`
+	CFOPInterestadual string               `json:"cfop_interestadual" example:"6102"`
+	Origem            models.ProductOrigin `json:"origem" example:"0"`
+	DescricaoOrigem   string               `json:"descricao_origem" example:"Nacional"`
+	AliquotaIPI       *float64             `json:"aliquota_ipi,omitempty" example:"9.75"`
+	AliquotaPIS       *float64             `json:"aliquota_pis,omitempty" example:"1.65"`
+	AliquotaCOFINS    *float64             `json:"aliquota_cofins,omitempty" example:"7.6"`
+}
+
+// TaxSimulationRequest representa a requisição de simulação de tributos de
+// uma venda do produto para a UF de destino
+type TaxSimulationRequest struct {
+	ProdutoID       uuid.UUID `json:"produto_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UFDestino       string    `json:"uf_destino" binding:"required,len=2" example:"RJ"`
+	Quantidade      int       `json:"quantidade" binding:"omitempty,min=1" example:"1"`
+	ValorUnitario   *float64  `json:"valor_unitario,omitempty" binding:"omitempty,gt=0" example:"2299.99"`
+	ConsumidorFinal bool      `json:"consumidor_final" example:"true"`
+}
+
+// TaxLine representa a base, a alíquota (%) e o valor de um tributo
+type TaxLine struct {
+	Base     float64 `json:"base" example:"2299.99"`
+	Aliquota float64 `json:"aliquota" example:"12"`
+	Valor    float64 `json:"valor" example:"276.00"`
+}
+
+// TaxSimulationResponse representa o resultado da simulação de tributos
+type TaxSimulationResponse struct {
+	ProdutoID       uuid.UUID            `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome            string               `json:"nome" example:"Smartphone Samsung Galaxy S24"`
+	NCM             string               `json:"ncm" example:"85171300"`
+	Origem          models.ProductOrigin `json:"origem" example:"0"`
+	UFOrigem        string               `json:"uf_origem" example:"SP"`
+	UFDestino       string               `json:"uf_destino" example:"RJ"`
+	Operacao        string               `json:"operacao" example:"interestadual"`
+	CFOP            string               `json:"cfop" example:"6102"`
+	ConsumidorFinal bool                 `json:"consumidor_final" example:"true"`
+	Quantidade      int                  `json:"quantidade" example:"1"`
+	ValorUnitario   float64              `json:"valor_unitario" example:"2299.99"`
+	ValorProdutos   float64              `json:"valor_produtos" example:"2299.99"`
+	IPI             TaxLine              `json:"ipi"`
+	ICMS            TaxLine              `json:"icms"`
+	DIFAL           *TaxLine             `json:"difal,omitempty"`
+	PIS             TaxLine              `json:"pis"`
+	COFINS          TaxLine              `json:"cofins"`
+	TotalTributos   float64              `json:"total_tributos" example:"812.45"`
+	ValorTotalNota  float64              `json:"valor_total_nota" example:"2524.23"`
+	CargaTributaria float64              `json:"carga_tributaria" example:"35.32"`
+}
+
+// NCMListResponse representa o resultado da busca na tabela NCM
+type NCMListResponse struct {
+	NCMs  []models.NCMEntry `json:"ncms"`
+	Total int               `json:"total" example:"2"`
+}
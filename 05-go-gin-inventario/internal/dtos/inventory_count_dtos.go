@@ -0,0 +1,111 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// InventoryCountRequest representa a requisição para abrir uma contagem de
+// inventário. Sem categoria nem localização, o escopo é o estoque inteiro.
+type InventoryCountRequest struct {
+	Descricao   string                  `json:"descricao,omitempty" binding:"max=200" example:"Inventário trimestral - corredor A"`
+	Categoria   *models.ProductCategory `json:"categoria,omitempty" binding:"omitempty,oneof=eletronicos roupas casa livros esportes beleza brinquedos automotivo alimentos outros" example:"eletronicos"`
+	Localizacao string                  `json:"localizacao,omitempty" binding:"max=50" example:"A-01"`
+	Cega        bool                    `json:"cega" example:"true"`
+}
+
+// CountEntryRequest representa a quantidade contada de um produto
+type CountEntryRequest struct {
+	ProdutoID  uuid.UUID `json:"produto_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Quantidade *int      `json:"quantidade" binding:"required,min=0" example:"12"`
+}
+
+// RecordCountsRequest representa o envio de quantidades contadas
+type RecordCountsRequest struct {
+	ContadoPor string              `json:"contado_por,omitempty" binding:"max=100" example:"joao.silva"`
+	Itens      []CountEntryRequest `json:"itens" binding:"required,min=1,dive"`
+}
+
+// PostInventoryCountRequest representa a aprovação de uma contagem
+type PostInventoryCountRequest struct {
+	AprovadoPor string `json:"aprovado_por,omitempty" binding:"max=100" example:"maria.souza"`
+}
+
+// InventoryCountItemResponse representa um produto da contagem. Em contagens
+// cegas abertas, a quantidade esperada e a divergência são omitidas.
+type InventoryCountItemResponse struct {
+	ProdutoID          uuid.UUID              `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome               string                 `json:"nome" example:"Notebook Dell Inspiron"`
+	Categoria          models.ProductCategory `json:"categoria" example:"eletronicos"`
+	Localizacao        string                 `json:"localizacao,omitempty" example:"A-01-03"`
+	QuantidadeEsperada *int                   `json:"quantidade_esperada,omitempty" example:"10"`
+	QuantidadeContada  *int                   `json:"quantidade_contada,omitempty" example:"9"`
+	Divergencia        *int                   `json:"divergencia,omitempty" example:"-1"`
+	ValorDivergencia   *float64               `json:"valor_divergencia,omitempty" example:"-2650.00"`
+	Contagens          int                    `json:"contagens" example:"1"`
+	ContadoPor         string                 `json:"contado_por,omitempty" example:"joao.silva"`
+	DataContagem       *time.Time             `json:"data_contagem,omitempty" example:"2023-03-31T15:04:05Z"`
+}
+
+// InventoryCountResponse representa uma contagem de inventário
+type InventoryCountResponse struct {
+	ID               uuid.UUID                    `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Numero           string                       `json:"numero" example:"INV-000001"`
+	Descricao        string                       `json:"descricao,omitempty" example:"Inventário trimestral - corredor A"`
+	Categoria        *models.ProductCategory      `json:"categoria,omitempty" example:"eletronicos"`
+	Localizacao      string                       `json:"localizacao,omitempty" example:"A-01"`
+	Cega             bool                         `json:"cega" example:"true"`
+	Status           models.InventoryCountStatus  `json:"status" example:"aberta"`
+	Itens            []InventoryCountItemResponse `json:"itens"`
+	TotalItens       int                          `json:"total_itens" example:"25"`
+	Contados         int                          `json:"contados" example:"20"`
+	Pendentes        int                          `json:"pendentes" example:"5"`
+	Ajustes          int                          `json:"ajustes" example:"0"`
+	AprovadoPor      string                       `json:"aprovado_por,omitempty" example:"maria.souza"`
+	DataAbertura     time.Time                    `json:"data_abertura" example:"2023-03-31T08:00:00Z"`
+	DataEncerramento *time.Time                   `json:"data_encerramento,omitempty" example:"2023-03-31T17:00:00Z"`
+	DataPostagem     *time.Time                   `json:"data_postagem,omitempty" example:"2023-04-01T10:00:00Z"`
+	DataCancelamento *time.Time                   `json:"data_cancelamento,omitempty" example:"2023-04-01T10:00:00Z"`
+}
+
+// InventoryCountListResponse representa a lista de contagens de inventário
+type InventoryCountListResponse struct {
+	Contagens []InventoryCountResponse `json:"contagens"`
+	Total     int                      `json:"total" example:"3"`
+}
+
+// CountVarianceItem representa a divergência de um produto contado. Custo e
+// valor só aparecem para usuários com acesso financeiro.
+type CountVarianceItem struct {
+	ProdutoID          uuid.UUID              `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome               string                 `json:"nome" example:"Notebook Dell Inspiron"`
+	Categoria          models.ProductCategory `json:"categoria" example:"eletronicos"`
+	Localizacao        string                 `json:"localizacao,omitempty" example:"A-01-03"`
+	QuantidadeEsperada int                    `json:"quantidade_esperada" example:"10"`
+	QuantidadeContada  int                    `json:"quantidade_contada" example:"9"`
+	Divergencia        int                    `json:"divergencia" example:"-1"`
+	CustoUnitario      *float64               `json:"custo_unitario,omitempty" example:"2650.00"`
+	ValorDivergencia   *float64               `json:"valor_divergencia,omitempty" example:"-2650.00"`
+	Contagens          int                    `json:"contagens" example:"2"`
+}
+
+// CountVarianceReportResponse representa o relatório de divergências de uma
+// contagem, com o impacto a custo das sobras e faltas para usuários com
+// acesso financeiro
+type CountVarianceReportResponse struct {
+	ContagemID            uuid.UUID                   `json:"contagem_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Numero                string                      `json:"numero" example:"INV-000001"`
+	Status                models.InventoryCountStatus `json:"status" example:"encerrada"`
+	TotalItens            int                         `json:"total_itens" example:"25"`
+	Contados              int                         `json:"contados" example:"25"`
+	ItensDivergentes      int                         `json:"itens_divergentes" example:"3"`
+	AcuracidadePercentual float64                     `json:"acuracidade_percentual" example:"88"`
+	UnidadesSobra         int                         `json:"unidades_sobra" example:"2"`
+	UnidadesFalta         int                         `json:"unidades_falta" example:"4"`
+	ValorSobras           *float64                    `json:"valor_sobras,omitempty" example:"180.00"`
+	ValorFaltas           *float64                    `json:"valor_faltas,omitempty" example:"2830.00"`
+	ValorLiquido          *float64                    `json:"valor_liquido,omitempty" example:"-2650.00"`
+	Itens                 []CountVarianceItem         `json:"itens"`
+}
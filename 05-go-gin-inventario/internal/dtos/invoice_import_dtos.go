@@ -0,0 +1,68 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// MapInvoiceLineRequest representa o vínculo manual de um item da nota. Sem
+// produto_id, ignorar deve ser verdadeiro e o item não entra no estoque.
+type MapInvoiceLineRequest struct {
+	ProdutoID *uuid.UUID `json:"produto_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Ignorar   bool       `json:"ignorar,omitempty" example:"false"`
+}
+
+// InvoiceDecisionRequest representa a confirmação ou o descarte de uma importação
+type InvoiceDecisionRequest struct {
+	Observacao string `json:"observacao,omitempty" binding:"max=500" example:"Conferido na doca 2"`
+}
+
+// InvoiceLineResponse representa um item da nota e o produto vinculado
+type InvoiceLineResponse struct {
+	Item          int                 `json:"item" example:"1"`
+	CodigoProduto string              `json:"codigo_produto" example:"SKU-4432"`
+	CodigoBarras  string              `json:"codigo_barras,omitempty" example:"7891000001011"`
+	Descricao     string              `json:"descricao" example:"SMARTPHONE GALAXY S24 128GB"`
+	NCM           string              `json:"ncm,omitempty" example:"85171300"`
+	CFOP          string              `json:"cfop,omitempty" example:"6102"`
+	Unidade       string              `json:"unidade,omitempty" example:"UN"`
+	Quantidade    float64             `json:"quantidade" example:"10"`
+	ValorUnitario float64             `json:"valor_unitario" example:"1550.00"`
+	ValorTotal    float64             `json:"valor_total" example:"15500.00"`
+	Lotes         []models.InvoiceLot `json:"lotes,omitempty"`
+	ProdutoID     *uuid.UUID          `json:"produto_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	NomeProduto   string              `json:"nome_produto,omitempty" example:"Smartphone Samsung Galaxy S24"`
+	Vinculo       models.InvoiceMatch `json:"vinculo,omitempty" example:"codigo_barras"`
+	Ignorado      bool                `json:"ignorado" example:"false"`
+	Pendente      bool                `json:"pendente" example:"false"`
+}
+
+// InvoiceImportResponse representa uma importação de NF-e
+type InvoiceImportResponse struct {
+	ID              uuid.UUID                  `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ChaveAcesso     string                     `json:"chave_acesso" example:"35240312345678000195550010000012341000012345"`
+	Numero          string                     `json:"numero" example:"1234"`
+	Serie           string                     `json:"serie" example:"1"`
+	DataEmissao     time.Time                  `json:"data_emissao" example:"2024-03-10T10:00:00-03:00"`
+	EmitenteCNPJ    string                     `json:"emitente_cnpj" example:"12345678000195"`
+	EmitenteNome    string                     `json:"emitente_nome" example:"Distribuidora Exemplo Ltda"`
+	FornecedorID    *uuid.UUID                 `json:"fornecedor_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Status          models.InvoiceImportStatus `json:"status" example:"pendente"`
+	Itens           []InvoiceLineResponse      `json:"itens"`
+	ItensVinculados int                        `json:"itens_vinculados" example:"2"`
+	ItensIgnorados  int                        `json:"itens_ignorados" example:"0"`
+	ItensPendentes  int                        `json:"itens_pendentes" example:"1"`
+	ValorProdutos   float64                    `json:"valor_produtos" example:"15800.00"`
+	ValorTotal      float64                    `json:"valor_total" example:"17222.00"`
+	Observacao      string                     `json:"observacao,omitempty" example:"Conferido na doca 2"`
+	DataImportacao  time.Time                  `json:"data_importacao" example:"2024-03-11T08:30:00Z"`
+	DataConclusao   *time.Time                 `json:"data_conclusao,omitempty" example:"2024-03-11T09:00:00Z"`
+}
+
+// InvoiceImportListResponse representa a listagem de importações de NF-e
+type InvoiceImportListResponse struct {
+	Importacoes []InvoiceImportResponse `json:"importacoes"`
+	Total       int                     `json:"total" example:"3"`
+}
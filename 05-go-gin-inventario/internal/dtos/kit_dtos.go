@@ -0,0 +1,40 @@
+package dtos
+
+import (
+	"github.com/google/uuid"
+)
+
+// KitComponentRequest representa um componente na definição de um kit
+type KitComponentRequest struct {
+	ProdutoID  uuid.UUID `json:"produto_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Quantidade int       `json:"quantidade" binding:"required,min=1" example:"1"`
+}
+
+// KitDefinitionRequest representa a requisição para definir a composição de um kit.
+// Uma lista de componentes vazia transforma o kit novamente em produto simples.
+type KitDefinitionRequest struct {
+	Componentes        []KitComponentRequest `json:"componentes" binding:"omitempty,dive"`
+	PrecoDerivado      bool                  `json:"preco_derivado" example:"true"`
+	DescontoPercentual float64               `json:"desconto_percentual" binding:"min=0,max=100" example:"10"`
+}
+
+// KitComponentDetail representa um componente do kit com sua disponibilidade
+type KitComponentDetail struct {
+	ProdutoID            uuid.UUID `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome                 string    `json:"nome" example:"Mouse sem fio Logitech"`
+	QuantidadePorKit     int       `json:"quantidade_por_kit" example:"1"`
+	QuantidadeDisponivel int       `json:"quantidade_disponivel" example:"40"`
+	KitsPossiveis        int       `json:"kits_possiveis" example:"40"`
+	PrecoUnitario        float64   `json:"preco_unitario" example:"129.90"`
+	Subtotal             float64   `json:"subtotal" example:"129.90"`
+}
+
+// KitCompositionResponse representa a composição de um kit com disponibilidade e preço calculados
+type KitCompositionResponse struct {
+	Kit              ProductResponse      `json:"kit"`
+	Componentes      []KitComponentDetail `json:"componentes"`
+	Disponivel       int                  `json:"disponivel" example:"8"`
+	PrecoComponentes float64              `json:"preco_componentes" example:"3929.79"`
+	PrecoKit         float64              `json:"preco_kit" example:"3536.81"`
+	Economia         float64              `json:"economia" example:"392.98"`
+}
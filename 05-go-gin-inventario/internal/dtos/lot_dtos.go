@@ -0,0 +1,56 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// CreateLotRequest representa a requisição para registrar a entrada de um lote
+type CreateLotRequest struct {
+	Codigo         string    `json:"codigo" binding:"required,max=50" example:"L2024-0315"`
+	DataFabricacao time.Time `json:"data_fabricacao" binding:"required" example:"2024-03-15T00:00:00Z"`
+	DataValidade   time.Time `json:"data_validade" binding:"required" example:"2024-09-15T00:00:00Z"`
+	Quantidade     int       `json:"quantidade" binding:"required,min=1" example:"120"`
+	CustoUnitario  float64   `json:"custo_unitario,omitempty" binding:"min=0" example:"9.80"`
+}
+
+// LotResponse representa a resposta de um lote
+type LotResponse struct {
+	ID                uuid.UUID `json:"id" example:"a3c1e7d2-6f4b-4c1a-9d8e-2b5f7a9c0e11"`
+	ProdutoID         uuid.UUID `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Codigo            string    `json:"codigo" example:"L2024-0315"`
+	DataFabricacao    time.Time `json:"data_fabricacao" example:"2024-03-15T00:00:00Z"`
+	DataValidade      time.Time `json:"data_validade" example:"2024-09-15T00:00:00Z"`
+	QuantidadeInicial int       `json:"quantidade_inicial" example:"120"`
+	Quantidade        int       `json:"quantidade" example:"85"`
+	Vencido           bool      `json:"vencido" example:"false"`
+	DiasParaVencer    int       `json:"dias_para_vencer" example:"42"`
+	DataCriacao       time.Time `json:"data_criacao" example:"2024-03-20T10:30:00Z"`
+}
+
+// LotListResponse representa os lotes de um produto na ordem de consumo (FEFO)
+type LotListResponse struct {
+	ProdutoID       uuid.UUID     `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Lotes           []LotResponse `json:"lotes"`
+	Total           int           `json:"total" example:"3"`
+	QuantidadeTotal int           `json:"quantidade_total" example:"240"`
+}
+
+// ExpiringLotItem representa um lote próximo do vencimento com o valor em estoque
+type ExpiringLotItem struct {
+	LotResponse
+	NomeProduto string                 `json:"nome_produto" example:"Café Torrado 500g"`
+	Categoria   models.ProductCategory `json:"categoria" example:"alimentos"`
+	Valor       float64                `json:"valor" example:"1274.15"`
+}
+
+// ExpiringLotsResponse representa o relatório de lotes que vencem dentro de N dias
+type ExpiringLotsResponse struct {
+	Dias            int               `json:"dias" example:"30"`
+	Lotes           []ExpiringLotItem `json:"lotes"`
+	TotalLotes      int               `json:"total_lotes" example:"4"`
+	QuantidadeTotal int               `json:"quantidade_total" example:"310"`
+	ValorTotal      float64           `json:"valor_total" example:"4820.50"`
+}
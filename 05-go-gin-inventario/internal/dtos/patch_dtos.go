@@ -0,0 +1,10 @@
+package dtos
+
+// PatchOperation representa uma operação de JSON Patch (RFC 6902) aplicada
+// à representação editável do produto (ReplaceProductRequest)
+type PatchOperation struct {
+	Op    string      `json:"op" binding:"required,oneof=add remove replace move copy test" example:"replace"`
+	Path  string      `json:"path" binding:"required" example:"/preco"`
+	From  string      `json:"from,omitempty" example:"/descricao"`
+	Value interface{} `json:"value,omitempty"`
+}
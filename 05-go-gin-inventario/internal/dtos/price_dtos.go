@@ -0,0 +1,52 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// SchedulePriceRequest representa a requisição para agendar um preço futuro
+type SchedulePriceRequest struct {
+	Preco     float64   `json:"preco" binding:"required,min=0" example:"2199.99"`
+	VigenteEm time.Time `json:"vigente_em" binding:"required" example:"2024-11-29T00:00:00Z"`
+	Motivo    string    `json:"motivo,omitempty" binding:"max=200" example:"Black Friday"`
+}
+
+// PriceHistoryResponse representa o histórico de preços e os agendamentos pendentes de um produto
+type PriceHistoryResponse struct {
+	ProdutoID    uuid.UUID               `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	PrecoAtual   float64                 `json:"preco_atual" example:"2299.99"`
+	Historico    []models.PriceChange    `json:"historico"`
+	Agendamentos []models.ScheduledPrice `json:"agendamentos"`
+}
+
+// PriceAdjustmentRequest representa um reajuste percentual de preços por categoria ou filtro
+type PriceAdjustmentRequest struct {
+	Percentual   float64                 `json:"percentual" binding:"required,gt=-100" example:"7.5"`
+	Categoria    *models.ProductCategory `json:"categoria,omitempty" binding:"omitempty,oneof=eletronicos roupas casa livros esportes beleza brinquedos automotivo alimentos outros" example:"eletronicos"`
+	Nome         *string                 `json:"nome,omitempty" example:"samsung"`
+	PrecoMinimo  *float64                `json:"preco_minimo,omitempty" binding:"omitempty,min=0" example:"100.00"`
+	PrecoMaximo  *float64                `json:"preco_maximo,omitempty" binding:"omitempty,min=0" example:"2000.00"`
+	ApenasAtivos *bool                   `json:"apenas_ativos,omitempty" example:"true"`
+	Motivo       string                  `json:"motivo,omitempty" binding:"max=200" example:"Reajuste anual do fornecedor"`
+}
+
+// PriceAdjustmentItem representa o efeito do reajuste em um produto
+type PriceAdjustmentItem struct {
+	ProdutoID     uuid.UUID              `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome          string                 `json:"nome" example:"Notebook Dell Inspiron"`
+	Categoria     models.ProductCategory `json:"categoria" example:"eletronicos"`
+	PrecoAnterior float64                `json:"preco_anterior" example:"3499.99"`
+	PrecoNovo     float64                `json:"preco_novo" example:"3762.49"`
+	Diferenca     float64                `json:"diferenca" example:"262.50"`
+}
+
+// PriceAdjustmentResponse representa o resultado (ou a prévia) de um reajuste de preços
+type PriceAdjustmentResponse struct {
+	Simulacao  bool                  `json:"simulacao" example:"true"`
+	Percentual float64               `json:"percentual" example:"7.5"`
+	Itens      []PriceAdjustmentItem `json:"itens"`
+	Total      int                   `json:"total" example:"3"`
+}
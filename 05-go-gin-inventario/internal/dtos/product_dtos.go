@@ -9,54 +9,124 @@ import (
 
 // CreateProductRequest representa a requisição para criar um novo produto
 type CreateProductRequest struct {
-	Nome       string                  `json:"nome" binding:"required,min=2,max=100" example:"Smartphone Samsung Galaxy"`
-	Descricao  string                  `json:"descricao" binding:"max=500" example:"Smartphone com tela de 6.1 polegadas e câmera de 64MP"`
-	Preco      float64                 `json:"preco" binding:"required,min=0" example:"1299.99"`
-	Quantidade int                     `json:"quantidade" binding:"min=0" example:"50"`
-	Categoria  models.ProductCategory  `json:"categoria" binding:"required,oneof=eletronicos roupas casa livros esportes beleza brinquedos automotivo alimentos outros" example:"eletronicos"`
-	Ativo      *bool                   `json:"ativo,omitempty" example:"true"`
+	Nome                string                 `json:"nome" binding:"required,min=2,max=100" example:"Smartphone Samsung Galaxy"`
+	Descricao           string                 `json:"descricao" binding:"max=500" example:"Smartphone com tela de 6.1 polegadas e câmera de 64MP"`
+	Preco               float64                `json:"preco" binding:"required,min=0" example:"1299.99"`
+	PrecoCusto          float64                `json:"preco_custo" binding:"min=0" example:"850.00"`
+	Quantidade          int                    `json:"quantidade" binding:"min=0" example:"50"`
+	EstoqueMinimo       int                    `json:"estoque_minimo" binding:"min=0" example:"10"`
+	QuantidadeReposicao int                    `json:"quantidade_reposicao" binding:"min=0" example:"30"`
+	Categoria           models.ProductCategory `json:"categoria" binding:"required,oneof=eletronicos roupas casa livros esportes beleza brinquedos automotivo alimentos outros" example:"eletronicos"`
+	Localizacao         string                 `json:"localizacao,omitempty" binding:"max=50" example:"A-01-03"`
+	CodigoBarras        string                 `json:"codigo_barras,omitempty" binding:"max=20" example:"7891000001011"`
+	Tags                []string               `json:"tags,omitempty" binding:"max=20,dive,max=30" example:"importado,frágil"`
+	Dimensoes           *DimensionsRequest     `json:"dimensoes,omitempty"`
+	Fiscal              *FiscalRequest         `json:"fiscal,omitempty"`
+	Ativo               *bool                  `json:"ativo,omitempty" example:"true"`
+	Status              models.ProductStatus   `json:"status,omitempty" binding:"omitempty,oneof=rascunho em_revisao ativo inativo" example:"rascunho"`
+	ControlaLote        bool                   `json:"controla_lote,omitempty" example:"false"`
+	Serializado         bool                   `json:"serializado,omitempty" example:"false"`
 }
 
 // UpdateProductRequest representa a requisição para atualizar um produto
 type UpdateProductRequest struct {
-	Nome       *string                 `json:"nome,omitempty" binding:"omitempty,min=2,max=100" example:"Smartphone Samsung Galaxy S24"`
-	Descricao  *string                 `json:"descricao,omitempty" binding:"omitempty,max=500" example:"Smartphone com tela de 6.1 polegadas, câmera de 64MP e 5G"`
-	Preco      *float64                `json:"preco,omitempty" binding:"omitempty,min=0" example:"1399.99"`
-	Quantidade *int                    `json:"quantidade,omitempty" binding:"omitempty,min=0" example:"45"`
-	Categoria  *models.ProductCategory `json:"categoria,omitempty" binding:"omitempty,oneof=eletronicos roupas casa livros esportes beleza brinquedos automotivo alimentos outros" example:"eletronicos"`
-	Ativo      *bool                   `json:"ativo,omitempty" example:"true"`
+	Nome                *string                 `json:"nome,omitempty" binding:"omitempty,min=2,max=100" example:"Smartphone Samsung Galaxy S24"`
+	Descricao           *string                 `json:"descricao,omitempty" binding:"omitempty,max=500" example:"Smartphone com tela de 6.1 polegadas, câmera de 64MP e 5G"`
+	Preco               *float64                `json:"preco,omitempty" binding:"omitempty,min=0" example:"1399.99"`
+	PrecoCusto          *float64                `json:"preco_custo,omitempty" binding:"omitempty,min=0" example:"870.00"`
+	Quantidade          *int                    `json:"quantidade,omitempty" binding:"omitempty,min=0" example:"45"`
+	EstoqueMinimo       *int                    `json:"estoque_minimo,omitempty" binding:"omitempty,min=0" example:"10"`
+	QuantidadeReposicao *int                    `json:"quantidade_reposicao,omitempty" binding:"omitempty,min=0" example:"30"`
+	Categoria           *models.ProductCategory `json:"categoria,omitempty" binding:"omitempty,oneof=eletronicos roupas casa livros esportes beleza brinquedos automotivo alimentos outros" example:"eletronicos"`
+	Localizacao         *string                 `json:"localizacao,omitempty" binding:"omitempty,max=50" example:"A-01-03"`
+	CodigoBarras        *string                 `json:"codigo_barras,omitempty" binding:"omitempty,max=20" example:"7891000001011"`
+	Tags                *[]string               `json:"tags,omitempty" binding:"omitempty,max=20,dive,max=30" example:"importado,frágil"`
+	Dimensoes           *DimensionsRequest      `json:"dimensoes,omitempty"`
+	Fiscal              *FiscalRequest          `json:"fiscal,omitempty"`
+	Ativo               *bool                   `json:"ativo,omitempty" example:"true"`
+	ControlaLote        *bool                   `json:"controla_lote,omitempty" example:"true"`
+	Serializado         *bool                   `json:"serializado,omitempty" example:"true"`
+}
+
+// ReplaceProductRequest representa a representação completa e editável de
+// um produto, usada pelo PUT e como documento base dos patches. Textos e tags
+// omitidos ficam vazios e dimensoes e fiscal nulos são removidos; os campos
+// numéricos são obrigatórios. O estoque físico não faz parte da
+// representação: muda apenas por /estoque e /estoque/ajuste. Situação,
+// traduções e composição de kits têm endpoints próprios.
+type ReplaceProductRequest struct {
+	Nome                string                 `json:"nome" binding:"required,min=2,max=100" example:"Smartphone Samsung Galaxy S24"`
+	Descricao           string                 `json:"descricao" binding:"max=500" example:"Smartphone com tela de 6.1 polegadas, câmera de 64MP e 5G"`
+	Preco               float64                `json:"preco" binding:"required,min=0" example:"1399.99"`
+	PrecoCusto          *float64               `json:"preco_custo" binding:"required,min=0" example:"870.00"`
+	EstoqueMinimo       *int                   `json:"estoque_minimo" binding:"required,min=0" example:"10"`
+	QuantidadeReposicao *int                   `json:"quantidade_reposicao" binding:"required,min=0" example:"30"`
+	Categoria           models.ProductCategory `json:"categoria" binding:"required,oneof=eletronicos roupas casa livros esportes beleza brinquedos automotivo alimentos outros" example:"eletronicos"`
+	Localizacao         string                 `json:"localizacao" binding:"max=50" example:"A-01-03"`
+	CodigoBarras        string                 `json:"codigo_barras" binding:"max=20" example:"7891000001011"`
+	Tags                []string               `json:"tags" binding:"max=20,dive,max=30" example:"importado,frágil"`
+	Dimensoes           *DimensionsRequest     `json:"dimensoes"`
+	Fiscal              *FiscalRequest         `json:"fiscal"`
+	Ativo               *bool                  `json:"ativo" binding:"required" example:"true"`
+	ControlaLote        bool                   `json:"controla_lote" example:"false"`
+	Serializado         bool                   `json:"serializado" example:"false"`
 }
 
 // ProductResponse representa a resposta de um produto
 type ProductResponse struct {
-	ID              uuid.UUID               `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Nome            string                  `json:"nome" example:"Smartphone Samsung Galaxy"`
-	Descricao       string                  `json:"descricao" example:"Smartphone com tela de 6.1 polegadas e câmera de 64MP"`
-	Preco           float64                 `json:"preco" example:"1299.99"`
-	PrecoFormatado  string                  `json:"preco_formatado" example:"R$ 1.299,99"`
-	Quantidade      int                     `json:"quantidade" example:"50"`
-	Categoria       models.ProductCategory  `json:"categoria" example:"eletronicos"`
-	Ativo           bool                    `json:"ativo" example:"true"`
-	EmEstoque       bool                    `json:"em_estoque" example:"true"`
-	DataCriacao     time.Time               `json:"data_criacao" example:"2023-01-15T10:30:00Z"`
-	DataAtualizacao time.Time               `json:"data_atualizacao" example:"2023-01-15T10:30:00Z"`
+	ID                    uuid.UUID                                   `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome                  string                                      `json:"nome" example:"Smartphone Samsung Galaxy"`
+	Descricao             string                                      `json:"descricao" example:"Smartphone com tela de 6.1 polegadas e câmera de 64MP"`
+	Preco                 float64                                     `json:"preco" example:"1299.99"`
+	PrecoFormatado        string                                      `json:"preco_formatado" example:"R$ 1.299,99"`
+	PrecoPromocional      *float64                                    `json:"preco_promocional,omitempty" example:"1104.99"`
+	PromocoesAtivas       []models.AppliedPromotion                   `json:"promocoes_ativas,omitempty"`
+	Custos                *ProductCostInfo                            `json:"custos,omitempty"`
+	Quantidade            int                                         `json:"quantidade" example:"50"`
+	QuantidadeReservada   int                                         `json:"quantidade_reservada" example:"5"`
+	QuantidadeDisponivel  int                                         `json:"quantidade_disponivel" example:"45"`
+	QuantidadeCaixaAberta int                                         `json:"quantidade_caixa_aberta" example:"2"`
+	EstoqueMinimo         int                                         `json:"estoque_minimo" example:"10"`
+	QuantidadeReposicao   int                                         `json:"quantidade_reposicao" example:"30"`
+	PrecisaReposicao      bool                                        `json:"precisa_reposicao" example:"false"`
+	Categoria             models.ProductCategory                      `json:"categoria" example:"eletronicos"`
+	Localizacao           string                                      `json:"localizacao,omitempty" example:"A-01-03"`
+	CodigoBarras          string                                      `json:"codigo_barras,omitempty" example:"7891000001011"`
+	Tags                  []string                                    `json:"tags" example:"importado,frágil"`
+	Idioma                models.Locale                               `json:"idioma,omitempty" example:"es"`
+	Traducoes             map[models.Locale]models.ProductTranslation `json:"-"`
+	Dimensoes             *DimensionsResponse                         `json:"dimensoes,omitempty"`
+	Fiscal                *FiscalResponse                             `json:"fiscal,omitempty"`
+	Imagens               []ProductImageResponse                      `json:"imagens,omitempty"`
+	Relacionados          []RelatedProductResponse                    `json:"relacionados,omitempty"`
+	Ativo                 bool                                        `json:"ativo" example:"true"`
+	Status                models.ProductStatus                        `json:"status" example:"ativo"`
+	EmEstoque             bool                                        `json:"em_estoque" example:"true"`
+	ControlaLote          bool                                        `json:"controla_lote" example:"false"`
+	Serializado           bool                                        `json:"serializado" example:"false"`
+	Kit                   bool                                        `json:"kit" example:"false"`
+	Componentes           []models.KitComponent                       `json:"componentes,omitempty"`
+	PrecoDerivado         bool                                        `json:"preco_derivado,omitempty" example:"false"`
+	DescontoKit           float64                                     `json:"desconto_kit,omitempty" example:"10"`
+	DataCriacao           time.Time                                   `json:"data_criacao" example:"2023-01-15T10:30:00Z"`
+	DataAtualizacao       time.Time                                   `json:"data_atualizacao" example:"2023-01-15T10:30:00Z"`
 }
 
 // ProductListResponse representa a resposta paginada de produtos
 type ProductListResponse struct {
-	Produtos   []ProductResponse `json:"produtos"`
-	Paginacao  PaginationMeta    `json:"paginacao"`
-	Filtros    FilterInfo        `json:"filtros"`
+	Produtos  []ProductResponse `json:"produtos"`
+	Paginacao PaginationMeta    `json:"paginacao"`
+	Filtros   FilterInfo        `json:"filtros"`
 }
 
 // PaginationMeta contém metadados da paginação
 type PaginationMeta struct {
-	PaginaAtual     int `json:"pagina_atual" example:"1"`
-	ItensPorPagina  int `json:"itens_por_pagina" example:"10"`
-	TotalItens      int `json:"total_itens" example:"150"`
-	TotalPaginas    int `json:"total_paginas" example:"15"`
-	TemProxima      bool `json:"tem_proxima" example:"true"`
-	TemAnterior     bool `json:"tem_anterior" example:"false"`
+	PaginaAtual    int  `json:"pagina_atual" example:"1"`
+	ItensPorPagina int  `json:"itens_por_pagina" example:"10"`
+	TotalItens     int  `json:"total_itens" example:"150"`
+	TotalPaginas   int  `json:"total_paginas" example:"15"`
+	TemProxima     bool `json:"tem_proxima" example:"true"`
+	TemAnterior    bool `json:"tem_anterior" example:"false"`
 }
 
 // FilterInfo contém informações sobre os filtros aplicados
@@ -66,7 +136,11 @@ type FilterInfo struct {
 	PrecoMaximo   *float64                `json:"preco_maximum,omitempty" example:"2000.00"`
 	ApenasAtivos  *bool                   `json:"apenas_ativos,omitempty" example:"true"`
 	ApenasEstoque *bool                   `json:"apenas_estoque,omitempty" example:"true"`
+	Status        *models.ProductStatus   `json:"status,omitempty" example:"descontinuado"`
+	Tags          []string                `json:"tags,omitempty" example:"importado,frágil"`
+	TagsModo      models.TagMatch         `json:"tags_modo,omitempty" example:"qualquer"`
 	Nome          *string                 `json:"nome,omitempty" example:"samsung"`
+	Idioma        models.Locale           `json:"idioma,omitempty" example:"es"`
 }
 
 // StockUpdateRequest representa a requisição para atualizar estoque
@@ -74,32 +148,86 @@ type StockUpdateRequest struct {
 	Quantidade int `json:"quantidade" binding:"required,min=0" example:"100"`
 }
 
+// StockAdjustmentRequest representa a requisição para ajuste relativo de estoque.
+// O custo unitário vale para entradas; se omitido, usa o preço de custo do produto.
+type StockAdjustmentRequest struct {
+	Delta         int     `json:"delta" binding:"required" example:"-3"`
+	CustoUnitario float64 `json:"custo_unitario,omitempty" binding:"min=0" example:"845.50"`
+}
+
+// StockAdjustmentItem representa um ajuste de estoque dentro de um lote
+type StockAdjustmentItem struct {
+	ProdutoID     uuid.UUID `json:"produto_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Delta         int       `json:"delta" binding:"required" example:"-3"`
+	CustoUnitario float64   `json:"custo_unitario,omitempty" binding:"min=0" example:"845.50"`
+}
+
+// StockAdjustmentBatchRequest representa a requisição para ajustar vários produtos de uma vez
+type StockAdjustmentBatchRequest struct {
+	Ajustes []StockAdjustmentItem `json:"ajustes" binding:"required,min=1,dive"`
+}
+
+// StockAdjustmentBatchResponse representa o resultado de um ajuste de estoque em lote
+type StockAdjustmentBatchResponse struct {
+	TotalAjustes int               `json:"total_ajustes" example:"2"`
+	Produtos     []ProductResponse `json:"produtos"`
+}
+
+// ReorderItem representa um produto no ponto de reposição com a quantidade sugerida para compra
+type ReorderItem struct {
+	ProdutoID            uuid.UUID              `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome                 string                 `json:"nome" example:"Notebook Dell Inspiron"`
+	Categoria            models.ProductCategory `json:"categoria" example:"eletronicos"`
+	Quantidade           int                    `json:"quantidade" example:"4"`
+	QuantidadeDisponivel int                    `json:"quantidade_disponivel" example:"3"`
+	EstoqueMinimo        int                    `json:"estoque_minimo" example:"5"`
+	QuantidadeReposicao  int                    `json:"quantidade_reposicao" example:"10"`
+	QuantidadeSugerida   int                    `json:"quantidade_sugerida" example:"10"`
+}
+
+// ReorderListResponse representa a lista de produtos que precisam de reposição
+type ReorderListResponse struct {
+	Produtos []ReorderItem `json:"produtos"`
+	Total    int           `json:"total" example:"3"`
+}
+
+// StockAlertListResponse representa os alertas de estoque mais recentes
+type StockAlertListResponse struct {
+	Alertas []models.StockAlert `json:"alertas"`
+	Total   int                 `json:"total" example:"12"`
+}
+
 // ProductStatistics representa as estatísticas dos produtos
 type ProductStatistics struct {
-	TotalProdutos         int                            `json:"total_produtos" example:"150"`
-	ProdutosAtivos        int                            `json:"produtos_ativos" example:"140"`
-	ProdutosInativos      int                            `json:"produtos_inativos" example:"10"`
-	ProdutosEmEstoque     int                            `json:"produtos_em_estoque" example:"130"`
-	ProdutosSemEstoque    int                            `json:"produtos_sem_estoque" example:"20"`
-	ValorTotalInventario  float64                        `json:"valor_total_inventario" example:"125000.50"`
-	PrecoMedio            float64                        `json:"preco_medio" example:"850.25"`
-	PrecoMinimo           float64                        `json:"preco_minimo" example:"15.99"`
-	PrecoMaximo           float64                        `json:"preco_maximo" example:"5999.99"`
-	QuantidadeTotal       int                            `json:"quantidade_total" example:"2500"`
-	PorCategoria          []CategoryStatistics           `json:"por_categoria"`
-	Top5MaisCaros         []ProductResponse              `json:"top5_mais_caros"`
-	Top5MaisBaratos       []ProductResponse              `json:"top5_mais_baratos"`
-	Top5MaisEstoque       []ProductResponse              `json:"top5_mais_estoque"`
+	TotalProdutos        int                        `json:"total_produtos" example:"150"`
+	ProdutosAtivos       int                        `json:"produtos_ativos" example:"140"`
+	ProdutosInativos     int                        `json:"produtos_inativos" example:"10"`
+	ProdutosEmEstoque    int                        `json:"produtos_em_estoque" example:"130"`
+	ProdutosSemEstoque   int                        `json:"produtos_sem_estoque" example:"20"`
+	ProdutosReposicao    int                        `json:"produtos_reposicao" example:"8"`
+	ValorTotalInventario float64                    `json:"valor_total_inventario" example:"125000.50"`
+	Valoracao            *InventoryValuationSummary `json:"valoracao,omitempty"`
+	PrecoMedio           float64                    `json:"preco_medio" example:"850.25"`
+	PrecoMinimo          float64                    `json:"preco_minimo" example:"15.99"`
+	PrecoMaximo          float64                    `json:"preco_maximo" example:"5999.99"`
+	QuantidadeTotal      int                        `json:"quantidade_total" example:"2500"`
+	PorCategoria         []CategoryStatistics       `json:"por_categoria"`
+	PorStatus            []StatusStatistics         `json:"por_status"`
+	PorTag               []TagStatistics            `json:"por_tag"`
+	Top5MaisCaros        []ProductResponse          `json:"top5_mais_caros"`
+	Top5MaisBaratos      []ProductResponse          `json:"top5_mais_baratos"`
+	Top5MaisEstoque      []ProductResponse          `json:"top5_mais_estoque"`
 }
 
 // CategoryStatistics representa estatísticas por categoria
 type CategoryStatistics struct {
-	Categoria            models.ProductCategory `json:"categoria" example:"eletronicos"`
-	TotalProdutos        int                    `json:"total_produtos" example:"25"`
-	ProdutosAtivos       int                    `json:"produtos_ativos" example:"23"`
-	ValorTotal           float64                `json:"valor_total" example:"45000.00"`
-	PrecoMedio           float64                `json:"preco_medio" example:"1800.00"`
-	QuantidadeTotal      int                    `json:"quantidade_total" example:"350"`
+	Categoria       models.ProductCategory  `json:"categoria" example:"eletronicos"`
+	TotalProdutos   int                     `json:"total_produtos" example:"25"`
+	ProdutosAtivos  int                     `json:"produtos_ativos" example:"23"`
+	ValorTotal      float64                 `json:"valor_total" example:"45000.00"`
+	Custos          *CategoryCostStatistics `json:"custos,omitempty"`
+	PrecoMedio      float64                 `json:"preco_medio" example:"1800.00"`
+	QuantidadeTotal int                     `json:"quantidade_total" example:"350"`
 }
 
 // ErrorResponse representa uma resposta de erro
@@ -112,24 +240,24 @@ type ErrorResponse struct {
 
 // ValidationErrorResponse representa uma resposta de erro de validação
 type ValidationErrorResponse struct {
-	Erro      string              `json:"erro" example:"Dados inválidos"`
-	Codigo    string              `json:"codigo" example:"VALIDATION_ERROR"`
-	Timestamp time.Time           `json:"timestamp" example:"2023-01-15T10:30:00Z"`
-	Campos    []ValidationError   `json:"campos"`
+	Erro      string            `json:"erro" example:"Dados inválidos"`
+	Codigo    string            `json:"codigo" example:"VALIDATION_ERROR"`
+	Timestamp time.Time         `json:"timestamp" example:"2023-01-15T10:30:00Z"`
+	Campos    []ValidationError `json:"campos"`
 }
 
 // ValidationError representa um erro específico de campo
 type ValidationError struct {
-	Campo   string `json:"campo" example:"preco"`
-	Valor   interface{} `json:"valor" example:"-10.5"`
-	Erro    string `json:"erro" example:"Preço deve ser maior ou igual a zero"`
-	Tag     string `json:"tag" example:"min"`
+	Campo string      `json:"campo" example:"preco"`
+	Valor interface{} `json:"valor" example:"-10.5"`
+	Erro  string      `json:"erro" example:"Preço deve ser maior ou igual a zero"`
+	Tag   string      `json:"tag" example:"min"`
 }
 
 // SuccessResponse representa uma resposta de sucesso genérica
 type SuccessResponse struct {
-	Sucesso   bool      `json:"sucesso" example:"true"`
-	Mensagem  string    `json:"mensagem" example:"Produto criado com sucesso"`
-	Timestamp time.Time `json:"timestamp" example:"2023-01-15T10:30:00Z"`
+	Sucesso   bool        `json:"sucesso" example:"true"`
+	Mensagem  string      `json:"mensagem" example:"Produto criado com sucesso"`
+	Timestamp time.Time   `json:"timestamp" example:"2023-01-15T10:30:00Z"`
 	Dados     interface{} `json:"dados,omitempty"`
-}
\ No newline at end of file
+}
@@ -0,0 +1,41 @@
+package dtos
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// StatusTransitionRequest representa a requisição de transição do ciclo de vida do produto
+type StatusTransitionRequest struct {
+	Status  models.ProductStatus `json:"status" binding:"required,oneof=rascunho em_revisao ativo descontinuado bloqueado inativo" example:"descontinuado"`
+	Motivo  string               `json:"motivo" binding:"required,max=200" example:"Linha substituída pelo modelo 2025"`
+	Usuario string               `json:"usuario,omitempty" binding:"max=100" example:"compras@loja.com"`
+}
+
+// ProductStatusResponse representa a situação atual do produto, o que ela
+// permite e as transições possíveis
+type ProductStatusResponse struct {
+	ProdutoID            uuid.UUID              `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome                 string                 `json:"nome" example:"Smartphone Samsung Galaxy S24"`
+	Status               models.ProductStatus   `json:"status" example:"ativo"`
+	Ativo                bool                   `json:"ativo" example:"true"`
+	PermiteVenda         bool                   `json:"permite_venda" example:"true"`
+	PermiteReposicao     bool                   `json:"permite_reposicao" example:"true"`
+	TransicoesPermitidas []models.ProductStatus `json:"transicoes_permitidas"`
+	Historico            []models.StatusChange  `json:"historico"`
+}
+
+// StatusTransitionResponse representa o resultado de uma transição
+type StatusTransitionResponse struct {
+	ProductStatusResponse
+	Transicao         models.StatusChange `json:"transicao"`
+	ReservasLiberadas int                 `json:"reservas_liberadas" example:"0"`
+}
+
+// StatusStatistics representa estatísticas de uma situação do ciclo de vida
+type StatusStatistics struct {
+	Status          models.ProductStatus `json:"status" example:"descontinuado"`
+	TotalProdutos   int                  `json:"total_produtos" example:"4"`
+	QuantidadeTotal int                  `json:"quantidade_total" example:"37"`
+	ValorTotal      float64              `json:"valor_total" example:"12450.90"`
+}
@@ -0,0 +1,57 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// PromotionRequest representa a requisição para criar, substituir ou simular uma promoção
+type PromotionRequest struct {
+	Nome          string                   `json:"nome" binding:"required,min=2,max=100" example:"Black Friday Eletrônicos"`
+	Descricao     string                   `json:"descricao,omitempty" binding:"max=500" example:"Descontos em toda a linha de eletrônicos"`
+	Tipo          models.DiscountType      `json:"tipo" binding:"required,oneof=percentual valor_fixo" example:"percentual"`
+	Valor         float64                  `json:"valor" binding:"required,gt=0" example:"15"`
+	TodosProdutos bool                     `json:"todos_produtos,omitempty" example:"false"`
+	Produtos      []uuid.UUID              `json:"produtos,omitempty"`
+	Categorias    []models.ProductCategory `json:"categorias,omitempty" binding:"omitempty,dive,oneof=eletronicos roupas casa livros esportes beleza brinquedos automotivo alimentos outros" example:"eletronicos"`
+	NomeContem    string                   `json:"nome_contem,omitempty" binding:"max=100" example:"samsung"`
+	PrecoMinimo   *float64                 `json:"preco_minimo,omitempty" binding:"omitempty,min=0" example:"500"`
+	PrecoMaximo   *float64                 `json:"preco_maximo,omitempty" binding:"omitempty,min=0" example:"5000"`
+	Inicio        time.Time                `json:"inicio" binding:"required" example:"2024-11-29T00:00:00Z"`
+	Fim           time.Time                `json:"fim" binding:"required" example:"2024-12-02T00:00:00Z"`
+	Prioridade    int                      `json:"prioridade" example:"10"`
+	Cumulativa    bool                     `json:"cumulativa,omitempty" example:"false"`
+	Ativa         *bool                    `json:"ativa,omitempty" example:"true"`
+}
+
+// PromotionResponse representa uma promoção com a indicação de vigência atual
+type PromotionResponse struct {
+	models.Promotion
+	Vigente bool `json:"vigente" example:"true"`
+}
+
+// PromotionListResponse representa a lista de promoções
+type PromotionListResponse struct {
+	Promocoes []PromotionResponse `json:"promocoes"`
+	Total     int                 `json:"total" example:"4"`
+}
+
+// PromotionSimulationItem representa o efeito de uma promoção sobre um produto
+type PromotionSimulationItem struct {
+	ProdutoID             uuid.UUID                 `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome                  string                    `json:"nome" example:"Smartphone Samsung Galaxy S24"`
+	Categoria             models.ProductCategory    `json:"categoria" example:"eletronicos"`
+	Preco                 float64                   `json:"preco" example:"2299.99"`
+	PrecoPromocionalAtual float64                   `json:"preco_promocional_atual" example:"2299.99"`
+	PrecoPromocional      float64                   `json:"preco_promocional" example:"1954.99"`
+	PromocoesAplicadas    []models.AppliedPromotion `json:"promocoes_aplicadas"`
+}
+
+// PromotionSimulationResponse representa a prévia dos produtos afetados por uma promoção
+type PromotionSimulationResponse struct {
+	Em    time.Time                 `json:"em" example:"2024-11-29T00:00:00Z"`
+	Itens []PromotionSimulationItem `json:"itens"`
+	Total int                       `json:"total" example:"3"`
+}
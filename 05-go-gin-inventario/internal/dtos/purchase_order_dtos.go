@@ -0,0 +1,85 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// PurchaseOrderLineRequest representa um item do pedido de compra
+type PurchaseOrderLineRequest struct {
+	ProdutoID        uuid.UUID `json:"produto_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Quantidade       int       `json:"quantidade" binding:"required,min=1" example:"20"`
+	CustoUnitario    float64   `json:"custo_unitario,omitempty" binding:"min=0" example:"1580.00"`
+	CodigoFornecedor string    `json:"codigo_fornecedor,omitempty" binding:"max=50" example:"SAM-S24-128"`
+}
+
+// PurchaseOrderRequest representa a requisição para criar ou substituir um pedido de compra
+type PurchaseOrderRequest struct {
+	FornecedorID    uuid.UUID                  `json:"fornecedor_id" binding:"required" example:"5f0c7a1e-2b7d-4d8e-9a55-0f1d2c3b4a59"`
+	Itens           []PurchaseOrderLineRequest `json:"itens" binding:"required,min=1,dive"`
+	Observacoes     string                     `json:"observacoes,omitempty" binding:"max=500" example:"Entregar no CD de Guarulhos"`
+	PrevisaoEntrega *time.Time                 `json:"previsao_entrega,omitempty" example:"2024-04-10T00:00:00Z"`
+}
+
+// CancelPurchaseOrderRequest representa a requisição para cancelar um pedido de compra
+type CancelPurchaseOrderRequest struct {
+	Motivo string `json:"motivo,omitempty" binding:"max=200" example:"Fornecedor sem estoque"`
+}
+
+// PurchaseReceiptLotRequest representa o lote de um item recebido
+type PurchaseReceiptLotRequest struct {
+	Codigo         string    `json:"codigo" binding:"required,max=50" example:"L2024-0315"`
+	DataFabricacao time.Time `json:"data_fabricacao" binding:"required" example:"2024-03-15T00:00:00Z"`
+	DataValidade   time.Time `json:"data_validade" binding:"required" example:"2024-09-15T00:00:00Z"`
+}
+
+// PurchaseReceiptLineRequest representa a quantidade recebida de um item do pedido
+type PurchaseReceiptLineRequest struct {
+	LinhaID       uuid.UUID                  `json:"linha_id" binding:"required" example:"a3c1e7d2-6f4b-4c1a-9d8e-2b5f7a9c0e11"`
+	Quantidade    int                        `json:"quantidade" binding:"required,min=1" example:"10"`
+	CustoUnitario float64                    `json:"custo_unitario,omitempty" binding:"min=0" example:"1575.00"`
+	Lote          *PurchaseReceiptLotRequest `json:"lote,omitempty"`
+	NumerosSerie  []string                   `json:"numeros_serie,omitempty" binding:"omitempty,dive,required,max=50" example:"352099001761481"`
+}
+
+// PurchaseReceiptRequest representa o recebimento de itens de um pedido de compra
+type PurchaseReceiptRequest struct {
+	Itens      []PurchaseReceiptLineRequest `json:"itens" binding:"required,min=1,dive"`
+	Observacao string                       `json:"observacao,omitempty" binding:"max=500" example:"NF-e 000123"`
+}
+
+// PurchaseOrderLineResponse representa um item do pedido com saldo pendente e subtotal
+type PurchaseOrderLineResponse struct {
+	models.PurchaseOrderLine
+	ProdutoNome        string  `json:"produto_nome" example:"Smartphone Samsung Galaxy S24"`
+	QuantidadePendente int     `json:"quantidade_pendente" example:"10"`
+	Subtotal           float64 `json:"subtotal" example:"31600.00"`
+}
+
+// PurchaseOrderResponse representa um pedido de compra com totais
+type PurchaseOrderResponse struct {
+	ID                 uuid.UUID                   `json:"id" example:"9b2d3c4e-5f60-4a7b-8c9d-0e1f2a3b4c5d"`
+	Numero             string                      `json:"numero" example:"PC-000001"`
+	FornecedorID       uuid.UUID                   `json:"fornecedor_id" example:"5f0c7a1e-2b7d-4d8e-9a55-0f1d2c3b4a59"`
+	FornecedorNome     string                      `json:"fornecedor_nome" example:"Distribuidora Tech Ltda"`
+	Status             models.PurchaseOrderStatus  `json:"status" example:"parcialmente_recebido"`
+	Itens              []PurchaseOrderLineResponse `json:"itens"`
+	Recebimentos       []models.PurchaseReceipt    `json:"recebimentos"`
+	ValorTotal         float64                     `json:"valor_total" example:"31600.00"`
+	ValorRecebido      float64                     `json:"valor_recebido" example:"15750.00"`
+	Observacoes        string                      `json:"observacoes,omitempty" example:"Entregar no CD de Guarulhos"`
+	MotivoCancelamento string                      `json:"motivo_cancelamento,omitempty" example:"Fornecedor sem estoque"`
+	PrevisaoEntrega    *time.Time                  `json:"previsao_entrega,omitempty" example:"2024-04-10T00:00:00Z"`
+	DataEnvio          *time.Time                  `json:"data_envio,omitempty" example:"2024-04-03T10:30:00Z"`
+	DataConclusao      *time.Time                  `json:"data_conclusao,omitempty" example:"2024-04-12T16:00:00Z"`
+	DataCriacao        time.Time                   `json:"data_criacao" example:"2024-04-02T09:00:00Z"`
+	DataAtualizacao    time.Time                   `json:"data_atualizacao" example:"2024-04-10T14:20:00Z"`
+}
+
+// PurchaseOrderListResponse representa a lista de pedidos de compra
+type PurchaseOrderListResponse struct {
+	Pedidos []PurchaseOrderResponse `json:"pedidos"`
+	Total   int                     `json:"total" example:"5"`
+}
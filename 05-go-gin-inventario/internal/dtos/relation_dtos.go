@@ -0,0 +1,51 @@
+package dtos
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// CreateRelationRequest representa a requisição para relacionar dois produtos
+type CreateRelationRequest struct {
+	RelacionadoID uuid.UUID           `json:"relacionado_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Tipo          models.RelationType `json:"tipo" binding:"required,oneof=substituto acessorio upsell" example:"substituto"`
+	Observacao    string              `json:"observacao,omitempty" binding:"max=200" example:"Mesma tela e câmera, 128GB"`
+}
+
+// RelatedProductResponse representa um produto relacionado
+type RelatedProductResponse struct {
+	ProdutoID        uuid.UUID              `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome             string                 `json:"nome" example:"Smartphone Samsung Galaxy S23"`
+	Categoria        models.ProductCategory `json:"categoria" example:"eletronicos"`
+	Tipo             models.RelationType    `json:"tipo" example:"substituto"`
+	Observacao       string                 `json:"observacao,omitempty" example:"Mesma tela e câmera, 128GB"`
+	Preco            float64                `json:"preco" example:"1999.99"`
+	PrecoPromocional float64                `json:"preco_promocional,omitempty" example:"1799.99"`
+	Disponivel       int                    `json:"disponivel" example:"12"`
+	Ativo            bool                   `json:"ativo" example:"true"`
+	Status           models.ProductStatus   `json:"status" example:"ativo"`
+}
+
+// RelationListResponse representa as relações de um produto
+type RelationListResponse struct {
+	ProdutoID    uuid.UUID                `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Relacionados []RelatedProductResponse `json:"relacionados"`
+	Total        int                      `json:"total" example:"3"`
+}
+
+// AlternativeResponse representa um substituto em estoque e a diferença de
+// preço em relação ao produto consultado
+type AlternativeResponse struct {
+	RelatedProductResponse
+	DiferencaPreco float64 `json:"diferenca_preco" example:"-300.00"`
+}
+
+// AlternativesResponse representa os substitutos em estoque de um produto
+type AlternativesResponse struct {
+	ProdutoID    uuid.UUID             `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome         string                `json:"nome" example:"Smartphone Samsung Galaxy S24"`
+	Preco        float64               `json:"preco" example:"2299.99"`
+	Disponivel   int                   `json:"disponivel" example:"0"`
+	Alternativas []AlternativeResponse `json:"alternativas"`
+	Total        int                   `json:"total" example:"2"`
+}
@@ -0,0 +1,34 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// CreateReservationRequest representa a requisição para reservar unidades de um produto
+type CreateReservationRequest struct {
+	ProdutoID   uuid.UUID `json:"produto_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Quantidade  int       `json:"quantidade" binding:"required,min=1" example:"2"`
+	Referencia  string    `json:"referencia" binding:"required,max=100" example:"pedido-web-98431"`
+	TTLSegundos int       `json:"ttl_segundos,omitempty" binding:"omitempty,min=30,max=86400" example:"900"`
+}
+
+// ReservationResponse representa a resposta de uma reserva
+type ReservationResponse struct {
+	ID              uuid.UUID                `json:"id" example:"5f0c7a1e-2b7d-4d8e-9a55-0f1d2c3b4a59"`
+	ProdutoID       uuid.UUID                `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Quantidade      int                      `json:"quantidade" example:"2"`
+	Referencia      string                   `json:"referencia" example:"pedido-web-98431"`
+	Status          models.ReservationStatus `json:"status" example:"ativa"`
+	ExpiraEm        time.Time                `json:"expira_em" example:"2023-01-15T10:45:00Z"`
+	DataCriacao     time.Time                `json:"data_criacao" example:"2023-01-15T10:30:00Z"`
+	DataAtualizacao time.Time                `json:"data_atualizacao" example:"2023-01-15T10:30:00Z"`
+}
+
+// ReservationListResponse representa uma lista de reservas
+type ReservationListResponse struct {
+	Reservas []ReservationResponse `json:"reservas"`
+	Total    int                   `json:"total" example:"3"`
+}
@@ -0,0 +1,72 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// ReturnLineRequest representa um item devolvido pelo cliente
+type ReturnLineRequest struct {
+	ItemPedidoID uuid.UUID `json:"item_pedido_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Quantidade   int       `json:"quantidade" binding:"required,min=1" example:"1"`
+	NumerosSerie []string  `json:"numeros_serie,omitempty" binding:"omitempty,dive,required,max=50" example:"352099001761481"`
+	Motivo       string    `json:"motivo" binding:"required,oneof=defeito avaria arrependimento produto_errado outro" example:"defeito"`
+	Observacao   string    `json:"observacao,omitempty" binding:"max=200" example:"Não liga"`
+}
+
+// ReturnRequest representa a requisição para registrar uma devolução
+type ReturnRequest struct {
+	PedidoVendaID uuid.UUID           `json:"pedido_venda_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Itens         []ReturnLineRequest `json:"itens" binding:"required,min=1,dive"`
+}
+
+// ReturnInspectionLineRequest representa o destino decidido para um item devolvido
+type ReturnInspectionLineRequest struct {
+	ItemID       uuid.UUID  `json:"item_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Destino      string     `json:"destino" binding:"required,oneof=reestoque_novo reestoque_caixa_aberta envio_fornecedor baixa" example:"reestoque_caixa_aberta"`
+	FornecedorID *uuid.UUID `json:"fornecedor_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Parecer      string     `json:"parecer,omitempty" binding:"max=200" example:"Embalagem violada, produto sem uso"`
+}
+
+// ReturnInspectionRequest representa a inspeção de todos os itens de uma devolução
+type ReturnInspectionRequest struct {
+	Itens []ReturnInspectionLineRequest `json:"itens" binding:"required,min=1,dive"`
+}
+
+// ReturnResponse representa uma devolução
+type ReturnResponse struct {
+	models.Return
+	QuantidadeTotal int `json:"quantidade_total" example:"2"`
+}
+
+// ReturnListResponse representa a lista de devoluções
+type ReturnListResponse struct {
+	Devolucoes []ReturnResponse `json:"devolucoes"`
+	Total      int              `json:"total" example:"4"`
+}
+
+// ReturnReportGroup representa os totais devolvidos de um agrupamento do relatório
+type ReturnReportGroup struct {
+	Chave          string  `json:"chave" example:"defeito"`
+	Quantidade     int     `json:"quantidade" example:"6"`
+	ValorReembolso float64 `json:"valor_reembolso" example:"1899.90"`
+	ValorCusto     float64 `json:"valor_custo" example:"1120.00"`
+}
+
+// ReturnReportResponse representa o relatório de devoluções do período por
+// motivo, categoria e destino. Itens ainda não inspecionados aparecem com o
+// destino "aguardando_inspecao".
+type ReturnReportResponse struct {
+	Inicio          *time.Time          `json:"inicio,omitempty" example:"2023-01-01T00:00:00Z"`
+	Fim             *time.Time          `json:"fim,omitempty" example:"2023-02-01T00:00:00Z"`
+	TotalDevolucoes int                 `json:"total_devolucoes" example:"4"`
+	Pendentes       int                 `json:"pendentes" example:"1"`
+	Quantidade      int                 `json:"quantidade" example:"9"`
+	ValorReembolso  float64             `json:"valor_reembolso" example:"2459.70"`
+	ValorPerdas     float64             `json:"valor_perdas" example:"310.00"`
+	PorMotivo       []ReturnReportGroup `json:"por_motivo"`
+	PorCategoria    []ReturnReportGroup `json:"por_categoria"`
+	PorDestino      []ReturnReportGroup `json:"por_destino"`
+}
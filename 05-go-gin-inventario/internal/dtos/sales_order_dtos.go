@@ -0,0 +1,59 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// SalesOrderLineRequest representa um item vendido
+type SalesOrderLineRequest struct {
+	ProdutoID    uuid.UUID `json:"produto_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Quantidade   int       `json:"quantidade" binding:"required,min=1" example:"2"`
+	NumerosSerie []string  `json:"numeros_serie,omitempty" binding:"omitempty,dive,required,max=50" example:"352099001761481"`
+	CaixaAberta  bool      `json:"caixa_aberta,omitempty" example:"false"`
+}
+
+// SalesOrderRequest representa a requisição para registrar uma venda
+type SalesOrderRequest struct {
+	Canal      string                  `json:"canal,omitempty" binding:"max=50" example:"pdv"`
+	Referencia string                  `json:"referencia,omitempty" binding:"max=100" example:"CUPOM-000457"`
+	Itens      []SalesOrderLineRequest `json:"itens" binding:"required,min=1,dive"`
+}
+
+// CancelSalesOrderRequest representa a requisição para cancelar uma venda
+type CancelSalesOrderRequest struct {
+	Motivo string `json:"motivo,omitempty" binding:"max=200" example:"Desistência do cliente"`
+}
+
+// SalesOrderResponse representa um pedido de venda
+type SalesOrderResponse struct {
+	models.SalesOrder
+	QuantidadeTotal int `json:"quantidade_total" example:"3"`
+}
+
+// SalesOrderListResponse representa a lista de pedidos de venda
+type SalesOrderListResponse struct {
+	Pedidos []SalesOrderResponse `json:"pedidos"`
+	Total   int                  `json:"total" example:"12"`
+}
+
+// SalesOrderLineError representa o problema de um item de uma venda recusada
+type SalesOrderLineError struct {
+	Indice     int       `json:"indice" example:"1"`
+	ProdutoID  uuid.UUID `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Codigo     string    `json:"codigo" example:"INSUFFICIENT_STOCK"`
+	Erro       string    `json:"erro" example:"estoque insuficiente: Notebook Dell Inspiron possui 1 unidade(s) disponível(is), pedido de 2"`
+	Disponivel *int      `json:"disponivel,omitempty" example:"1"`
+	Solicitado *int      `json:"solicitado,omitempty" example:"2"`
+}
+
+// SalesOrderRejectedResponse representa uma venda recusada, com o erro de cada item.
+// Nenhuma baixa de estoque é feita.
+type SalesOrderRejectedResponse struct {
+	Erro      string                `json:"erro" example:"Pedido de venda recusado"`
+	Codigo    string                `json:"codigo" example:"SALES_ORDER_REJECTED"`
+	Timestamp time.Time             `json:"timestamp" example:"2023-01-01T12:00:00Z"`
+	Itens     []SalesOrderLineError `json:"itens"`
+}
@@ -0,0 +1,43 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// SerialMovementRequest representa a entrada ou saída de unidades serializadas
+type SerialMovementRequest struct {
+	Seriais       []string   `json:"seriais" binding:"required,min=1,dive,required,max=50" example:"352099001761481,352099001761499"`
+	Referencia    string     `json:"referencia,omitempty" binding:"max=100" example:"NF-000123"`
+	ReservaID     *uuid.UUID `json:"reserva_id,omitempty" example:"5f0c7a1e-2b7d-4d8e-9a55-0f1d2c3b4a59"`
+	CustoUnitario float64    `json:"custo_unitario,omitempty" binding:"min=0" example:"1580.00"`
+}
+
+// SerialResponse representa um número de série com sua situação e histórico
+type SerialResponse struct {
+	Numero          string               `json:"numero" example:"352099001761481"`
+	ProdutoID       uuid.UUID            `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Status          models.SerialStatus  `json:"status" example:"em_estoque"`
+	Historico       []models.SerialEvent `json:"historico"`
+	DataCriacao     time.Time            `json:"data_criacao" example:"2023-01-15T10:30:00Z"`
+	DataAtualizacao time.Time            `json:"data_atualizacao" example:"2023-01-15T10:30:00Z"`
+}
+
+// SerialMovementResponse representa o resultado de uma movimentação serializada
+type SerialMovementResponse struct {
+	ProdutoID  uuid.UUID        `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Quantidade int              `json:"quantidade" example:"24"`
+	Seriais    []SerialResponse `json:"seriais"`
+}
+
+// SerialListResponse representa os números de série de um produto
+type SerialListResponse struct {
+	ProdutoID         uuid.UUID        `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Seriais           []SerialResponse `json:"seriais"`
+	Total             int              `json:"total" example:"25"`
+	QuantidadeProduto int              `json:"quantidade_produto" example:"25"`
+	SeriaisEmEstoque  int              `json:"seriais_em_estoque" example:"25"`
+	Consistente       bool             `json:"consistente" example:"true"`
+}
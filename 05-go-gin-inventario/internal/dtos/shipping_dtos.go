@@ -0,0 +1,71 @@
+package dtos
+
+import (
+	"github.com/google/uuid"
+)
+
+// DimensionsRequest representa peso, medidas e embalagem do produto pronto para envio
+type DimensionsRequest struct {
+	PesoKg        float64 `json:"peso_kg" binding:"required,gt=0,lte=10000" example:"0.45"`
+	ComprimentoCm float64 `json:"comprimento_cm" binding:"required,gt=0,lte=1000" example:"20"`
+	LarguraCm     float64 `json:"largura_cm" binding:"required,gt=0,lte=1000" example:"12"`
+	AlturaCm      float64 `json:"altura_cm" binding:"required,gt=0,lte=1000" example:"8"`
+	Embalagem     string  `json:"embalagem,omitempty" binding:"max=50" example:"caixa de papelão"`
+}
+
+// DimensionsResponse representa peso, medidas e embalagem do produto com o
+// volume e o peso cubado pelo fator de cubagem padrão
+type DimensionsResponse struct {
+	PesoKg        float64 `json:"peso_kg" example:"0.45"`
+	ComprimentoCm float64 `json:"comprimento_cm" example:"20"`
+	LarguraCm     float64 `json:"largura_cm" example:"12"`
+	AlturaCm      float64 `json:"altura_cm" example:"8"`
+	Embalagem     string  `json:"embalagem,omitempty" example:"caixa de papelão"`
+	VolumeCm3     float64 `json:"volume_cm3" example:"1920"`
+	PesoCubadoKg  float64 `json:"peso_cubado_kg" example:"0.32"`
+}
+
+// ShippingItemRequest representa um item do carrinho na cotação de frete
+type ShippingItemRequest struct {
+	ProdutoID  uuid.UUID `json:"produto_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Quantidade int       `json:"quantidade" binding:"required,min=1" example:"2"`
+}
+
+// ShippingQuoteRequest representa a requisição de cotação de frete. Informe o
+// CEP de destino ou diretamente a zona.
+type ShippingQuoteRequest struct {
+	CEP   string                `json:"cep,omitempty" example:"22041-001"`
+	Zona  string                `json:"zona,omitempty" example:"sudeste"`
+	Itens []ShippingItemRequest `json:"itens" binding:"required,min=1,max=100,dive"`
+}
+
+// ShippingQuoteItem representa o peso e o volume de um item na cotação
+type ShippingQuoteItem struct {
+	ProdutoID         uuid.UUID `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome              string    `json:"nome" example:"Smartphone Samsung Galaxy S24"`
+	Quantidade        int       `json:"quantidade" example:"2"`
+	PesoUnitarioKg    float64   `json:"peso_unitario_kg" example:"0.45"`
+	VolumeUnitarioCm3 float64   `json:"volume_unitario_cm3" example:"1920"`
+	PesoRealKg        float64   `json:"peso_real_kg" example:"0.9"`
+	PesoCubadoKg      float64   `json:"peso_cubado_kg" example:"0.64"`
+	ValorMercadorias  float64   `json:"valor_mercadorias" example:"4599.98"`
+}
+
+// ShippingQuoteResponse representa o resultado da cotação de frete. O peso
+// taxado é o maior entre o peso real e o cubado do carrinho.
+type ShippingQuoteResponse struct {
+	CEP              string              `json:"cep,omitempty" example:"22041001"`
+	Zona             string              `json:"zona" example:"sudeste"`
+	Descricao        string              `json:"descricao,omitempty" example:"Rio de Janeiro, Espírito Santo e Minas Gerais"`
+	PrazoDias        int                 `json:"prazo_dias" example:"4"`
+	FatorCubagem     float64             `json:"fator_cubagem" example:"6000"`
+	Itens            []ShippingQuoteItem `json:"itens"`
+	PesoRealKg       float64             `json:"peso_real_kg" example:"0.9"`
+	VolumeCm3        float64             `json:"volume_cm3" example:"3840"`
+	PesoCubadoKg     float64             `json:"peso_cubado_kg" example:"0.64"`
+	PesoTaxadoKg     float64             `json:"peso_taxado_kg" example:"0.9"`
+	ValorMercadorias float64             `json:"valor_mercadorias" example:"4599.98"`
+	ValorFrete       float64             `json:"valor_frete" example:"21.90"`
+	ValorSeguro      float64             `json:"valor_seguro" example:"23.00"`
+	ValorTotal       float64             `json:"valor_total" example:"44.90"`
+}
@@ -0,0 +1,48 @@
+package dtos
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// SupplierRequest representa a requisição para criar ou substituir um fornecedor
+type SupplierRequest struct {
+	Nome     string `json:"nome" binding:"required,min=2,max=100" example:"Distribuidora Tech Ltda"`
+	CNPJ     string `json:"cnpj,omitempty" binding:"max=18" example:"12.345.678/0001-90"`
+	Email    string `json:"email,omitempty" binding:"omitempty,email,max=100" example:"compras@distribuidoratech.com.br"`
+	Telefone string `json:"telefone,omitempty" binding:"max=20" example:"(11) 3456-7890"`
+	Ativo    *bool  `json:"ativo,omitempty" example:"true"`
+}
+
+// SupplierResponse representa um fornecedor com a quantidade de produtos vinculados
+type SupplierResponse struct {
+	models.Supplier
+	TotalProdutos int `json:"total_produtos" example:"12"`
+}
+
+// SupplierListResponse representa a lista de fornecedores
+type SupplierListResponse struct {
+	Fornecedores []SupplierResponse `json:"fornecedores"`
+	Total        int                `json:"total" example:"3"`
+}
+
+// SupplierProductRequest representa a requisição para vincular um produto a um fornecedor
+type SupplierProductRequest struct {
+	CodigoFornecedor string  `json:"codigo_fornecedor" binding:"required,max=50" example:"SAM-S24-128"`
+	PrazoEntregaDias int     `json:"prazo_entrega_dias" binding:"min=0,max=365" example:"7"`
+	UltimoCusto      float64 `json:"ultimo_custo,omitempty" binding:"min=0" example:"1580.00"`
+}
+
+// SupplierProductResponse representa o vínculo de um produto com um fornecedor
+type SupplierProductResponse struct {
+	models.SupplierProduct
+	FornecedorNome string `json:"fornecedor_nome" example:"Distribuidora Tech Ltda"`
+	ProdutoNome    string `json:"produto_nome" example:"Smartphone Samsung Galaxy S24"`
+}
+
+// SupplierProductListResponse representa a lista de vínculos entre fornecedores e produtos
+type SupplierProductListResponse struct {
+	ID    uuid.UUID                 `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Itens []SupplierProductResponse `json:"itens"`
+	Total int                       `json:"total" example:"2"`
+}
@@ -0,0 +1,21 @@
+package dtos
+
+// HealthResponse representa a resposta do health check
+type HealthResponse struct {
+	Status    string `json:"status" example:"OK"`
+	Service   string `json:"service" example:"Inventário API"`
+	Version   string `json:"version" example:"1.0.0"`
+	Timestamp string `json:"timestamp" example:"2023-01-15T10:30:00Z"`
+}
+
+// APIIndexResponse representa a página inicial da API, com os endpoints
+// agrupados por tag conforme a especificação OpenAPI
+type APIIndexResponse struct {
+	Message       string              `json:"message" example:"API de Inventário - Go + Gin"`
+	Version       string              `json:"version" example:"1.0.0"`
+	Description   string              `json:"description" example:"API REST para gerenciamento de inventário/estoque"`
+	Documentacao  string              `json:"documentacao" example:"/docs"`
+	Especificacao string              `json:"openapi" example:"/openapi.json"`
+	Endpoints     map[string][]string `json:"endpoints"`
+	Categories    []string            `json:"categories" example:"eletronicos,roupas,casa"`
+}
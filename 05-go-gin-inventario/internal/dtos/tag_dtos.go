@@ -0,0 +1,54 @@
+package dtos
+
+import (
+	"github.com/google/uuid"
+)
+
+// AddTagsRequest representa a requisição para adicionar tags a um produto
+type AddTagsRequest struct {
+	Tags []string `json:"tags" binding:"required,min=1,max=20,dive,max=30" example:"importado,frágil"`
+}
+
+// BulkTagRequest representa a requisição para adicionar e remover tags de vários produtos
+type BulkTagRequest struct {
+	ProdutoIDs []uuid.UUID `json:"produto_ids" binding:"required,min=1,max=500"`
+	Adicionar  []string    `json:"adicionar,omitempty" binding:"max=20,dive,max=30" example:"lançamento"`
+	Remover    []string    `json:"remover,omitempty" binding:"max=20,dive,max=30" example:"promoção de verão"`
+}
+
+// ProductTagsResponse representa as tags de um produto
+type ProductTagsResponse struct {
+	ProdutoID uuid.UUID `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome      string    `json:"nome" example:"Notebook Dell Inspiron"`
+	Tags      []string  `json:"tags" example:"frágil,importado"`
+}
+
+// BulkTagResponse representa o resultado de uma alteração de tags em lote
+type BulkTagResponse struct {
+	Produtos []ProductTagsResponse `json:"produtos"`
+	Total    int                   `json:"total" example:"12"`
+}
+
+// TagCloudItem representa uma tag na nuvem de tags. Peso vai de 1 (menos
+// usada) a 5 (mais usada) para dimensionar a exibição.
+type TagCloudItem struct {
+	Tag            string `json:"tag" example:"importado"`
+	TotalProdutos  int    `json:"total_produtos" example:"14"`
+	ProdutosAtivos int    `json:"produtos_ativos" example:"12"`
+	Peso           int    `json:"peso" example:"5"`
+}
+
+// TagCloudResponse representa a nuvem de tags
+type TagCloudResponse struct {
+	Tags  []TagCloudItem `json:"tags"`
+	Total int            `json:"total" example:"8"`
+}
+
+// TagStatistics representa estatísticas dos produtos com uma tag
+type TagStatistics struct {
+	Tag             string  `json:"tag" example:"importado"`
+	TotalProdutos   int     `json:"total_produtos" example:"14"`
+	ProdutosAtivos  int     `json:"produtos_ativos" example:"12"`
+	QuantidadeTotal int     `json:"quantidade_total" example:"230"`
+	ValorTotal      float64 `json:"valor_total" example:"38450.00"`
+}
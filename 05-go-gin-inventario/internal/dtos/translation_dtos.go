@@ -0,0 +1,67 @@
+package dtos
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/models"
+)
+
+// TranslationRequest representa o nome e a descrição do produto em outro idioma.
+// Campos vazios usam o texto em português.
+type TranslationRequest struct {
+	Nome      string `json:"nome,omitempty" binding:"max=100" example:"Teléfono inteligente Samsung Galaxy"`
+	Descricao string `json:"descricao,omitempty" binding:"max=500" example:"Teléfono inteligente con pantalla de 6,1 pulgadas"`
+}
+
+// ProductTranslationsResponse representa os textos do produto no idioma de
+// cadastro e as traduções existentes
+type ProductTranslationsResponse struct {
+	ProdutoID         uuid.UUID                                   `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	IdiomaPadrao      models.Locale                               `json:"idioma_padrao" example:"pt-BR"`
+	Nome              string                                      `json:"nome" example:"Smartphone Samsung Galaxy"`
+	Descricao         string                                      `json:"descricao" example:"Smartphone com tela de 6.1 polegadas"`
+	Traducoes         map[models.Locale]models.ProductTranslation `json:"traducoes"`
+	IdiomasSuportados []models.Locale                             `json:"idiomas_suportados" example:"pt-BR,es,en"`
+	IdiomasPendentes  []models.Locale                             `json:"idiomas_pendentes" example:"en"`
+}
+
+// Localize exibe o nome e a descrição no idioma, mantendo o português nos
+// campos sem tradução
+func (r *ProductResponse) Localize(locale models.Locale) {
+	r.Idioma = locale
+	if translation, ok := r.Traducoes[locale]; ok {
+		if translation.Nome != "" {
+			r.Nome = translation.Nome
+		}
+		if translation.Descricao != "" {
+			r.Descricao = translation.Descricao
+		}
+	}
+}
+
+// Localize traduz todos os produtos da lista
+func (r *ProductListResponse) Localize(locale models.Locale) {
+	for i := range r.Produtos {
+		r.Produtos[i].Localize(locale)
+	}
+}
+
+// Localize traduz os produtos ajustados
+func (r *StockAdjustmentBatchResponse) Localize(locale models.Locale) {
+	for i := range r.Produtos {
+		r.Produtos[i].Localize(locale)
+	}
+}
+
+// Localize traduz os produtos dos rankings
+func (r *ProductStatistics) Localize(locale models.Locale) {
+	for _, ranking := range [][]ProductResponse{r.Top5MaisCaros, r.Top5MaisBaratos, r.Top5MaisEstoque} {
+		for i := range ranking {
+			ranking[i].Localize(locale)
+		}
+	}
+}
+
+// Localize traduz o kit
+func (r *KitCompositionResponse) Localize(locale models.Locale) {
+	r.Kit.Localize(locale)
+}
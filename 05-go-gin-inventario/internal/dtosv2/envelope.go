@@ -0,0 +1,78 @@
+// Package dtosv2 contém os DTOs da versão 2 da API. Toda resposta da v2 vem
+// num envelope com os dados, os metadados da requisição e os links do
+// recurso, e os erros seguem sempre o mesmo formato.
+package dtosv2
+
+import (
+	"time"
+
+	"inventario-api/internal/models"
+)
+
+// Version é a versão da API atendida por este pacote
+const Version = "2"
+
+// Meta reúne os metadados comuns às respostas da v2
+type Meta struct {
+	Versao    string        `json:"versao" example:"2"`
+	RequestID string        `json:"request_id,omitempty" example:"1792371433681360082"`
+	Idioma    models.Locale `json:"idioma,omitempty" example:"pt-BR"`
+	Timestamp time.Time     `json:"timestamp" example:"2023-01-15T10:30:00Z"`
+}
+
+// ListMeta acrescenta a paginação aos metadados das listagens
+type ListMeta struct {
+	Meta
+	Paginacao Pagination `json:"paginacao"`
+}
+
+// Pagination descreve a página retornada
+type Pagination struct {
+	Pagina         int `json:"pagina" example:"1"`
+	ItensPorPagina int `json:"itens_por_pagina" example:"10"`
+	TotalItens     int `json:"total_itens" example:"150"`
+	TotalPaginas   int `json:"total_paginas" example:"15"`
+}
+
+// Links aponta para o próprio recurso e, nas listagens, para as páginas vizinhas
+type Links struct {
+	Self     string `json:"self" example:"/api/v2/produtos?page=2&size=10"`
+	Colecao  string `json:"colecao,omitempty" example:"/api/v2/produtos"`
+	Primeira string `json:"primeira,omitempty" example:"/api/v2/produtos?page=1&size=10"`
+	Anterior string `json:"anterior,omitempty" example:"/api/v2/produtos?page=1&size=10"`
+	Proxima  string `json:"proxima,omitempty" example:"/api/v2/produtos?page=3&size=10"`
+	Ultima   string `json:"ultima,omitempty" example:"/api/v2/produtos?page=15&size=10"`
+}
+
+// ErrorEnvelope é a resposta de erro da v2
+type ErrorEnvelope struct {
+	Erro ErrorBody `json:"erro"`
+	Meta Meta      `json:"meta"`
+}
+
+// ErrorBody descreve o erro com código estável, mensagem e, nos erros de
+// validação, os campos rejeitados
+type ErrorBody struct {
+	Status   int          `json:"status" example:"404"`
+	Codigo   string       `json:"codigo" example:"PRODUCT_NOT_FOUND"`
+	Mensagem string       `json:"mensagem" example:"Produto não encontrado"`
+	Campos   []FieldError `json:"campos,omitempty"`
+}
+
+// FieldError é um campo rejeitado pela validação
+type FieldError struct {
+	Campo     string `json:"campo" example:"dimensoes.altura_cm"`
+	Regra     string `json:"regra" example:"min"`
+	Parametro string `json:"parametro,omitempty" example:"0"`
+	Mensagem  string `json:"mensagem" example:"deve ser no mínimo 0"`
+}
+
+// NewMeta cria os metadados de uma resposta
+func NewMeta(requestID string, idioma models.Locale) Meta {
+	return Meta{
+		Versao:    Version,
+		RequestID: requestID,
+		Idioma:    idioma,
+		Timestamp: time.Now(),
+	}
+}
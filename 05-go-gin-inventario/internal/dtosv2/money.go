@@ -0,0 +1,60 @@
+package dtosv2
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Currency é a moeda dos valores do inventário
+const Currency = "BRL"
+
+// Money representa um valor monetário. O valor decimal vai como string e em
+// centavos inteiros, para que o cliente não dependa de ponto flutuante.
+type Money struct {
+	Valor     string `json:"valor" example:"1299.99"`
+	Centavos  int64  `json:"centavos" example:"129999"`
+	Moeda     string `json:"moeda" example:"BRL"`
+	Formatado string `json:"formatado" example:"R$ 1.299,99"`
+}
+
+// NewMoney converte um valor em reais, arredondando para centavos
+func NewMoney(value float64) Money {
+	cents := int64(math.Round(value * 100))
+	sign := ""
+	abs := cents
+	if cents < 0 {
+		sign = "-"
+		abs = -cents
+	}
+	units, fraction := abs/100, abs%100
+
+	return Money{
+		Valor:     fmt.Sprintf("%s%d.%02d", sign, units, fraction),
+		Centavos:  cents,
+		Moeda:     Currency,
+		Formatado: fmt.Sprintf("%sR$ %s,%02d", sign, groupThousands(units), fraction),
+	}
+}
+
+// NewMoneyPtr converte um valor opcional
+func NewMoneyPtr(value *float64) *Money {
+	if value == nil {
+		return nil
+	}
+	money := NewMoney(*value)
+	return &money
+}
+
+// groupThousands separa os milhares com ponto, como em 1.299
+func groupThousands(value int64) string {
+	digits := fmt.Sprintf("%d", value)
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(digit)
+	}
+	return b.String()
+}
@@ -0,0 +1,182 @@
+package dtosv2
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+)
+
+// ProductResponse representa um produto na v2: valores monetários como
+// objetos Money, estoque agrupado e link para o próprio recurso
+type ProductResponse struct {
+	ID               uuid.UUID                   `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome             string                      `json:"nome" example:"Smartphone Samsung Galaxy"`
+	Descricao        string                      `json:"descricao" example:"Smartphone com tela de 6.1 polegadas e câmera de 64MP"`
+	Categoria        models.ProductCategory      `json:"categoria" example:"eletronicos"`
+	Status           models.ProductStatus        `json:"status" example:"ativo"`
+	Ativo            bool                        `json:"ativo" example:"true"`
+	Preco            Money                       `json:"preco"`
+	PrecoPromocional *Money                      `json:"preco_promocional,omitempty"`
+	Promocoes        []models.AppliedPromotion   `json:"promocoes,omitempty"`
+	Custos           *CostInfo                   `json:"custos,omitempty"`
+	Estoque          StockInfo                   `json:"estoque"`
+	Localizacao      string                      `json:"localizacao,omitempty" example:"A-01-03"`
+	CodigoBarras     string                      `json:"codigo_barras,omitempty" example:"7891000001011"`
+	Tags             []string                    `json:"tags" example:"importado,frágil"`
+	ControlaLote     bool                        `json:"controla_lote" example:"false"`
+	Serializado      bool                        `json:"serializado" example:"false"`
+	Kit              *KitInfo                    `json:"kit,omitempty"`
+	Dimensoes        *dtos.DimensionsResponse    `json:"dimensoes,omitempty"`
+	Fiscal           *dtos.FiscalResponse        `json:"fiscal,omitempty"`
+	Imagens          []dtos.ProductImageResponse `json:"imagens,omitempty"`
+	Relacionados     []RelatedProduct            `json:"relacionados,omitempty"`
+	DataCriacao      time.Time                   `json:"data_criacao" example:"2023-01-15T10:30:00Z"`
+	DataAtualizacao  time.Time                   `json:"data_atualizacao" example:"2023-01-15T10:30:00Z"`
+	Links            Links                       `json:"links"`
+}
+
+// StockInfo agrupa as quantidades do produto
+type StockInfo struct {
+	Quantidade       int  `json:"quantidade" example:"50"`
+	Reservada        int  `json:"reservada" example:"5"`
+	Disponivel       int  `json:"disponivel" example:"45"`
+	CaixaAberta      int  `json:"caixa_aberta" example:"2"`
+	Minimo           int  `json:"minimo" example:"10"`
+	Reposicao        int  `json:"reposicao" example:"30"`
+	PrecisaReposicao bool `json:"precisa_reposicao" example:"false"`
+	EmEstoque        bool `json:"em_estoque" example:"true"`
+}
+
+// CostInfo traz custos e margens, exibidos apenas com acesso financeiro
+type CostInfo struct {
+	PrecoCusto       Money   `json:"preco_custo"`
+	CustoMedio       Money   `json:"custo_medio"`
+	MargemPercentual float64 `json:"margem_percentual" example:"32.89"`
+	MarkupPercentual float64 `json:"markup_percentual" example:"49.01"`
+}
+
+// KitInfo descreve a composição de um kit
+type KitInfo struct {
+	Componentes   []models.KitComponent `json:"componentes"`
+	PrecoDerivado bool                  `json:"preco_derivado" example:"true"`
+	DescontoKit   float64               `json:"desconto_kit" example:"10"`
+}
+
+// RelatedProduct é um produto relacionado, com preço como Money
+type RelatedProduct struct {
+	ProdutoID        uuid.UUID              `json:"produto_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Nome             string                 `json:"nome" example:"Smartphone Samsung Galaxy S23"`
+	Categoria        models.ProductCategory `json:"categoria" example:"eletronicos"`
+	Tipo             models.RelationType    `json:"tipo" example:"substituto"`
+	Observacao       string                 `json:"observacao,omitempty" example:"Mesma tela e câmera, 128GB"`
+	Preco            Money                  `json:"preco"`
+	PrecoPromocional *Money                 `json:"preco_promocional,omitempty"`
+	Disponivel       int                    `json:"disponivel" example:"12"`
+	Ativo            bool                   `json:"ativo" example:"true"`
+	Status           models.ProductStatus   `json:"status" example:"ativo"`
+	Links            Links                  `json:"links"`
+}
+
+// ProductEnvelope é a resposta com um produto
+type ProductEnvelope struct {
+	Dados ProductResponse `json:"dados"`
+	Meta  Meta            `json:"meta"`
+	Links Links           `json:"links"`
+}
+
+// ProductListEnvelope é a resposta paginada de produtos
+type ProductListEnvelope struct {
+	Dados []ProductResponse `json:"dados"`
+	Meta  ListMeta          `json:"meta"`
+	Links Links             `json:"links"`
+}
+
+// ProductPath retorna o caminho do produto na v2
+func ProductPath(id uuid.UUID) string {
+	return "/api/v2/produtos/" + id.String()
+}
+
+// FromProduct converte a resposta do serviço, já com custos omitidos e
+// textos localizados conforme a requisição, para o formato da v2
+func FromProduct(p dtos.ProductResponse) ProductResponse {
+	response := ProductResponse{
+		ID:           p.ID,
+		Nome:         p.Nome,
+		Descricao:    p.Descricao,
+		Categoria:    p.Categoria,
+		Status:       p.Status,
+		Ativo:        p.Ativo,
+		Preco:        NewMoney(p.Preco),
+		Promocoes:    p.PromocoesAtivas,
+		Localizacao:  p.Localizacao,
+		CodigoBarras: p.CodigoBarras,
+		Tags:         p.Tags,
+		ControlaLote: p.ControlaLote,
+		Serializado:  p.Serializado,
+		Dimensoes:    p.Dimensoes,
+		Fiscal:       p.Fiscal,
+		Imagens:      p.Imagens,
+		Estoque: StockInfo{
+			Quantidade:       p.Quantidade,
+			Reservada:        p.QuantidadeReservada,
+			Disponivel:       p.QuantidadeDisponivel,
+			CaixaAberta:      p.QuantidadeCaixaAberta,
+			Minimo:           p.EstoqueMinimo,
+			Reposicao:        p.QuantidadeReposicao,
+			PrecisaReposicao: p.PrecisaReposicao,
+			EmEstoque:        p.EmEstoque,
+		},
+		PrecoPromocional: NewMoneyPtr(p.PrecoPromocional),
+		DataCriacao:      p.DataCriacao,
+		DataAtualizacao:  p.DataAtualizacao,
+		Links: Links{
+			Self:    ProductPath(p.ID),
+			Colecao: "/api/v2/produtos",
+		},
+	}
+	if p.Custos != nil {
+		response.Custos = &CostInfo{
+			PrecoCusto:       NewMoney(p.Custos.PrecoCusto),
+			CustoMedio:       NewMoney(p.Custos.CustoMedio),
+			MargemPercentual: p.Custos.MargemPercentual,
+			MarkupPercentual: p.Custos.MarkupPercentual,
+		}
+	}
+	if p.Kit {
+		response.Kit = &KitInfo{
+			Componentes:   p.Componentes,
+			PrecoDerivado: p.PrecoDerivado,
+			DescontoKit:   p.DescontoKit,
+		}
+	}
+	for _, related := range p.Relacionados {
+		item := RelatedProduct{
+			ProdutoID:  related.ProdutoID,
+			Nome:       related.Nome,
+			Categoria:  related.Categoria,
+			Tipo:       related.Tipo,
+			Observacao: related.Observacao,
+			Preco:      NewMoney(related.Preco),
+			Disponivel: related.Disponivel,
+			Ativo:      related.Ativo,
+			Status:     related.Status,
+			Links:      Links{Self: ProductPath(related.ProdutoID)},
+		}
+		if related.PrecoPromocional > 0 {
+			item.PrecoPromocional = NewMoneyPtr(&related.PrecoPromocional)
+		}
+		response.Relacionados = append(response.Relacionados, item)
+	}
+	return response
+}
+
+// FromProducts converte uma lista de produtos
+func FromProducts(products []dtos.ProductResponse) []ProductResponse {
+	responses := make([]ProductResponse, len(products))
+	for i, product := range products {
+		responses[i] = FromProduct(product)
+	}
+	return responses
+}
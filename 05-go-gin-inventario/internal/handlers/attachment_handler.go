@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/service"
+)
+
+// multipartOverhead é a folga para cabeçalhos e campos de texto do formulário
+// além do tamanho máximo do arquivo
+const multipartOverhead = 1 << 20
+
+// AttachmentHandler gerencia os endpoints de imagens e documentos de produtos
+type AttachmentHandler struct {
+	service *service.AttachmentService
+}
+
+// NewAttachmentHandler cria uma nova instância do handler
+func NewAttachmentHandler(service *service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		service: service,
+	}
+}
+
+// UploadAttachment godoc
+// @Summary Enviar anexo do produto
+// @Description Envia uma imagem (JPEG, PNG ou GIF, até 5 MB) ou documento (PDF, texto ou formatos de escritório, até 10 MB). O tipo é detectado pelo conteúdo; imagens recebem miniaturas pequena, media e grande
+// @Tags anexos
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param arquivo formData file true "Arquivo"
+// @Param tipo formData string false "Tipo do anexo; padrão pelo conteúdo" Enums(imagem,documento)
+// @Param descricao formData string false "Descrição"
+// @Param principal formData boolean false "Definir como imagem principal"
+// @Success 201 {object} dtos.AttachmentResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 413 {object} dtos.ErrorResponse
+// @Failure 415 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/{id}/anexos [post]
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, service.MaxAttachmentSize+multipartOverhead)
+
+	var req dtos.UploadAttachmentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		if isBodyTooLarge(err) {
+			respondError(c, http.StatusRequestEntityTooLarge, "ATTACHMENT_TOO_LARGE", "Arquivo excede o tamanho máximo permitido")
+			return
+		}
+		respondValidationError(c, err)
+		return
+	}
+
+	header, err := c.FormFile("arquivo")
+	if err != nil {
+		if isBodyTooLarge(err) {
+			respondError(c, http.StatusRequestEntityTooLarge, "ATTACHMENT_TOO_LARGE", "Arquivo excede o tamanho máximo permitido")
+			return
+		}
+		respondError(c, http.StatusBadRequest, "FILE_REQUIRED", "Envie o arquivo no campo 'arquivo' de um formulário multipart")
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "FILE_READ_ERROR", "Não foi possível ler o arquivo enviado")
+		return
+	}
+	defer file.Close()
+
+	// Lê um byte além do limite para que o service identifique arquivos grandes demais
+	dados, err := io.ReadAll(io.LimitReader(file, service.MaxAttachmentSize+1))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "FILE_READ_ERROR", "Não foi possível ler o arquivo enviado")
+		return
+	}
+	req.NomeArquivo = header.Filename
+	req.Dados = dados
+
+	attachment, err := h.service.UploadAttachment(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "ATTACHMENT_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// GetProductAttachments godoc
+// @Summary Listar anexos do produto
+// @Description Retorna imagens e documentos do produto com as URLs dos arquivos; a imagem principal vem primeiro
+// @Tags anexos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param tipo query string false "Filtrar por tipo" Enums(imagem,documento)
+// @Success 200 {object} dtos.AttachmentListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/anexos [get]
+func (h *AttachmentHandler) GetProductAttachments(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	var tipo *models.AttachmentKind
+	if value := c.Query("tipo"); value != "" {
+		kind := models.AttachmentKind(value)
+		tipo = &kind
+	}
+
+	attachments, err := h.service.GetProductAttachments(id, tipo)
+	if err != nil {
+		respondDomainError(c, err, "ATTACHMENT_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// DeleteAttachment godoc
+// @Summary Remover anexo do produto
+// @Description Remove o anexo e seus arquivos. Se for a imagem principal, a imagem mais antiga restante assume o lugar
+// @Tags anexos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param anexo path string true "ID do anexo"
+// @Success 204 "Anexo removido com sucesso"
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/anexos/{anexo} [delete]
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	attachmentID, err := uuid.Parse(c.Param("anexo"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do anexo inválido")
+		return
+	}
+
+	if err := h.service.DeleteAttachment(id, attachmentID); err != nil {
+		respondDomainError(c, err, "ATTACHMENT_ERROR")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// isBodyTooLarge verifica se o erro vem do limite de tamanho do corpo da requisição
+func isBodyTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge)
+}
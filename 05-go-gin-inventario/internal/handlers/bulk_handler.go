@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+)
+
+// bulkSuccessStatus é o status de cada operação do lote quando bem-sucedida,
+// o mesmo do endpoint individual
+var bulkSuccessStatus = map[models.BulkOperation]int{
+	models.BulkCreate: http.StatusCreated,
+	models.BulkUpdate: http.StatusOK,
+	models.BulkDelete: http.StatusNoContent,
+}
+
+// BulkProducts godoc
+// @Summary Cadastrar, atualizar e remover produtos em lote
+// @Description Executa até 1000 itens em criar (corpo do POST), atualizar (id e campos do antigo PUT parcial) e remover (IDs), com as mesmas validações das operações individuais. No modo tudo_ou_nada (padrão) qualquer falha impede todo o lote e os itens válidos são relatados como BULK_ABORTED; em melhor_esforco os itens válidos são aplicados. O relatório traz, para cada item, a operação, o índice na lista, o ID do produto, o status e os campos rejeitados. Responde 200 sem falhas, 207 com falhas parciais em melhor_esforco e 422 quando um lote tudo_ou_nada é recusado
+// @Tags produtos
+// @Accept json
+// @Produce json
+// @Param lote body dtos.BulkProductRequest true "Itens do lote"
+// @Success 200 {object} dtos.BulkProductResponse
+// @Success 207 {object} dtos.BulkProductResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.BulkProductResponse
+// @Router /api/produtos/lote [post]
+func (h *ProductHandler) BulkProducts(c *gin.Context) {
+	var req dtos.BulkProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleValidationError(c, err)
+		return
+	}
+
+	result, err := h.service.BulkProducts(&req, binding.Validator)
+	if err != nil {
+		respondDomainError(c, err, "BULK_ERROR")
+		return
+	}
+
+	for i := range result.Itens {
+		describeBulkItem(&result.Itens[i])
+	}
+
+	statusCode := http.StatusOK
+	switch {
+	case result.Falhas == 0:
+	case result.Modo == models.BulkAllOrNothing:
+		statusCode = http.StatusUnprocessableEntity
+	default:
+		statusCode = http.StatusMultiStatus
+	}
+	c.JSON(statusCode, result)
+}
+
+// describeBulkItem preenche o status e o código do item a partir da sua
+// falha, listando os campos rejeitados pelo binding como no endpoint individual
+func describeBulkItem(item *dtos.BulkItemResult) {
+	if item.Err == nil {
+		item.Status = bulkSuccessStatus[item.Operacao]
+		return
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(item.Err, &validationErrors) {
+		item.Status, item.Codigo = domainErrorCode(item.Err, "BULK_ITEM_ERROR")
+		item.Erro = item.Err.Error()
+		return
+	}
+
+	root := reflect.TypeOf(dtos.CreateProductRequest{})
+	if item.Operacao == models.BulkUpdate {
+		root = reflect.TypeOf(dtos.BulkUpdateItem{})
+	}
+	item.Status, item.Codigo, item.Erro = http.StatusUnprocessableEntity, "VALIDATION_ERROR", "Dados inválidos"
+	for _, fieldError := range validationErrors {
+		item.Campos = append(item.Campos, dtos.ValidationError{
+			Campo: jsonFieldPath(root, fieldError.StructNamespace()),
+			Valor: fieldError.Value(),
+			Erro:  validationMessage(fieldError),
+			Tag:   fieldError.Tag(),
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"inventario-api/internal/models"
+)
+
+// GetCostLayers godoc
+// @Summary Camadas de custo do produto
+// @Description Retorna as entradas de estoque com custo unitário e saldo, na ordem de consumo (FIFO). Requer acesso financeiro.
+// @Tags custos
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "ID do produto"
+// @Param apenas_saldo query boolean false "Apenas camadas com saldo" default(true)
+// @Success 200 {object} dtos.CostLayerListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/custos [get]
+func (h *ProductHandler) GetCostLayers(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	apenasSaldo, err := strconv.ParseBool(c.DefaultQuery("apenas_saldo", "true"))
+	if err != nil {
+		apenasSaldo = true
+	}
+
+	layers, err := h.service.GetCostLayers(id, apenasSaldo)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, layers)
+}
+
+// GetInventoryValuation godoc
+// @Summary Valoração do inventário a custo
+// @Description Valora o estoque de cada produto por FIFO ou custo médio ponderado, ao lado do valor a preço de venda. Requer acesso financeiro.
+// @Tags custos
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param metodo query string false "Método de valoração (fifo, media_ponderada)" default(fifo)
+// @Success 200 {object} dtos.InventoryValuationResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 403 {object} dtos.ErrorResponse
+// @Router /api/produtos/valoracao [get]
+func (h *ProductHandler) GetInventoryValuation(c *gin.Context) {
+	metodo := models.ValuationMethod(c.DefaultQuery("metodo", string(models.ValuationFIFO)))
+
+	valuation, err := h.service.GetInventoryValuation(metodo)
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_PARAMETER", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, valuation)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/service"
+)
+
+// FiscalHandler gerencia os endpoints de tributos e da tabela NCM
+type FiscalHandler struct {
+	service *service.FiscalService
+}
+
+// NewFiscalHandler cria uma nova instância do handler
+func NewFiscalHandler(service *service.FiscalService) *FiscalHandler {
+	return &FiscalHandler{
+		service: service,
+	}
+}
+
+// SimulateTaxes godoc
+// @Summary Simular tributos
+// @Description Calcula IPI, ICMS, DIFAL (interestadual a consumidor final), PIS e COFINS da venda do produto para a UF de destino, pelos dados fiscais do produto e pela tabela local de alíquotas
+// @Tags tributos
+// @Accept json
+// @Produce json
+// @Param simulacao body dtos.TaxSimulationRequest true "Produto, UF de destino e tipo de destinatário"
+// @Success 200 {object} dtos.TaxSimulationResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Router /api/tributos/simulacao [post]
+func (h *FiscalHandler) SimulateTaxes(c *gin.Context) {
+	var req dtos.TaxSimulationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	simulation, err := h.service.SimulateTaxes(&req)
+	if err != nil {
+		respondDomainError(c, err, "TAX_SIMULATION_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, simulation)
+}
+
+// SearchNCM godoc
+// @Summary Buscar NCM
+// @Description Busca na tabela NCM distribuída com a aplicação pelo início do código ou por parte da descrição
+// @Tags tributos
+// @Produce json
+// @Param busca query string false "Início do código ou parte da descrição"
+// @Param limite query int false "Máximo de resultados" default(50)
+// @Success 200 {object} dtos.NCMListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Router /api/tributos/ncm [get]
+func (h *FiscalHandler) SearchNCM(c *gin.Context) {
+	limite, err := strconv.Atoi(c.DefaultQuery("limite", "50"))
+	if err != nil || limite < 1 {
+		respondError(c, http.StatusBadRequest, "INVALID_PARAMETER", "Parâmetro limite inválido")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.service.SearchNCM(c.Query("busca"), limite))
+}
+
+// GetTaxTable godoc
+// @Summary Consultar tabela de tributos
+// @Description Retorna as alíquotas em uso: UF de origem, ICMS interno por UF, ICMS interestadual (padrão, reduzida e de importados) e PIS/COFINS
+// @Tags tributos
+// @Produce json
+// @Success 200 {object} models.TaxTable
+// @Router /api/tributos/tabela [get]
+func (h *FiscalHandler) GetTaxTable(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.GetTaxTable())
+}
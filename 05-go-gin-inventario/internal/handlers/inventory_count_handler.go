@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/service"
+)
+
+// InventoryCountHandler gerencia os endpoints de contagens de inventário
+type InventoryCountHandler struct {
+	service *service.InventoryCountService
+}
+
+// NewInventoryCountHandler cria uma nova instância do handler
+func NewInventoryCountHandler(service *service.InventoryCountService) *InventoryCountHandler {
+	return &InventoryCountHandler{
+		service: service,
+	}
+}
+
+// CreateInventoryCount godoc
+// @Summary Abrir contagem de inventário
+// @Description Abre uma contagem sobre os produtos da categoria e/ou com localização iniciada pelo prefixo informado, congelando a quantidade esperada e o custo de cada um. Kits e produtos serializados ficam de fora.
+// @Tags inventarios
+// @Accept json
+// @Produce json
+// @Param contagem body dtos.InventoryCountRequest true "Escopo da contagem"
+// @Success 201 {object} dtos.InventoryCountResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/inventarios [post]
+func (h *InventoryCountHandler) CreateInventoryCount(c *gin.Context) {
+	var req dtos.InventoryCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondValidationError(c, err)
+		return
+	}
+
+	count, err := h.service.CreateInventoryCount(&req)
+	if err != nil {
+		respondDomainError(c, err, "CREATE_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusCreated, count)
+}
+
+// ListInventoryCounts godoc
+// @Summary Listar contagens de inventário
+// @Description Lista contagens, das mais recentes para as mais antigas, filtrando opcionalmente por status e produto
+// @Tags inventarios
+// @Accept json
+// @Produce json
+// @Param status query string false "Status da contagem" Enums(aberta,encerrada,postada,cancelada)
+// @Param produto_id query string false "ID do produto"
+// @Success 200 {object} dtos.InventoryCountListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/inventarios [get]
+func (h *InventoryCountHandler) ListInventoryCounts(c *gin.Context) {
+	var filter database.InventoryCountFilter
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := models.InventoryCountStatus(statusStr)
+		filter.Status = &status
+	}
+
+	if produtoStr := c.Query("produto_id"); produtoStr != "" {
+		produtoID, err := uuid.Parse(produtoStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+			return
+		}
+		filter.ProdutoID = &produtoID
+	}
+
+	counts, err := h.service.ListInventoryCounts(filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "FETCH_ERROR", "Erro ao buscar contagens de inventário")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, counts)
+}
+
+// GetInventoryCount godoc
+// @Summary Buscar contagem de inventário
+// @Description Retorna a contagem com os itens na ordem de percurso. Em contagens cegas abertas, as quantidades esperadas são omitidas.
+// @Tags inventarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da contagem"
+// @Success 200 {object} dtos.InventoryCountResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/inventarios/{id} [get]
+func (h *InventoryCountHandler) GetInventoryCount(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da contagem inválido")
+		return
+	}
+
+	count, err := h.service.GetInventoryCount(id)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, count)
+}
+
+// RecordCounts godoc
+// @Summary Registrar quantidades contadas
+// @Description Registra as quantidades contadas de um ou mais produtos da contagem aberta. Enviar de novo um produto registra uma recontagem, que substitui a anterior.
+// @Tags inventarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da contagem"
+// @Param contagens body dtos.RecordCountsRequest true "Quantidades contadas"
+// @Success 200 {object} dtos.InventoryCountResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/inventarios/{id}/contagens [post]
+func (h *InventoryCountHandler) RecordCounts(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da contagem inválido")
+		return
+	}
+
+	var req dtos.RecordCountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	count, err := h.service.RecordCounts(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "COUNT_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, count)
+}
+
+// CloseInventoryCount godoc
+// @Summary Encerrar contagem de inventário
+// @Description Encerra a contagem para revisão das divergências. Todos os itens devem ter sido contados.
+// @Tags inventarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da contagem"
+// @Success 200 {object} dtos.InventoryCountResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/inventarios/{id}/encerrar [post]
+func (h *InventoryCountHandler) CloseInventoryCount(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da contagem inválido")
+		return
+	}
+
+	count, err := h.service.CloseInventoryCount(id)
+	if err != nil {
+		respondDomainError(c, err, "CLOSE_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, count)
+}
+
+// ReopenInventoryCount godoc
+// @Summary Reabrir contagem de inventário
+// @Description Reabre uma contagem encerrada para que divergências sejam recontadas
+// @Tags inventarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da contagem"
+// @Success 200 {object} dtos.InventoryCountResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/inventarios/{id}/reabrir [post]
+func (h *InventoryCountHandler) ReopenInventoryCount(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da contagem inválido")
+		return
+	}
+
+	count, err := h.service.ReopenInventoryCount(id)
+	if err != nil {
+		respondDomainError(c, err, "REOPEN_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, count)
+}
+
+// GetVarianceReport godoc
+// @Summary Relatório de divergências
+// @Description Lista as divergências dos itens contados com o impacto a custo de sobras e faltas e a acuracidade da contagem. Custos e valores só aparecem com acesso financeiro. Em contagens cegas, disponível apenas após o encerramento.
+// @Tags inventarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da contagem"
+// @Success 200 {object} dtos.CountVarianceReportResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/inventarios/{id}/divergencias [get]
+func (h *InventoryCountHandler) GetVarianceReport(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da contagem inválido")
+		return
+	}
+
+	report, err := h.service.GetVarianceReport(id)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, report)
+}
+
+// PostInventoryCount godoc
+// @Summary Aprovar e postar contagem de inventário
+// @Description Aprova a contagem encerrada e lança todos os ajustes de estoque de uma só vez. Cada ajuste é a divergência entre o contado e o esperado na abertura, aplicada sobre o estoque atual. Se algum ajuste não puder ser aplicado, nenhum é.
+// @Tags inventarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da contagem"
+// @Param aprovacao body dtos.PostInventoryCountRequest false "Responsável pela aprovação"
+// @Success 200 {object} dtos.InventoryCountResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/inventarios/{id}/postar [post]
+func (h *InventoryCountHandler) PostInventoryCount(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da contagem inválido")
+		return
+	}
+
+	// O corpo é opcional: sem ele a contagem é postada sem responsável
+	var req dtos.PostInventoryCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondValidationError(c, err)
+		return
+	}
+
+	count, err := h.service.PostInventoryCount(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "POST_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, count)
+}
+
+// CancelInventoryCount godoc
+// @Summary Cancelar contagem de inventário
+// @Description Cancela uma contagem aberta ou encerrada sem alterar o estoque
+// @Tags inventarios
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da contagem"
+// @Success 200 {object} dtos.InventoryCountResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/inventarios/{id}/cancelar [post]
+func (h *InventoryCountHandler) CancelInventoryCount(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da contagem inválido")
+		return
+	}
+
+	count, err := h.service.CancelInventoryCount(id)
+	if err != nil {
+		respondDomainError(c, err, "CANCEL_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, count)
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/service"
+)
+
+// InvoiceImportHandler gerencia os endpoints de importação de NF-e
+type InvoiceImportHandler struct {
+	service *service.InvoiceImportService
+}
+
+// NewInvoiceImportHandler cria uma nova instância do handler
+func NewInvoiceImportHandler(service *service.InvoiceImportService) *InvoiceImportHandler {
+	return &InvoiceImportHandler{
+		service: service,
+	}
+}
+
+// ImportNFe godoc
+// @Summary Importar XML de NF-e
+// @Description Lê o XML da NF-e do fornecedor (no corpo da requisição ou no campo 'arquivo' de um formulário multipart) e registra uma importação pendente. Os itens são vinculados pelo código de barras (cEAN) e, quando o CNPJ do emitente é de um fornecedor cadastrado, pelo código do fornecedor (cProd); os demais ficam pendentes de vínculo manual.
+// @Tags notas-fiscais
+// @Accept xml
+// @Accept mpfd
+// @Produce json
+// @Param arquivo formData file false "XML da NF-e"
+// @Success 201 {object} dtos.InvoiceImportResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/notas-fiscais/importacoes [post]
+func (h *InvoiceImportHandler) ImportNFe(c *gin.Context) {
+	body := io.Reader(c.Request.Body)
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("arquivo")
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "FILE_REQUIRED", "Envie o XML no campo 'arquivo' de um formulário multipart")
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "FILE_READ_ERROR", "Não foi possível ler o arquivo enviado")
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	// Lê um byte além do limite para que o service identifique arquivos grandes demais
+	dados, err := io.ReadAll(io.LimitReader(body, service.MaxInvoiceSize+1))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "FILE_READ_ERROR", "Não foi possível ler o XML enviado")
+		return
+	}
+
+	invoice, err := h.service.ImportNFe(dados)
+	if err != nil {
+		respondDomainError(c, err, "INVOICE_IMPORT_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusCreated, invoice)
+}
+
+// ListInvoiceImports godoc
+// @Summary Listar importações de NF-e
+// @Description Lista as importações, das mais recentes para as mais antigas, filtrando opcionalmente por status e fornecedor
+// @Tags notas-fiscais
+// @Produce json
+// @Param status query string false "Status da importação" Enums(pendente, confirmada, descartada)
+// @Param fornecedor_id query string false "ID do fornecedor emitente"
+// @Success 200 {object} dtos.InvoiceImportListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Router /api/notas-fiscais/importacoes [get]
+func (h *InvoiceImportHandler) ListInvoiceImports(c *gin.Context) {
+	var filter database.InvoiceImportFilter
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := models.InvoiceImportStatus(statusStr)
+		filter.Status = &status
+	}
+
+	if fornecedorStr := c.Query("fornecedor_id"); fornecedorStr != "" {
+		fornecedorID, err := uuid.Parse(fornecedorStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do fornecedor inválido")
+			return
+		}
+		filter.FornecedorID = &fornecedorID
+	}
+
+	invoices, err := h.service.ListInvoiceImports(filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "FETCH_ERROR", "Erro ao buscar importações de NF-e")
+		return
+	}
+
+	c.JSON(http.StatusOK, invoices)
+}
+
+// GetInvoiceImport godoc
+// @Summary Buscar importação de NF-e
+// @Description Retorna a importação com os itens da nota, o produto vinculado a cada um e os itens pendentes
+// @Tags notas-fiscais
+// @Produce json
+// @Param id path string true "ID da importação"
+// @Success 200 {object} dtos.InvoiceImportResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/notas-fiscais/importacoes/{id} [get]
+func (h *InvoiceImportHandler) GetInvoiceImport(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da importação inválido")
+		return
+	}
+
+	invoice, err := h.service.GetInvoiceImport(id)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
+
+// MapInvoiceLine godoc
+// @Summary Vincular item da NF-e
+// @Description Vincula manualmente um item da nota a um produto ou marca o item como ignorado. O vínculo é lembrado como código do fornecedor quando a nota é confirmada.
+// @Tags notas-fiscais
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da importação"
+// @Param item path int true "Número do item na nota (nItem)"
+// @Param vinculo body dtos.MapInvoiceLineRequest true "Produto ou ignorar"
+// @Success 200 {object} dtos.InvoiceImportResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/notas-fiscais/importacoes/{id}/itens/{item} [put]
+func (h *InvoiceImportHandler) MapInvoiceLine(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da importação inválido")
+		return
+	}
+
+	item, err := strconv.Atoi(c.Param("item"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_PARAMETER", "Número do item inválido")
+		return
+	}
+
+	var req dtos.MapInvoiceLineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	invoice, err := h.service.MapInvoiceLine(id, item, &req)
+	if err != nil {
+		respondDomainError(c, err, "INVOICE_MAPPING_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
+
+// ConfirmInvoiceImport godoc
+// @Summary Confirmar entrada da NF-e
+// @Description Soma as quantidades dos itens vinculados ao estoque, registra o custo unitário da nota e atualiza o último custo no vínculo com o fornecedor. Todos os itens precisam estar vinculados ou ignorados; a operação é atômica.
+// @Tags notas-fiscais
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da importação"
+// @Param confirmacao body dtos.InvoiceDecisionRequest false "Observação"
+// @Success 200 {object} dtos.InvoiceImportResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Router /api/notas-fiscais/importacoes/{id}/confirmar [post]
+func (h *InvoiceImportHandler) ConfirmInvoiceImport(c *gin.Context) {
+	h.decide(c, h.service.ConfirmInvoiceImport, "INVOICE_CONFIRM_ERROR")
+}
+
+// DiscardInvoiceImport godoc
+// @Summary Descartar importação de NF-e
+// @Description Descarta uma importação pendente sem alterar o estoque; a nota pode ser importada novamente
+// @Tags notas-fiscais
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da importação"
+// @Param descarte body dtos.InvoiceDecisionRequest false "Observação"
+// @Success 200 {object} dtos.InvoiceImportResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/notas-fiscais/importacoes/{id}/descartar [post]
+func (h *InvoiceImportHandler) DiscardInvoiceImport(c *gin.Context) {
+	h.decide(c, h.service.DiscardInvoiceImport, "INVOICE_DISCARD_ERROR")
+}
+
+// decide trata a confirmação ou o descarte de uma importação, cujo corpo é opcional
+func (h *InvoiceImportHandler) decide(c *gin.Context, action func(uuid.UUID, *dtos.InvoiceDecisionRequest) (*dtos.InvoiceImportResponse, error), fallbackCodigo string) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da importação inválido")
+		return
+	}
+
+	var req dtos.InvoiceDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondValidationError(c, err)
+		return
+	}
+
+	invoice, err := action(id, &req)
+	if err != nil {
+		respondDomainError(c, err, fallbackCodigo)
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventario-api/internal/dtos"
+)
+
+// SetKitComponents godoc
+// @Summary Definir composição do kit
+// @Description Define os componentes e quantidades de um kit e se o preço é derivado dos componentes com desconto
+// @Tags kits
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do kit"
+// @Param composicao body dtos.KitDefinitionRequest true "Componentes do kit"
+// @Success 200 {object} dtos.KitCompositionResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/{id}/componentes [put]
+func (h *ProductHandler) SetKitComponents(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	var req dtos.KitDefinitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleValidationError(c, err)
+		return
+	}
+
+	composition, err := h.service.SetKitComponents(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "KIT_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, composition)
+}
+
+// GetKitComposition godoc
+// @Summary Consultar composição do kit
+// @Description Retorna os componentes do kit, a disponibilidade calculada pelo estoque dos componentes e o preço
+// @Tags kits
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do kit"
+// @Success 200 {object} dtos.KitCompositionResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/componentes [get]
+func (h *ProductHandler) GetKitComposition(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	composition, err := h.service.GetKitComposition(id)
+	if err != nil {
+		respondDomainError(c, err, "KIT_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, composition)
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/service"
+)
+
+// LotHandler gerencia os endpoints de lotes e validade
+type LotHandler struct {
+	service *service.LotService
+}
+
+// NewLotHandler cria uma nova instância do handler
+func NewLotHandler(service *service.LotService) *LotHandler {
+	return &LotHandler{
+		service: service,
+	}
+}
+
+// ReceiveLot godoc
+// @Summary Registrar entrada de lote
+// @Description Registra um lote com fabricação e validade, somando sua quantidade ao estoque do produto
+// @Tags lotes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param lote body dtos.CreateLotRequest true "Dados do lote"
+// @Success 201 {object} dtos.LotResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/{id}/lotes [post]
+func (h *LotHandler) ReceiveLot(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	var req dtos.CreateLotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	lot, err := h.service.ReceiveLot(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "LOT_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusCreated, lot)
+}
+
+// GetProductLots godoc
+// @Summary Listar lotes do produto
+// @Description Retorna os lotes do produto na ordem de consumo (primeiro a vencer, primeiro a sair)
+// @Tags lotes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param apenas_saldo query boolean false "Apenas lotes com saldo" default(true)
+// @Success 200 {object} dtos.LotListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/lotes [get]
+func (h *LotHandler) GetProductLots(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	apenasSaldo, err := strconv.ParseBool(c.DefaultQuery("apenas_saldo", "true"))
+	if err != nil {
+		apenasSaldo = true
+	}
+
+	lots, err := h.service.GetProductLots(id, apenasSaldo)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, lots)
+}
+
+// GetExpiringLots godoc
+// @Summary Relatório de lotes a vencer
+// @Description Retorna os lotes com saldo que vencem nos próximos N dias (incluindo vencidos) e o valor em estoque
+// @Tags lotes
+// @Accept json
+// @Produce json
+// @Param dias query int false "Janela em dias" default(30)
+// @Success 200 {object} dtos.ExpiringLotsResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Router /api/produtos/lotes/vencendo [get]
+func (h *LotHandler) GetExpiringLots(c *gin.Context) {
+	dias, err := strconv.Atoi(c.DefaultQuery("dias", "30"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_PARAMETER", "Parâmetro dias inválido")
+		return
+	}
+
+	report, err := h.service.GetExpiringLots(dias)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/jsonpatch"
+	"inventario-api/internal/service"
+)
+
+// acceptPatch lista os formatos aceitos por PATCH, anunciados quando o
+// cliente envia outro tipo de conteúdo
+const acceptPatch = jsonpatch.MergePatchMediaType + ", " + jsonpatch.JSONPatchMediaType
+
+// PatchProduct godoc
+// @Summary Alterar produto parcialmente
+// @Description Aplica um JSON Merge Patch (application/merge-patch+json, RFC 7396) ou um JSON Patch (application/json-patch+json, RFC 6902) à representação do produto usada pelo PUT. No merge patch, null limpa textos e tags e remove dimensões ou dados fiscais; remover campos obrigatórios, como preco_custo ou estoque_minimo, responde 422. O estoque físico não faz parte da representação. No JSON Patch, uma operação test que não confere impede todo o patch. O resultado é validado como um PUT
+// @Tags produtos
+// @Accept merge-patch,json-patch
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param produto body dtos.ReplaceProductRequest true "Campos a alterar" mime(merge-patch)
+// @Param operacoes body []dtos.PatchOperation true "Operações a aplicar" mime(json-patch)
+// @Success 200 {object} dtos.ProductResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 415 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/{id} [patch]
+func (h *ProductHandler) PatchProduct(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	product, err := patchProduct(c, h.service, id)
+	if err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			h.handleValidationError(c, validationErrors)
+			return
+		}
+		respondDomainError(c, err, "PATCH_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, product)
+}
+
+// PatchProduct godoc
+// @Summary Alterar produto parcialmente (v2)
+// @Description Aplica um JSON Merge Patch ou um JSON Patch como na v1 e responde no envelope da v2
+// @Tags produtos-v2
+// @ID patchProductV2
+// @Accept merge-patch,json-patch
+// @Produce application/vnd.inventario.v2+json
+// @Param id path string true "ID do produto"
+// @Param produto body dtos.ReplaceProductRequest true "Campos a alterar" mime(merge-patch)
+// @Param operacoes body []dtos.PatchOperation true "Operações a aplicar" mime(json-patch)
+// @Success 200 {object} dtosv2.ProductEnvelope
+// @Failure 400 {object} dtosv2.ErrorEnvelope
+// @Failure 404 {object} dtosv2.ErrorEnvelope
+// @Failure 406 {object} dtosv2.ErrorEnvelope
+// @Failure 409 {object} dtosv2.ErrorEnvelope
+// @Failure 415 {object} dtosv2.ErrorEnvelope
+// @Failure 422 {object} dtosv2.ErrorEnvelope
+// @Router /api/v2/produtos/{id} [patch]
+func (h *ProductV2Handler) PatchProduct(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	product, err := patchProduct(c, h.service, id)
+	if err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			respondValidationErrorV2(c, validationErrors, dtos.ReplaceProductRequest{})
+			return
+		}
+		respondDomainErrorV2(c, err, "PATCH_ERROR")
+		return
+	}
+
+	h.respondProduct(c, http.StatusOK, product)
+}
+
+// patchProduct aplica o patch do corpo conforme o Content-Type, validando o
+// resultado com as mesmas regras do PUT
+func patchProduct(c *gin.Context, productService *service.ProductService, id uuid.UUID) (*dtos.ProductResponse, error) {
+	patch, err := c.GetRawData()
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := productService.PatchProduct(id, c.ContentType(), patch, binding.Validator)
+	if errors.Is(err, database.ErrPatchUnsupported) {
+		c.Header("Accept-Patch", acceptPatch)
+	}
+	return product, err
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/service"
+)
+
+// PriceHandler gerencia os endpoints de histórico, agendamento e reajuste de preços
+type PriceHandler struct {
+	service *service.PriceService
+}
+
+// NewPriceHandler cria uma nova instância do handler
+func NewPriceHandler(service *service.PriceService) *PriceHandler {
+	return &PriceHandler{
+		service: service,
+	}
+}
+
+// GetPriceHistory godoc
+// @Summary Histórico de preços do produto
+// @Description Retorna todas as alterações de preço do produto (mais recentes primeiro) e os preços agendados pendentes
+// @Tags precos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Success 200 {object} dtos.PriceHistoryResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/precos [get]
+func (h *PriceHandler) GetPriceHistory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	history, err := h.service.GetPriceHistory(id)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// SchedulePrice godoc
+// @Summary Agendar preço
+// @Description Agenda um novo preço que entra em vigor automaticamente no instante informado
+// @Tags precos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param agendamento body dtos.SchedulePriceRequest true "Preço e início da vigência"
+// @Success 201 {object} models.ScheduledPrice
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/{id}/precos/agendamentos [post]
+func (h *PriceHandler) SchedulePrice(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	var req dtos.SchedulePriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	scheduled, err := h.service.SchedulePrice(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "PRICE_SCHEDULE_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusCreated, scheduled)
+}
+
+// CancelScheduledPrice godoc
+// @Summary Cancelar preço agendado
+// @Description Cancela um preço agendado que ainda não entrou em vigor
+// @Tags precos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param agendamento path string true "ID do agendamento"
+// @Success 200 {object} models.ScheduledPrice
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/precos/agendamentos/{agendamento} [delete]
+func (h *PriceHandler) CancelScheduledPrice(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	scheduledID, err := uuid.Parse(c.Param("agendamento"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do agendamento inválido")
+		return
+	}
+
+	scheduled, err := h.service.CancelScheduledPrice(id, scheduledID)
+	if err != nil {
+		respondDomainError(c, err, "PRICE_SCHEDULE_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, scheduled)
+}
+
+// PreviewPriceAdjustment godoc
+// @Summary Simular reajuste de preços
+// @Description Calcula os novos preços de um reajuste percentual por categoria ou filtro sem aplicá-lo
+// @Tags precos
+// @Accept json
+// @Produce json
+// @Param reajuste body dtos.PriceAdjustmentRequest true "Percentual e filtros"
+// @Success 200 {object} dtos.PriceAdjustmentResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/precos/reajuste/simulacao [post]
+func (h *PriceHandler) PreviewPriceAdjustment(c *gin.Context) {
+	h.adjustPrices(c, true)
+}
+
+// ApplyPriceAdjustment godoc
+// @Summary Aplicar reajuste de preços
+// @Description Aplica atomicamente um reajuste percentual aos produtos da categoria ou filtro, registrando o histórico
+// @Tags precos
+// @Accept json
+// @Produce json
+// @Param reajuste body dtos.PriceAdjustmentRequest true "Percentual e filtros"
+// @Success 200 {object} dtos.PriceAdjustmentResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/precos/reajuste [post]
+func (h *PriceHandler) ApplyPriceAdjustment(c *gin.Context) {
+	h.adjustPrices(c, false)
+}
+
+// Métodos auxiliares privados
+
+func (h *PriceHandler) adjustPrices(c *gin.Context, simular bool) {
+	var req dtos.PriceAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	result, err := h.service.AdjustPrices(&req, simular)
+	if err != nil {
+		respondDomainError(c, err, "PRICE_ADJUSTMENT_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -1,9 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -44,11 +45,11 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 
 	product, err := h.service.CreateProduct(&req)
 	if err != nil {
-		h.handleError(c, http.StatusBadRequest, "CREATION_ERROR", err.Error())
+		respondDomainError(c, err, "CREATION_ERROR")
 		return
 	}
 
-	c.JSON(http.StatusCreated, product)
+	respondWithCosts(c, http.StatusCreated, product)
 }
 
 // GetProduct godoc
@@ -58,6 +59,7 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "ID do produto"
+// @Param incluir query string false "Dados adicionais" Enums(relacionados)
 // @Success 200 {object} dtos.ProductResponse
 // @Failure 400 {object} dtos.ErrorResponse
 // @Failure 404 {object} dtos.ErrorResponse
@@ -69,13 +71,18 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 		return
 	}
 
-	product, err := h.service.GetProductByID(id)
+	var product *dtos.ProductResponse
+	if c.Query("incluir") == "relacionados" {
+		product, err = h.service.GetProductWithRelations(id)
+	} else {
+		product, err = h.service.GetProductByID(id)
+	}
 	if err != nil {
 		h.handleError(c, http.StatusNotFound, "PRODUCT_NOT_FOUND", "Produto não encontrado")
 		return
 	}
 
-	c.JSON(http.StatusOK, product)
+	respondWithCosts(c, http.StatusOK, product)
 }
 
 // GetAllProducts godoc
@@ -94,7 +101,7 @@ func (h *ProductHandler) GetAllProducts(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, products)
+	respondWithCosts(c, http.StatusOK, products)
 }
 
 // GetProductsFiltered godoc
@@ -108,7 +115,12 @@ func (h *ProductHandler) GetAllProducts(c *gin.Context) {
 // @Param preco_maximo query number false "Preço máximo"
 // @Param apenas_ativos query boolean false "Apenas produtos ativos"
 // @Param apenas_estoque query boolean false "Apenas produtos em estoque"
-// @Param nome query string false "Busca por nome ou descrição"
+// @Param status query string false "Situação do ciclo de vida" Enums(rascunho,em_revisao,ativo,descontinuado,bloqueado,inativo)
+// @Param tags query string false "Tags separadas por vírgula"
+// @Param tags_modo query string false "Combinação das tags" Enums(qualquer,todas) default(qualquer)
+// @Param nome query string false "Busca por nome ou descrição no idioma da resposta"
+// @Param lang query string false "Idioma da resposta e da busca (tem prioridade sobre Accept-Language)" Enums(pt-BR,es,en)
+// @Param Accept-Language header string false "Idiomas aceitos" default(pt-BR)
 // @Param page query int false "Número da página" default(1)
 // @Param size query int false "Itens por página" default(10)
 // @Success 200 {object} dtos.ProductListResponse
@@ -116,65 +128,103 @@ func (h *ProductHandler) GetAllProducts(c *gin.Context) {
 // @Failure 500 {object} dtos.ErrorResponse
 // @Router /api/produtos/filtros [get]
 func (h *ProductHandler) GetProductsFiltered(c *gin.Context) {
+	filters, err := parseProductFilters(c)
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_PARAMETER", err.Error())
+		return
+	}
+
+	products, err := h.service.GetProductsFiltered(filters.categoria, filters.precoMin, filters.precoMax, filters.apenasAtivos, filters.apenasEstoque, filters.status, filters.tags, filters.tagsModo, filters.nome, filters.idioma, filters.page, filters.size)
+	if err != nil {
+		h.handleError(c, http.StatusInternalServerError, "FETCH_ERROR", "Erro ao buscar produtos")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, products)
+}
+
+// productFilters reúne os filtros e a paginação da listagem de produtos
+type productFilters struct {
+	categoria                   *models.ProductCategory
+	precoMin, precoMax          *float64
+	apenasAtivos, apenasEstoque *bool
+	status                      *models.ProductStatus
+	tags                        []string
+	tagsModo                    models.TagMatch
+	nome                        *string
+	idioma                      models.Locale
+	page, size                  int
+}
+
+// parseProductFilters lê os filtros da query string, usados pela listagem
+// filtrada da v1 e pela listagem da v2
+func parseProductFilters(c *gin.Context) (productFilters, error) {
+	var filters productFilters
+
 	// Parse dos parâmetros de query
-	var categoria *models.ProductCategory
 	if catStr := c.Query("categoria"); catStr != "" {
 		cat := models.ProductCategory(catStr)
-		categoria = &cat
+		filters.categoria = &cat
 	}
 
-	var precoMin, precoMax *float64
 	if minStr := c.Query("preco_minimo"); minStr != "" {
 		if min, err := strconv.ParseFloat(minStr, 64); err == nil {
-			precoMin = &min
+			filters.precoMin = &min
 		}
 	}
 	if maxStr := c.Query("preco_maximo"); maxStr != "" {
 		if max, err := strconv.ParseFloat(maxStr, 64); err == nil {
-			precoMax = &max
+			filters.precoMax = &max
 		}
 	}
 
-	var apenasAtivos, apenasEstoque *bool
 	if ativosStr := c.Query("apenas_ativos"); ativosStr != "" {
 		if ativos, err := strconv.ParseBool(ativosStr); err == nil {
-			apenasAtivos = &ativos
+			filters.apenasAtivos = &ativos
 		}
 	}
 	if estoqueStr := c.Query("apenas_estoque"); estoqueStr != "" {
 		if estoque, err := strconv.ParseBool(estoqueStr); err == nil {
-			apenasEstoque = &estoque
+			filters.apenasEstoque = &estoque
 		}
 	}
 
-	var nome *string
-	if nomeStr := c.Query("nome"); nomeStr != "" {
-		nome = &nomeStr
+	if statusStr := c.Query("status"); statusStr != "" {
+		st := models.ProductStatus(statusStr)
+		filters.status = &st
 	}
 
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+	// Tags separadas por vírgula ou em parâmetros repetidos
+	for _, value := range c.QueryArray("tags") {
+		filters.tags = append(filters.tags, strings.Split(value, ",")...)
+	}
+	filters.tagsModo = models.TagMatch(c.Query("tags_modo"))
+	if filters.tagsModo != "" && !filters.tagsModo.IsValid() {
+		return filters, errors.New("Parâmetro tags_modo deve ser 'qualquer' ou 'todas'")
+	}
 
-	products, err := h.service.GetProductsFiltered(categoria, precoMin, precoMax, apenasAtivos, apenasEstoque, nome, page, size)
-	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, "FETCH_ERROR", "Erro ao buscar produtos")
-		return
+	if nomeStr := c.Query("nome"); nomeStr != "" {
+		filters.nome = &nomeStr
 	}
+	filters.idioma = requestLocale(c)
 
-	c.JSON(http.StatusOK, products)
+	filters.page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filters.size, _ = strconv.Atoi(c.DefaultQuery("size", "10"))
+	return filters, nil
 }
 
 // UpdateProduct godoc
-// @Summary Atualizar produto
-// @Description Atualiza um produto existente
+// @Summary Substituir produto
+// @Description Substitui a representação completa do produto: nome, preço, custo, estoque mínimo, quantidade de reposição, categoria e ativo são obrigatórios, textos e tags omitidos ficam vazios e dimensões ou dados fiscais ausentes são removidos. O estoque físico não faz parte da representação e muda apenas por /estoque e /estoque/ajuste. Para alterações parciais use PATCH. Custo e preço derivados de kits são ignorados
 // @Tags produtos
 // @Accept json
 // @Produce json
 // @Param id path string true "ID do produto"
-// @Param produto body dtos.UpdateProductRequest true "Dados para atualização"
+// @Param produto body dtos.ReplaceProductRequest true "Representação completa do produto"
 // @Success 200 {object} dtos.ProductResponse
 // @Failure 400 {object} dtos.ErrorResponse
 // @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
 // @Failure 422 {object} dtos.ValidationErrorResponse
 // @Router /api/produtos/{id} [put]
 func (h *ProductHandler) UpdateProduct(c *gin.Context) {
@@ -184,23 +234,19 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 		return
 	}
 
-	var req dtos.UpdateProductRequest
+	var req dtos.ReplaceProductRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.handleValidationError(c, err)
 		return
 	}
 
-	product, err := h.service.UpdateProduct(id, &req)
+	product, err := h.service.ReplaceProduct(id, &req)
 	if err != nil {
-		if err.Error() == "produto não encontrado" {
-			h.handleError(c, http.StatusNotFound, "PRODUCT_NOT_FOUND", "Produto não encontrado")
-		} else {
-			h.handleError(c, http.StatusBadRequest, "UPDATE_ERROR", err.Error())
-		}
+		respondDomainError(c, err, "UPDATE_ERROR")
 		return
 	}
 
-	c.JSON(http.StatusOK, product)
+	respondWithCosts(c, http.StatusOK, product)
 }
 
 // DeleteProduct godoc
@@ -222,11 +268,7 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 	}
 
 	if err := h.service.DeleteProduct(id); err != nil {
-		if err.Error() == "produto não encontrado" {
-			h.handleError(c, http.StatusNotFound, "PRODUCT_NOT_FOUND", "Produto não encontrado")
-		} else {
-			h.handleError(c, http.StatusInternalServerError, "DELETE_ERROR", "Erro ao deletar produto")
-		}
+		respondDomainError(c, err, "DELETE_ERROR")
 		return
 	}
 
@@ -246,7 +288,7 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 // @Router /api/produtos/categoria/{categoria} [get]
 func (h *ProductHandler) GetProductsByCategory(c *gin.Context) {
 	categoria := models.ProductCategory(c.Param("categoria"))
-	
+
 	// Valida se a categoria é válida
 	if !h.isValidCategory(categoria) {
 		h.handleError(c, http.StatusBadRequest, "INVALID_CATEGORY", "Categoria inválida")
@@ -259,7 +301,7 @@ func (h *ProductHandler) GetProductsByCategory(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, products)
+	respondWithCosts(c, http.StatusOK, products)
 }
 
 // GetActiveProducts godoc
@@ -278,7 +320,7 @@ func (h *ProductHandler) GetActiveProducts(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, products)
+	respondWithCosts(c, http.StatusOK, products)
 }
 
 // GetInStockProducts godoc
@@ -297,7 +339,7 @@ func (h *ProductHandler) GetInStockProducts(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, products)
+	respondWithCosts(c, http.StatusOK, products)
 }
 
 // UpdateStock godoc
@@ -328,15 +370,117 @@ func (h *ProductHandler) UpdateStock(c *gin.Context) {
 
 	product, err := h.service.UpdateStock(id, req.Quantidade)
 	if err != nil {
-		if err.Error() == "produto não encontrado" {
-			h.handleError(c, http.StatusNotFound, "PRODUCT_NOT_FOUND", "Produto não encontrado")
-		} else {
-			h.handleError(c, http.StatusBadRequest, "UPDATE_ERROR", err.Error())
-		}
+		respondDomainError(c, err, "UPDATE_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, product)
+}
+
+// AdjustStock godoc
+// @Summary Ajustar estoque do produto
+// @Description Incrementa ou decrementa o estoque de forma atômica, rejeitando resultados negativos
+// @Tags produtos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param ajuste body dtos.StockAdjustmentRequest true "Variação de estoque"
+// @Success 200 {object} dtos.ProductResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/{id}/estoque/ajuste [patch]
+func (h *ProductHandler) AdjustStock(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	var req dtos.StockAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleValidationError(c, err)
+		return
+	}
+
+	product, err := h.service.AdjustStock(id, &req)
+	if err != nil {
+		h.handleStockError(c, err)
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, product)
+}
+
+// AdjustStockBatch godoc
+// @Summary Ajustar estoque em lote
+// @Description Aplica vários ajustes de estoque atomicamente: ou todos são aplicados ou nenhum
+// @Tags produtos
+// @Accept json
+// @Produce json
+// @Param ajustes body dtos.StockAdjustmentBatchRequest true "Lista de ajustes"
+// @Success 200 {object} dtos.StockAdjustmentBatchResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/estoque/ajustes [post]
+func (h *ProductHandler) AdjustStockBatch(c *gin.Context) {
+	var req dtos.StockAdjustmentBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleValidationError(c, err)
+		return
+	}
+
+	result, err := h.service.AdjustStockBatch(&req)
+	if err != nil {
+		h.handleStockError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, product)
+	respondWithCosts(c, http.StatusOK, result)
+}
+
+// GetReorderList godoc
+// @Summary Listar produtos para reposição
+// @Description Retorna produtos ativos com disponível igual ou abaixo do estoque mínimo e a quantidade sugerida para compra
+// @Tags estoque
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.ReorderListResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/produtos/reposicao [get]
+func (h *ProductHandler) GetReorderList(c *gin.Context) {
+	result, err := h.service.GetReorderList()
+	if err != nil {
+		h.handleError(c, http.StatusInternalServerError, "FETCH_ERROR", "Erro ao buscar produtos para reposição")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetStockAlerts godoc
+// @Summary Listar alertas de estoque
+// @Description Retorna os eventos emitidos quando o disponível cruza o ponto de reposição
+// @Tags estoque
+// @Accept json
+// @Produce json
+// @Param limite query int false "Quantidade máxima de alertas" default(50)
+// @Success 200 {object} dtos.StockAlertListResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/produtos/reposicao/alertas [get]
+func (h *ProductHandler) GetStockAlerts(c *gin.Context) {
+	limite, _ := strconv.Atoi(c.DefaultQuery("limite", "50"))
+
+	result, err := h.service.GetStockAlerts(limite)
+	if err != nil {
+		h.handleError(c, http.StatusInternalServerError, "FETCH_ERROR", "Erro ao buscar alertas de estoque")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
 }
 
 // GetStatistics godoc
@@ -355,7 +499,7 @@ func (h *ProductHandler) GetStatistics(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, stats)
+	respondWithCosts(c, http.StatusOK, stats)
 }
 
 // Métodos auxiliares privados
@@ -387,24 +531,13 @@ func (h *ProductHandler) isValidCategory(categoria models.ProductCategory) bool
 }
 
 func (h *ProductHandler) handleError(c *gin.Context, statusCode int, codigo string, mensagem string) {
-	c.JSON(statusCode, dtos.ErrorResponse{
-		Erro:      mensagem,
-		Codigo:    codigo,
-		Timestamp: time.Now(),
-	})
+	respondError(c, statusCode, codigo, mensagem)
+}
+
+func (h *ProductHandler) handleStockError(c *gin.Context, err error) {
+	respondDomainError(c, err, "STOCK_ADJUSTMENT_ERROR")
 }
 
 func (h *ProductHandler) handleValidationError(c *gin.Context, err error) {
-	c.JSON(http.StatusUnprocessableEntity, dtos.ValidationErrorResponse{
-		Erro:      "Dados inválidos",
-		Codigo:    "VALIDATION_ERROR",
-		Timestamp: time.Now(),
-		Campos:    []dtos.ValidationError{
-			{
-				Campo: "request_body",
-				Erro:  err.Error(),
-				Tag:   "binding",
-			},
-		},
-	})
-}
\ No newline at end of file
+	respondValidationError(c, err)
+}
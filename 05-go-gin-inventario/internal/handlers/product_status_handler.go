@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventario-api/internal/dtos"
+)
+
+// ChangeProductStatus godoc
+// @Summary Alterar situação do produto
+// @Description Aplica uma transição do ciclo de vida (rascunho, em_revisao, ativo, descontinuado, bloqueado, inativo). Transições não permitidas retornam 409; ao deixar de ser vendável, as reservas ativas são liberadas
+// @Tags ciclo-de-vida
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param transicao body dtos.StatusTransitionRequest true "Nova situação e motivo"
+// @Success 200 {object} dtos.StatusTransitionResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/{id}/status [post]
+func (h *ProductHandler) ChangeProductStatus(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	var req dtos.StatusTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleValidationError(c, err)
+		return
+	}
+
+	result, err := h.service.ChangeProductStatus(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "STATUS_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetProductStatus godoc
+// @Summary Consultar situação do produto
+// @Description Retorna a situação atual, se permite venda e reposição, as transições permitidas e o histórico de mudanças
+// @Tags ciclo-de-vida
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Success 200 {object} dtos.ProductStatusResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/status [get]
+func (h *ProductHandler) GetProductStatus(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	status, err := h.service.GetProductStatus(id)
+	if err != nil {
+		respondDomainError(c, err, "STATUS_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
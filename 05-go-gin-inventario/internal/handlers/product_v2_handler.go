@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/dtosv2"
+	"inventario-api/internal/service"
+)
+
+// productsV2Path é a coleção de produtos da v2
+const productsV2Path = "/api/v2/produtos"
+
+// ProductV2Handler gerencia os endpoints de produtos da versão 2 da API. A
+// lógica de negócio é a mesma da v1; muda apenas o formato das respostas.
+type ProductV2Handler struct {
+	service *service.ProductService
+}
+
+// NewProductV2Handler cria uma nova instância do handler
+func NewProductV2Handler(service *service.ProductService) *ProductV2Handler {
+	return &ProductV2Handler{
+		service: service,
+	}
+}
+
+// ListProducts godoc
+// @Summary Listar produtos (v2)
+// @Description Lista produtos com os mesmos filtros de /api/produtos/filtros, paginados, com links para as páginas vizinhas. Também atende GET /api/produtos com Accept application/vnd.inventario.v2+json
+// @Tags produtos-v2
+// @ID listProductsV2
+// @Produce application/vnd.inventario.v2+json
+// @Param categoria query string false "Categoria do produto" Enums(eletronicos,roupas,casa,livros,esportes,beleza,brinquedos,automotivo,alimentos,outros)
+// @Param preco_minimo query number false "Preço mínimo"
+// @Param preco_maximo query number false "Preço máximo"
+// @Param apenas_ativos query boolean false "Apenas produtos ativos"
+// @Param apenas_estoque query boolean false "Apenas produtos em estoque"
+// @Param status query string false "Situação do ciclo de vida" Enums(rascunho,em_revisao,ativo,descontinuado,bloqueado,inativo)
+// @Param tags query string false "Tags separadas por vírgula"
+// @Param tags_modo query string false "Combinação das tags" Enums(qualquer,todas) default(qualquer)
+// @Param nome query string false "Busca por nome ou descrição no idioma da resposta"
+// @Param lang query string false "Idioma da resposta e da busca (tem prioridade sobre Accept-Language)" Enums(pt-BR,es,en)
+// @Param Accept-Language header string false "Idiomas aceitos" default(pt-BR)
+// @Param page query int false "Número da página" default(1)
+// @Param size query int false "Itens por página" default(10) maximum(100)
+// @Success 200 {object} dtosv2.ProductListEnvelope
+// @Failure 400 {object} dtosv2.ErrorEnvelope
+// @Failure 406 {object} dtosv2.ErrorEnvelope
+// @Failure 500 {object} dtosv2.ErrorEnvelope
+// @Router /api/v2/produtos [get]
+func (h *ProductV2Handler) ListProducts(c *gin.Context) {
+	filters, err := parseProductFilters(c)
+	if err != nil {
+		respondErrorV2(c, http.StatusBadRequest, "INVALID_PARAMETER", err.Error(), nil)
+		return
+	}
+
+	products, err := h.service.GetProductsFiltered(filters.categoria, filters.precoMin, filters.precoMax, filters.apenasAtivos, filters.apenasEstoque, filters.status, filters.tags, filters.tagsModo, filters.nome, filters.idioma, filters.page, filters.size)
+	if err != nil {
+		respondErrorV2(c, http.StatusInternalServerError, "FETCH_ERROR", "Erro ao buscar produtos", nil)
+		return
+	}
+	prepareResponse(c, products)
+
+	paginacao := products.Paginacao
+	meta := dtosv2.ListMeta{
+		Meta: metaV2(c),
+		Paginacao: dtosv2.Pagination{
+			Pagina:         paginacao.PaginaAtual,
+			ItensPorPagina: paginacao.ItensPorPagina,
+			TotalItens:     paginacao.TotalItens,
+			TotalPaginas:   paginacao.TotalPaginas,
+		},
+	}
+	links := dtosv2.Links{
+		Self:     pageLink(c, paginacao.PaginaAtual, paginacao.ItensPorPagina),
+		Primeira: pageLink(c, 1, paginacao.ItensPorPagina),
+	}
+	if paginacao.TotalPaginas > 0 {
+		links.Ultima = pageLink(c, paginacao.TotalPaginas, paginacao.ItensPorPagina)
+	}
+	if paginacao.TemAnterior {
+		links.Anterior = pageLink(c, paginacao.PaginaAtual-1, paginacao.ItensPorPagina)
+	}
+	if paginacao.TemProxima {
+		links.Proxima = pageLink(c, paginacao.PaginaAtual+1, paginacao.ItensPorPagina)
+	}
+
+	respondV2(c, http.StatusOK, dtosv2.ProductListEnvelope{
+		Dados: dtosv2.FromProducts(products.Produtos),
+		Meta:  meta,
+		Links: links,
+	})
+}
+
+// GetProduct godoc
+// @Summary Buscar produto por ID (v2)
+// @Description Retorna um produto no envelope da v2
+// @Tags produtos-v2
+// @ID getProductV2
+// @Produce application/vnd.inventario.v2+json
+// @Param id path string true "ID do produto"
+// @Param incluir query string false "Dados adicionais" Enums(relacionados)
+// @Param lang query string false "Idioma da resposta (tem prioridade sobre Accept-Language)" Enums(pt-BR,es,en)
+// @Success 200 {object} dtosv2.ProductEnvelope
+// @Failure 400 {object} dtosv2.ErrorEnvelope
+// @Failure 404 {object} dtosv2.ErrorEnvelope
+// @Failure 406 {object} dtosv2.ErrorEnvelope
+// @Router /api/v2/produtos/{id} [get]
+func (h *ProductV2Handler) GetProduct(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var product *dtos.ProductResponse
+	var err error
+	if c.Query("incluir") == "relacionados" {
+		product, err = h.service.GetProductWithRelations(id)
+	} else {
+		product, err = h.service.GetProductByID(id)
+	}
+	if err != nil {
+		respondDomainErrorV2(c, err, "FETCH_ERROR")
+		return
+	}
+
+	h.respondProduct(c, http.StatusOK, product)
+}
+
+// CreateProduct godoc
+// @Summary Criar produto (v2)
+// @Description Cria um produto com o mesmo corpo da v1 e responde no envelope da v2, com o cabeçalho Location
+// @Tags produtos-v2
+// @ID createProductV2
+// @Accept json
+// @Produce application/vnd.inventario.v2+json
+// @Param produto body dtos.CreateProductRequest true "Dados do produto"
+// @Success 201 {object} dtosv2.ProductEnvelope
+// @Header 201 {string} Location "Caminho do produto criado"
+// @Failure 400 {object} dtosv2.ErrorEnvelope
+// @Failure 406 {object} dtosv2.ErrorEnvelope
+// @Failure 409 {object} dtosv2.ErrorEnvelope
+// @Failure 422 {object} dtosv2.ErrorEnvelope
+// @Router /api/v2/produtos [post]
+func (h *ProductV2Handler) CreateProduct(c *gin.Context) {
+	var req dtos.CreateProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationErrorV2(c, err, req)
+		return
+	}
+
+	product, err := h.service.CreateProduct(&req)
+	if err != nil {
+		respondDomainErrorV2(c, err, "CREATION_ERROR")
+		return
+	}
+
+	c.Header("Location", dtosv2.ProductPath(product.ID))
+	h.respondProduct(c, http.StatusCreated, product)
+}
+
+// UpdateProduct godoc
+// @Summary Substituir produto (v2)
+// @Description Substitui a representação completa do produto com o mesmo corpo da v1 e responde no envelope da v2
+// @Tags produtos-v2
+// @ID updateProductV2
+// @Accept json
+// @Produce application/vnd.inventario.v2+json
+// @Param id path string true "ID do produto"
+// @Param produto body dtos.ReplaceProductRequest true "Representação completa do produto"
+// @Success 200 {object} dtosv2.ProductEnvelope
+// @Failure 400 {object} dtosv2.ErrorEnvelope
+// @Failure 404 {object} dtosv2.ErrorEnvelope
+// @Failure 406 {object} dtosv2.ErrorEnvelope
+// @Failure 409 {object} dtosv2.ErrorEnvelope
+// @Failure 422 {object} dtosv2.ErrorEnvelope
+// @Router /api/v2/produtos/{id} [put]
+func (h *ProductV2Handler) UpdateProduct(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dtos.ReplaceProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationErrorV2(c, err, req)
+		return
+	}
+
+	product, err := h.service.ReplaceProduct(id, &req)
+	if err != nil {
+		respondDomainErrorV2(c, err, "UPDATE_ERROR")
+		return
+	}
+
+	h.respondProduct(c, http.StatusOK, product)
+}
+
+// DeleteProduct godoc
+// @Summary Deletar produto (v2)
+// @Description Remove um produto do inventário
+// @Tags produtos-v2
+// @ID deleteProductV2
+// @Produce application/vnd.inventario.v2+json
+// @Param id path string true "ID do produto"
+// @Success 204 "Produto deletado com sucesso"
+// @Failure 400 {object} dtosv2.ErrorEnvelope
+// @Failure 404 {object} dtosv2.ErrorEnvelope
+// @Failure 406 {object} dtosv2.ErrorEnvelope
+// @Failure 409 {object} dtosv2.ErrorEnvelope
+// @Router /api/v2/produtos/{id} [delete]
+func (h *ProductV2Handler) DeleteProduct(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteProduct(id); err != nil {
+		respondDomainErrorV2(c, err, "DELETE_ERROR")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Métodos auxiliares privados
+
+func (h *ProductV2Handler) parseID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondErrorV2(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *ProductV2Handler) respondProduct(c *gin.Context, statusCode int, product *dtos.ProductResponse) {
+	prepareResponse(c, product)
+	data := dtosv2.FromProduct(*product)
+	respondV2(c, statusCode, dtosv2.ProductEnvelope{
+		Dados: data,
+		Meta:  metaV2(c),
+		Links: data.Links,
+	})
+}
+
+// pageLink monta o link de uma página mantendo os filtros da requisição
+func pageLink(c *gin.Context, page, size int) string {
+	query := url.Values{}
+	for key, values := range c.Request.URL.Query() {
+		query[key] = values
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("size", strconv.Itoa(size))
+	return productsV2Path + "?" + query.Encode()
+}
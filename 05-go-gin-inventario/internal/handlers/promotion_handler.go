@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/service"
+)
+
+// PromotionHandler gerencia os endpoints de promoções e regras de desconto
+type PromotionHandler struct {
+	service *service.PromotionService
+}
+
+// NewPromotionHandler cria uma nova instância do handler
+func NewPromotionHandler(service *service.PromotionService) *PromotionHandler {
+	return &PromotionHandler{
+		service: service,
+	}
+}
+
+// CreatePromotion godoc
+// @Summary Criar promoção
+// @Description Cadastra uma promoção percentual ou de valor fixo para produtos, categorias ou filtro, com vigência, prioridade e regra de acúmulo
+// @Tags promocoes
+// @Accept json
+// @Produce json
+// @Param promocao body dtos.PromotionRequest true "Dados da promoção"
+// @Success 201 {object} dtos.PromotionResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/promocoes [post]
+func (h *PromotionHandler) CreatePromotion(c *gin.Context) {
+	var req dtos.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	promotion, err := h.service.CreatePromotion(&req)
+	if err != nil {
+		respondDomainError(c, err, "CREATE_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusCreated, promotion)
+}
+
+// ListPromotions godoc
+// @Summary Listar promoções
+// @Description Lista as promoções cadastradas por início de vigência, opcionalmente apenas as vigentes
+// @Tags promocoes
+// @Accept json
+// @Produce json
+// @Param vigentes query bool false "Apenas promoções vigentes agora"
+// @Success 200 {object} dtos.PromotionListResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/promocoes [get]
+func (h *PromotionHandler) ListPromotions(c *gin.Context) {
+	vigentes, err := strconv.ParseBool(c.DefaultQuery("vigentes", "false"))
+	if err != nil {
+		vigentes = false
+	}
+
+	promotions, err := h.service.ListPromotions(vigentes)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "FETCH_ERROR", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, promotions)
+}
+
+// GetPromotion godoc
+// @Summary Buscar promoção
+// @Description Retorna uma promoção pelo ID
+// @Tags promocoes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da promoção"
+// @Success 200 {object} dtos.PromotionResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/promocoes/{id} [get]
+func (h *PromotionHandler) GetPromotion(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da promoção inválido")
+		return
+	}
+
+	promotion, err := h.service.GetPromotion(id)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, promotion)
+}
+
+// UpdatePromotion godoc
+// @Summary Atualizar promoção
+// @Description Substitui todos os dados de uma promoção
+// @Tags promocoes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da promoção"
+// @Param promocao body dtos.PromotionRequest true "Dados da promoção"
+// @Success 200 {object} dtos.PromotionResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/promocoes/{id} [put]
+func (h *PromotionHandler) UpdatePromotion(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da promoção inválido")
+		return
+	}
+
+	var req dtos.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	promotion, err := h.service.UpdatePromotion(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "UPDATE_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, promotion)
+}
+
+// DeletePromotion godoc
+// @Summary Deletar promoção
+// @Description Remove uma promoção; os preços voltam a ser calculados sem ela
+// @Tags promocoes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da promoção"
+// @Success 204 "Promoção deletada com sucesso"
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/promocoes/{id} [delete]
+func (h *PromotionHandler) DeletePromotion(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da promoção inválido")
+		return
+	}
+
+	if err := h.service.DeletePromotion(id); err != nil {
+		respondDomainError(c, err, "DELETE_ERROR")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SimulatePromotion godoc
+// @Summary Simular promoção
+// @Description Lista os produtos que seriam afetados pela promoção e o preço final de cada um, considerando as promoções já cadastradas, sem gravar nada
+// @Tags promocoes
+// @Accept json
+// @Produce json
+// @Param promocao body dtos.PromotionRequest true "Dados da promoção"
+// @Success 200 {object} dtos.PromotionSimulationResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/promocoes/simulacao [post]
+func (h *PromotionHandler) SimulatePromotion(c *gin.Context) {
+	var req dtos.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	simulation, err := h.service.SimulatePromotion(&req)
+	if err != nil {
+		respondDomainError(c, err, "SIMULATION_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, simulation)
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/service"
+)
+
+// PurchaseOrderHandler gerencia os endpoints de pedidos de compra e recebimento
+type PurchaseOrderHandler struct {
+	service *service.PurchaseOrderService
+}
+
+// NewPurchaseOrderHandler cria uma nova instância do handler
+func NewPurchaseOrderHandler(service *service.PurchaseOrderService) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{
+		service: service,
+	}
+}
+
+// CreatePurchaseOrder godoc
+// @Summary Criar pedido de compra
+// @Description Cria um pedido de compra em rascunho. Itens sem custo usam o último custo do fornecedor ou o preço de custo do produto.
+// @Tags pedidos-compra
+// @Accept json
+// @Produce json
+// @Param pedido body dtos.PurchaseOrderRequest true "Fornecedor e itens do pedido"
+// @Success 201 {object} dtos.PurchaseOrderResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/pedidos-compra [post]
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(c *gin.Context) {
+	var req dtos.PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	order, err := h.service.CreatePurchaseOrder(&req)
+	if err != nil {
+		respondDomainError(c, err, "CREATE_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// ListPurchaseOrders godoc
+// @Summary Listar pedidos de compra
+// @Description Lista pedidos de compra, dos mais recentes para os mais antigos, filtrando opcionalmente por fornecedor, produto e status
+// @Tags pedidos-compra
+// @Accept json
+// @Produce json
+// @Param fornecedor_id query string false "ID do fornecedor"
+// @Param produto_id query string false "ID do produto"
+// @Param status query string false "Status do pedido" Enums(rascunho,enviado,parcialmente_recebido,recebido,cancelado)
+// @Success 200 {object} dtos.PurchaseOrderListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/pedidos-compra [get]
+func (h *PurchaseOrderHandler) ListPurchaseOrders(c *gin.Context) {
+	var filter database.PurchaseOrderFilter
+
+	if fornecedorStr := c.Query("fornecedor_id"); fornecedorStr != "" {
+		fornecedorID, err := uuid.Parse(fornecedorStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do fornecedor inválido")
+			return
+		}
+		filter.FornecedorID = &fornecedorID
+	}
+
+	if produtoStr := c.Query("produto_id"); produtoStr != "" {
+		produtoID, err := uuid.Parse(produtoStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+			return
+		}
+		filter.ProdutoID = &produtoID
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := models.PurchaseOrderStatus(statusStr)
+		filter.Status = &status
+	}
+
+	orders, err := h.service.ListPurchaseOrders(filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "FETCH_ERROR", "Erro ao buscar pedidos de compra")
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+// GetPurchaseOrder godoc
+// @Summary Buscar pedido de compra
+// @Description Retorna um pedido de compra com itens, saldos pendentes e recebimentos
+// @Tags pedidos-compra
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do pedido de compra"
+// @Success 200 {object} dtos.PurchaseOrderResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/pedidos-compra/{id} [get]
+func (h *PurchaseOrderHandler) GetPurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do pedido de compra inválido")
+		return
+	}
+
+	order, err := h.service.GetPurchaseOrder(id)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// UpdatePurchaseOrder godoc
+// @Summary Atualizar pedido de compra
+// @Description Substitui fornecedor, itens e observações de um pedido ainda em rascunho
+// @Tags pedidos-compra
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do pedido de compra"
+// @Param pedido body dtos.PurchaseOrderRequest true "Fornecedor e itens do pedido"
+// @Success 200 {object} dtos.PurchaseOrderResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/pedidos-compra/{id} [put]
+func (h *PurchaseOrderHandler) UpdatePurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do pedido de compra inválido")
+		return
+	}
+
+	var req dtos.PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	order, err := h.service.UpdatePurchaseOrder(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "UPDATE_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// SendPurchaseOrder godoc
+// @Summary Enviar pedido de compra
+// @Description Marca o pedido em rascunho como enviado ao fornecedor; sem previsão de entrega, ela é calculada pelo maior prazo de entrega dos produtos
+// @Tags pedidos-compra
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do pedido de compra"
+// @Success 200 {object} dtos.PurchaseOrderResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/pedidos-compra/{id}/enviar [post]
+func (h *PurchaseOrderHandler) SendPurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do pedido de compra inválido")
+		return
+	}
+
+	order, err := h.service.SendPurchaseOrder(id)
+	if err != nil {
+		respondDomainError(c, err, "SEND_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// CancelPurchaseOrder godoc
+// @Summary Cancelar pedido de compra
+// @Description Cancela um pedido não concluído; quantidades já recebidas permanecem no estoque
+// @Tags pedidos-compra
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do pedido de compra"
+// @Param cancelamento body dtos.CancelPurchaseOrderRequest false "Motivo do cancelamento"
+// @Success 200 {object} dtos.PurchaseOrderResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/pedidos-compra/{id}/cancelar [post]
+func (h *PurchaseOrderHandler) CancelPurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do pedido de compra inválido")
+		return
+	}
+
+	// O corpo é opcional: sem ele o pedido é cancelado sem motivo
+	var req dtos.CancelPurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondValidationError(c, err)
+		return
+	}
+
+	order, err := h.service.CancelPurchaseOrder(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "CANCEL_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// ReceivePurchaseOrder godoc
+// @Summary Receber pedido de compra
+// @Description Registra o recebimento total ou parcial de itens de um pedido enviado, dando entrada no estoque e registrando o custo de cada item. Produtos controlados por lote exigem o lote e produtos serializados exigem os números de série. A operação é atômica.
+// @Tags pedidos-compra
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do pedido de compra"
+// @Param recebimento body dtos.PurchaseReceiptRequest true "Itens recebidos"
+// @Success 200 {object} dtos.PurchaseOrderResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/pedidos-compra/{id}/recebimentos [post]
+func (h *PurchaseOrderHandler) ReceivePurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do pedido de compra inválido")
+		return
+	}
+
+	var req dtos.PurchaseReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	order, err := h.service.ReceivePurchaseOrder(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "RECEIVE_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+)
+
+// CreateProductRelation godoc
+// @Summary Relacionar produtos
+// @Description Relaciona o produto a outro como substituto, acessório ou upsell. Substitutos valem nos dois sentidos
+// @Tags relacionados
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param relacao body dtos.CreateRelationRequest true "Produto relacionado e tipo"
+// @Success 201 {object} dtos.RelatedProductResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/{id}/relacionados [post]
+func (h *ProductHandler) CreateProductRelation(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	var req dtos.CreateRelationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleValidationError(c, err)
+		return
+	}
+
+	related, err := h.service.CreateProductRelation(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "RELATION_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusCreated, related)
+}
+
+// GetProductRelations godoc
+// @Summary Listar produtos relacionados
+// @Description Retorna os produtos relacionados com preço e disponível, agrupados por tipo
+// @Tags relacionados
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param tipo query string false "Filtrar por tipo" Enums(substituto,acessorio,upsell)
+// @Success 200 {object} dtos.RelationListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/relacionados [get]
+func (h *ProductHandler) GetProductRelations(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	tipo, ok := h.parseRelationType(c)
+	if !ok {
+		return
+	}
+
+	relations, err := h.service.GetProductRelations(id, tipo)
+	if err != nil {
+		respondDomainError(c, err, "RELATION_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, relations)
+}
+
+// DeleteProductRelation godoc
+// @Summary Remover relação entre produtos
+// @Description Remove a relação do tipo informado ou, sem tipo, todas as relações com o produto relacionado
+// @Tags relacionados
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param relacionado path string true "ID do produto relacionado"
+// @Param tipo query string false "Tipo da relação" Enums(substituto,acessorio,upsell)
+// @Success 204 "Relação removida com sucesso"
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/relacionados/{relacionado} [delete]
+func (h *ProductHandler) DeleteProductRelation(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	relatedID, err := h.parseUUID(c.Param("relacionado"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto relacionado inválido")
+		return
+	}
+
+	tipo, ok := h.parseRelationType(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteProductRelation(id, relatedID, tipo); err != nil {
+		respondDomainError(c, err, "RELATION_ERROR")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetProductAlternatives godoc
+// @Summary Alternativas em estoque
+// @Description Retorna os substitutos ativos com disponível em estoque, do preço mais próximo ao mais distante do produto
+// @Tags relacionados
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Success 200 {object} dtos.AlternativesResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/alternativas [get]
+func (h *ProductHandler) GetProductAlternatives(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	alternatives, err := h.service.GetProductAlternatives(id)
+	if err != nil {
+		respondDomainError(c, err, "RELATION_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, alternatives)
+}
+
+// parseRelationType lê o filtro opcional de tipo de relação, respondendo 400
+// quando o tipo é desconhecido
+func (h *ProductHandler) parseRelationType(c *gin.Context) (*models.RelationType, bool) {
+	value := c.Query("tipo")
+	if value == "" {
+		return nil, true
+	}
+
+	tipo := models.RelationType(value)
+	if !tipo.IsValid() {
+		h.handleError(c, http.StatusBadRequest, "INVALID_PARAMETER", "Tipo deve ser substituto, acessorio ou upsell")
+		return nil, false
+	}
+	return &tipo, true
+}
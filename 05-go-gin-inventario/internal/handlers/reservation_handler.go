@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/service"
+)
+
+// ReservationHandler gerencia os endpoints de reservas de estoque
+type ReservationHandler struct {
+	service *service.ReservationService
+}
+
+// NewReservationHandler cria uma nova instância do handler
+func NewReservationHandler(service *service.ReservationService) *ReservationHandler {
+	return &ReservationHandler{
+		service: service,
+	}
+}
+
+// CreateReservation godoc
+// @Summary Criar reserva de estoque
+// @Description Retém unidades de um produto por um tempo limitado sem alterar o estoque físico
+// @Tags reservas
+// @Accept json
+// @Produce json
+// @Param reserva body dtos.CreateReservationRequest true "Dados da reserva"
+// @Success 201 {object} dtos.ReservationResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/reservas [post]
+func (h *ReservationHandler) CreateReservation(c *gin.Context) {
+	var req dtos.CreateReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	reservation, err := h.service.CreateReservation(&req)
+	if err != nil {
+		respondDomainError(c, err, "RESERVATION_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusCreated, reservation)
+}
+
+// ListReservations godoc
+// @Summary Listar reservas
+// @Description Lista reservas filtrando opcionalmente por produto, status e referência
+// @Tags reservas
+// @Accept json
+// @Produce json
+// @Param produto_id query string false "ID do produto"
+// @Param status query string false "Status da reserva" Enums(ativa,confirmada,liberada,expirada)
+// @Param referencia query string false "Referência do solicitante"
+// @Success 200 {object} dtos.ReservationListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/reservas [get]
+func (h *ReservationHandler) ListReservations(c *gin.Context) {
+	var filter database.ReservationFilter
+
+	if produtoStr := c.Query("produto_id"); produtoStr != "" {
+		produtoID, err := uuid.Parse(produtoStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+			return
+		}
+		filter.ProdutoID = &produtoID
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := models.ReservationStatus(statusStr)
+		filter.Status = &status
+	}
+
+	if referencia := c.Query("referencia"); referencia != "" {
+		filter.Referencia = &referencia
+	}
+
+	reservations, err := h.service.ListReservations(filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "FETCH_ERROR", "Erro ao buscar reservas")
+		return
+	}
+
+	c.JSON(http.StatusOK, reservations)
+}
+
+// GetReservation godoc
+// @Summary Buscar reserva por ID
+// @Description Retorna uma reserva específica pelo seu ID
+// @Tags reservas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da reserva"
+// @Success 200 {object} dtos.ReservationResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/reservas/{id} [get]
+func (h *ReservationHandler) GetReservation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da reserva inválido")
+		return
+	}
+
+	reservation, err := h.service.GetReservation(id)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+// ConfirmReservation godoc
+// @Summary Confirmar reserva
+// @Description Efetiva a reserva, baixando as unidades reservadas do estoque físico
+// @Tags reservas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da reserva"
+// @Success 200 {object} dtos.ReservationResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/reservas/{id}/confirmar [post]
+func (h *ReservationHandler) ConfirmReservation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da reserva inválido")
+		return
+	}
+
+	reservation, err := h.service.ConfirmReservation(id)
+	if err != nil {
+		respondDomainError(c, err, "RESERVATION_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+// ReleaseReservation godoc
+// @Summary Liberar reserva
+// @Description Cancela a reserva, devolvendo as unidades ao disponível para venda
+// @Tags reservas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da reserva"
+// @Success 200 {object} dtos.ReservationResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/reservas/{id}/liberar [post]
+func (h *ReservationHandler) ReleaseReservation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da reserva inválido")
+		return
+	}
+
+	reservation, err := h.service.ReleaseReservation(id)
+	if err != nil {
+		respondDomainError(c, err, "RESERVATION_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
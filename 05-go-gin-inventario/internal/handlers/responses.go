@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/middleware"
+	"inventario-api/internal/models"
+)
+
+// costRedactor é implementado pelas respostas que carregam dados de custo
+type costRedactor interface {
+	HideCosts()
+}
+
+// localizer é implementado pelas respostas com textos traduzíveis de produtos
+type localizer interface {
+	Localize(locale models.Locale)
+}
+
+// respondWithCosts escreve a resposta omitindo custos e margens quando a
+// requisição não tem acesso financeiro e, nas respostas com produtos, exibe
+// nomes e descrições no idioma solicitado
+func respondWithCosts(c *gin.Context, statusCode int, payload costRedactor) {
+	prepareResponse(c, payload)
+	c.JSON(statusCode, payload)
+}
+
+// prepareResponse aplica à resposta as regras de acesso financeiro e de
+// idioma da requisição, sem escrevê-la
+func prepareResponse(c *gin.Context, payload costRedactor) {
+	if !middleware.HasFinancialAccess(c) {
+		payload.HideCosts()
+	}
+	if translatable, ok := payload.(localizer); ok {
+		locale := requestLocale(c)
+		translatable.Localize(locale)
+		c.Header("Content-Language", string(locale))
+		c.Writer.Header().Add("Vary", "Accept-Language")
+	}
+}
+
+// requestLocale retorna o idioma da resposta, escolhido pelo parâmetro lang
+// ou pelo cabeçalho Accept-Language, com pt-BR como padrão
+func requestLocale(c *gin.Context) models.Locale {
+	return models.NegotiateLocale(c.Query("lang"), c.GetHeader("Accept-Language"))
+}
+
+// respondError escreve uma resposta de erro padronizada
+func respondError(c *gin.Context, statusCode int, codigo string, mensagem string) {
+	c.JSON(statusCode, dtos.ErrorResponse{
+		Erro:      mensagem,
+		Codigo:    codigo,
+		Timestamp: time.Now(),
+	})
+}
+
+// respondValidationError escreve uma resposta de erro de binding/validação
+func respondValidationError(c *gin.Context, err error) {
+	c.JSON(http.StatusUnprocessableEntity, dtos.ValidationErrorResponse{
+		Erro:      "Dados inválidos",
+		Codigo:    "VALIDATION_ERROR",
+		Timestamp: time.Now(),
+		Campos: []dtos.ValidationError{
+			{
+				Campo: "request_body",
+				Erro:  err.Error(),
+				Tag:   "binding",
+			},
+		},
+	})
+}
+
+// respondDomainError traduz erros conhecidos do domínio em status HTTP e
+// códigos específicos; erros não mapeados usam 400 com o código informado
+func respondDomainError(c *gin.Context, err error, fallbackCodigo string) {
+	statusCode, codigo := domainErrorCode(err, fallbackCodigo)
+	respondError(c, statusCode, codigo, err.Error())
+}
+
+// domainErrorCode retorna o status HTTP e o código de um erro do domínio;
+// erros não mapeados usam 400 com o código informado
+func domainErrorCode(err error, fallbackCodigo string) (int, string) {
+	switch {
+	case errors.Is(err, database.ErrProductNotFound):
+		return http.StatusNotFound, "PRODUCT_NOT_FOUND"
+	case errors.Is(err, database.ErrProductInactive):
+		return http.StatusConflict, "PRODUCT_INACTIVE"
+	case errors.Is(err, database.ErrProductStatus):
+		return http.StatusConflict, "INVALID_STATUS_TRANSITION"
+	case errors.Is(err, database.ErrRestockNotAllowed):
+		return http.StatusConflict, "RESTOCK_NOT_ALLOWED"
+	case errors.Is(err, database.ErrInsufficientStock):
+		return http.StatusConflict, "INSUFFICIENT_STOCK"
+	case errors.Is(err, database.ErrReservationNotFound):
+		return http.StatusNotFound, "RESERVATION_NOT_FOUND"
+	case errors.Is(err, database.ErrReservationNotActive):
+		return http.StatusConflict, "RESERVATION_NOT_ACTIVE"
+	case errors.Is(err, database.ErrLotRequired):
+		return http.StatusBadRequest, "LOT_REQUIRED"
+	case errors.Is(err, database.ErrLotNotSupported):
+		return http.StatusBadRequest, "LOT_NOT_SUPPORTED"
+	case errors.Is(err, database.ErrLotDuplicate):
+		return http.StatusConflict, "LOT_ALREADY_EXISTS"
+	case errors.Is(err, database.ErrLotExpired):
+		return http.StatusConflict, "LOT_EXPIRED"
+	case errors.Is(err, database.ErrSerialRequired):
+		return http.StatusBadRequest, "SERIAL_REQUIRED"
+	case errors.Is(err, database.ErrSerialNotSupported):
+		return http.StatusBadRequest, "SERIAL_NOT_SUPPORTED"
+	case errors.Is(err, database.ErrSerialNotFound):
+		return http.StatusNotFound, "SERIAL_NOT_FOUND"
+	case errors.Is(err, database.ErrSerialConflict):
+		return http.StatusConflict, "SERIAL_CONFLICT"
+	case errors.Is(err, database.ErrKitInvalid):
+		return http.StatusBadRequest, "KIT_INVALID"
+	case errors.Is(err, database.ErrKitOperation):
+		return http.StatusBadRequest, "KIT_OPERATION_NOT_ALLOWED"
+	case errors.Is(err, database.ErrKitComponentInUse):
+		return http.StatusConflict, "KIT_COMPONENT_IN_USE"
+	case errors.Is(err, database.ErrScheduledPriceNotFound):
+		return http.StatusNotFound, "SCHEDULED_PRICE_NOT_FOUND"
+	case errors.Is(err, database.ErrScheduledPriceNotPending):
+		return http.StatusConflict, "SCHEDULED_PRICE_NOT_PENDING"
+	case errors.Is(err, database.ErrPromotionNotFound):
+		return http.StatusNotFound, "PROMOTION_NOT_FOUND"
+	case errors.Is(err, database.ErrPromotionInvalid):
+		return http.StatusBadRequest, "PROMOTION_INVALID"
+	case errors.Is(err, database.ErrSupplierNotFound):
+		return http.StatusNotFound, "SUPPLIER_NOT_FOUND"
+	case errors.Is(err, database.ErrSupplierDuplicate):
+		return http.StatusConflict, "SUPPLIER_ALREADY_EXISTS"
+	case errors.Is(err, database.ErrSupplierInactive):
+		return http.StatusConflict, "SUPPLIER_INACTIVE"
+	case errors.Is(err, database.ErrSupplierInUse):
+		return http.StatusConflict, "SUPPLIER_IN_USE"
+	case errors.Is(err, database.ErrPurchaseOrderNotFound):
+		return http.StatusNotFound, "PURCHASE_ORDER_NOT_FOUND"
+	case errors.Is(err, database.ErrPurchaseOrderInvalid):
+		return http.StatusBadRequest, "PURCHASE_ORDER_INVALID"
+	case errors.Is(err, database.ErrPurchaseOrderStatus):
+		return http.StatusConflict, "PURCHASE_ORDER_STATUS_CONFLICT"
+	case errors.Is(err, database.ErrProductInPurchase):
+		return http.StatusConflict, "PRODUCT_IN_PURCHASE_ORDER"
+	case errors.Is(err, database.ErrSalesOrderNotFound):
+		return http.StatusNotFound, "SALES_ORDER_NOT_FOUND"
+	case errors.Is(err, database.ErrSalesOrderDuplicate):
+		return http.StatusConflict, "SALES_ORDER_ALREADY_EXISTS"
+	case errors.Is(err, database.ErrSalesOrderStatus):
+		return http.StatusConflict, "SALES_ORDER_NOT_CONFIRMED"
+	case errors.Is(err, database.ErrSalesOrderRejected):
+		return http.StatusConflict, "SALES_ORDER_REJECTED"
+	case errors.Is(err, database.ErrSalesOrderReturned):
+		return http.StatusConflict, "SALES_ORDER_HAS_RETURNS"
+	case errors.Is(err, database.ErrReturnNotFound):
+		return http.StatusNotFound, "RETURN_NOT_FOUND"
+	case errors.Is(err, database.ErrReturnInvalid):
+		return http.StatusBadRequest, "RETURN_INVALID"
+	case errors.Is(err, database.ErrReturnStatus):
+		return http.StatusConflict, "RETURN_ALREADY_INSPECTED"
+	case errors.Is(err, database.ErrCountNotFound):
+		return http.StatusNotFound, "COUNT_NOT_FOUND"
+	case errors.Is(err, database.ErrCountInvalid):
+		return http.StatusBadRequest, "COUNT_INVALID"
+	case errors.Is(err, database.ErrCountStatus):
+		return http.StatusConflict, "COUNT_STATUS_CONFLICT"
+	case errors.Is(err, database.ErrCountIncomplete):
+		return http.StatusConflict, "COUNT_INCOMPLETE"
+	case errors.Is(err, database.ErrCountConflict):
+		return http.StatusConflict, "PRODUCT_IN_COUNT"
+	case errors.Is(err, database.ErrAttachmentNotFound):
+		return http.StatusNotFound, "ATTACHMENT_NOT_FOUND"
+	case errors.Is(err, database.ErrAttachmentInvalid):
+		return http.StatusBadRequest, "ATTACHMENT_INVALID"
+	case errors.Is(err, database.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge, "ATTACHMENT_TOO_LARGE"
+	case errors.Is(err, database.ErrAttachmentUnsupported):
+		return http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE"
+	case errors.Is(err, database.ErrTagInvalid):
+		return http.StatusBadRequest, "TAG_INVALID"
+	case errors.Is(err, database.ErrTagNotFound):
+		return http.StatusNotFound, "TAG_NOT_FOUND"
+	case errors.Is(err, database.ErrRelationInvalid):
+		return http.StatusBadRequest, "RELATION_INVALID"
+	case errors.Is(err, database.ErrRelationNotFound):
+		return http.StatusNotFound, "RELATION_NOT_FOUND"
+	case errors.Is(err, database.ErrRelationDuplicate):
+		return http.StatusConflict, "RELATION_ALREADY_EXISTS"
+	case errors.Is(err, database.ErrShippingInvalid):
+		return http.StatusBadRequest, "SHIPPING_INVALID"
+	case errors.Is(err, database.ErrShippingUnavailable):
+		return http.StatusUnprocessableEntity, "SHIPPING_UNAVAILABLE"
+	case errors.Is(err, database.ErrDimensionsMissing):
+		return http.StatusUnprocessableEntity, "DIMENSIONS_MISSING"
+	case errors.Is(err, database.ErrFiscalInvalid):
+		return http.StatusBadRequest, "FISCAL_INVALID"
+	case errors.Is(err, database.ErrFiscalMissing):
+		return http.StatusUnprocessableEntity, "FISCAL_DATA_MISSING"
+	case errors.Is(err, database.ErrTaxInvalid):
+		return http.StatusBadRequest, "TAX_SIMULATION_INVALID"
+	case errors.Is(err, database.ErrBarcodeInvalid):
+		return http.StatusBadRequest, "BARCODE_INVALID"
+	case errors.Is(err, database.ErrBarcodeDuplicate):
+		return http.StatusConflict, "BARCODE_ALREADY_EXISTS"
+	case errors.Is(err, database.ErrInvoiceNotFound):
+		return http.StatusNotFound, "INVOICE_NOT_FOUND"
+	case errors.Is(err, database.ErrInvoiceInvalid):
+		return http.StatusBadRequest, "INVOICE_INVALID"
+	case errors.Is(err, database.ErrInvoiceDuplicate):
+		return http.StatusConflict, "INVOICE_ALREADY_IMPORTED"
+	case errors.Is(err, database.ErrInvoiceStatus):
+		return http.StatusConflict, "INVOICE_NOT_PENDING"
+	case errors.Is(err, database.ErrInvoiceUnmapped):
+		return http.StatusUnprocessableEntity, "INVOICE_UNMAPPED_ITEMS"
+	case errors.Is(err, database.ErrTranslationInvalid):
+		return http.StatusBadRequest, "TRANSLATION_INVALID"
+	case errors.Is(err, database.ErrTranslationNotFound):
+		return http.StatusNotFound, "TRANSLATION_NOT_FOUND"
+	case errors.Is(err, database.ErrPatchInvalid):
+		return http.StatusBadRequest, "PATCH_INVALID"
+	case errors.Is(err, database.ErrPatchUnprocessable):
+		return http.StatusUnprocessableEntity, "PATCH_UNPROCESSABLE"
+	case errors.Is(err, database.ErrPatchTestFailed):
+		return http.StatusConflict, "PATCH_TEST_FAILED"
+	case errors.Is(err, database.ErrPatchUnsupported):
+		return http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE"
+	case errors.Is(err, database.ErrBulkInvalid):
+		return http.StatusBadRequest, "BULK_INVALID"
+	case errors.Is(err, database.ErrBulkDuplicate):
+		return http.StatusConflict, "BULK_DUPLICATE_PRODUCT"
+	case errors.Is(err, database.ErrBulkAborted):
+		return http.StatusFailedDependency, "BULK_ABORTED"
+	default:
+		return http.StatusBadRequest, fallbackCodigo
+	}
+}
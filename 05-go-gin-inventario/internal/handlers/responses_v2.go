@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"inventario-api/internal/dtosv2"
+	"inventario-api/internal/middleware"
+)
+
+// respondV2 escreve uma resposta da v2 com o tipo de mídia da versão
+func respondV2(c *gin.Context, statusCode int, payload interface{}) {
+	c.Header("Content-Type", middleware.MediaTypeV2+"; charset=utf-8")
+	c.JSON(statusCode, payload)
+}
+
+// metaV2 monta os metadados da resposta a partir da requisição
+func metaV2(c *gin.Context) dtosv2.Meta {
+	return dtosv2.NewMeta(c.GetString("RequestID"), requestLocale(c))
+}
+
+// respondErrorV2 escreve um erro no envelope da v2
+func respondErrorV2(c *gin.Context, statusCode int, codigo string, mensagem string, campos []dtosv2.FieldError) {
+	respondV2(c, statusCode, dtosv2.ErrorEnvelope{
+		Erro: dtosv2.ErrorBody{
+			Status:   statusCode,
+			Codigo:   codigo,
+			Mensagem: mensagem,
+			Campos:   campos,
+		},
+		Meta: metaV2(c),
+	})
+}
+
+// respondDomainErrorV2 traduz erros do domínio com o mesmo mapeamento da v1
+func respondDomainErrorV2(c *gin.Context, err error, fallbackCodigo string) {
+	statusCode, codigo := domainErrorCode(err, fallbackCodigo)
+	respondErrorV2(c, statusCode, codigo, err.Error(), nil)
+}
+
+// respondValidationErrorV2 lista cada campo rejeitado pelo binding com o
+// nome usado no JSON, a regra violada e uma mensagem legível
+func respondValidationErrorV2(c *gin.Context, err error, request interface{}) {
+	var campos []dtosv2.FieldError
+	var validationErrors validator.ValidationErrors
+	var typeError *json.UnmarshalTypeError
+	var syntaxError *json.SyntaxError
+
+	switch {
+	case errors.As(err, &validationErrors):
+		root := reflect.TypeOf(request)
+		for _, fieldError := range validationErrors {
+			campos = append(campos, dtosv2.FieldError{
+				Campo:     jsonFieldPath(root, fieldError.StructNamespace()),
+				Regra:     fieldError.Tag(),
+				Parametro: fieldError.Param(),
+				Mensagem:  validationMessage(fieldError),
+			})
+		}
+	case errors.As(err, &typeError):
+		campos = append(campos, dtosv2.FieldError{
+			Campo:    typeError.Field,
+			Regra:    "tipo",
+			Mensagem: fmt.Sprintf("deve ser do tipo %s", typeError.Type.Kind()),
+		})
+	case errors.As(err, &syntaxError):
+		respondErrorV2(c, http.StatusBadRequest, "INVALID_JSON", "Corpo da requisição não é um JSON válido", nil)
+		return
+	default:
+		respondErrorV2(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), nil)
+		return
+	}
+
+	respondErrorV2(c, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "Dados inválidos", campos)
+}
+
+// jsonFieldPath converte o caminho do validador (CreateProductRequest.Dimensoes.AlturaCm)
+// no caminho do JSON (dimensoes.altura_cm)
+func jsonFieldPath(root reflect.Type, namespace string) string {
+	parts := strings.Split(namespace, ".")
+	if len(parts) > 1 {
+		parts = parts[1:]
+	}
+
+	current := root
+	path := make([]string, 0, len(parts))
+	for _, part := range parts {
+		for current != nil && current.Kind() == reflect.Ptr {
+			current = current.Elem()
+		}
+		name, index, indexed := strings.Cut(part, "[")
+		if current == nil || current.Kind() != reflect.Struct {
+			path = append(path, part)
+			continue
+		}
+		field, ok := current.FieldByName(name)
+		if !ok {
+			path = append(path, part)
+			current = nil
+			continue
+		}
+
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && jsonName == "" {
+			// Campos de structs embutidas ficam no mesmo nível do JSON
+			current = field.Type
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		current = field.Type
+		if indexed {
+			jsonName += "[" + index
+			for current.Kind() == reflect.Ptr {
+				current = current.Elem()
+			}
+			if kind := current.Kind(); kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map {
+				current = current.Elem()
+			}
+		}
+		path = append(path, jsonName)
+	}
+	return strings.Join(path, ".")
+}
+
+// validationMessage descreve em português a regra violada
+func validationMessage(fieldError validator.FieldError) string {
+	param := fieldError.Param()
+	text := fieldError.Kind() == reflect.String
+	switch fieldError.Tag() {
+	case "required":
+		return "é obrigatório"
+	case "min":
+		if text {
+			return fmt.Sprintf("deve ter no mínimo %s caracteres", param)
+		}
+		if fieldError.Kind() == reflect.Slice {
+			return fmt.Sprintf("deve ter no mínimo %s itens", param)
+		}
+		return fmt.Sprintf("deve ser no mínimo %s", param)
+	case "max":
+		if text {
+			return fmt.Sprintf("deve ter no máximo %s caracteres", param)
+		}
+		if fieldError.Kind() == reflect.Slice {
+			return fmt.Sprintf("deve ter no máximo %s itens", param)
+		}
+		return fmt.Sprintf("deve ser no máximo %s", param)
+	case "len":
+		return fmt.Sprintf("deve ter tamanho %s", param)
+	case "gt":
+		return fmt.Sprintf("deve ser maior que %s", param)
+	case "gte":
+		return fmt.Sprintf("deve ser maior ou igual a %s", param)
+	case "lt":
+		return fmt.Sprintf("deve ser menor que %s", param)
+	case "lte":
+		return fmt.Sprintf("deve ser menor ou igual a %s", param)
+	case "oneof":
+		return fmt.Sprintf("deve ser um de: %s", strings.ReplaceAll(param, " ", ", "))
+	case "email":
+		return "deve ser um e-mail válido"
+	default:
+		return fmt.Sprintf("não atende à regra %s", fieldError.Tag())
+	}
+}
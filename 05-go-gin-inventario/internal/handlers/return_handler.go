@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/service"
+)
+
+// ReturnHandler gerencia os endpoints de devoluções de clientes
+type ReturnHandler struct {
+	service *service.ReturnService
+}
+
+// NewReturnHandler cria uma nova instância do handler
+func NewReturnHandler(service *service.ReturnService) *ReturnHandler {
+	return &ReturnHandler{
+		service: service,
+	}
+}
+
+// CreateReturn godoc
+// @Summary Registrar devolução
+// @Description Registra a devolução de itens de um pedido de venda confirmado, com o motivo de cada item. Os itens ficam aguardando inspeção, sem efeito no estoque.
+// @Tags devolucoes
+// @Accept json
+// @Produce json
+// @Param devolucao body dtos.ReturnRequest true "Pedido de venda e itens devolvidos"
+// @Success 201 {object} dtos.ReturnResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/devolucoes [post]
+func (h *ReturnHandler) CreateReturn(c *gin.Context) {
+	var req dtos.ReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	ret, err := h.service.CreateReturn(&req)
+	if err != nil {
+		respondDomainError(c, err, "CREATE_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusCreated, ret)
+}
+
+// ListReturns godoc
+// @Summary Listar devoluções
+// @Description Lista devoluções, das mais recentes para as mais antigas, filtrando opcionalmente por pedido, produto, status, motivo e destino
+// @Tags devolucoes
+// @Accept json
+// @Produce json
+// @Param pedido_venda_id query string false "ID do pedido de venda"
+// @Param produto_id query string false "ID do produto"
+// @Param status query string false "Status da devolução" Enums(aguardando_inspecao,concluida)
+// @Param motivo query string false "Motivo de algum item" Enums(defeito,avaria,arrependimento,produto_errado,outro)
+// @Param destino query string false "Destino de algum item" Enums(reestoque_novo,reestoque_caixa_aberta,envio_fornecedor,baixa)
+// @Success 200 {object} dtos.ReturnListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/devolucoes [get]
+func (h *ReturnHandler) ListReturns(c *gin.Context) {
+	var filter database.ReturnFilter
+
+	if pedidoStr := c.Query("pedido_venda_id"); pedidoStr != "" {
+		pedidoID, err := uuid.Parse(pedidoStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do pedido de venda inválido")
+			return
+		}
+		filter.PedidoVendaID = &pedidoID
+	}
+
+	if produtoStr := c.Query("produto_id"); produtoStr != "" {
+		produtoID, err := uuid.Parse(produtoStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+			return
+		}
+		filter.ProdutoID = &produtoID
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := models.ReturnStatus(statusStr)
+		filter.Status = &status
+	}
+
+	if motivoStr := c.Query("motivo"); motivoStr != "" {
+		motivo := models.ReturnReason(motivoStr)
+		filter.Motivo = &motivo
+	}
+
+	if destinoStr := c.Query("destino"); destinoStr != "" {
+		destino := models.ReturnOutcome(destinoStr)
+		filter.Destino = &destino
+	}
+
+	returns, err := h.service.ListReturns(filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "FETCH_ERROR", "Erro ao buscar devoluções")
+		return
+	}
+
+	c.JSON(http.StatusOK, returns)
+}
+
+// GetReturnReport godoc
+// @Summary Relatório de devoluções
+// @Description Totaliza as devoluções registradas no período por motivo, categoria e destino, com valores reembolsados, custo das unidades e perdas por baixa
+// @Tags devolucoes
+// @Accept json
+// @Produce json
+// @Param inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Success 200 {object} dtos.ReturnReportResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/devolucoes/relatorio [get]
+func (h *ReturnHandler) GetReturnReport(c *gin.Context) {
+	var inicio, fim *time.Time
+
+	if inicioStr := c.Query("inicio"); inicioStr != "" {
+		data, err := time.Parse("2006-01-02", inicioStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_DATE", "Data inicial inválida, use AAAA-MM-DD")
+			return
+		}
+		inicio = &data
+	}
+
+	if fimStr := c.Query("fim"); fimStr != "" {
+		data, err := time.Parse("2006-01-02", fimStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_DATE", "Data final inválida, use AAAA-MM-DD")
+			return
+		}
+		// A data final é inclusiva: o período vai até o início do dia seguinte
+		data = data.AddDate(0, 0, 1)
+		fim = &data
+	}
+
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		respondError(c, http.StatusBadRequest, "INVALID_DATE", "Data inicial deve ser anterior ou igual à data final")
+		return
+	}
+
+	report, err := h.service.GetReturnReport(inicio, fim)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "REPORT_ERROR", "Erro ao gerar relatório de devoluções")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetReturn godoc
+// @Summary Buscar devolução
+// @Description Retorna uma devolução com os itens, o destino de cada um e as entradas em estoque
+// @Tags devolucoes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da devolução"
+// @Success 200 {object} dtos.ReturnResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/devolucoes/{id} [get]
+func (h *ReturnHandler) GetReturn(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da devolução inválido")
+		return
+	}
+
+	ret, err := h.service.GetReturn(id)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, ret)
+}
+
+// InspectReturn godoc
+// @Summary Inspecionar devolução
+// @Description Registra o destino de cada item devolvido e aplica o efeito no estoque de uma só vez: reestoque_novo devolve aos lotes e números de série de origem, reestoque_caixa_aberta alimenta o estoque de caixa aberta, envio_fornecedor e baixa retiram as unidades definitivamente
+// @Tags devolucoes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da devolução"
+// @Param inspecao body dtos.ReturnInspectionRequest true "Destino de cada item"
+// @Success 200 {object} dtos.ReturnResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/devolucoes/{id}/inspecao [post]
+func (h *ReturnHandler) InspectReturn(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID da devolução inválido")
+		return
+	}
+
+	var req dtos.ReturnInspectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	ret, err := h.service.InspectReturn(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "INSPECTION_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, ret)
+}
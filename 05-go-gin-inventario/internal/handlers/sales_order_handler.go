@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/service"
+)
+
+// SalesOrderHandler gerencia os endpoints de pedidos de venda
+type SalesOrderHandler struct {
+	service *service.SalesOrderService
+}
+
+// NewSalesOrderHandler cria uma nova instância do handler
+func NewSalesOrderHandler(service *service.SalesOrderService) *SalesOrderHandler {
+	return &SalesOrderHandler{
+		service: service,
+	}
+}
+
+// CreateSalesOrder godoc
+// @Summary Registrar venda
+// @Description Registra uma venda com vários itens e baixa o estoque de todos de uma só vez, ao preço vigente (promocional, se houver). Itens com caixa_aberta saem do estoque de caixa aberta. Se algum item não puder ser atendido, nada é baixado e a resposta traz o erro de cada item.
+// @Tags pedidos-venda
+// @Accept json
+// @Produce json
+// @Param pedido body dtos.SalesOrderRequest true "Canal, referência e itens da venda"
+// @Success 201 {object} dtos.SalesOrderResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.SalesOrderRejectedResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/pedidos-venda [post]
+func (h *SalesOrderHandler) CreateSalesOrder(c *gin.Context) {
+	var req dtos.SalesOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	order, err := h.service.CreateSalesOrder(&req)
+	if err != nil {
+		var rejection *database.SalesOrderRejection
+		if errors.As(err, &rejection) {
+			respondSalesOrderRejection(c, rejection)
+			return
+		}
+		respondDomainError(c, err, "CREATE_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// ListSalesOrders godoc
+// @Summary Listar vendas
+// @Description Lista pedidos de venda, dos mais recentes para os mais antigos, filtrando opcionalmente por produto, status, canal e referência
+// @Tags pedidos-venda
+// @Accept json
+// @Produce json
+// @Param produto_id query string false "ID do produto"
+// @Param status query string false "Status do pedido" Enums(confirmado,cancelado)
+// @Param canal query string false "Canal de venda"
+// @Param referencia query string false "Referência no canal de origem"
+// @Success 200 {object} dtos.SalesOrderListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/pedidos-venda [get]
+func (h *SalesOrderHandler) ListSalesOrders(c *gin.Context) {
+	var filter database.SalesOrderFilter
+
+	if produtoStr := c.Query("produto_id"); produtoStr != "" {
+		produtoID, err := uuid.Parse(produtoStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+			return
+		}
+		filter.ProdutoID = &produtoID
+	}
+
+	if statusStr := c.Query("status"); statusStr != "" {
+		status := models.SalesOrderStatus(statusStr)
+		filter.Status = &status
+	}
+
+	if canal := c.Query("canal"); canal != "" {
+		filter.Canal = &canal
+	}
+
+	if referencia := c.Query("referencia"); referencia != "" {
+		filter.Referencia = &referencia
+	}
+
+	orders, err := h.service.ListSalesOrders(filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "FETCH_ERROR", "Erro ao buscar pedidos de venda")
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+// GetSalesOrder godoc
+// @Summary Buscar venda
+// @Description Retorna um pedido de venda com os itens e as baixas de estoque efetivadas
+// @Tags pedidos-venda
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do pedido de venda"
+// @Success 200 {object} dtos.SalesOrderResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/pedidos-venda/{id} [get]
+func (h *SalesOrderHandler) GetSalesOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do pedido de venda inválido")
+		return
+	}
+
+	order, err := h.service.GetSalesOrder(id)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// CancelSalesOrder godoc
+// @Summary Cancelar venda
+// @Description Cancela uma venda confirmada e devolve ao estoque tudo o que saiu, nos mesmos lotes e com os mesmos números de série
+// @Tags pedidos-venda
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do pedido de venda"
+// @Param cancelamento body dtos.CancelSalesOrderRequest false "Motivo do cancelamento"
+// @Success 200 {object} dtos.SalesOrderResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/pedidos-venda/{id}/cancelar [post]
+func (h *SalesOrderHandler) CancelSalesOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do pedido de venda inválido")
+		return
+	}
+
+	// O corpo é opcional: sem ele a venda é cancelada sem motivo
+	var req dtos.CancelSalesOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondValidationError(c, err)
+		return
+	}
+
+	order, err := h.service.CancelSalesOrder(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "CANCEL_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// respondSalesOrderRejection escreve a recusa de uma venda com o erro de cada item
+func respondSalesOrderRejection(c *gin.Context, rejection *database.SalesOrderRejection) {
+	itens := make([]dtos.SalesOrderLineError, len(rejection.Itens))
+	for i, item := range rejection.Itens {
+		_, codigo := domainErrorCode(item.Err, "INVALID_ITEM")
+		itens[i] = dtos.SalesOrderLineError{
+			Indice:    item.Indice,
+			ProdutoID: item.ProdutoID,
+			Codigo:    codigo,
+			Erro:      item.Err.Error(),
+		}
+		if errors.Is(item.Err, database.ErrInsufficientStock) || errors.Is(item.Err, database.ErrLotExpired) {
+			disponivel, solicitado := item.Disponivel, item.Solicitado
+			itens[i].Disponivel = &disponivel
+			itens[i].Solicitado = &solicitado
+		}
+	}
+
+	c.JSON(http.StatusConflict, dtos.SalesOrderRejectedResponse{
+		Erro:      "Pedido de venda recusado",
+		Codigo:    "SALES_ORDER_REJECTED",
+		Timestamp: time.Now(),
+		Itens:     itens,
+	})
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/service"
+)
+
+// SerialHandler gerencia os endpoints de rastreio por número de série
+type SerialHandler struct {
+	service *service.SerialService
+}
+
+// NewSerialHandler cria uma nova instância do handler
+func NewSerialHandler(service *service.SerialService) *SerialHandler {
+	return &SerialHandler{
+		service: service,
+	}
+}
+
+// ReceiveSerials godoc
+// @Summary Registrar entrada de números de série
+// @Description Registra a entrada de unidades serializadas; o estoque aumenta na quantidade de números informados
+// @Tags seriais
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param entrada body dtos.SerialMovementRequest true "Números de série recebidos"
+// @Success 200 {object} dtos.SerialMovementResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/{id}/seriais/entrada [post]
+func (h *SerialHandler) ReceiveSerials(c *gin.Context) {
+	h.handleMovement(c, h.service.ReceiveSerials)
+}
+
+// ShipSerials godoc
+// @Summary Registrar saída de números de série
+// @Description Registra a venda de unidades serializadas, opcionalmente efetivando uma reserva
+// @Tags seriais
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param saida body dtos.SerialMovementRequest true "Números de série vendidos"
+// @Success 200 {object} dtos.SerialMovementResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/{id}/seriais/saida [post]
+func (h *SerialHandler) ShipSerials(c *gin.Context) {
+	h.handleMovement(c, h.service.ShipSerials)
+}
+
+// GetProductSerials godoc
+// @Summary Listar números de série do produto
+// @Description Lista os números de série do produto e indica se o estoque confere com os números em estoque
+// @Tags seriais
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param status query string false "Situação" Enums(em_estoque,vendido,caixa_aberta,devolvido_fornecedor,baixado)
+// @Success 200 {object} dtos.SerialListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/seriais [get]
+func (h *SerialHandler) GetProductSerials(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	var status *models.SerialStatus
+	if statusStr := c.Query("status"); statusStr != "" {
+		st := models.SerialStatus(statusStr)
+		status = &st
+	}
+
+	serials, err := h.service.GetProductSerials(id, status)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, serials)
+}
+
+// GetSerial godoc
+// @Summary Consultar número de série
+// @Description Retorna a situação atual e o histórico de movimentações de um número de série
+// @Tags seriais
+// @Accept json
+// @Produce json
+// @Param numero path string true "Número de série ou IMEI"
+// @Success 200 {object} dtos.SerialResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/seriais/{numero} [get]
+func (h *SerialHandler) GetSerial(c *gin.Context) {
+	serial, err := h.service.GetSerial(c.Param("numero"))
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, serial)
+}
+
+// Métodos auxiliares privados
+
+func (h *SerialHandler) handleMovement(c *gin.Context, movement func(uuid.UUID, *dtos.SerialMovementRequest) (*dtos.SerialMovementResponse, error)) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	var req dtos.SerialMovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	result, err := movement(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "SERIAL_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
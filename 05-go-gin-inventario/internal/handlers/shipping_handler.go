@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/service"
+)
+
+// ShippingHandler gerencia os endpoints de frete
+type ShippingHandler struct {
+	service *service.ShippingService
+}
+
+// NewShippingHandler cria uma nova instância do handler
+func NewShippingHandler(service *service.ShippingService) *ShippingHandler {
+	return &ShippingHandler{
+		service: service,
+	}
+}
+
+// QuoteShipping godoc
+// @Summary Cotar frete
+// @Description Calcula o frete de um carrinho para o CEP ou a zona de destino pela tabela local. Cobra pelo maior entre o peso real e o cubado, mais o ad valorem sobre as mercadorias
+// @Tags frete
+// @Accept json
+// @Produce json
+// @Param cotacao body dtos.ShippingQuoteRequest true "Destino e itens do carrinho"
+// @Success 200 {object} dtos.ShippingQuoteResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ErrorResponse
+// @Router /api/frete/cotacao [post]
+func (h *ShippingHandler) QuoteShipping(c *gin.Context) {
+	var req dtos.ShippingQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	quote, err := h.service.QuoteShipping(&req)
+	if err != nil {
+		respondDomainError(c, err, "SHIPPING_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+// GetRateTable godoc
+// @Summary Consultar tabela de frete
+// @Description Retorna a tabela de frete em uso: fator de cubagem e, por zona, as faixas de CEP, prazo, faixas de peso, valor por kg adicional e ad valorem (%)
+// @Tags frete
+// @Produce json
+// @Success 200 {object} models.RateTable
+// @Router /api/frete/tabela [get]
+func (h *ShippingHandler) GetRateTable(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.GetRateTable())
+}
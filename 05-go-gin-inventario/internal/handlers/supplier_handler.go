@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/service"
+)
+
+// SupplierHandler gerencia os endpoints de fornecedores e seus produtos
+type SupplierHandler struct {
+	service *service.SupplierService
+}
+
+// NewSupplierHandler cria uma nova instância do handler
+func NewSupplierHandler(service *service.SupplierService) *SupplierHandler {
+	return &SupplierHandler{
+		service: service,
+	}
+}
+
+// CreateSupplier godoc
+// @Summary Criar fornecedor
+// @Description Cadastra um novo fornecedor
+// @Tags fornecedores
+// @Accept json
+// @Produce json
+// @Param fornecedor body dtos.SupplierRequest true "Dados do fornecedor"
+// @Success 201 {object} dtos.SupplierResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/fornecedores [post]
+func (h *SupplierHandler) CreateSupplier(c *gin.Context) {
+	var req dtos.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	supplier, err := h.service.CreateSupplier(&req)
+	if err != nil {
+		respondDomainError(c, err, "CREATE_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusCreated, supplier)
+}
+
+// ListSuppliers godoc
+// @Summary Listar fornecedores
+// @Description Lista os fornecedores por nome, opcionalmente apenas os ativos
+// @Tags fornecedores
+// @Accept json
+// @Produce json
+// @Param ativos query bool false "Apenas fornecedores ativos"
+// @Success 200 {object} dtos.SupplierListResponse
+// @Failure 500 {object} dtos.ErrorResponse
+// @Router /api/fornecedores [get]
+func (h *SupplierHandler) ListSuppliers(c *gin.Context) {
+	ativos, err := strconv.ParseBool(c.DefaultQuery("ativos", "false"))
+	if err != nil {
+		ativos = false
+	}
+
+	suppliers, err := h.service.ListSuppliers(ativos)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "FETCH_ERROR", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, suppliers)
+}
+
+// GetSupplier godoc
+// @Summary Buscar fornecedor
+// @Description Retorna um fornecedor pelo ID
+// @Tags fornecedores
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do fornecedor"
+// @Success 200 {object} dtos.SupplierResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/fornecedores/{id} [get]
+func (h *SupplierHandler) GetSupplier(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do fornecedor inválido")
+		return
+	}
+
+	supplier, err := h.service.GetSupplier(id)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, supplier)
+}
+
+// UpdateSupplier godoc
+// @Summary Atualizar fornecedor
+// @Description Substitui os dados cadastrais de um fornecedor
+// @Tags fornecedores
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do fornecedor"
+// @Param fornecedor body dtos.SupplierRequest true "Dados do fornecedor"
+// @Success 200 {object} dtos.SupplierResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/fornecedores/{id} [put]
+func (h *SupplierHandler) UpdateSupplier(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do fornecedor inválido")
+		return
+	}
+
+	var req dtos.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	supplier, err := h.service.UpdateSupplier(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "UPDATE_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, supplier)
+}
+
+// DeleteSupplier godoc
+// @Summary Deletar fornecedor
+// @Description Remove um fornecedor e seus vínculos com produtos; fornecedores com pedidos de compra em aberto não podem ser removidos
+// @Tags fornecedores
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do fornecedor"
+// @Success 204 "Fornecedor deletado com sucesso"
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 409 {object} dtos.ErrorResponse
+// @Router /api/fornecedores/{id} [delete]
+func (h *SupplierHandler) DeleteSupplier(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do fornecedor inválido")
+		return
+	}
+
+	if err := h.service.DeleteSupplier(id); err != nil {
+		respondDomainError(c, err, "DELETE_ERROR")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetSupplierProducts godoc
+// @Summary Produtos do fornecedor
+// @Description Lista os produtos vinculados ao fornecedor com código, prazo de entrega e último custo (este apenas com acesso financeiro)
+// @Tags fornecedores
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do fornecedor"
+// @Success 200 {object} dtos.SupplierProductListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/fornecedores/{id}/produtos [get]
+func (h *SupplierHandler) GetSupplierProducts(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do fornecedor inválido")
+		return
+	}
+
+	products, err := h.service.GetSupplierProducts(id)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, products)
+}
+
+// LinkProduct godoc
+// @Summary Vincular produto ao fornecedor
+// @Description Cria ou atualiza o vínculo do produto com o fornecedor (código do fornecedor, prazo de entrega e último custo). O último custo só é retornado com acesso financeiro.
+// @Tags fornecedores
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do fornecedor"
+// @Param produto path string true "ID do produto"
+// @Param vinculo body dtos.SupplierProductRequest true "Dados do vínculo"
+// @Success 200 {object} dtos.SupplierProductResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/fornecedores/{id}/produtos/{produto} [put]
+func (h *SupplierHandler) LinkProduct(c *gin.Context) {
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do fornecedor inválido")
+		return
+	}
+
+	productID, err := uuid.Parse(c.Param("produto"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	var req dtos.SupplierProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	link, err := h.service.LinkProduct(supplierID, productID, &req)
+	if err != nil {
+		respondDomainError(c, err, "LINK_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, link)
+}
+
+// UnlinkProduct godoc
+// @Summary Desvincular produto do fornecedor
+// @Description Remove o vínculo do produto com o fornecedor
+// @Tags fornecedores
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do fornecedor"
+// @Param produto path string true "ID do produto"
+// @Success 204 "Vínculo removido com sucesso"
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/fornecedores/{id}/produtos/{produto} [delete]
+func (h *SupplierHandler) UnlinkProduct(c *gin.Context) {
+	supplierID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do fornecedor inválido")
+		return
+	}
+
+	productID, err := uuid.Parse(c.Param("produto"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	if err := h.service.UnlinkProduct(supplierID, productID); err != nil {
+		respondDomainError(c, err, "UNLINK_ERROR")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetProductSuppliers godoc
+// @Summary Fornecedores do produto
+// @Description Lista os fornecedores do produto, do menor prazo de entrega para o maior. O último custo só é retornado com acesso financeiro.
+// @Tags fornecedores
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Success 200 {object} dtos.SupplierProductListResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/fornecedores [get]
+func (h *SupplierHandler) GetProductSuppliers(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	suppliers, err := h.service.GetProductSuppliers(id)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	respondWithCosts(c, http.StatusOK, suppliers)
+}
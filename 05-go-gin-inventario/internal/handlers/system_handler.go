@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"inventario-api/docs"
+	"inventario-api/internal/dtos"
+)
+
+// swaggerUIPolicy libera os estilos inline e as imagens em data URI usados
+// pela Swagger UI, que a política padrão do middleware Security bloqueia
+const swaggerUIPolicy = "default-src 'self'; img-src 'self' data:; style-src 'self' 'unsafe-inline'"
+
+// categorySchema é o schema da especificação com as categorias de produto
+const categorySchema = "models.ProductCategory"
+
+// SystemHandler gerencia os endpoints de monitoramento e documentação
+type SystemHandler struct {
+	spec  []byte
+	index dtos.APIIndexResponse
+	ui    http.FileSystem
+}
+
+// NewSystemHandler cria uma nova instância do handler a partir da
+// especificação OpenAPI gerada. A página inicial é montada a partir dela,
+// para que a lista de endpoints acompanhe as rotas documentadas.
+func NewSystemHandler(spec []byte) (*SystemHandler, error) {
+	var document struct {
+		Info struct {
+			Title       string `json:"title"`
+			Description string `json:"description"`
+			Version     string `json:"version"`
+		} `json:"info"`
+		Paths map[string]map[string]struct {
+			Tags []string `json:"tags"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(spec, &document); err != nil {
+		return nil, fmt.Errorf("erro ao ler especificação OpenAPI: %w", err)
+	}
+
+	endpoints := make(map[string][]string)
+	for path, operations := range document.Paths {
+		for method, operation := range operations {
+			tag := "outros"
+			if len(operation.Tags) > 0 {
+				tag = operation.Tags[0]
+			}
+			endpoints[tag] = append(endpoints[tag], strings.ToUpper(method)+" "+path)
+		}
+	}
+	for _, routes := range endpoints {
+		sort.Slice(routes, func(i, j int) bool {
+			pathI := routes[i][strings.Index(routes[i], " ")+1:]
+			pathJ := routes[j][strings.Index(routes[j], " ")+1:]
+			if pathI != pathJ {
+				return pathI < pathJ
+			}
+			return routes[i] < routes[j]
+		})
+	}
+
+	var categories struct {
+		Enum []string `json:"enum"`
+	}
+	if schema, ok := document.Components.Schemas[categorySchema]; ok {
+		if err := json.Unmarshal(schema, &categories); err != nil {
+			return nil, fmt.Errorf("erro ao ler categorias da especificação: %w", err)
+		}
+	}
+
+	return &SystemHandler{
+		spec: spec,
+		index: dtos.APIIndexResponse{
+			Message:       document.Info.Title,
+			Version:       document.Info.Version,
+			Description:   document.Info.Description,
+			Documentacao:  "/docs",
+			Especificacao: "/openapi.json",
+			Endpoints:     endpoints,
+			Categories:    categories.Enum,
+		},
+		ui: http.FS(swaggerFiles.FS),
+	}, nil
+}
+
+// HealthCheck godoc
+// @Summary Health check
+// @Description Informa se o serviço está no ar
+// @Tags sistema
+// @Produce json
+// @Success 200 {object} dtos.HealthResponse
+// @Router /health [get]
+func (h *SystemHandler) HealthCheck(c *gin.Context) {
+	c.JSON(http.StatusOK, dtos.HealthResponse{
+		Status:    "OK",
+		Service:   "Inventário API",
+		Version:   h.index.Version,
+		Timestamp: "2023-01-15T10:30:00Z",
+	})
+}
+
+// GetIndex godoc
+// @Summary Página inicial da API
+// @Description Lista os endpoints documentados na especificação OpenAPI, agrupados por tag, e as categorias de produto
+// @Tags sistema
+// @Produce json
+// @Success 200 {object} dtos.APIIndexResponse
+// @Router / [get]
+func (h *SystemHandler) GetIndex(c *gin.Context) {
+	c.JSON(http.StatusOK, h.index)
+}
+
+// GetOpenAPISpec godoc
+// @Summary Especificação OpenAPI
+// @Description Retorna a especificação OpenAPI 3 gerada a partir das anotações dos handlers
+// @Tags documentacao
+// @Produce json
+// @Success 200 {object} object "Documento OpenAPI 3"
+// @Router /openapi.json [get]
+func (h *SystemHandler) GetOpenAPISpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.spec)
+}
+
+// SwaggerUI godoc
+// @Summary Interface Swagger UI
+// @Description Serve a Swagger UI embutida no binário, que funciona sem acesso à internet e carrega /openapi.json. Acesse /docs/ para a página inicial
+// @Tags documentacao
+// @Produce html
+// @Param arquivo path string true "Arquivo da interface; vazio para a página inicial"
+// @Success 200 "Página ou arquivo da interface"
+// @Failure 404 "Arquivo não encontrado"
+// @Router /docs/{arquivo} [get]
+func (h *SystemHandler) SwaggerUI(c *gin.Context) {
+	c.Header("Content-Security-Policy", swaggerUIPolicy)
+
+	arquivo := strings.TrimPrefix(c.Param("arquivo"), "/")
+	if arquivo == "swagger-initializer.js" {
+		c.Data(http.StatusOK, "application/javascript; charset=utf-8", docs.SwaggerInitializer)
+		return
+	}
+	c.FileFromFS("/"+arquivo, h.ui)
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"inventario-api/internal/dtos"
+)
+
+// AddProductTags godoc
+// @Summary Adicionar tags ao produto
+// @Description Adiciona tags ao produto mantendo as existentes. Tags são normalizadas (minúsculas, espaços simples) e cada produto aceita até 20
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param tags body dtos.AddTagsRequest true "Tags a adicionar"
+// @Success 200 {object} dtos.ProductTagsResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/{id}/tags [post]
+func (h *ProductHandler) AddProductTags(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	var req dtos.AddTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleValidationError(c, err)
+		return
+	}
+
+	tags, err := h.service.AddProductTags(id, &req)
+	if err != nil {
+		respondDomainError(c, err, "TAG_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// RemoveProductTag godoc
+// @Summary Remover tag do produto
+// @Description Remove uma tag do produto
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param tag path string true "Tag"
+// @Success 200 {object} dtos.ProductTagsResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/tags/{tag} [delete]
+func (h *ProductHandler) RemoveProductTag(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	tags, err := h.service.RemoveProductTag(id, c.Param("tag"))
+	if err != nil {
+		respondDomainError(c, err, "TAG_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// UpdateTagsBatch godoc
+// @Summary Alterar tags em lote
+// @Description Adiciona e remove tags de vários produtos de forma atômica: se algum produto não existir ou exceder o limite de tags, nenhum é alterado
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param lote body dtos.BulkTagRequest true "Produtos e tags a adicionar e remover"
+// @Success 200 {object} dtos.BulkTagResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/tags/lote [post]
+func (h *ProductHandler) UpdateTagsBatch(c *gin.Context) {
+	var req dtos.BulkTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleValidationError(c, err)
+		return
+	}
+
+	result, err := h.service.UpdateTagsBatch(&req)
+	if err != nil {
+		respondDomainError(c, err, "TAG_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetTagCloud godoc
+// @Summary Nuvem de tags
+// @Description Retorna as tags em uso com a quantidade de produtos e um peso de 1 a 5 para exibição, das mais usadas para as menos usadas
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param minimo query int false "Quantidade mínima de produtos por tag" default(1)
+// @Param limite query int false "Quantidade máxima de tags (0 para todas)" default(0)
+// @Success 200 {object} dtos.TagCloudResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Router /api/produtos/tags [get]
+func (h *ProductHandler) GetTagCloud(c *gin.Context) {
+	minimo, err := strconv.Atoi(c.DefaultQuery("minimo", "1"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_PARAMETER", "Parâmetro minimo inválido")
+		return
+	}
+	limite, err := strconv.Atoi(c.DefaultQuery("limite", "0"))
+	if err != nil || limite < 0 {
+		respondError(c, http.StatusBadRequest, "INVALID_PARAMETER", "Parâmetro limite inválido")
+		return
+	}
+
+	cloud, err := h.service.GetTagCloud(minimo, limite)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, cloud)
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"inventario-api/internal/dtos"
+)
+
+// GetProductTranslations godoc
+// @Summary Listar traduções do produto
+// @Description Retorna o nome e a descrição no idioma de cadastro (pt-BR), as traduções existentes e os idiomas suportados ainda sem tradução
+// @Tags traducoes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Success 200 {object} dtos.ProductTranslationsResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/traducoes [get]
+func (h *ProductHandler) GetProductTranslations(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	translations, err := h.service.GetProductTranslations(id)
+	if err != nil {
+		respondDomainError(c, err, "FETCH_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, translations)
+}
+
+// SetProductTranslation godoc
+// @Summary Salvar tradução do produto
+// @Description Cria ou substitui o nome e a descrição do produto em um idioma suportado (es, en). Variantes regionais, como es-AR, usam o idioma base; campos vazios exibem o texto em português.
+// @Tags traducoes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param idioma path string true "Idioma" Enums(es,en)
+// @Param traducao body dtos.TranslationRequest true "Textos traduzidos"
+// @Success 200 {object} dtos.ProductTranslationsResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Failure 422 {object} dtos.ValidationErrorResponse
+// @Router /api/produtos/{id}/traducoes/{idioma} [put]
+func (h *ProductHandler) SetProductTranslation(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	var req dtos.TranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleValidationError(c, err)
+		return
+	}
+
+	translations, err := h.service.SetProductTranslation(id, c.Param("idioma"), &req)
+	if err != nil {
+		respondDomainError(c, err, "TRANSLATION_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, translations)
+}
+
+// DeleteProductTranslation godoc
+// @Summary Remover tradução do produto
+// @Description Remove a tradução do idioma; o produto volta a ser exibido em português nesse idioma
+// @Tags traducoes
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do produto"
+// @Param idioma path string true "Idioma" Enums(es,en)
+// @Success 200 {object} dtos.ProductTranslationsResponse
+// @Failure 400 {object} dtos.ErrorResponse
+// @Failure 404 {object} dtos.ErrorResponse
+// @Router /api/produtos/{id}/traducoes/{idioma} [delete]
+func (h *ProductHandler) DeleteProductTranslation(c *gin.Context) {
+	id, err := h.parseUUID(c.Param("id"))
+	if err != nil {
+		h.handleError(c, http.StatusBadRequest, "INVALID_ID", "ID do produto inválido")
+		return
+	}
+
+	translations, err := h.service.DeleteProductTranslation(id, c.Param("idioma"))
+	if err != nil {
+		respondDomainError(c, err, "TRANSLATION_ERROR")
+		return
+	}
+
+	c.JSON(http.StatusOK, translations)
+}
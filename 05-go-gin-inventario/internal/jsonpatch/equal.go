@@ -0,0 +1,66 @@
+package jsonpatch
+
+import "encoding/json"
+
+// equal compara dois valores JSON; números são comparados pelo valor, de
+// modo que 10 e 10.0 são iguais
+func equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if x == y {
+			return true
+		}
+		fx, errX := x.Float64()
+		fy, errY := y.Float64()
+		return errX == nil && errY == nil && fx == fy
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for name, value := range x {
+			other, exists := y[name]
+			if !exists || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// deepCopy copia objetos e listas, para que copy não compartilhe valores
+func deepCopy(value interface{}) interface{} {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(node))
+		for name, child := range node {
+			copied[name] = deepCopy(child)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(node))
+		for i, child := range node {
+			copied[i] = deepCopy(child)
+		}
+		return copied
+	default:
+		return value
+	}
+}
@@ -0,0 +1,172 @@
+// Package jsonpatch aplica JSON Merge Patch (RFC 7396) e JSON Patch
+// (RFC 6902) a documentos JSON. Os números são preservados como
+// json.Number, para que valores não percam precisão entre a leitura e a
+// gravação do documento.
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Tipos de mídia dos formatos de patch
+const (
+	MergePatchMediaType = "application/merge-patch+json"
+	JSONPatchMediaType  = "application/json-patch+json"
+)
+
+var (
+	// ErrInvalidPatch indica um documento de patch malformado
+	ErrInvalidPatch = errors.New("documento malformado")
+	// ErrPathNotFound indica uma operação sobre um caminho inexistente
+	ErrPathNotFound = errors.New("caminho inexistente")
+	// ErrTestFailed indica uma operação test cujo valor não confere
+	ErrTestFailed = errors.New("operação test falhou")
+)
+
+// decode lê um valor JSON preservando os números
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("conteúdo após o fim do JSON")
+	}
+	return value, nil
+}
+
+// MergePatch aplica um JSON Merge Patch: membros do patch substituem os do
+// documento, null remove o membro e objetos são mesclados recursivamente
+func MergePatch(document, patch []byte) ([]byte, error) {
+	target, err := decode(document)
+	if err != nil {
+		return nil, fmt.Errorf("documento inválido: %w", err)
+	}
+	changes, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return json.Marshal(mergeValue(target, changes))
+}
+
+func mergeValue(target, patch interface{}) interface{} {
+	changes, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	object, ok := target.(map[string]interface{})
+	if !ok {
+		object = make(map[string]interface{})
+	}
+	for name, value := range changes {
+		if value == nil {
+			delete(object, name)
+			continue
+		}
+		object[name] = mergeValue(object[name], value)
+	}
+	return object
+}
+
+// Operation é uma operação de JSON Patch
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Apply aplica as operações de um JSON Patch em ordem. Se alguma falhar,
+// inclusive um test, nenhuma alteração é devolvida.
+func Apply(document, patch []byte) ([]byte, error) {
+	root, err := decode(document)
+	if err != nil {
+		return nil, fmt.Errorf("documento inválido: %w", err)
+	}
+
+	var operations []Operation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("%w: o patch deve ser uma lista de operações: %v", ErrInvalidPatch, err)
+	}
+
+	for i, operation := range operations {
+		root, err = applyOperation(root, operation)
+		if err != nil {
+			return nil, fmt.Errorf("operação %d (%s %s): %w", i, operation.Op, operation.Path, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+func applyOperation(root interface{}, operation Operation) (interface{}, error) {
+	path, err := parsePointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value == nil {
+			return nil, fmt.Errorf("%w: value é obrigatório", ErrInvalidPatch)
+		}
+		value, err := decode(operation.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: value: %v", ErrInvalidPatch, err)
+		}
+		switch operation.Op {
+		case "add":
+			return add(root, path, value)
+		case "replace":
+			if _, err := get(root, path); err != nil {
+				return nil, err
+			}
+			return set(root, path, value)
+		default:
+			current, err := get(root, path)
+			if err != nil {
+				return nil, err
+			}
+			if !equal(current, value) {
+				return nil, fmt.Errorf("%w: o valor atual é %s", ErrTestFailed, encodeValue(current))
+			}
+			return root, nil
+		}
+	case "remove":
+		return remove(root, path)
+	case "move", "copy":
+		from, err := parsePointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(root, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		if operation.Op == "move" {
+			if isPrefix(from, path) && len(from) < len(path) {
+				return nil, fmt.Errorf("%w: não é possível mover um valor para dentro dele mesmo", ErrInvalidPatch)
+			}
+			if root, err = remove(root, from); err != nil {
+				return nil, err
+			}
+		} else {
+			value = deepCopy(value)
+		}
+		return add(root, path, value)
+	default:
+		return nil, fmt.Errorf("%w: operação %q desconhecida", ErrInvalidPatch, operation.Op)
+	}
+}
+
+func encodeValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
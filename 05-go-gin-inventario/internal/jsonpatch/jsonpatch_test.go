@@ -0,0 +1,186 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// assertJSON compara dois documentos JSON pelo conteúdo, ignorando a ordem
+// dos membros e a formatação
+func assertJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("resultado não é JSON: %v (%s)", err, got)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("esperado não é JSON: %v (%s)", err, want)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("resultado %s, esperado %s", got, want)
+	}
+}
+
+// TestApplyRFC6902 cobre os exemplos do Apêndice A da RFC 6902
+func TestApplyRFC6902(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		want     string
+		err      error
+	}{
+		{
+			name:     "A.1 adicionando membro a um objeto",
+			document: `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/baz", "value": "qux"}]`,
+			want:     `{"baz": "qux", "foo": "bar"}`,
+		},
+		{
+			name:     "A.2 adicionando elemento a uma lista",
+			document: `{"foo": ["bar", "baz"]}`,
+			patch:    `[{"op": "add", "path": "/foo/1", "value": "qux"}]`,
+			want:     `{"foo": ["bar", "qux", "baz"]}`,
+		},
+		{
+			name:     "A.3 removendo membro de um objeto",
+			document: `{"baz": "qux", "foo": "bar"}`,
+			patch:    `[{"op": "remove", "path": "/baz"}]`,
+			want:     `{"foo": "bar"}`,
+		},
+		{
+			name:     "A.4 removendo elemento de uma lista",
+			document: `{"foo": ["bar", "qux", "baz"]}`,
+			patch:    `[{"op": "remove", "path": "/foo/1"}]`,
+			want:     `{"foo": ["bar", "baz"]}`,
+		},
+		{
+			name:     "A.5 substituindo um valor",
+			document: `{"baz": "qux", "foo": "bar"}`,
+			patch:    `[{"op": "replace", "path": "/baz", "value": "boo"}]`,
+			want:     `{"baz": "boo", "foo": "bar"}`,
+		},
+		{
+			name:     "A.6 movendo um valor",
+			document: `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			patch:    `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			want:     `{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`,
+		},
+		{
+			name:     "A.7 movendo elemento de uma lista",
+			document: `{"foo": ["all", "grass", "cows", "eat"]}`,
+			patch:    `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`,
+			want:     `{"foo": ["all", "cows", "eat", "grass"]}`,
+		},
+		{
+			name:     "A.8 testando um valor com sucesso",
+			document: `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+			patch: `[
+				{"op": "test", "path": "/baz", "value": "qux"},
+				{"op": "test", "path": "/foo/1", "value": 2}
+			]`,
+			want: `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+		},
+		{
+			name:     "A.9 testando um valor com erro",
+			document: `{"baz": "qux"}`,
+			patch:    `[{"op": "test", "path": "/baz", "value": "bar"}]`,
+			err:      ErrTestFailed,
+		},
+		{
+			name:     "A.10 adicionando um valor aninhado",
+			document: `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`,
+			want:     `{"foo": "bar", "child": {"grandchild": {}}}`,
+		},
+		{
+			name:     "A.11 ignorando elementos desconhecidos",
+			document: `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/baz", "value": "qux", "xyz": 123}]`,
+			want:     `{"foo": "bar", "baz": "qux"}`,
+		},
+		{
+			name:     "A.12 adicionando a um destino inexistente",
+			document: `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`,
+			err:      ErrPathNotFound,
+		},
+		{
+			name:     "A.13 documento de patch inválido",
+			document: `{"foo": "bar"}`,
+			patch:    `[{"op": "add", "path": "/baz", "value": "qux", "op": "remove"}]`,
+			err:      ErrPathNotFound,
+		},
+		{
+			name:     "A.14 escape de ~",
+			document: `{"/": 9, "~1": 10}`,
+			patch:    `[{"op": "test", "path": "/~01", "value": 10}]`,
+			want:     `{"/": 9, "~1": 10}`,
+		},
+		{
+			name:     "A.15 comparando texto e número",
+			document: `{"/": 9, "~1": 10}`,
+			patch:    `[{"op": "test", "path": "/~01", "value": "10"}]`,
+			err:      ErrTestFailed,
+		},
+		{
+			name:     "A.16 adicionando uma lista como valor",
+			document: `{"foo": ["bar"]}`,
+			patch:    `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`,
+			want:     `{"foo": ["bar", ["abc", "def"]]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(tt.document), []byte(tt.patch))
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("erro %v, esperado %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
+
+// TestMergePatchRFC7396 cobre os exemplos do Apêndice A da RFC 7396
+func TestMergePatchRFC7396(t *testing.T) {
+	tests := []struct {
+		document string
+		patch    string
+		want     string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.document+" + "+tt.patch, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.document), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
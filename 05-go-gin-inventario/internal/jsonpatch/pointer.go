@@ -0,0 +1,187 @@
+package jsonpatch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parsePointer interpreta um JSON Pointer (RFC 6901), como /tags/0 ou /a~1b
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: caminho %q deve começar com /", ErrInvalidPatch, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// arrayIndex converte o token em índice; "-" aponta o fim da lista quando permitido
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: índice %q", ErrPathNotFound, token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("%w: índice %q", ErrPathNotFound, token)
+	}
+	limit := length - 1
+	if allowEnd {
+		limit = length
+	}
+	if index > limit {
+		return 0, fmt.Errorf("%w: índice %d fora da lista de %d itens", ErrPathNotFound, index, length)
+	}
+	return index, nil
+}
+
+// get retorna o valor apontado pelo caminho
+func get(root interface{}, path []string) (interface{}, error) {
+	current := root
+	for _, token := range path {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, exists := node[token]
+			if !exists {
+				return nil, fmt.Errorf("%w: membro %q", ErrPathNotFound, token)
+			}
+			current = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("%w: %q não é objeto nem lista", ErrPathNotFound, token)
+		}
+	}
+	return current, nil
+}
+
+// update aplica fn ao contêiner pai do caminho e devolve a raiz alterada
+func update(root interface{}, path []string, fn func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return fn(root, path[0])
+	}
+	token := path[0]
+	switch node := root.(type) {
+	case map[string]interface{}:
+		child, exists := node[token]
+		if !exists {
+			return nil, fmt.Errorf("%w: membro %q", ErrPathNotFound, token)
+		}
+		updated, err := update(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[token] = updated
+		return node, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := update(node[index], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = updated
+		return node, nil
+	default:
+		return nil, fmt.Errorf("%w: %q não é objeto nem lista", ErrPathNotFound, token)
+	}
+}
+
+// add insere o valor; em listas desloca os itens seguintes
+func add(root interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return update(root, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[index+1:], node[index:])
+			node[index] = value
+			return node, nil
+		default:
+			return nil, fmt.Errorf("%w: %q não é objeto nem lista", ErrPathNotFound, token)
+		}
+	})
+}
+
+// set substitui um valor existente
+func set(root interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return update(root, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			node[index] = value
+			return node, nil
+		default:
+			return nil, fmt.Errorf("%w: %q não é objeto nem lista", ErrPathNotFound, token)
+		}
+	})
+}
+
+// remove apaga o valor; em listas desloca os itens seguintes
+func remove(root interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: não é possível remover o documento inteiro", ErrInvalidPatch)
+	}
+	return update(root, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			if _, exists := node[token]; !exists {
+				return nil, fmt.Errorf("%w: membro %q", ErrPathNotFound, token)
+			}
+			delete(node, token)
+			return node, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			return append(node[:index], node[index+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%w: %q não é objeto nem lista", ErrPathNotFound, token)
+		}
+	})
+}
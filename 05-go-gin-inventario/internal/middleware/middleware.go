@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"log"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,7 +15,7 @@ func CORS() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, X-API-Key, Authorization, accept, origin, Cache-Control, X-Requested-With")
 		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 
 		if c.Request.Method == "OPTIONS" {
@@ -53,7 +55,7 @@ func RequestID() gin.HandlerFunc {
 		if requestID == "" {
 			requestID = fmt.Sprintf("%d", time.Now().UnixNano())
 		}
-		
+
 		c.Header("X-Request-ID", requestID)
 		c.Set("RequestID", requestID)
 		c.Next()
@@ -66,7 +68,7 @@ func Recovery() gin.HandlerFunc {
 		if err, ok := recovered.(string); ok {
 			log.Printf("Panic recovered: %s", err)
 		}
-		
+
 		c.JSON(500, gin.H{
 			"erro":      "Erro interno do servidor",
 			"codigo":    "INTERNAL_SERVER_ERROR",
@@ -81,21 +83,21 @@ func RateLimiter() gin.HandlerFunc {
 		count     int
 		lastReset time.Time
 	}
-	
+
 	clients := make(map[string]*client)
 	const maxRequests = 100 // 100 requests por minuto
-	
+
 	return func(c *gin.Context) {
 		clientIP := c.ClientIP()
 		now := time.Now()
-		
+
 		if clientData, exists := clients[clientIP]; exists {
 			// Reset contador se passou 1 minuto
 			if now.Sub(clientData.lastReset) > time.Minute {
 				clientData.count = 0
 				clientData.lastReset = now
 			}
-			
+
 			if clientData.count >= maxRequests {
 				c.JSON(429, gin.H{
 					"erro":      "Muitas requisições",
@@ -105,7 +107,7 @@ func RateLimiter() gin.HandlerFunc {
 				c.Abort()
 				return
 			}
-			
+
 			clientData.count++
 		} else {
 			clients[clientIP] = &client{
@@ -113,7 +115,7 @@ func RateLimiter() gin.HandlerFunc {
 				lastReset: now,
 			}
 		}
-		
+
 		c.Next()
 	}
 }
@@ -128,4 +130,40 @@ func Security() gin.HandlerFunc {
 		c.Header("Content-Security-Policy", "default-src 'self'")
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// FinancialAccessKey identifica no contexto as requisições com acesso a custos e margens
+const FinancialAccessKey = "AcessoFinanceiro"
+
+// FinancialAccess marca as requisições que apresentam o token financeiro no
+// header X-API-Key. Com token vazio nenhuma requisição recebe acesso.
+func FinancialAccess(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		informado := c.GetHeader("X-API-Key")
+		if token != "" && subtle.ConstantTimeCompare([]byte(informado), []byte(token)) == 1 {
+			c.Set(FinancialAccessKey, true)
+		}
+		c.Next()
+	}
+}
+
+// RequireFinancialAccess bloqueia as requisições sem acesso financeiro
+func RequireFinancialAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasFinancialAccess(c) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"erro":      "Acesso restrito a usuários com acesso financeiro",
+				"codigo":    "FORBIDDEN",
+				"timestamp": time.Now(),
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// HasFinancialAccess verifica se a requisição tem acesso a custos e margens
+func HasFinancialAccess(c *gin.Context) bool {
+	return c.GetBool(FinancialAccessKey)
+}
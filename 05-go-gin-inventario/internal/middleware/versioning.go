@@ -0,0 +1,236 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"inventario-api/internal/dtosv2"
+)
+
+// Tipos de mídia que escolhem a versão da API pelo cabeçalho Accept
+const (
+	MediaTypeV1 = "application/vnd.inventario.v1+json"
+	MediaTypeV2 = "application/vnd.inventario.v2+json"
+)
+
+const (
+	apiPrefix   = "/api"
+	apiV2Prefix = "/api/v2"
+)
+
+var vendorMediaType = regexp.MustCompile(`^application/vnd\.inventario\.v(\d+)\+json$`)
+
+// VersionRouter negocia a versão da API antes do roteamento do Gin. A versão
+// vem do caminho (/api/v2/...) ou do tipo de mídia no Accept: um pedido a
+// /api/produtos com Accept application/vnd.inventario.v2+json é atendido pela
+// rota equivalente da v2. Sem tipo de mídia da API, vale a versão do caminho.
+type VersionRouter struct {
+	engine *gin.Engine
+	once   sync.Once
+	routes map[string][][]string
+}
+
+// NewVersionRouter envolve o roteador com a negociação de versão
+func NewVersionRouter(engine *gin.Engine) *VersionRouter {
+	return &VersionRouter{engine: engine}
+}
+
+// ServeHTTP escolhe a versão da requisição e a entrega ao Gin
+func (v *VersionRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	if path != apiPrefix && !strings.HasPrefix(path, apiPrefix+"/") {
+		v.engine.ServeHTTP(w, r)
+		return
+	}
+	w.Header().Add("Vary", "Accept")
+
+	version, requested, err := acceptedVersion(r.Header.Get("Accept"))
+	if err != nil {
+		writeNotAcceptable(w, err.Error())
+		return
+	}
+
+	pathV2 := path == apiV2Prefix || strings.HasPrefix(path, apiV2Prefix+"/")
+	switch {
+	case !requested:
+	case version == 2 && !pathV2:
+		target := apiV2Prefix + strings.TrimPrefix(path, apiPrefix)
+		if !v.hasV2Route(r.Method, target) {
+			writeNotAcceptable(w, fmt.Sprintf("%s %s não está disponível na versão 2", r.Method, path))
+			return
+		}
+		r.URL.Path = target
+		r.URL.RawPath = ""
+	case version == 1 && pathV2:
+		writeNotAcceptable(w, "as rotas /api/v2 respondem apenas na versão 2")
+		return
+	}
+	v.engine.ServeHTTP(w, r)
+}
+
+// Deprecation anuncia nas rotas da v1 a data de descontinuação (cabeçalho
+// Deprecation, RFC 9745) e a de desligamento (Sunset, RFC 8594), com um
+// link para a rota equivalente da v2 quando ela existe. Sem datas
+// configuradas nenhum cabeçalho é enviado.
+func (v *VersionRouter) Deprecation(deprecatedAt, sunsetAt time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if deprecatedAt.IsZero() && sunsetAt.IsZero() {
+			c.Next()
+			return
+		}
+		if !deprecatedAt.IsZero() {
+			c.Header("Deprecation", "@"+strconv.FormatInt(deprecatedAt.Unix(), 10))
+		}
+		if !sunsetAt.IsZero() {
+			c.Header("Sunset", sunsetAt.UTC().Format(http.TimeFormat))
+		}
+		successor := apiV2Prefix + strings.TrimPrefix(c.Request.URL.Path, apiPrefix)
+		if v.hasV2Route(c.Request.Method, successor) {
+			c.Writer.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+		}
+		c.Next()
+	}
+}
+
+// ParseVersionDate lê as datas de descontinuação configuradas, no formato
+// AAAA-MM-DD ou RFC 3339. Valor vazio resulta em data zero.
+func ParseVersionDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("data %q inválida: use AAAA-MM-DD ou RFC 3339", value)
+	}
+	return date, nil
+}
+
+// acceptedVersion retorna a versão pedida pelo tipo de mídia da API no
+// Accept. Outros tipos, como application/json, não escolhem versão.
+func acceptedVersion(accept string) (int, bool, error) {
+	var unsupported string
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, _, _ := strings.Cut(mediaRange, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		match := vendorMediaType.FindStringSubmatch(mediaType)
+		if match == nil {
+			continue
+		}
+		switch match[1] {
+		case "1":
+			return 1, true, nil
+		case "2":
+			return 2, true, nil
+		default:
+			unsupported = mediaType
+		}
+	}
+	if unsupported != "" {
+		return 0, false, fmt.Errorf("versão não suportada em %s; use %s ou %s", unsupported, MediaTypeV1, MediaTypeV2)
+	}
+	return 0, false, nil
+}
+
+// hasV2Route verifica se a rota da v1 que atende o método e o caminho tem
+// equivalente na v2. O caminho é resolvido como o Gin faria, com rotas
+// estáticas antes das parametrizadas, para que /api/produtos/reposicao não
+// seja confundido com /api/v2/produtos/:id.
+func (v *VersionRouter) hasV2Route(method, path string) bool {
+	v.once.Do(func() {
+		v.routes = make(map[string][][]string)
+		for _, route := range v.engine.Routes() {
+			v.routes[route.Method] = append(v.routes[route.Method], strings.Split(route.Path, "/"))
+		}
+	})
+
+	v1Path := apiPrefix + strings.TrimPrefix(path, apiV2Prefix)
+	pattern := v.matchRoute(method, strings.Split(v1Path, "/"))
+	if pattern == "" || strings.HasPrefix(pattern, apiV2Prefix+"/") {
+		return false
+	}
+	successor := apiV2Prefix + strings.TrimPrefix(pattern, apiPrefix)
+	return v.matchRoute(method, strings.Split(path, "/")) == successor
+}
+
+// matchRoute retorna o padrão da rota que atende o caminho, preferindo em
+// cada segmento o trecho estático ao parâmetro e o parâmetro ao curinga
+func (v *VersionRouter) matchRoute(method string, segments []string) string {
+	var best []string
+	for _, pattern := range v.routes[method] {
+		if matchSegments(pattern, segments) && (best == nil || moreSpecific(pattern, best)) {
+			best = pattern
+		}
+	}
+	return strings.Join(best, "/")
+}
+
+// moreSpecific indica se o padrão a vence b no primeiro segmento em que diferem
+func moreSpecific(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		return segmentRank(a[i]) < segmentRank(b[i])
+	}
+	return len(a) > len(b)
+}
+
+// segmentRank ordena os segmentos de rota pela prioridade do Gin
+func segmentRank(part string) int {
+	switch {
+	case strings.HasPrefix(part, "*"):
+		return 2
+	case strings.HasPrefix(part, ":"):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// matchSegments compara um caminho com o padrão de uma rota do Gin
+func matchSegments(pattern, segments []string) bool {
+	for i, part := range pattern {
+		if strings.HasPrefix(part, "*") {
+			return true
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if strings.HasPrefix(part, ":") {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if part != segments[i] {
+			return false
+		}
+	}
+	return len(pattern) == len(segments)
+}
+
+// writeNotAcceptable responde 406 no formato de erro da v2, já que a
+// requisição ainda não chegou a uma versão
+func writeNotAcceptable(w http.ResponseWriter, mensagem string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusNotAcceptable)
+	json.NewEncoder(w).Encode(dtosv2.ErrorEnvelope{
+		Erro: dtosv2.ErrorBody{
+			Status:   http.StatusNotAcceptable,
+			Codigo:   "VERSION_NOT_ACCEPTABLE",
+			Mensagem: mensagem,
+		},
+		Meta: dtosv2.NewMeta("", ""),
+	})
+}
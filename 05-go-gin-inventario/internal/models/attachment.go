@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttachmentKind representa o tipo de um anexo de produto
+type AttachmentKind string
+
+const (
+	// AttachmentImagem são fotos do produto, com miniaturas geradas no envio
+	AttachmentImagem AttachmentKind = "imagem"
+	// AttachmentDocumento são manuais, fichas técnicas e demais documentos
+	AttachmentDocumento AttachmentKind = "documento"
+)
+
+// IsValid verifica se o tipo de anexo é conhecido
+func (k AttachmentKind) IsValid() bool {
+	return k == AttachmentImagem || k == AttachmentDocumento
+}
+
+// Thumbnail representa uma miniatura gerada a partir de uma imagem
+type Thumbnail struct {
+	Tamanho string `json:"tamanho"`
+	Arquivo string `json:"arquivo"`
+	Largura int    `json:"largura"`
+	Altura  int    `json:"altura"`
+}
+
+// Attachment representa um arquivo enviado para um produto. O conteúdo fica
+// no armazenamento de arquivos; aqui ficam apenas os metadados e os nomes dos
+// arquivos gravados, relativos ao diretório de armazenamento.
+type Attachment struct {
+	ID           uuid.UUID      `json:"id"`
+	ProdutoID    uuid.UUID      `json:"produto_id"`
+	Tipo         AttachmentKind `json:"tipo"`
+	NomeOriginal string         `json:"nome_original"`
+	Descricao    string         `json:"descricao,omitempty"`
+	ContentType  string         `json:"content_type"`
+	Tamanho      int64          `json:"tamanho"`
+	Arquivo      string         `json:"arquivo"`
+	Largura      int            `json:"largura,omitempty"`
+	Altura       int            `json:"altura,omitempty"`
+	Miniaturas   []Thumbnail    `json:"miniaturas,omitempty"`
+	Principal    bool           `json:"principal"`
+	DataCriacao  time.Time      `json:"data_criacao"`
+}
+
+// Clone retorna uma cópia independente do anexo, incluindo as miniaturas
+func (a *Attachment) Clone() *Attachment {
+	clone := *a
+	clone.Miniaturas = append([]Thumbnail(nil), a.Miniaturas...)
+	return &clone
+}
+
+// IsImage verifica se o anexo é uma imagem do produto
+func (a *Attachment) IsImage() bool {
+	return a.Tipo == AttachmentImagem
+}
+
+// Files retorna todos os arquivos gravados do anexo, original e miniaturas
+func (a *Attachment) Files() []string {
+	files := []string{a.Arquivo}
+	for _, thumbnail := range a.Miniaturas {
+		files = append(files, thumbnail.Arquivo)
+	}
+	return files
+}
+
+// Thumbnail retorna a miniatura do tamanho informado, se gerada
+func (a *Attachment) Thumbnail(tamanho string) *Thumbnail {
+	for i := range a.Miniaturas {
+		if a.Miniaturas[i].Tamanho == tamanho {
+			return &a.Miniaturas[i]
+		}
+	}
+	return nil
+}
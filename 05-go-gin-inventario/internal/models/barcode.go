@@ -0,0 +1,42 @@
+package models
+
+import "strings"
+
+// NoGTIN é o valor usado na NF-e para itens sem código de barras
+const NoGTIN = "SEM GTIN"
+
+// NormalizeBarcode remove espaços e hífens do código de barras; "SEM GTIN"
+// vira vazio
+func NormalizeBarcode(codigo string) string {
+	codigo = strings.TrimSpace(codigo)
+	if strings.EqualFold(codigo, NoGTIN) {
+		return ""
+	}
+	return strings.NewReplacer(" ", "", "-", "").Replace(codigo)
+}
+
+// IsValidGTIN verifica se o código é um GTIN-8, GTIN-12 (UPC), GTIN-13 (EAN)
+// ou GTIN-14 com dígito verificador correto
+func IsValidGTIN(codigo string) bool {
+	switch len(codigo) {
+	case 8, 12, 13, 14:
+	default:
+		return false
+	}
+
+	soma := 0
+	for i := len(codigo) - 2; i >= 0; i-- {
+		digito := codigo[i]
+		if digito < '0' || digito > '9' {
+			return false
+		}
+		peso := 1
+		if (len(codigo)-2-i)%2 == 0 {
+			peso = 3
+		}
+		soma += int(digito-'0') * peso
+	}
+
+	verificador := codigo[len(codigo)-1]
+	return verificador >= '0' && verificador <= '9' && int(verificador-'0') == (10-soma%10)%10
+}
@@ -0,0 +1,28 @@
+package models
+
+// MaxBulkItems limita a soma de cadastros, atualizações e remoções de um lote
+const MaxBulkItems = 1000
+
+// BulkMode define como um lote de operações reage às falhas de seus itens
+type BulkMode string
+
+const (
+	// BulkAllOrNothing aplica o lote apenas se todos os itens forem válidos
+	BulkAllOrNothing BulkMode = "tudo_ou_nada"
+	// BulkBestEffort aplica os itens válidos e relata os que falharam
+	BulkBestEffort BulkMode = "melhor_esforco"
+)
+
+// IsValid verifica se o modo do lote é conhecido
+func (m BulkMode) IsValid() bool {
+	return m == BulkAllOrNothing || m == BulkBestEffort
+}
+
+// BulkOperation identifica a operação de um item do lote
+type BulkOperation string
+
+const (
+	BulkCreate BulkOperation = "criar"
+	BulkUpdate BulkOperation = "atualizar"
+	BulkDelete BulkOperation = "remover"
+)
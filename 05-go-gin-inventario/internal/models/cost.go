@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ValuationMethod representa o método de valoração do estoque a custo
+type ValuationMethod string
+
+const (
+	ValuationFIFO           ValuationMethod = "fifo"
+	ValuationMediaPonderada ValuationMethod = "media_ponderada"
+)
+
+// CostLayer representa uma camada de custo do estoque: a quantidade recebida em
+// uma entrada e o custo unitário pago, consumida na ordem de chegada (FIFO)
+type CostLayer struct {
+	ID                uuid.UUID `json:"id"`
+	ProdutoID         uuid.UUID `json:"produto_id"`
+	CustoUnitario     float64   `json:"custo_unitario"`
+	QuantidadeInicial int       `json:"quantidade_inicial"`
+	Quantidade        int       `json:"quantidade"`
+	Referencia        string    `json:"referencia,omitempty"`
+	DataEntrada       time.Time `json:"data_entrada"`
+}
+
+// Consume retira até a quantidade solicitada da camada e retorna quanto foi consumido
+func (l *CostLayer) Consume(quantidade int) int {
+	if quantidade > l.Quantidade {
+		quantidade = l.Quantidade
+	}
+	l.Quantidade -= quantidade
+	return quantidade
+}
+
+// UnitCost retorna o custo unitário usado em margens: o custo médio ponderado
+// ou, antes da primeira entrada, o preço de custo cadastrado
+func (p *Product) UnitCost() float64 {
+	if p.CustoMedio > 0 {
+		return p.CustoMedio
+	}
+	return p.PrecoCusto
+}
+
+// Margin retorna a margem bruta sobre o preço de venda, em percentual
+func (p *Product) Margin() float64 {
+	if p.Preco <= 0 {
+		return 0
+	}
+	return (p.Preco - p.UnitCost()) / p.Preco * 100
+}
+
+// Markup retorna o acréscimo do preço de venda sobre o custo, em percentual
+func (p *Product) Markup() float64 {
+	custo := p.UnitCost()
+	if custo <= 0 {
+		return 0
+	}
+	return (p.Preco - custo) / custo * 100
+}
+
+// ReceiveCost recalcula o custo médio ponderado com uma entrada de estoque.
+// A quantidade em estoque deve ser a anterior à entrada.
+func (p *Product) ReceiveCost(emEstoque, quantidade int, custoUnitario float64) {
+	if emEstoque <= 0 {
+		p.CustoMedio = custoUnitario
+		return
+	}
+	total := float64(emEstoque)*p.CustoMedio + float64(quantidade)*custoUnitario
+	p.CustoMedio = total / float64(emEstoque+quantidade)
+}
@@ -0,0 +1,231 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ProductOrigin representa a origem da mercadoria (tabela A do CST do ICMS)
+type ProductOrigin int
+
+const (
+	OrigemNacional                     ProductOrigin = 0
+	OrigemEstrangeiraImportacaoDireta  ProductOrigin = 1
+	OrigemEstrangeiraMercadoInterno    ProductOrigin = 2
+	OrigemNacionalImportacao40a70      ProductOrigin = 3
+	OrigemNacionalPPB                  ProductOrigin = 4
+	OrigemNacionalImportacaoAte40      ProductOrigin = 5
+	OrigemEstrangeiraDiretaSemSimilar  ProductOrigin = 6
+	OrigemEstrangeiraInternoSemSimilar ProductOrigin = 7
+	OrigemNacionalImportacaoAcima70    ProductOrigin = 8
+)
+
+var originDescriptions = map[ProductOrigin]string{
+	OrigemNacional:                     "Nacional",
+	OrigemEstrangeiraImportacaoDireta:  "Estrangeira - importação direta",
+	OrigemEstrangeiraMercadoInterno:    "Estrangeira - adquirida no mercado interno",
+	OrigemNacionalImportacao40a70:      "Nacional com conteúdo de importação acima de 40% e até 70%",
+	OrigemNacionalPPB:                  "Nacional produzida conforme processo produtivo básico",
+	OrigemNacionalImportacaoAte40:      "Nacional com conteúdo de importação de até 40%",
+	OrigemEstrangeiraDiretaSemSimilar:  "Estrangeira - importação direta, sem similar nacional",
+	OrigemEstrangeiraInternoSemSimilar: "Estrangeira - adquirida no mercado interno, sem similar nacional",
+	OrigemNacionalImportacaoAcima70:    "Nacional com conteúdo de importação acima de 70%",
+}
+
+// IsValid verifica se a origem é conhecida
+func (o ProductOrigin) IsValid() bool {
+	_, exists := originDescriptions[o]
+	return exists
+}
+
+// Description retorna a descrição da origem
+func (o ProductOrigin) Description() string {
+	return originDescriptions[o]
+}
+
+// UsesImportRate verifica se operações interestaduais usam a alíquota de ICMS
+// de importados (Resolução do Senado 13/2012)
+func (o ProductOrigin) UsesImportRate() bool {
+	return o == OrigemEstrangeiraImportacaoDireta || o == OrigemEstrangeiraMercadoInterno ||
+		o == OrigemNacionalImportacao40a70 || o == OrigemNacionalImportacaoAcima70
+}
+
+// FiscalData representa os dados fiscais do produto usados na emissão de notas.
+// Alíquotas não informadas vêm da tabela NCM (IPI) e da tabela de tributos (PIS e COFINS).
+type FiscalData struct {
+	NCM               string        `json:"ncm"`
+	CEST              string        `json:"cest,omitempty"`
+	CFOPEstadual      string        `json:"cfop_estadual"`
+	CFOPInterestadual string        `json:"cfop_interestadual"`
+	Origem            ProductOrigin `json:"origem"`
+	AliquotaIPI       *float64      `json:"aliquota_ipi,omitempty"`
+	AliquotaPIS       *float64      `json:"aliquota_pis,omitempty"`
+	AliquotaCOFINS    *float64      `json:"aliquota_cofins,omitempty"`
+}
+
+// CFOPs padrão de venda de mercadoria adquirida de terceiros
+const (
+	DefaultCFOPEstadual      = "5102"
+	DefaultCFOPInterestadual = "6102"
+)
+
+// Clone retorna uma cópia independente dos dados fiscais
+func (f *FiscalData) Clone() *FiscalData {
+	clone := *f
+	clone.AliquotaIPI = cloneRate(f.AliquotaIPI)
+	clone.AliquotaPIS = cloneRate(f.AliquotaPIS)
+	clone.AliquotaCOFINS = cloneRate(f.AliquotaCOFINS)
+	return &clone
+}
+
+// Normalize remove a pontuação dos códigos e aplica os CFOPs padrão
+func (f *FiscalData) Normalize() {
+	f.NCM = strings.NewReplacer(".", "", " ", "").Replace(f.NCM)
+	f.CEST = strings.NewReplacer(".", "", " ", "").Replace(f.CEST)
+	f.CFOPEstadual = strings.ReplaceAll(f.CFOPEstadual, ".", "")
+	f.CFOPInterestadual = strings.ReplaceAll(f.CFOPInterestadual, ".", "")
+	if f.CFOPEstadual == "" {
+		f.CFOPEstadual = DefaultCFOPEstadual
+	}
+	if f.CFOPInterestadual == "" {
+		f.CFOPInterestadual = DefaultCFOPInterestadual
+	}
+}
+
+// Validate verifica os dados fiscais já normalizados: NCM existente na tabela,
+// CEST com 7 dígitos, CFOPs de saída estadual (5) e interestadual (6),
+// origem conhecida e alíquotas entre 0 e 100
+func (f *FiscalData) Validate() error {
+	if _, exists := LookupNCM(f.NCM); !exists {
+		if NormalizeNCM(f.NCM) == "" {
+			return fmt.Errorf("NCM %q deve ter 8 dígitos", f.NCM)
+		}
+		return fmt.Errorf("NCM %s não consta na tabela NCM", f.NCM)
+	}
+	if f.CEST != "" && onlyDigits(f.CEST, 7) == "" {
+		return fmt.Errorf("CEST %q deve ter 7 dígitos", f.CEST)
+	}
+	if onlyDigits(f.CFOPEstadual, 4) == "" || f.CFOPEstadual[0] != '5' {
+		return fmt.Errorf("CFOP estadual %q deve ter 4 dígitos e começar com 5", f.CFOPEstadual)
+	}
+	if onlyDigits(f.CFOPInterestadual, 4) == "" || f.CFOPInterestadual[0] != '6' {
+		return fmt.Errorf("CFOP interestadual %q deve ter 4 dígitos e começar com 6", f.CFOPInterestadual)
+	}
+	if !f.Origem.IsValid() {
+		return fmt.Errorf("origem %d inválida (use de 0 a 8)", f.Origem)
+	}
+	for nome, aliquota := range map[string]*float64{"IPI": f.AliquotaIPI, "PIS": f.AliquotaPIS, "COFINS": f.AliquotaCOFINS} {
+		if aliquota != nil && (*aliquota < 0 || *aliquota > 100) {
+			return fmt.Errorf("alíquota de %s deve estar entre 0 e 100", nome)
+		}
+	}
+	return nil
+}
+
+// TaxTable representa as alíquotas usadas na simulação de tributos: ICMS
+// interno de cada UF, ICMS interestadual e PIS/COFINS do regime não cumulativo
+type TaxTable struct {
+	UFOrigem                      string             `json:"uf_origem"`
+	AliquotasInternas             map[string]float64 `json:"aliquotas_internas"`
+	AliquotaInterestadual         float64            `json:"aliquota_interestadual"`
+	AliquotaInterestadualReduzida float64            `json:"aliquota_interestadual_reduzida"`
+	UFsAliquotaReduzida           []string           `json:"ufs_aliquota_reduzida"`
+	AliquotaImportados            float64            `json:"aliquota_importados"`
+	AliquotaPIS                   float64            `json:"aliquota_pis"`
+	AliquotaCOFINS                float64            `json:"aliquota_cofins"`
+}
+
+// InternalRate retorna a alíquota interna de ICMS da UF
+func (t *TaxTable) InternalRate(uf string) (float64, bool) {
+	aliquota, exists := t.AliquotasInternas[uf]
+	return aliquota, exists
+}
+
+// InterstateRate retorna a alíquota de ICMS de uma saída da UF de origem para
+// a UF de destino: 4% para importados, a reduzida para as UFs listadas e a
+// padrão para as demais
+func (t *TaxTable) InterstateRate(ufDestino string, origem ProductOrigin) float64 {
+	if origem.UsesImportRate() {
+		return t.AliquotaImportados
+	}
+	for _, uf := range t.UFsAliquotaReduzida {
+		if uf == ufDestino {
+			return t.AliquotaInterestadualReduzida
+		}
+	}
+	return t.AliquotaInterestadual
+}
+
+// Validate verifica a consistência da tabela de tributos
+func (t *TaxTable) Validate() error {
+	if _, exists := t.AliquotasInternas[t.UFOrigem]; !exists {
+		return fmt.Errorf("a UF de origem %q precisa de alíquota interna", t.UFOrigem)
+	}
+	for uf, aliquota := range t.AliquotasInternas {
+		if len(uf) != 2 || strings.ToUpper(uf) != uf || aliquota < 0 || aliquota > 100 {
+			return fmt.Errorf("alíquota interna de %q inválida", uf)
+		}
+	}
+	for _, uf := range t.UFsAliquotaReduzida {
+		if _, exists := t.AliquotasInternas[uf]; !exists {
+			return fmt.Errorf("UF %q com alíquota reduzida não consta nas alíquotas internas", uf)
+		}
+	}
+	for _, aliquota := range []float64{t.AliquotaInterestadual, t.AliquotaInterestadualReduzida, t.AliquotaImportados, t.AliquotaPIS, t.AliquotaCOFINS} {
+		if aliquota < 0 || aliquota > 100 {
+			return fmt.Errorf("alíquotas devem estar entre 0 e 100")
+		}
+	}
+	return nil
+}
+
+// UFs retorna as UFs da tabela em ordem alfabética
+func (t *TaxTable) UFs() []string {
+	ufs := make([]string, 0, len(t.AliquotasInternas))
+	for uf := range t.AliquotasInternas {
+		ufs = append(ufs, uf)
+	}
+	sort.Strings(ufs)
+	return ufs
+}
+
+// DefaultTaxTable retorna a tabela de tributos usada quando nenhuma é
+// configurada: saídas de SP, alíquotas modais internas de ICMS (incluindo o
+// adicional de fundo de pobreza onde é geral), 12%/7% interestadual e
+// PIS/COFINS não cumulativos
+func DefaultTaxTable() TaxTable {
+	return TaxTable{
+		UFOrigem: "SP",
+		AliquotasInternas: map[string]float64{
+			"AC": 19, "AL": 19, "AM": 20, "AP": 18, "BA": 20.5, "CE": 20, "DF": 20,
+			"ES": 17, "GO": 19, "MA": 22, "MG": 18, "MS": 17, "MT": 17, "PA": 19,
+			"PB": 20, "PE": 20.5, "PI": 21, "PR": 19.5, "RJ": 22, "RN": 18, "RO": 19.5,
+			"RR": 20, "RS": 17, "SC": 17, "SE": 19, "SP": 18, "TO": 20,
+		},
+		AliquotaInterestadual:         12,
+		AliquotaInterestadualReduzida: 7,
+		UFsAliquotaReduzida: []string{
+			"AC", "AL", "AM", "AP", "BA", "CE", "DF", "ES", "GO", "MA", "MS", "MT", "PA",
+			"PB", "PE", "PI", "RN", "RO", "RR", "SE", "TO",
+		},
+		AliquotaImportados: 4,
+		AliquotaPIS:        1.65,
+		AliquotaCOFINS:     7.6,
+	}
+}
+
+// ApplyRate calcula o tributo sobre a base, arredondado em centavos
+func ApplyRate(base, aliquota float64) float64 {
+	return math.Round(base*aliquota) / 100
+}
+
+// cloneRate copia uma alíquota opcional
+func cloneRate(rate *float64) *float64 {
+	if rate == nil {
+		return nil
+	}
+	value := *rate
+	return &value
+}
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InventoryCountStatus representa a situação de uma contagem de inventário
+type InventoryCountStatus string
+
+const (
+	CountAberta    InventoryCountStatus = "aberta"
+	CountEncerrada InventoryCountStatus = "encerrada"
+	CountPostada   InventoryCountStatus = "postada"
+	CountCancelada InventoryCountStatus = "cancelada"
+)
+
+// InventoryCountItem representa um produto de uma contagem. A quantidade
+// esperada e o custo unitário são congelados na abertura da contagem.
+type InventoryCountItem struct {
+	ProdutoID          uuid.UUID       `json:"produto_id"`
+	Nome               string          `json:"nome"`
+	Categoria          ProductCategory `json:"categoria"`
+	Localizacao        string          `json:"localizacao,omitempty"`
+	QuantidadeEsperada int             `json:"quantidade_esperada"`
+	QuantidadeContada  *int            `json:"quantidade_contada,omitempty"`
+	CustoUnitario      float64         `json:"custo_unitario"`
+	Contagens          int             `json:"contagens"`
+	ContadoPor         string          `json:"contado_por,omitempty"`
+	DataContagem       *time.Time      `json:"data_contagem,omitempty"`
+}
+
+// IsCounted verifica se o item já recebeu contagem
+func (i *InventoryCountItem) IsCounted() bool {
+	return i.QuantidadeContada != nil
+}
+
+// Variance retorna a diferença entre o contado e o esperado: positiva para
+// sobras e negativa para faltas. Itens não contados não têm divergência.
+func (i *InventoryCountItem) Variance() int {
+	if !i.IsCounted() {
+		return 0
+	}
+	return *i.QuantidadeContada - i.QuantidadeEsperada
+}
+
+// VarianceValue retorna o impacto da divergência a custo
+func (i *InventoryCountItem) VarianceValue() float64 {
+	return math.Round(float64(i.Variance())*i.CustoUnitario*100) / 100
+}
+
+// Count registra a quantidade contada; contagens seguintes substituem a anterior
+func (i *InventoryCountItem) Count(quantidade int, contadoPor string) {
+	now := time.Now()
+	i.QuantidadeContada = &quantidade
+	i.Contagens++
+	i.ContadoPor = contadoPor
+	i.DataContagem = &now
+}
+
+// InventoryCount representa uma sessão de contagem física de estoque sobre um
+// escopo de produtos, filtrado por categoria e/ou localização. Em contagens
+// cegas as quantidades esperadas só são exibidas após o encerramento.
+type InventoryCount struct {
+	ID               uuid.UUID            `json:"id"`
+	Numero           string               `json:"numero"`
+	Descricao        string               `json:"descricao,omitempty"`
+	Categoria        *ProductCategory     `json:"categoria,omitempty"`
+	Localizacao      string               `json:"localizacao,omitempty"`
+	Cega             bool                 `json:"cega"`
+	Status           InventoryCountStatus `json:"status"`
+	Itens            []InventoryCountItem `json:"itens"`
+	Ajustes          int                  `json:"ajustes"`
+	AprovadoPor      string               `json:"aprovado_por,omitempty"`
+	DataAbertura     time.Time            `json:"data_abertura"`
+	DataEncerramento *time.Time           `json:"data_encerramento,omitempty"`
+	DataPostagem     *time.Time           `json:"data_postagem,omitempty"`
+	DataCancelamento *time.Time           `json:"data_cancelamento,omitempty"`
+	DataAtualizacao  time.Time            `json:"data_atualizacao"`
+}
+
+// Clone retorna uma cópia independente da contagem, incluindo os itens
+func (c *InventoryCount) Clone() *InventoryCount {
+	clone := *c
+	if c.Categoria != nil {
+		categoria := *c.Categoria
+		clone.Categoria = &categoria
+	}
+	clone.Itens = make([]InventoryCountItem, len(c.Itens))
+	for i, item := range c.Itens {
+		if item.QuantidadeContada != nil {
+			contada := *item.QuantidadeContada
+			item.QuantidadeContada = &contada
+		}
+		clone.Itens[i] = item
+	}
+	return &clone
+}
+
+// IsInProgress verifica se a contagem ainda não foi postada nem cancelada
+func (c *InventoryCount) IsInProgress() bool {
+	return c.Status == CountAberta || c.Status == CountEncerrada
+}
+
+// HidesExpected verifica se as quantidades esperadas devem ser ocultadas:
+// contagens cegas enquanto ainda estão abertas
+func (c *InventoryCount) HidesExpected() bool {
+	return c.Cega && c.Status == CountAberta
+}
+
+// Item retorna o item da contagem para o produto informado
+func (c *InventoryCount) Item(productID uuid.UUID) *InventoryCountItem {
+	for i := range c.Itens {
+		if c.Itens[i].ProdutoID == productID {
+			return &c.Itens[i]
+		}
+	}
+	return nil
+}
+
+// Pending retorna quantos itens ainda não foram contados
+func (c *InventoryCount) Pending() int {
+	pendentes := 0
+	for _, item := range c.Itens {
+		if !item.IsCounted() {
+			pendentes++
+		}
+	}
+	return pendentes
+}
+
+// SetStatus altera a situação da contagem registrando a data correspondente
+func (c *InventoryCount) SetStatus(status InventoryCountStatus) {
+	now := time.Now()
+	c.Status = status
+	switch status {
+	case CountAberta:
+		c.DataEncerramento = nil
+	case CountEncerrada:
+		c.DataEncerramento = &now
+	case CountPostada:
+		c.DataPostagem = &now
+	case CountCancelada:
+		c.DataCancelamento = &now
+	}
+	c.DataAtualizacao = now
+}
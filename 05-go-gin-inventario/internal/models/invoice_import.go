@@ -0,0 +1,164 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceImportStatus representa a situação de uma importação de NF-e
+type InvoiceImportStatus string
+
+const (
+	InvoiceImportPendente   InvoiceImportStatus = "pendente"
+	InvoiceImportConfirmada InvoiceImportStatus = "confirmada"
+	InvoiceImportDescartada InvoiceImportStatus = "descartada"
+)
+
+// InvoiceMatch indica como o item da nota foi vinculado a um produto
+type InvoiceMatch string
+
+const (
+	InvoiceMatchCodigoBarras     InvoiceMatch = "codigo_barras"
+	InvoiceMatchCodigoFornecedor InvoiceMatch = "codigo_fornecedor"
+	InvoiceMatchManual           InvoiceMatch = "manual"
+)
+
+// InvoiceLot representa um lote informado no grupo de rastreabilidade do item
+type InvoiceLot struct {
+	Codigo         string    `json:"codigo"`
+	Quantidade     float64   `json:"quantidade"`
+	DataFabricacao time.Time `json:"data_fabricacao"`
+	DataValidade   time.Time `json:"data_validade"`
+}
+
+// InvoiceLine representa um item da NF-e e o produto ao qual foi vinculado
+type InvoiceLine struct {
+	Item          int          `json:"item"`
+	CodigoProduto string       `json:"codigo_produto"`
+	CodigoBarras  string       `json:"codigo_barras,omitempty"`
+	Descricao     string       `json:"descricao"`
+	NCM           string       `json:"ncm,omitempty"`
+	CFOP          string       `json:"cfop,omitempty"`
+	Unidade       string       `json:"unidade,omitempty"`
+	Quantidade    float64      `json:"quantidade"`
+	ValorUnitario float64      `json:"valor_unitario"`
+	ValorTotal    float64      `json:"valor_total"`
+	Lotes         []InvoiceLot `json:"lotes,omitempty"`
+	ProdutoID     *uuid.UUID   `json:"produto_id,omitempty"`
+	NomeProduto   string       `json:"nome_produto,omitempty"`
+	Vinculo       InvoiceMatch `json:"vinculo,omitempty"`
+	Ignorado      bool         `json:"ignorado"`
+}
+
+// IsResolved verifica se o item já foi vinculado a um produto ou ignorado
+func (l *InvoiceLine) IsResolved() bool {
+	return l.ProdutoID != nil || l.Ignorado
+}
+
+// Link vincula o item ao produto
+func (l *InvoiceLine) Link(product *Product, vinculo InvoiceMatch) {
+	id := product.ID
+	l.ProdutoID = &id
+	l.NomeProduto = product.Nome
+	l.Vinculo = vinculo
+	l.Ignorado = false
+}
+
+// Unlink desfaz o vínculo do item
+func (l *InvoiceLine) Unlink() {
+	l.ProdutoID = nil
+	l.NomeProduto = ""
+	l.Vinculo = ""
+}
+
+// WholeQuantity retorna a quantidade comercial como inteiro, se não tiver
+// casas decimais
+func WholeQuantity(quantidade float64) (int, bool) {
+	if quantidade != math.Trunc(quantidade) {
+		return 0, false
+	}
+	return int(quantidade), true
+}
+
+// InvoiceImport representa uma NF-e de entrada importada do XML do
+// fornecedor. Fluxo: pendente → confirmada (estoque e custos lançados) ou
+// descartada.
+type InvoiceImport struct {
+	ID              uuid.UUID           `json:"id"`
+	ChaveAcesso     string              `json:"chave_acesso"`
+	Numero          string              `json:"numero"`
+	Serie           string              `json:"serie"`
+	DataEmissao     time.Time           `json:"data_emissao"`
+	EmitenteCNPJ    string              `json:"emitente_cnpj"`
+	EmitenteNome    string              `json:"emitente_nome"`
+	FornecedorID    *uuid.UUID          `json:"fornecedor_id,omitempty"`
+	Status          InvoiceImportStatus `json:"status"`
+	Itens           []InvoiceLine       `json:"itens"`
+	ValorProdutos   float64             `json:"valor_produtos"`
+	ValorTotal      float64             `json:"valor_total"`
+	Observacao      string              `json:"observacao,omitempty"`
+	DataImportacao  time.Time           `json:"data_importacao"`
+	DataConclusao   *time.Time          `json:"data_conclusao,omitempty"`
+	DataAtualizacao time.Time           `json:"data_atualizacao"`
+}
+
+// Clone retorna uma cópia independente da importação, incluindo os itens
+func (n *InvoiceImport) Clone() *InvoiceImport {
+	clone := *n
+	if n.FornecedorID != nil {
+		fornecedorID := *n.FornecedorID
+		clone.FornecedorID = &fornecedorID
+	}
+	if n.DataConclusao != nil {
+		data := *n.DataConclusao
+		clone.DataConclusao = &data
+	}
+	clone.Itens = make([]InvoiceLine, len(n.Itens))
+	for i, line := range n.Itens {
+		if line.ProdutoID != nil {
+			produtoID := *line.ProdutoID
+			line.ProdutoID = &produtoID
+		}
+		line.Lotes = append([]InvoiceLot(nil), line.Lotes...)
+		clone.Itens[i] = line
+	}
+	return &clone
+}
+
+// IsPending verifica se a importação ainda aguarda confirmação
+func (n *InvoiceImport) IsPending() bool {
+	return n.Status == InvoiceImportPendente
+}
+
+// Line busca um item da nota pelo número
+func (n *InvoiceImport) Line(item int) *InvoiceLine {
+	for i := range n.Itens {
+		if n.Itens[i].Item == item {
+			return &n.Itens[i]
+		}
+	}
+	return nil
+}
+
+// Unresolved retorna os números dos itens ainda sem produto vinculado
+func (n *InvoiceImport) Unresolved() []int {
+	itens := make([]int, 0)
+	for _, line := range n.Itens {
+		if !line.IsResolved() {
+			itens = append(itens, line.Item)
+		}
+	}
+	return itens
+}
+
+// SetStatus altera a situação da importação, registrando a conclusão
+func (n *InvoiceImport) SetStatus(status InvoiceImportStatus) {
+	now := time.Now()
+	n.Status = status
+	n.DataAtualizacao = now
+	if status != InvoiceImportPendente {
+		n.DataConclusao = &now
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// KitComponent representa um item da composição (lista de materiais) de um kit
+type KitComponent struct {
+	ProdutoID  uuid.UUID `json:"produto_id"`
+	Quantidade int       `json:"quantidade"`
+}
+
+// IsKit verifica se o produto é um kit composto por outros produtos
+func (p *Product) IsKit() bool {
+	return len(p.Componentes) > 0
+}
+
+// KitPrice calcula o preço do kit a partir da soma dos componentes aplicando o
+// desconto, arredondado em centavos
+func (p *Product) KitPrice(totalComponentes float64) float64 {
+	return math.Round(totalComponentes*(1-p.DescontoKit/100)*100) / 100
+}
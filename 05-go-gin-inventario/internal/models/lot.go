@@ -0,0 +1,50 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Lot representa um lote de fabricação de um produto, com estoque próprio e validade
+type Lot struct {
+	ID                uuid.UUID `json:"id"`
+	ProdutoID         uuid.UUID `json:"produto_id"`
+	Codigo            string    `json:"codigo"`
+	DataFabricacao    time.Time `json:"data_fabricacao"`
+	DataValidade      time.Time `json:"data_validade"`
+	QuantidadeInicial int       `json:"quantidade_inicial"`
+	Quantidade        int       `json:"quantidade"`
+	CustoUnitario     float64   `json:"custo_unitario"`
+	DataCriacao       time.Time `json:"data_criacao"`
+	DataAtualizacao   time.Time `json:"data_atualizacao"`
+}
+
+// IsExpired verifica se o lote está vencido no instante informado. O lote
+// pode ser usado até o fim do dia da validade.
+func (l *Lot) IsExpired(now time.Time) bool {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return l.DataValidade.Before(today)
+}
+
+// DaysToExpire retorna quantos dias faltam para o vencimento (negativo se já venceu)
+func (l *Lot) DaysToExpire(now time.Time) int {
+	return int(math.Floor(l.DataValidade.Sub(now).Hours() / 24))
+}
+
+// Consume retira até a quantidade solicitada do lote e retorna quanto foi consumido
+func (l *Lot) Consume(quantidade int) int {
+	if quantidade > l.Quantidade {
+		quantidade = l.Quantidade
+	}
+	l.Quantidade -= quantidade
+	l.DataAtualizacao = time.Now()
+	return quantidade
+}
+
+// Restore devolve ao lote unidades que haviam sido consumidas
+func (l *Lot) Restore(quantidade int) {
+	l.Quantidade += quantidade
+	l.DataAtualizacao = time.Now()
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ncmCSV é a tabela NCM distribuída com a aplicação, um recorte com os
+// códigos mais comuns do catálogo: código, descrição e alíquota de IPI (TIPI)
+// separados por ponto e vírgula
+//
+//go:embed data/ncm.csv
+var ncmCSV string
+
+// NCMEntry representa um código da Nomenclatura Comum do Mercosul
+type NCMEntry struct {
+	Codigo      string  `json:"codigo"`
+	Descricao   string  `json:"descricao"`
+	AliquotaIPI float64 `json:"aliquota_ipi"`
+}
+
+// ncmTable indexa a tabela NCM pelo código
+var ncmTable = mustParseNCM(ncmCSV)
+
+// mustParseNCM interpreta a tabela embutida; um arquivo malformado é erro de
+// empacotamento e interrompe a inicialização
+func mustParseNCM(data string) map[string]NCMEntry {
+	table, err := parseNCM(data)
+	if err != nil {
+		panic(err.Error())
+	}
+	return table
+}
+
+// UseNCMTable substitui a tabela embutida por uma tabela completa no mesmo
+// formato. Deve ser chamada na inicialização, antes de a API atender
+// requisições.
+func UseNCMTable(data string) error {
+	table, err := parseNCM(data)
+	if err != nil {
+		return err
+	}
+	ncmTable = table
+	return nil
+}
+
+// parseNCM interpreta uma tabela NCM com cabeçalho. Códigos não tributados
+// pelo IPI (NT) ficam com alíquota zero.
+func parseNCM(data string) (map[string]NCMEntry, error) {
+	table := make(map[string]NCMEntry)
+	lines := strings.Split(strings.TrimSpace(data), "\n")
+	for i, line := range lines[1:] {
+		fields := strings.Split(strings.TrimSpace(line), ";")
+		if len(fields) != 3 || NormalizeNCM(fields[0]) != fields[0] {
+			return nil, fmt.Errorf("tabela NCM: linha %d inválida: %q", i+2, line)
+		}
+		aliquota := 0.0
+		if !strings.EqualFold(fields[2], "NT") {
+			var err error
+			aliquota, err = strconv.ParseFloat(strings.ReplaceAll(fields[2], ",", "."), 64)
+			if err != nil {
+				return nil, fmt.Errorf("tabela NCM: alíquota inválida na linha %d: %v", i+2, err)
+			}
+		}
+		table[fields[0]] = NCMEntry{Codigo: fields[0], Descricao: fields[1], AliquotaIPI: aliquota}
+	}
+	if len(table) == 0 {
+		return nil, fmt.Errorf("tabela NCM vazia")
+	}
+	return table, nil
+}
+
+// NormalizeNCM remove pontos e espaços do código NCM e retorna vazio se o
+// resultado não tiver exatamente 8 dígitos
+func NormalizeNCM(ncm string) string {
+	return onlyDigits(ncm, 8)
+}
+
+// LookupNCM busca um código NCM na tabela
+func LookupNCM(ncm string) (NCMEntry, bool) {
+	entry, exists := ncmTable[NormalizeNCM(ncm)]
+	return entry, exists
+}
+
+// SearchNCM retorna os códigos cujo código começa pelo termo ou cuja
+// descrição o contém, ordenados pelo código
+func SearchNCM(termo string) []NCMEntry {
+	termo = strings.ToLower(strings.TrimSpace(termo))
+	prefixo := strings.NewReplacer(".", "", " ", "").Replace(termo)
+
+	entries := make([]NCMEntry, 0)
+	for _, entry := range ncmTable {
+		if termo == "" ||
+			strings.HasPrefix(entry.Codigo, prefixo) ||
+			strings.Contains(strings.ToLower(entry.Descricao), termo) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Codigo < entries[j].Codigo })
+	return entries
+}
+
+// onlyDigits remove pontos, hífens e espaços e retorna vazio se o resultado
+// não tiver exatamente a quantidade de dígitos informada
+func onlyDigits(value string, size int) string {
+	digits := strings.NewReplacer(".", "", "-", "", " ", "").Replace(value)
+	if len(digits) != size {
+		return ""
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return ""
+		}
+	}
+	return digits
+}
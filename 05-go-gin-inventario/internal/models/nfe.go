@@ -0,0 +1,159 @@
+package models
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Estrutura do grupo infNFe do leiaute da NF-e (versões 3.10 e 4.00), apenas
+// com os campos usados na entrada de mercadorias. O XML pode vir com ou sem
+// o envelope nfeProc do protocolo de autorização.
+type nfeInfo struct {
+	ID  string `xml:"Id,attr"`
+	Ide struct {
+		Numero string `xml:"nNF"`
+		Serie  string `xml:"serie"`
+		DhEmi  string `xml:"dhEmi"`
+		DEmi   string `xml:"dEmi"`
+	} `xml:"ide"`
+	Emit struct {
+		CNPJ string `xml:"CNPJ"`
+		Nome string `xml:"xNome"`
+	} `xml:"emit"`
+	Det []struct {
+		Item int `xml:"nItem,attr"`
+		Prod struct {
+			Codigo        string  `xml:"cProd"`
+			EAN           string  `xml:"cEAN"`
+			Descricao     string  `xml:"xProd"`
+			NCM           string  `xml:"NCM"`
+			CFOP          string  `xml:"CFOP"`
+			Unidade       string  `xml:"uCom"`
+			Quantidade    float64 `xml:"qCom"`
+			ValorUnitario float64 `xml:"vUnCom"`
+			ValorTotal    float64 `xml:"vProd"`
+			Rastro        []struct {
+				Lote           string  `xml:"nLote"`
+				Quantidade     float64 `xml:"qLote"`
+				DataFabricacao string  `xml:"dFab"`
+				DataValidade   string  `xml:"dVal"`
+			} `xml:"rastro"`
+		} `xml:"prod"`
+	} `xml:"det"`
+	Total struct {
+		ICMSTot struct {
+			ValorProdutos float64 `xml:"vProd"`
+			ValorNota     float64 `xml:"vNF"`
+		} `xml:"ICMSTot"`
+	} `xml:"total"`
+}
+
+// ParseNFe interpreta o XML de uma NF-e e retorna a importação pendente com
+// os itens ainda sem vínculo
+func ParseNFe(data []byte) (*InvoiceImport, error) {
+	info, err := decodeNFeInfo(data)
+	if err != nil {
+		return nil, err
+	}
+
+	chave := strings.TrimPrefix(info.ID, "NFe")
+	if onlyDigits(chave, 44) == "" {
+		return nil, fmt.Errorf("chave de acesso %q inválida", info.ID)
+	}
+	if len(info.Det) == 0 {
+		return nil, fmt.Errorf("a nota não possui itens")
+	}
+
+	emissao, err := parseNFeDate(info.Ide.DhEmi, info.Ide.DEmi)
+	if err != nil {
+		return nil, fmt.Errorf("data de emissão inválida: %v", err)
+	}
+
+	invoice := &InvoiceImport{
+		ChaveAcesso:   chave,
+		Numero:        strings.TrimSpace(info.Ide.Numero),
+		Serie:         strings.TrimSpace(info.Ide.Serie),
+		DataEmissao:   emissao,
+		EmitenteCNPJ:  strings.TrimSpace(info.Emit.CNPJ),
+		EmitenteNome:  strings.TrimSpace(info.Emit.Nome),
+		Itens:         make([]InvoiceLine, 0, len(info.Det)),
+		ValorProdutos: info.Total.ICMSTot.ValorProdutos,
+		ValorTotal:    info.Total.ICMSTot.ValorNota,
+	}
+
+	for _, det := range info.Det {
+		prod := det.Prod
+		if strings.TrimSpace(prod.Codigo) == "" || prod.Quantidade <= 0 {
+			return nil, fmt.Errorf("item %d sem código ou quantidade", det.Item)
+		}
+
+		line := InvoiceLine{
+			Item:          det.Item,
+			CodigoProduto: strings.TrimSpace(prod.Codigo),
+			CodigoBarras:  NormalizeBarcode(prod.EAN),
+			Descricao:     strings.TrimSpace(prod.Descricao),
+			NCM:           strings.TrimSpace(prod.NCM),
+			CFOP:          strings.TrimSpace(prod.CFOP),
+			Unidade:       strings.TrimSpace(prod.Unidade),
+			Quantidade:    prod.Quantidade,
+			ValorUnitario: prod.ValorUnitario,
+			ValorTotal:    prod.ValorTotal,
+		}
+		for _, rastro := range prod.Rastro {
+			fabricacao, errFab := time.Parse("2006-01-02", strings.TrimSpace(rastro.DataFabricacao))
+			validade, errVal := time.Parse("2006-01-02", strings.TrimSpace(rastro.DataValidade))
+			if errFab != nil || errVal != nil {
+				return nil, fmt.Errorf("item %d: datas do lote %q inválidas", det.Item, rastro.Lote)
+			}
+			line.Lotes = append(line.Lotes, InvoiceLot{
+				Codigo:         strings.TrimSpace(rastro.Lote),
+				Quantidade:     rastro.Quantidade,
+				DataFabricacao: fabricacao,
+				DataValidade:   validade,
+			})
+		}
+		invoice.Itens = append(invoice.Itens, line)
+	}
+
+	return invoice, nil
+}
+
+// decodeNFeInfo localiza o grupo infNFe no documento, esteja ele dentro de
+// nfeProc ou diretamente em NFe
+func decodeNFeInfo(data []byte) (*nfeInfo, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("grupo infNFe não encontrado")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("XML malformado: %v", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "infNFe" {
+			continue
+		}
+
+		var info nfeInfo
+		if err := decoder.DecodeElement(&info, &start); err != nil {
+			return nil, fmt.Errorf("XML malformado: %v", err)
+		}
+		return &info, nil
+	}
+}
+
+// parseNFeDate interpreta a data de emissão da versão 3.10+ (dhEmi, com fuso)
+// ou da versão 2.00 (dEmi, apenas a data)
+func parseNFeDate(dhEmi, dEmi string) (time.Time, error) {
+	if dhEmi = strings.TrimSpace(dhEmi); dhEmi != "" {
+		return time.Parse(time.RFC3339, dhEmi)
+	}
+	return time.Parse("2006-01-02", strings.TrimSpace(dEmi))
+}
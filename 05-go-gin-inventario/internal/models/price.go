@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PriceChangeOrigin representa a origem de uma alteração de preço
+type PriceChangeOrigin string
+
+const (
+	PriceOriginCadastro PriceChangeOrigin = "cadastro"
+	PriceOriginManual   PriceChangeOrigin = "manual"
+	PriceOriginAgendada PriceChangeOrigin = "agendada"
+	PriceOriginReajuste PriceChangeOrigin = "reajuste"
+)
+
+// PriceChange representa uma entrada do histórico de preços de um produto
+type PriceChange struct {
+	ID            uuid.UUID         `json:"id"`
+	ProdutoID     uuid.UUID         `json:"produto_id"`
+	PrecoAnterior float64           `json:"preco_anterior"`
+	PrecoNovo     float64           `json:"preco_novo"`
+	Origem        PriceChangeOrigin `json:"origem"`
+	Motivo        string            `json:"motivo,omitempty"`
+	VigenteDesde  time.Time         `json:"vigente_desde"`
+}
+
+// ScheduledPriceStatus representa os estados de um preço agendado
+type ScheduledPriceStatus string
+
+const (
+	ScheduledPricePendente  ScheduledPriceStatus = "pendente"
+	ScheduledPriceAplicado  ScheduledPriceStatus = "aplicado"
+	ScheduledPriceCancelado ScheduledPriceStatus = "cancelado"
+)
+
+// ScheduledPrice representa um preço futuro que entra em vigor automaticamente
+type ScheduledPrice struct {
+	ID              uuid.UUID            `json:"id"`
+	ProdutoID       uuid.UUID            `json:"produto_id"`
+	Preco           float64              `json:"preco"`
+	VigenteEm       time.Time            `json:"vigente_em"`
+	Motivo          string               `json:"motivo,omitempty"`
+	Status          ScheduledPriceStatus `json:"status"`
+	DataCriacao     time.Time            `json:"data_criacao"`
+	DataAtualizacao time.Time            `json:"data_atualizacao"`
+}
+
+// IsPending verifica se o preço agendado ainda não foi aplicado nem cancelado
+func (s *ScheduledPrice) IsPending() bool {
+	return s.Status == ScheduledPricePendente
+}
+
+// IsDue verifica se o preço agendado pendente já deve entrar em vigor
+func (s *ScheduledPrice) IsDue(now time.Time) bool {
+	return s.IsPending() && !now.Before(s.VigenteEm)
+}
+
+// SetStatus altera o status do agendamento atualizando o timestamp
+func (s *ScheduledPrice) SetStatus(status ScheduledPriceStatus) {
+	s.Status = status
+	s.DataAtualizacao = time.Now()
+}
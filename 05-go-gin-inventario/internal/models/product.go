@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,7 +12,7 @@ import (
 type ProductCategory string
 
 const (
-	CategoryEletronicos  ProductCategory = "eletronicos"
+	CategoryEletronicos ProductCategory = "eletronicos"
 	CategoryRoupas      ProductCategory = "roupas"
 	CategoryCasa        ProductCategory = "casa"
 	CategoryLivros      ProductCategory = "livros"
@@ -25,15 +26,36 @@ const (
 
 // Product representa um produto no inventário
 type Product struct {
-	ID             uuid.UUID       `json:"id" gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
-	Nome           string          `json:"nome" gorm:"not null;size:100" validate:"required,min=2,max=100"`
-	Descricao      string          `json:"descricao" gorm:"size:500" validate:"max=500"`
-	Preco          float64         `json:"preco" gorm:"not null;check:preco >= 0" validate:"required,min=0"`
-	Quantidade     int             `json:"quantidade" gorm:"not null;default:0;check:quantidade >= 0" validate:"min=0"`
-	Categoria      ProductCategory `json:"categoria" gorm:"not null;size:50" validate:"required,oneof=eletronicos roupas casa livros esportes beleza brinquedos automotivo alimentos outros"`
-	Ativo          bool            `json:"ativo" gorm:"not null;default:true"`
-	DataCriacao    time.Time       `json:"data_criacao" gorm:"autoCreateTime"`
-	DataAtualizacao time.Time      `json:"data_atualizacao" gorm:"autoUpdateTime"`
+	ID                    uuid.UUID                     `json:"id" gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Nome                  string                        `json:"nome" gorm:"not null;size:100" validate:"required,min=2,max=100"`
+	Descricao             string                        `json:"descricao" gorm:"size:500" validate:"max=500"`
+	Preco                 float64                       `json:"preco" gorm:"not null;check:preco >= 0" validate:"required,min=0"`
+	PrecoCusto            float64                       `json:"preco_custo" gorm:"not null;default:0;check:preco_custo >= 0" validate:"min=0"`
+	CustoMedio            float64                       `json:"custo_medio" gorm:"not null;default:0"`
+	Quantidade            int                           `json:"quantidade" gorm:"not null;default:0;check:quantidade >= 0" validate:"min=0"`
+	QuantidadeReservada   int                           `json:"quantidade_reservada" gorm:"not null;default:0" validate:"min=0"`
+	QuantidadeCaixaAberta int                           `json:"quantidade_caixa_aberta" gorm:"not null;default:0" validate:"min=0"`
+	EstoqueMinimo         int                           `json:"estoque_minimo" gorm:"not null;default:0" validate:"min=0"`
+	QuantidadeReposicao   int                           `json:"quantidade_reposicao" gorm:"not null;default:0" validate:"min=0"`
+	Categoria             ProductCategory               `json:"categoria" gorm:"not null;size:50" validate:"required,oneof=eletronicos roupas casa livros esportes beleza brinquedos automotivo alimentos outros"`
+	Localizacao           string                        `json:"localizacao,omitempty" gorm:"size:50" validate:"max=50"`
+	CodigoBarras          string                        `json:"codigo_barras,omitempty" gorm:"size:14;index"`
+	Tags                  []string                      `json:"tags" gorm:"serializer:json"`
+	Traducoes             map[Locale]ProductTranslation `json:"traducoes,omitempty" gorm:"serializer:json"`
+	Dimensoes             *Dimensions                   `json:"dimensoes,omitempty" gorm:"embedded;embeddedPrefix:dim_"`
+	Fiscal                *FiscalData                   `json:"fiscal,omitempty" gorm:"embedded;embeddedPrefix:fiscal_"`
+	Ativo                 bool                          `json:"ativo" gorm:"not null;default:true"`
+	Status                ProductStatus                 `json:"status" gorm:"not null;size:20;default:ativo"`
+	ControlaLote          bool                          `json:"controla_lote" gorm:"not null;default:false"`
+	Serializado           bool                          `json:"serializado" gorm:"not null;default:false"`
+	Componentes           []KitComponent                `json:"componentes,omitempty" gorm:"-"`
+	PrecoDerivado         bool                          `json:"preco_derivado" gorm:"not null;default:false"`
+	DescontoKit           float64                       `json:"desconto_kit" gorm:"not null;default:0" validate:"min=0,max=100"`
+	PrecoPromocional      float64                       `json:"preco_promocional,omitempty" gorm:"-"`
+	Promocoes             []AppliedPromotion            `json:"promocoes,omitempty" gorm:"-"`
+	Imagens               []Attachment                  `json:"imagens,omitempty" gorm:"-"`
+	DataCriacao           time.Time                     `json:"data_criacao" gorm:"autoCreateTime"`
+	DataAtualizacao       time.Time                     `json:"data_atualizacao" gorm:"autoUpdateTime"`
 }
 
 // TableName especifica o nome da tabela para GORM
@@ -41,12 +63,38 @@ func (Product) TableName() string {
 	return "produtos"
 }
 
+// Clone retorna uma cópia independente do produto, incluindo coleções
+func (p *Product) Clone() *Product {
+	clone := *p
+	clone.Tags = append([]string(nil), p.Tags...)
+	if p.Traducoes != nil {
+		clone.Traducoes = make(map[Locale]ProductTranslation, len(p.Traducoes))
+		for locale, translation := range p.Traducoes {
+			clone.Traducoes[locale] = translation
+		}
+	}
+	if p.Dimensoes != nil {
+		dimensoes := *p.Dimensoes
+		clone.Dimensoes = &dimensoes
+	}
+	if p.Fiscal != nil {
+		clone.Fiscal = p.Fiscal.Clone()
+	}
+	clone.Componentes = append([]KitComponent(nil), p.Componentes...)
+	clone.Promocoes = append([]AppliedPromotion(nil), p.Promocoes...)
+	clone.Imagens = make([]Attachment, len(p.Imagens))
+	for i := range p.Imagens {
+		clone.Imagens[i] = *p.Imagens[i].Clone()
+	}
+	return &clone
+}
+
 // IsValid verifica se o produto tem dados válidos
 func (p *Product) IsValid() bool {
-	return p.Nome != "" && 
-		   p.Preco >= 0 && 
-		   p.Quantidade >= 0 && 
-		   p.Categoria != ""
+	return p.Nome != "" &&
+		p.Preco >= 0 &&
+		p.Quantidade >= 0 &&
+		p.Categoria != ""
 }
 
 // IsInStock verifica se o produto está em estoque
@@ -54,6 +102,46 @@ func (p *Product) IsInStock() bool {
 	return p.Quantidade > 0 && p.Ativo
 }
 
+// AvailableQuantity retorna a quantidade disponível para venda (estoque físico menos reservas)
+func (p *Product) AvailableQuantity() int {
+	disponivel := p.Quantidade - p.QuantidadeReservada
+	if disponivel < 0 {
+		return 0
+	}
+	return disponivel
+}
+
+// NeedsReorder verifica se o disponível atingiu o ponto de reposição.
+// Produtos sem estoque mínimo configurado nunca entram em reposição.
+func (p *Product) NeedsReorder() bool {
+	return p.EstoqueMinimo > 0 && p.AvailableQuantity() <= p.EstoqueMinimo
+}
+
+// SuggestedReorderQuantity retorna a quantidade sugerida para compra: a
+// quantidade de reposição configurada ou, se insuficiente, o necessário para
+// voltar acima do estoque mínimo
+func (p *Product) SuggestedReorderQuantity() int {
+	if !p.NeedsReorder() {
+		return 0
+	}
+	falta := p.EstoqueMinimo - p.AvailableQuantity() + 1
+	if p.QuantidadeReposicao > falta {
+		return p.QuantidadeReposicao
+	}
+	return falta
+}
+
+// HasPromotion verifica se há promoção vigente aplicada ao preço do produto
+func (p *Product) HasPromotion() bool {
+	return len(p.Promocoes) > 0
+}
+
+// NormalizeLocation padroniza o endereço de armazenagem do produto para
+// comparação, como "a-01-03" e "A-01-03 "
+func NormalizeLocation(localizacao string) string {
+	return strings.ToUpper(strings.TrimSpace(localizacao))
+}
+
 // GetDisplayPrice retorna o preço formatado para exibição
 func (p *Product) GetDisplayPrice() string {
 	return fmt.Sprintf("R$ %.2f", p.Preco)
@@ -69,14 +157,30 @@ func (p *Product) UpdateStock(novaQuantidade int) error {
 	return nil
 }
 
+// AdjustStock aplica uma variação relativa (positiva ou negativa) ao estoque
+func (p *Product) AdjustStock(delta int) error {
+	novaQuantidade := p.Quantidade + delta
+	if novaQuantidade < p.QuantidadeReservada {
+		return fmt.Errorf("estoque insuficiente: disponível %d, ajuste %d", p.AvailableQuantity(), delta)
+	}
+	p.Quantidade = novaQuantidade
+	p.DataAtualizacao = time.Now()
+	return nil
+}
+
+// SetStatus altera a situação do ciclo de vida mantendo o campo ativo derivado
+func (p *Product) SetStatus(status ProductStatus) {
+	p.Status = status
+	p.Ativo = status.IsSellable()
+	p.DataAtualizacao = time.Now()
+}
+
 // Deactivate desativa o produto
 func (p *Product) Deactivate() {
-	p.Ativo = false
-	p.DataAtualizacao = time.Now()
+	p.SetStatus(StatusInativo)
 }
 
 // Activate ativa o produto
 func (p *Product) Activate() {
-	p.Ativo = true
-	p.DataAtualizacao = time.Now()
-}
\ No newline at end of file
+	p.SetStatus(StatusAtivo)
+}
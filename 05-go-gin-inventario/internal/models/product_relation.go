@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RelationType representa o tipo de relação entre dois produtos
+type RelationType string
+
+const (
+	// RelationSubstituto indica um produto que pode ser vendido no lugar do outro.
+	// A relação vale nos dois sentidos.
+	RelationSubstituto RelationType = "substituto"
+	// RelationAcessorio indica um produto que complementa o outro
+	RelationAcessorio RelationType = "acessorio"
+	// RelationUpsell indica uma versão superior a ser oferecida no lugar do produto
+	RelationUpsell RelationType = "upsell"
+)
+
+// IsValid verifica se o tipo de relação é conhecido
+func (t RelationType) IsValid() bool {
+	return t == RelationSubstituto || t == RelationAcessorio || t == RelationUpsell
+}
+
+// IsSymmetric verifica se a relação é registrada nos dois sentidos
+func (t RelationType) IsSymmetric() bool {
+	return t == RelationSubstituto
+}
+
+// ProductRelation representa a ligação de um produto com outro
+type ProductRelation struct {
+	ProdutoID     uuid.UUID    `json:"produto_id"`
+	RelacionadoID uuid.UUID    `json:"relacionado_id"`
+	Tipo          RelationType `json:"tipo"`
+	Observacao    string       `json:"observacao,omitempty"`
+	DataCriacao   time.Time    `json:"data_criacao"`
+}
+
+// Clone retorna uma cópia independente da relação
+func (r *ProductRelation) Clone() *ProductRelation {
+	clone := *r
+	return &clone
+}
+
+// Reverse retorna a relação no sentido oposto, usada em relações simétricas
+func (r *ProductRelation) Reverse() *ProductRelation {
+	return &ProductRelation{
+		ProdutoID:     r.RelacionadoID,
+		RelacionadoID: r.ProdutoID,
+		Tipo:          r.Tipo,
+		Observacao:    r.Observacao,
+		DataCriacao:   r.DataCriacao,
+	}
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductStatus representa a etapa do ciclo de vida de um produto
+type ProductStatus string
+
+const (
+	// StatusRascunho é o cadastro em elaboração, ainda fora do catálogo
+	StatusRascunho ProductStatus = "rascunho"
+	// StatusEmRevisao aguarda a aprovação do cadastro
+	StatusEmRevisao ProductStatus = "em_revisao"
+	// StatusAtivo está no catálogo, vendável e com reposição normal
+	StatusAtivo ProductStatus = "ativo"
+	// StatusDescontinuado vende o estoque restante, sem novas reposições
+	StatusDescontinuado ProductStatus = "descontinuado"
+	// StatusBloqueado suspende vendas e reposições, por exemplo em um recall
+	StatusBloqueado ProductStatus = "bloqueado"
+	// StatusInativo está fora do catálogo e pode ser reativado
+	StatusInativo ProductStatus = "inativo"
+)
+
+// ProductStatuses lista as situações na ordem do ciclo de vida
+var ProductStatuses = []ProductStatus{
+	StatusRascunho, StatusEmRevisao, StatusAtivo, StatusDescontinuado, StatusBloqueado, StatusInativo,
+}
+
+// productTransitions define as transições permitidas a partir de cada situação
+var productTransitions = map[ProductStatus][]ProductStatus{
+	StatusRascunho:      {StatusEmRevisao, StatusInativo},
+	StatusEmRevisao:     {StatusAtivo, StatusRascunho, StatusInativo},
+	StatusAtivo:         {StatusDescontinuado, StatusBloqueado, StatusInativo},
+	StatusDescontinuado: {StatusAtivo, StatusBloqueado, StatusInativo},
+	StatusBloqueado:     {StatusAtivo, StatusDescontinuado, StatusInativo},
+	StatusInativo:       {StatusAtivo, StatusRascunho},
+}
+
+// IsValid verifica se a situação é conhecida
+func (s ProductStatus) IsValid() bool {
+	_, exists := productTransitions[s]
+	return exists
+}
+
+// IsInitial verifica se o produto pode ser cadastrado diretamente nesta situação
+func (s ProductStatus) IsInitial() bool {
+	return s == StatusRascunho || s == StatusEmRevisao || s == StatusAtivo || s == StatusInativo
+}
+
+// Transitions retorna as situações para as quais o produto pode passar
+func (s ProductStatus) Transitions() []ProductStatus {
+	return append([]ProductStatus(nil), productTransitions[s]...)
+}
+
+// CanTransitionTo verifica se a transição para a situação informada é permitida
+func (s ProductStatus) CanTransitionTo(target ProductStatus) bool {
+	for _, allowed := range productTransitions[s] {
+		if allowed == target {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSellable verifica se a situação permite vendas e reservas. É o valor do
+// campo ativo exposto aos clientes antigos.
+func (s ProductStatus) IsSellable() bool {
+	return s == StatusAtivo || s == StatusDescontinuado
+}
+
+// AllowsRestock verifica se a situação aceita novas entradas de estoque por
+// compra, lote, número de série ou ajuste positivo
+func (s ProductStatus) AllowsRestock() bool {
+	return s != StatusDescontinuado && s != StatusBloqueado
+}
+
+// StatusFromActive retorna a situação equivalente ao antigo campo ativo
+func StatusFromActive(ativo bool) ProductStatus {
+	if ativo {
+		return StatusAtivo
+	}
+	return StatusInativo
+}
+
+// StatusChange registra uma transição do ciclo de vida do produto
+type StatusChange struct {
+	ProdutoID uuid.UUID     `json:"produto_id"`
+	De        ProductStatus `json:"de"`
+	Para      ProductStatus `json:"para"`
+	Motivo    string        `json:"motivo"`
+	Usuario   string        `json:"usuario,omitempty"`
+	Data      time.Time     `json:"data"`
+}
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DiscountType representa a forma de cálculo do desconto de uma promoção
+type DiscountType string
+
+const (
+	DiscountPercentual DiscountType = "percentual"
+	DiscountValorFixo  DiscountType = "valor_fixo"
+)
+
+// PromotionTarget define quais produtos uma promoção alcança. Um produto é
+// alcançado se estiver na lista de produtos, em uma das categorias ou no filtro
+// por nome e faixa de preço; TodosProdutos alcança o catálogo inteiro.
+type PromotionTarget struct {
+	TodosProdutos bool              `json:"todos_produtos"`
+	Produtos      []uuid.UUID       `json:"produtos,omitempty"`
+	Categorias    []ProductCategory `json:"categorias,omitempty"`
+	NomeContem    string            `json:"nome_contem,omitempty"`
+	PrecoMinimo   *float64          `json:"preco_minimo,omitempty"`
+	PrecoMaximo   *float64          `json:"preco_maximo,omitempty"`
+}
+
+// Promotion representa uma regra de desconto com vigência. Entre as promoções
+// vigentes de um produto vence a de maior prioridade; se ela for cumulativa, as
+// demais promoções cumulativas são aplicadas em sequência sobre o preço já descontado.
+type Promotion struct {
+	ID              uuid.UUID       `json:"id"`
+	Nome            string          `json:"nome"`
+	Descricao       string          `json:"descricao,omitempty"`
+	Tipo            DiscountType    `json:"tipo"`
+	Valor           float64         `json:"valor"`
+	Alvo            PromotionTarget `json:"alvo"`
+	Inicio          time.Time       `json:"inicio"`
+	Fim             time.Time       `json:"fim"`
+	Prioridade      int             `json:"prioridade"`
+	Cumulativa      bool            `json:"cumulativa"`
+	Ativa           bool            `json:"ativa"`
+	DataCriacao     time.Time       `json:"data_criacao"`
+	DataAtualizacao time.Time       `json:"data_atualizacao"`
+}
+
+// AppliedPromotion representa uma promoção aplicada ao preço de um produto
+type AppliedPromotion struct {
+	ID       uuid.UUID    `json:"id"`
+	Nome     string       `json:"nome"`
+	Tipo     DiscountType `json:"tipo"`
+	Valor    float64      `json:"valor"`
+	Desconto float64      `json:"desconto"`
+	Fim      time.Time    `json:"fim"`
+}
+
+// Clone retorna uma cópia independente da promoção, incluindo o alvo
+func (p *Promotion) Clone() *Promotion {
+	clone := *p
+	clone.Alvo.Produtos = append([]uuid.UUID(nil), p.Alvo.Produtos...)
+	clone.Alvo.Categorias = append([]ProductCategory(nil), p.Alvo.Categorias...)
+	return &clone
+}
+
+// IsActiveAt verifica se a promoção está habilitada e dentro da vigência
+func (p *Promotion) IsActiveAt(now time.Time) bool {
+	return p.Ativa && !now.Before(p.Inicio) && now.Before(p.Fim)
+}
+
+// Matches verifica se o produto está no alvo da promoção
+func (p *Promotion) Matches(product *Product) bool {
+	alvo := p.Alvo
+	if alvo.TodosProdutos {
+		return true
+	}
+
+	for _, id := range alvo.Produtos {
+		if id == product.ID {
+			return true
+		}
+	}
+
+	for _, categoria := range alvo.Categorias {
+		if categoria == product.Categoria {
+			return true
+		}
+	}
+
+	// O filtro só alcança produtos se tiver algum critério definido
+	if alvo.NomeContem == "" && alvo.PrecoMinimo == nil && alvo.PrecoMaximo == nil {
+		return false
+	}
+	if alvo.NomeContem != "" && !strings.Contains(strings.ToLower(product.Nome), strings.ToLower(alvo.NomeContem)) {
+		return false
+	}
+	if alvo.PrecoMinimo != nil && product.Preco < *alvo.PrecoMinimo {
+		return false
+	}
+	if alvo.PrecoMaximo != nil && product.Preco > *alvo.PrecoMaximo {
+		return false
+	}
+	return true
+}
+
+// Apply aplica o desconto ao preço informado, sem deixá-lo negativo
+func (p *Promotion) Apply(preco float64) float64 {
+	novo := preco
+	switch p.Tipo {
+	case DiscountPercentual:
+		novo = preco * (1 - p.Valor/100)
+	case DiscountValorFixo:
+		novo = preco - p.Valor
+	}
+	if novo < 0 {
+		novo = 0
+	}
+	return math.Round(novo*100) / 100
+}
+
+// ApplyPromotions calcula o preço promocional do produto com as promoções
+// vigentes que o alcançam, respeitando prioridade e acúmulo. Retorna o preço
+// final e as promoções aplicadas, na ordem de aplicação.
+func ApplyPromotions(product *Product, promotions []*Promotion, now time.Time) (float64, []AppliedPromotion) {
+	candidates := make([]*Promotion, 0)
+	for _, promotion := range promotions {
+		if promotion.IsActiveAt(now) && promotion.Matches(product) {
+			candidates = append(candidates, promotion)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return product.Preco, nil
+	}
+
+	// Maior prioridade primeiro; no empate, o maior desconto
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Prioridade != candidates[j].Prioridade {
+			return candidates[i].Prioridade > candidates[j].Prioridade
+		}
+		return candidates[i].Apply(product.Preco) < candidates[j].Apply(product.Preco)
+	})
+
+	selected := candidates[:1]
+	if candidates[0].Cumulativa {
+		selected = make([]*Promotion, 0, len(candidates))
+		for _, promotion := range candidates {
+			if promotion.Cumulativa {
+				selected = append(selected, promotion)
+			}
+		}
+	}
+
+	preco := product.Preco
+	applied := make([]AppliedPromotion, 0, len(selected))
+	for _, promotion := range selected {
+		novo := promotion.Apply(preco)
+		applied = append(applied, AppliedPromotion{
+			ID:       promotion.ID,
+			Nome:     promotion.Nome,
+			Tipo:     promotion.Tipo,
+			Valor:    promotion.Valor,
+			Desconto: math.Round((preco-novo)*100) / 100,
+			Fim:      promotion.Fim,
+		})
+		preco = novo
+	}
+
+	return preco, applied
+}
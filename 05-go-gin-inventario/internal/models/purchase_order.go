@@ -0,0 +1,159 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PurchaseOrderStatus representa a situação de um pedido de compra
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderRascunho             PurchaseOrderStatus = "rascunho"
+	PurchaseOrderEnviado              PurchaseOrderStatus = "enviado"
+	PurchaseOrderParcialmenteRecebido PurchaseOrderStatus = "parcialmente_recebido"
+	PurchaseOrderRecebido             PurchaseOrderStatus = "recebido"
+	PurchaseOrderCancelado            PurchaseOrderStatus = "cancelado"
+)
+
+// PurchaseOrderLine representa um item de um pedido de compra
+type PurchaseOrderLine struct {
+	ID                 uuid.UUID `json:"id"`
+	ProdutoID          uuid.UUID `json:"produto_id"`
+	CodigoFornecedor   string    `json:"codigo_fornecedor,omitempty"`
+	Quantidade         int       `json:"quantidade"`
+	QuantidadeRecebida int       `json:"quantidade_recebida"`
+	CustoUnitario      float64   `json:"custo_unitario"`
+}
+
+// Pending retorna a quantidade ainda não recebida do item
+func (l *PurchaseOrderLine) Pending() int {
+	return l.Quantidade - l.QuantidadeRecebida
+}
+
+// PurchaseReceiptLot identifica o lote de um item recebido de produto controlado por lote
+type PurchaseReceiptLot struct {
+	Codigo         string    `json:"codigo"`
+	DataFabricacao time.Time `json:"data_fabricacao"`
+	DataValidade   time.Time `json:"data_validade"`
+}
+
+// PurchaseReceiptLine representa a quantidade recebida de um item do pedido
+type PurchaseReceiptLine struct {
+	LinhaID       uuid.UUID           `json:"linha_id"`
+	ProdutoID     uuid.UUID           `json:"produto_id"`
+	Quantidade    int                 `json:"quantidade"`
+	CustoUnitario float64             `json:"custo_unitario"`
+	Lote          *PurchaseReceiptLot `json:"lote,omitempty"`
+	NumerosSerie  []string            `json:"numeros_serie,omitempty"`
+}
+
+// PurchaseReceipt representa um recebimento (total ou parcial) de um pedido de compra
+type PurchaseReceipt struct {
+	ID              uuid.UUID             `json:"id"`
+	Itens           []PurchaseReceiptLine `json:"itens"`
+	Observacao      string                `json:"observacao,omitempty"`
+	DataRecebimento time.Time             `json:"data_recebimento"`
+}
+
+// PurchaseOrder representa um pedido de compra a um fornecedor.
+// Fluxo: rascunho → enviado → parcialmente_recebido → recebido; pedidos ainda
+// não concluídos podem ser cancelados.
+type PurchaseOrder struct {
+	ID                 uuid.UUID           `json:"id"`
+	Numero             string              `json:"numero"`
+	FornecedorID       uuid.UUID           `json:"fornecedor_id"`
+	Status             PurchaseOrderStatus `json:"status"`
+	Itens              []PurchaseOrderLine `json:"itens"`
+	Recebimentos       []PurchaseReceipt   `json:"recebimentos"`
+	Observacoes        string              `json:"observacoes,omitempty"`
+	MotivoCancelamento string              `json:"motivo_cancelamento,omitempty"`
+	PrevisaoEntrega    *time.Time          `json:"previsao_entrega,omitempty"`
+	DataEnvio          *time.Time          `json:"data_envio,omitempty"`
+	DataConclusao      *time.Time          `json:"data_conclusao,omitempty"`
+	DataCriacao        time.Time           `json:"data_criacao"`
+	DataAtualizacao    time.Time           `json:"data_atualizacao"`
+}
+
+// Clone retorna uma cópia independente do pedido, incluindo itens e recebimentos
+func (o *PurchaseOrder) Clone() *PurchaseOrder {
+	clone := *o
+	clone.Itens = append([]PurchaseOrderLine(nil), o.Itens...)
+	clone.Recebimentos = make([]PurchaseReceipt, len(o.Recebimentos))
+	for i, receipt := range o.Recebimentos {
+		receipt.Itens = append([]PurchaseReceiptLine(nil), receipt.Itens...)
+		clone.Recebimentos[i] = receipt
+	}
+	return &clone
+}
+
+// Line busca um item do pedido pelo ID
+func (o *PurchaseOrder) Line(id uuid.UUID) *PurchaseOrderLine {
+	for i := range o.Itens {
+		if o.Itens[i].ID == id {
+			return &o.Itens[i]
+		}
+	}
+	return nil
+}
+
+// IsEditable verifica se os itens do pedido ainda podem ser alterados
+func (o *PurchaseOrder) IsEditable() bool {
+	return o.Status == PurchaseOrderRascunho
+}
+
+// IsOpen verifica se o pedido aguarda recebimento
+func (o *PurchaseOrder) IsOpen() bool {
+	return o.Status == PurchaseOrderEnviado || o.Status == PurchaseOrderParcialmenteRecebido
+}
+
+// CanCancel verifica se o pedido ainda pode ser cancelado
+func (o *PurchaseOrder) CanCancel() bool {
+	return o.IsEditable() || o.IsOpen()
+}
+
+// IsFullyReceived verifica se todos os itens foram recebidos por completo
+func (o *PurchaseOrder) IsFullyReceived() bool {
+	for _, line := range o.Itens {
+		if line.Pending() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Total retorna o valor total do pedido
+func (o *PurchaseOrder) Total() float64 {
+	total := 0.0
+	for _, line := range o.Itens {
+		total += float64(line.Quantidade) * line.CustoUnitario
+	}
+	return math.Round(total*100) / 100
+}
+
+// ReceivedTotal retorna o valor das quantidades já recebidas, ao custo de cada recebimento
+func (o *PurchaseOrder) ReceivedTotal() float64 {
+	total := 0.0
+	for _, receipt := range o.Recebimentos {
+		for _, line := range receipt.Itens {
+			total += float64(line.Quantidade) * line.CustoUnitario
+		}
+	}
+	return math.Round(total*100) / 100
+}
+
+// SetStatus altera a situação do pedido, registrando o instante de envio ou conclusão
+func (o *PurchaseOrder) SetStatus(status PurchaseOrderStatus) {
+	now := time.Now()
+	o.Status = status
+	o.DataAtualizacao = now
+
+	switch status {
+	case PurchaseOrderEnviado:
+		o.DataEnvio = &now
+	case PurchaseOrderRecebido, PurchaseOrderCancelado:
+		o.DataConclusao = &now
+	}
+}
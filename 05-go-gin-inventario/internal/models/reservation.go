@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReservationStatus representa os estados possíveis de uma reserva de estoque
+type ReservationStatus string
+
+const (
+	ReservationAtiva      ReservationStatus = "ativa"
+	ReservationConfirmada ReservationStatus = "confirmada"
+	ReservationLiberada   ReservationStatus = "liberada"
+	ReservationExpirada   ReservationStatus = "expirada"
+)
+
+// Reservation representa unidades de um produto retidas temporariamente
+// (por exemplo, durante o pagamento no checkout) sem alterar o estoque físico
+type Reservation struct {
+	ID              uuid.UUID         `json:"id"`
+	ProdutoID       uuid.UUID         `json:"produto_id"`
+	Quantidade      int               `json:"quantidade"`
+	Referencia      string            `json:"referencia"`
+	Status          ReservationStatus `json:"status"`
+	ExpiraEm        time.Time         `json:"expira_em"`
+	DataCriacao     time.Time         `json:"data_criacao"`
+	DataAtualizacao time.Time         `json:"data_atualizacao"`
+}
+
+// IsActive verifica se a reserva ainda retém unidades do produto
+func (r *Reservation) IsActive() bool {
+	return r.Status == ReservationAtiva
+}
+
+// IsExpired verifica se a reserva ativa já passou do prazo de validade
+func (r *Reservation) IsExpired(now time.Time) bool {
+	return r.IsActive() && !now.Before(r.ExpiraEm)
+}
+
+// SetStatus altera o status da reserva atualizando o timestamp
+func (r *Reservation) SetStatus(status ReservationStatus) {
+	r.Status = status
+	r.DataAtualizacao = time.Now()
+}
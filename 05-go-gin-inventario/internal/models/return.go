@@ -0,0 +1,164 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReturnReason representa o motivo informado pelo cliente para a devolução
+type ReturnReason string
+
+const (
+	ReturnReasonDefeito        ReturnReason = "defeito"
+	ReturnReasonAvaria         ReturnReason = "avaria"
+	ReturnReasonArrependimento ReturnReason = "arrependimento"
+	ReturnReasonProdutoErrado  ReturnReason = "produto_errado"
+	ReturnReasonOutro          ReturnReason = "outro"
+)
+
+// IsValid verifica se o motivo é conhecido
+func (r ReturnReason) IsValid() bool {
+	switch r {
+	case ReturnReasonDefeito, ReturnReasonAvaria, ReturnReasonArrependimento,
+		ReturnReasonProdutoErrado, ReturnReasonOutro:
+		return true
+	}
+	return false
+}
+
+// ReturnOutcome representa o destino decidido na inspeção do item devolvido
+type ReturnOutcome string
+
+const (
+	// ReturnOutcomeRestockNew devolve as unidades ao estoque como novas
+	ReturnOutcomeRestockNew ReturnOutcome = "reestoque_novo"
+	// ReturnOutcomeRestockOpenBox devolve as unidades ao estoque de caixa aberta
+	ReturnOutcomeRestockOpenBox ReturnOutcome = "reestoque_caixa_aberta"
+	// ReturnOutcomeSupplier encaminha as unidades ao fornecedor, fora do estoque
+	ReturnOutcomeSupplier ReturnOutcome = "envio_fornecedor"
+	// ReturnOutcomeWriteOff baixa as unidades como perda
+	ReturnOutcomeWriteOff ReturnOutcome = "baixa"
+)
+
+// IsValid verifica se o destino é conhecido
+func (o ReturnOutcome) IsValid() bool {
+	switch o {
+	case ReturnOutcomeRestockNew, ReturnOutcomeRestockOpenBox, ReturnOutcomeSupplier, ReturnOutcomeWriteOff:
+		return true
+	}
+	return false
+}
+
+// IsRestock verifica se o destino devolve as unidades a algum estoque vendável
+func (o ReturnOutcome) IsRestock() bool {
+	return o == ReturnOutcomeRestockNew || o == ReturnOutcomeRestockOpenBox
+}
+
+// ReturnStatus representa a situação de uma devolução
+type ReturnStatus string
+
+const (
+	ReturnStatusAguardandoInspecao ReturnStatus = "aguardando_inspecao"
+	ReturnStatusConcluida          ReturnStatus = "concluida"
+)
+
+// ReturnLine representa um item devolvido, vinculado ao item do pedido de venda
+// de origem. O destino e as entradas em estoque são definidos na inspeção.
+type ReturnLine struct {
+	ID              uuid.UUID       `json:"id"`
+	ItemPedidoID    uuid.UUID       `json:"item_pedido_id"`
+	ProdutoID       uuid.UUID       `json:"produto_id"`
+	Nome            string          `json:"nome"`
+	Categoria       ProductCategory `json:"categoria"`
+	Quantidade      int             `json:"quantidade"`
+	NumerosSerie    []string        `json:"numeros_serie,omitempty"`
+	Motivo          ReturnReason    `json:"motivo"`
+	Observacao      string          `json:"observacao,omitempty"`
+	ValorReembolso  float64         `json:"valor_reembolso"`
+	Destino         ReturnOutcome   `json:"destino,omitempty"`
+	FornecedorID    *uuid.UUID      `json:"fornecedor_id,omitempty"`
+	ParecerInspecao string          `json:"parecer_inspecao,omitempty"`
+	ValorCusto      float64         `json:"valor_custo"`
+	Entradas        []StockIssue    `json:"entradas,omitempty"`
+}
+
+// Return representa a devolução de itens de um pedido de venda. Os itens
+// ficam em quarentena até a inspeção, que decide o destino de cada um.
+type Return struct {
+	ID                uuid.UUID    `json:"id"`
+	Numero            string       `json:"numero"`
+	PedidoVendaID     uuid.UUID    `json:"pedido_venda_id"`
+	PedidoVendaNumero string       `json:"pedido_venda_numero"`
+	Status            ReturnStatus `json:"status"`
+	Itens             []ReturnLine `json:"itens"`
+	ValorReembolso    float64      `json:"valor_reembolso"`
+	DataInspecao      *time.Time   `json:"data_inspecao,omitempty"`
+	DataCriacao       time.Time    `json:"data_criacao"`
+	DataAtualizacao   time.Time    `json:"data_atualizacao"`
+}
+
+// Clone retorna uma cópia independente da devolução, incluindo itens e entradas
+func (r *Return) Clone() *Return {
+	clone := *r
+	clone.Itens = make([]ReturnLine, len(r.Itens))
+	for i, line := range r.Itens {
+		line.NumerosSerie = append([]string(nil), line.NumerosSerie...)
+		if line.FornecedorID != nil {
+			fornecedorID := *line.FornecedorID
+			line.FornecedorID = &fornecedorID
+		}
+		entradas := make([]StockIssue, len(line.Entradas))
+		for j, entrada := range line.Entradas {
+			entrada.Lotes = append([]LotConsumption(nil), entrada.Lotes...)
+			entrada.NumerosSerie = append([]string(nil), entrada.NumerosSerie...)
+			entradas[j] = entrada
+		}
+		line.Entradas = entradas
+		clone.Itens[i] = line
+	}
+	return &clone
+}
+
+// IsPending verifica se a devolução aguarda inspeção
+func (r *Return) IsPending() bool {
+	return r.Status == ReturnStatusAguardandoInspecao
+}
+
+// Line retorna o item da devolução com o ID informado
+func (r *Return) Line(id uuid.UUID) *ReturnLine {
+	for i := range r.Itens {
+		if r.Itens[i].ID == id {
+			return &r.Itens[i]
+		}
+	}
+	return nil
+}
+
+// HasProduct verifica se a devolução possui item do produto
+func (r *Return) HasProduct(productID uuid.UUID) bool {
+	for _, line := range r.Itens {
+		if line.ProdutoID == productID {
+			return true
+		}
+	}
+	return false
+}
+
+// CalculateTotals recalcula o valor a reembolsar da devolução
+func (r *Return) CalculateTotals() {
+	total := 0.0
+	for _, line := range r.Itens {
+		total += line.ValorReembolso
+	}
+	r.ValorReembolso = math.Round(total*100) / 100
+}
+
+// Complete marca a devolução como inspecionada e concluída
+func (r *Return) Complete() {
+	now := time.Now()
+	r.Status = ReturnStatusConcluida
+	r.DataInspecao = &now
+	r.DataAtualizacao = now
+}
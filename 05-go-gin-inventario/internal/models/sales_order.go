@@ -0,0 +1,127 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SalesOrderStatus representa a situação de um pedido de venda
+type SalesOrderStatus string
+
+const (
+	SalesOrderConfirmado SalesOrderStatus = "confirmado"
+	SalesOrderCancelado  SalesOrderStatus = "cancelado"
+)
+
+// LotConsumption representa a quantidade retirada de um lote em uma saída
+type LotConsumption struct {
+	LoteID     uuid.UUID `json:"lote_id"`
+	Codigo     string    `json:"codigo"`
+	Quantidade int       `json:"quantidade"`
+}
+
+// StockIssue representa a saída de estoque efetivada para um item de pedido:
+// o próprio produto ou, em kits, cada componente. Guarda os lotes consumidos,
+// os números de série vendidos e se as unidades saíram do estoque de caixa
+// aberta para que o cancelamento devolva exatamente o que saiu.
+type StockIssue struct {
+	ProdutoID    uuid.UUID        `json:"produto_id"`
+	Quantidade   int              `json:"quantidade"`
+	CaixaAberta  bool             `json:"caixa_aberta,omitempty"`
+	Lotes        []LotConsumption `json:"lotes,omitempty"`
+	NumerosSerie []string         `json:"numeros_serie,omitempty"`
+}
+
+// SalesOrderLine representa um item de um pedido de venda
+type SalesOrderLine struct {
+	ID            uuid.UUID    `json:"id"`
+	ProdutoID     uuid.UUID    `json:"produto_id"`
+	Nome          string       `json:"nome"`
+	Quantidade    int          `json:"quantidade"`
+	PrecoUnitario float64      `json:"preco_unitario"`
+	Subtotal      float64      `json:"subtotal"`
+	NumerosSerie  []string     `json:"numeros_serie,omitempty"`
+	CaixaAberta   bool         `json:"caixa_aberta,omitempty"`
+	Baixas        []StockIssue `json:"baixas"`
+}
+
+// SalesOrder representa uma venda com vários itens cuja baixa de estoque é
+// feita de uma só vez. O cancelamento devolve ao estoque tudo o que saiu.
+type SalesOrder struct {
+	ID                 uuid.UUID        `json:"id"`
+	Numero             string           `json:"numero"`
+	Canal              string           `json:"canal,omitempty"`
+	Referencia         string           `json:"referencia,omitempty"`
+	Status             SalesOrderStatus `json:"status"`
+	Itens              []SalesOrderLine `json:"itens"`
+	ValorTotal         float64          `json:"valor_total"`
+	MotivoCancelamento string           `json:"motivo_cancelamento,omitempty"`
+	DataCancelamento   *time.Time       `json:"data_cancelamento,omitempty"`
+	DataCriacao        time.Time        `json:"data_criacao"`
+	DataAtualizacao    time.Time        `json:"data_atualizacao"`
+}
+
+// Clone retorna uma cópia independente do pedido, incluindo itens e baixas
+func (o *SalesOrder) Clone() *SalesOrder {
+	clone := *o
+	clone.Itens = make([]SalesOrderLine, len(o.Itens))
+	for i, line := range o.Itens {
+		line.NumerosSerie = append([]string(nil), line.NumerosSerie...)
+		issues := make([]StockIssue, len(line.Baixas))
+		for j, issue := range line.Baixas {
+			issue.Lotes = append([]LotConsumption(nil), issue.Lotes...)
+			issue.NumerosSerie = append([]string(nil), issue.NumerosSerie...)
+			issues[j] = issue
+		}
+		line.Baixas = issues
+		clone.Itens[i] = line
+	}
+	return &clone
+}
+
+// IsConfirmed verifica se o pedido está confirmado e pode ser cancelado
+func (o *SalesOrder) IsConfirmed() bool {
+	return o.Status == SalesOrderConfirmado
+}
+
+// Line retorna o item do pedido com o ID informado
+func (o *SalesOrder) Line(id uuid.UUID) *SalesOrderLine {
+	for i := range o.Itens {
+		if o.Itens[i].ID == id {
+			return &o.Itens[i]
+		}
+	}
+	return nil
+}
+
+// HasProduct verifica se o pedido possui item do produto
+func (o *SalesOrder) HasProduct(productID uuid.UUID) bool {
+	for _, line := range o.Itens {
+		if line.ProdutoID == productID {
+			return true
+		}
+	}
+	return false
+}
+
+// CalculateTotals recalcula o subtotal de cada item e o valor total do pedido
+func (o *SalesOrder) CalculateTotals() {
+	total := 0.0
+	for i := range o.Itens {
+		line := &o.Itens[i]
+		line.Subtotal = math.Round(line.PrecoUnitario*float64(line.Quantidade)*100) / 100
+		total += line.Subtotal
+	}
+	o.ValorTotal = math.Round(total*100) / 100
+}
+
+// Cancel marca o pedido como cancelado
+func (o *SalesOrder) Cancel(motivo string) {
+	now := time.Now()
+	o.Status = SalesOrderCancelado
+	o.MotivoCancelamento = motivo
+	o.DataCancelamento = &now
+	o.DataAtualizacao = now
+}
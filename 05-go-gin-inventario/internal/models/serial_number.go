@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SerialStatus representa a situação atual de um número de série
+type SerialStatus string
+
+const (
+	SerialEmEstoque           SerialStatus = "em_estoque"
+	SerialVendido             SerialStatus = "vendido"
+	SerialCaixaAberta         SerialStatus = "caixa_aberta"
+	SerialDevolvidoFornecedor SerialStatus = "devolvido_fornecedor"
+	SerialBaixado             SerialStatus = "baixado"
+)
+
+// SerialEventType representa os tipos de movimentação de um número de série
+type SerialEventType string
+
+const (
+	SerialEventEntrada   SerialEventType = "entrada"
+	SerialEventSaida     SerialEventType = "saida"
+	SerialEventDevolucao SerialEventType = "devolucao"
+)
+
+// SerialEvent representa uma movimentação no histórico de um número de série
+type SerialEvent struct {
+	Tipo       SerialEventType `json:"tipo"`
+	Referencia string          `json:"referencia,omitempty"`
+	Data       time.Time       `json:"data"`
+}
+
+// SerialNumber representa uma unidade rastreável (número de série/IMEI) de um produto serializado
+type SerialNumber struct {
+	Numero          string        `json:"numero"`
+	ProdutoID       uuid.UUID     `json:"produto_id"`
+	Status          SerialStatus  `json:"status"`
+	Historico       []SerialEvent `json:"historico"`
+	DataCriacao     time.Time     `json:"data_criacao"`
+	DataAtualizacao time.Time     `json:"data_atualizacao"`
+}
+
+// Clone retorna uma cópia independente do número de série, incluindo o histórico
+func (s *SerialNumber) Clone() *SerialNumber {
+	clone := *s
+	clone.Historico = append([]SerialEvent(nil), s.Historico...)
+	return &clone
+}
+
+// Record registra uma movimentação, atualizando o status correspondente
+func (s *SerialNumber) Record(tipo SerialEventType, referencia string) {
+	status := s.Status
+	switch tipo {
+	case SerialEventEntrada:
+		status = SerialEmEstoque
+	case SerialEventSaida:
+		status = SerialVendido
+	}
+	s.RecordAs(tipo, status, referencia)
+}
+
+// RecordAs registra uma movimentação que leva o número de série à situação
+// informada, como a entrada como caixa aberta ou a baixa após uma devolução
+func (s *SerialNumber) RecordAs(tipo SerialEventType, status SerialStatus, referencia string) {
+	now := time.Now()
+	s.Status = status
+	s.Historico = append(s.Historico, SerialEvent{
+		Tipo:       tipo,
+		Referencia: referencia,
+		Data:       now,
+	})
+	s.DataAtualizacao = now
+}
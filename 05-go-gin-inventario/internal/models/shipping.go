@@ -0,0 +1,239 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DefaultCubingFactor é o divisor padrão de cubagem (cm³ por kg) usado pelas
+// transportadoras rodoviárias
+const DefaultCubingFactor = 6000
+
+// Dimensions representa peso, medidas e embalagem do produto pronto para envio
+type Dimensions struct {
+	PesoKg        float64 `json:"peso_kg"`
+	ComprimentoCm float64 `json:"comprimento_cm"`
+	LarguraCm     float64 `json:"largura_cm"`
+	AlturaCm      float64 `json:"altura_cm"`
+	Embalagem     string  `json:"embalagem,omitempty"`
+}
+
+// VolumeCm3 retorna o volume da embalagem em centímetros cúbicos
+func (d *Dimensions) VolumeCm3() float64 {
+	return d.ComprimentoCm * d.LarguraCm * d.AlturaCm
+}
+
+// CubedWeight retorna o peso cubado (volumétrico) em kg para o fator de
+// cubagem informado
+func (d *Dimensions) CubedWeight(fator float64) float64 {
+	if fator <= 0 {
+		fator = DefaultCubingFactor
+	}
+	return d.VolumeCm3() / fator
+}
+
+// CEPRange representa um intervalo de CEPs, com 8 dígitos, inclusivo
+type CEPRange struct {
+	Inicio string `json:"inicio"`
+	Fim    string `json:"fim"`
+}
+
+// Contains verifica se o CEP normalizado está no intervalo
+func (r CEPRange) Contains(cep string) bool {
+	return cep >= r.Inicio && cep <= r.Fim
+}
+
+// WeightBracket representa o valor do frete até um peso taxado
+type WeightBracket struct {
+	AteKg float64 `json:"ate_kg"`
+	Valor float64 `json:"valor"`
+}
+
+// ShippingZone representa uma zona de entrega e seus preços
+type ShippingZone struct {
+	Zona             string          `json:"zona"`
+	Descricao        string          `json:"descricao,omitempty"`
+	CEPs             []CEPRange      `json:"ceps"`
+	PrazoDias        int             `json:"prazo_dias"`
+	Faixas           []WeightBracket `json:"faixas"`
+	ValorKgAdicional float64         `json:"valor_kg_adicional"`
+	AdValorem        float64         `json:"ad_valorem"`
+}
+
+// Freight retorna o valor do frete para o peso taxado: o valor da primeira
+// faixa que comporta o peso ou, acima da última faixa, o valor dela mais
+// cada kg adicional iniciado
+func (z *ShippingZone) Freight(pesoKg float64) float64 {
+	for _, faixa := range z.Faixas {
+		if pesoKg <= faixa.AteKg {
+			return faixa.Valor
+		}
+	}
+	ultima := z.Faixas[len(z.Faixas)-1]
+	adicional := math.Ceil(pesoKg - ultima.AteKg)
+	return math.Round((ultima.Valor+adicional*z.ValorKgAdicional)*100) / 100
+}
+
+// Insurance retorna o ad valorem (seguro) sobre o valor das mercadorias
+func (z *ShippingZone) Insurance(valorMercadorias float64) float64 {
+	return math.Round(valorMercadorias*z.AdValorem) / 100
+}
+
+// RateTable representa a tabela de frete por zona de CEP
+type RateTable struct {
+	FatorCubagem float64        `json:"fator_cubagem"`
+	Zonas        []ShippingZone `json:"zonas"`
+}
+
+// ZoneByCEP retorna a zona que atende o CEP normalizado
+func (t *RateTable) ZoneByCEP(cep string) *ShippingZone {
+	for i := range t.Zonas {
+		for _, faixa := range t.Zonas[i].CEPs {
+			if faixa.Contains(cep) {
+				return &t.Zonas[i]
+			}
+		}
+	}
+	return nil
+}
+
+// ZoneByName retorna a zona pelo nome
+func (t *RateTable) ZoneByName(zona string) *ShippingZone {
+	for i := range t.Zonas {
+		if t.Zonas[i].Zona == zona {
+			return &t.Zonas[i]
+		}
+	}
+	return nil
+}
+
+// Validate verifica a consistência da tabela e ordena as faixas de peso
+func (t *RateTable) Validate() error {
+	if t.FatorCubagem <= 0 {
+		return fmt.Errorf("fator_cubagem deve ser maior que zero")
+	}
+	if len(t.Zonas) == 0 {
+		return fmt.Errorf("a tabela deve ter ao menos uma zona")
+	}
+
+	nomes := make(map[string]bool, len(t.Zonas))
+	var faixasCEP []CEPRange
+	for i := range t.Zonas {
+		zona := &t.Zonas[i]
+		if zona.Zona == "" || nomes[zona.Zona] {
+			return fmt.Errorf("zona %d: nome vazio ou repetido", i+1)
+		}
+		nomes[zona.Zona] = true
+
+		if len(zona.Faixas) == 0 {
+			return fmt.Errorf("zona %s: informe ao menos uma faixa de peso", zona.Zona)
+		}
+		sort.Slice(zona.Faixas, func(a, b int) bool { return zona.Faixas[a].AteKg < zona.Faixas[b].AteKg })
+		for _, faixa := range zona.Faixas {
+			if faixa.AteKg <= 0 || faixa.Valor < 0 {
+				return fmt.Errorf("zona %s: faixas precisam de ate_kg positivo e valor não negativo", zona.Zona)
+			}
+		}
+		if zona.ValorKgAdicional < 0 || zona.AdValorem < 0 || zona.PrazoDias < 0 {
+			return fmt.Errorf("zona %s: valores não podem ser negativos", zona.Zona)
+		}
+
+		for _, faixa := range zona.CEPs {
+			if NormalizeCEP(faixa.Inicio) != faixa.Inicio || NormalizeCEP(faixa.Fim) != faixa.Fim || faixa.Inicio > faixa.Fim {
+				return fmt.Errorf("zona %s: intervalo de CEP %s-%s inválido", zona.Zona, faixa.Inicio, faixa.Fim)
+			}
+			for _, outra := range faixasCEP {
+				if faixa.Inicio <= outra.Fim && outra.Inicio <= faixa.Fim {
+					return fmt.Errorf("zona %s: intervalo de CEP %s-%s sobrepõe %s-%s",
+						zona.Zona, faixa.Inicio, faixa.Fim, outra.Inicio, outra.Fim)
+				}
+			}
+			faixasCEP = append(faixasCEP, faixa)
+		}
+	}
+	return nil
+}
+
+// NormalizeCEP mantém apenas os dígitos do CEP e retorna vazio se não
+// restarem exatamente 8
+func NormalizeCEP(cep string) string {
+	return onlyDigits(cep, 8)
+}
+
+// DefaultRateTable retorna a tabela de frete usada quando nenhuma é configurada,
+// com zonas pelas faixas de CEP das regiões a partir de um centro de
+// distribuição na Grande São Paulo
+func DefaultRateTable() RateTable {
+	return RateTable{
+		FatorCubagem: DefaultCubingFactor,
+		Zonas: []ShippingZone{
+			{
+				Zona:      "local",
+				Descricao: "Grande São Paulo",
+				CEPs:      []CEPRange{{Inicio: "01000000", Fim: "09999999"}},
+				PrazoDias: 2,
+				Faixas: []WeightBracket{
+					{AteKg: 1, Valor: 12.90}, {AteKg: 5, Valor: 18.90}, {AteKg: 10, Valor: 26.90}, {AteKg: 30, Valor: 49.90},
+				},
+				ValorKgAdicional: 1.50,
+				AdValorem:        0.3,
+			},
+			{
+				Zona:      "estadual",
+				Descricao: "Interior e litoral de São Paulo",
+				CEPs:      []CEPRange{{Inicio: "11000000", Fim: "19999999"}},
+				PrazoDias: 3,
+				Faixas: []WeightBracket{
+					{AteKg: 1, Valor: 16.90}, {AteKg: 5, Valor: 24.90}, {AteKg: 10, Valor: 36.90}, {AteKg: 30, Valor: 69.90},
+				},
+				ValorKgAdicional: 2.20,
+				AdValorem:        0.5,
+			},
+			{
+				Zona:      "sudeste",
+				Descricao: "Rio de Janeiro, Espírito Santo e Minas Gerais",
+				CEPs:      []CEPRange{{Inicio: "20000000", Fim: "39999999"}},
+				PrazoDias: 4,
+				Faixas: []WeightBracket{
+					{AteKg: 1, Valor: 21.90}, {AteKg: 5, Valor: 32.90}, {AteKg: 10, Valor: 47.90}, {AteKg: 30, Valor: 89.90},
+				},
+				ValorKgAdicional: 2.90,
+				AdValorem:        0.5,
+			},
+			{
+				Zona:      "sul",
+				Descricao: "Paraná, Santa Catarina e Rio Grande do Sul",
+				CEPs:      []CEPRange{{Inicio: "80000000", Fim: "99999999"}},
+				PrazoDias: 5,
+				Faixas: []WeightBracket{
+					{AteKg: 1, Valor: 24.90}, {AteKg: 5, Valor: 36.90}, {AteKg: 10, Valor: 54.90}, {AteKg: 30, Valor: 99.90},
+				},
+				ValorKgAdicional: 3.40,
+				AdValorem:        0.7,
+			},
+			{
+				Zona:      "centro_oeste",
+				Descricao: "Distrito Federal, Goiás, Tocantins, Mato Grosso, Mato Grosso do Sul, Rondônia e Acre",
+				CEPs:      []CEPRange{{Inicio: "70000000", Fim: "79999999"}},
+				PrazoDias: 6,
+				Faixas: []WeightBracket{
+					{AteKg: 1, Valor: 27.90}, {AteKg: 5, Valor: 42.90}, {AteKg: 10, Valor: 64.90}, {AteKg: 30, Valor: 119.90},
+				},
+				ValorKgAdicional: 4.10,
+				AdValorem:        0.8,
+			},
+			{
+				Zona:      "norte_nordeste",
+				Descricao: "Regiões Norte e Nordeste",
+				CEPs:      []CEPRange{{Inicio: "40000000", Fim: "69999999"}},
+				PrazoDias: 8,
+				Faixas: []WeightBracket{
+					{AteKg: 1, Valor: 32.90}, {AteKg: 5, Valor: 52.90}, {AteKg: 10, Valor: 79.90}, {AteKg: 30, Valor: 149.90},
+				},
+				ValorKgAdicional: 5.20,
+				AdValorem:        1.0,
+			},
+		},
+	}
+}
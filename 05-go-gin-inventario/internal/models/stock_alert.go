@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StockAlertType representa os tipos de alerta de estoque
+type StockAlertType string
+
+const (
+	// StockAlertAbaixoMinimo é emitido quando o disponível cai até o estoque mínimo
+	StockAlertAbaixoMinimo StockAlertType = "abaixo_minimo"
+	// StockAlertNormalizado é emitido quando o disponível volta acima do estoque mínimo
+	StockAlertNormalizado StockAlertType = "normalizado"
+)
+
+// StockAlert representa um evento de cruzamento do ponto de reposição
+type StockAlert struct {
+	ID                   uuid.UUID      `json:"id"`
+	ProdutoID            uuid.UUID      `json:"produto_id"`
+	NomeProduto          string         `json:"nome_produto"`
+	Tipo                 StockAlertType `json:"tipo"`
+	QuantidadeDisponivel int            `json:"quantidade_disponivel"`
+	EstoqueMinimo        int            `json:"estoque_minimo"`
+	QuantidadeSugerida   int            `json:"quantidade_sugerida"`
+	DataCriacao          time.Time      `json:"data_criacao"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Supplier representa um fornecedor de produtos
+type Supplier struct {
+	ID              uuid.UUID `json:"id"`
+	Nome            string    `json:"nome"`
+	CNPJ            string    `json:"cnpj,omitempty"`
+	Email           string    `json:"email,omitempty"`
+	Telefone        string    `json:"telefone,omitempty"`
+	Ativo           bool      `json:"ativo"`
+	DataCriacao     time.Time `json:"data_criacao"`
+	DataAtualizacao time.Time `json:"data_atualizacao"`
+}
+
+// SupplierProduct vincula um produto a um fornecedor com o código usado pelo
+// fornecedor, o prazo de entrega e o custo da última compra
+type SupplierProduct struct {
+	FornecedorID     uuid.UUID  `json:"fornecedor_id"`
+	ProdutoID        uuid.UUID  `json:"produto_id"`
+	CodigoFornecedor string     `json:"codigo_fornecedor"`
+	PrazoEntregaDias int        `json:"prazo_entrega_dias"`
+	UltimoCusto      float64    `json:"ultimo_custo,omitempty"`
+	DataUltimaCompra *time.Time `json:"data_ultima_compra,omitempty"`
+	DataAtualizacao  time.Time  `json:"data_atualizacao"`
+}
+
+// Clone retorna uma cópia independente do vínculo
+func (sp *SupplierProduct) Clone() *SupplierProduct {
+	clone := *sp
+	if sp.DataUltimaCompra != nil {
+		data := *sp.DataUltimaCompra
+		clone.DataUltimaCompra = &data
+	}
+	return &clone
+}
+
+// RecordPurchase registra o custo e a data da última compra recebida
+func (sp *SupplierProduct) RecordPurchase(custoUnitario float64, now time.Time) {
+	sp.UltimoCusto = custoUnitario
+	sp.DataUltimaCompra = &now
+	sp.DataAtualizacao = now
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxTagLength é o tamanho máximo de uma tag, em caracteres
+	MaxTagLength = 30
+	// MaxTagsPerProduct é a quantidade máxima de tags por produto
+	MaxTagsPerProduct = 20
+)
+
+// TagMatch define como várias tags são combinadas em um filtro
+type TagMatch string
+
+const (
+	// TagMatchAny seleciona produtos com ao menos uma das tags
+	TagMatchAny TagMatch = "qualquer"
+	// TagMatchAll seleciona produtos com todas as tags
+	TagMatchAll TagMatch = "todas"
+)
+
+// IsValid verifica se o modo de combinação é conhecido
+func (m TagMatch) IsValid() bool {
+	return m == TagMatchAny || m == TagMatchAll
+}
+
+// TagCount representa quantos produtos usam uma tag
+type TagCount struct {
+	Tag            string `json:"tag"`
+	TotalProdutos  int    `json:"total_produtos"`
+	ProdutosAtivos int    `json:"produtos_ativos"`
+}
+
+// NormalizeTag padroniza uma tag: minúsculas, sem espaços nas pontas e com
+// espaços internos simples. Acentos são mantidos ("frágil" e "fragil" são tags diferentes).
+func NormalizeTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+}
+
+// NormalizeTags padroniza as tags, descarta vazias e repetidas e ordena o resultado
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// HasTag verifica se o produto possui a tag
+func (p *Product) HasTag(tag string) bool {
+	for _, t := range p.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesTags verifica se o produto atende às tags no modo informado.
+// Uma lista vazia é sempre atendida.
+func (p *Product) MatchesTags(tags []string, mode TagMatch) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	for _, tag := range tags {
+		has := p.HasTag(tag)
+		if mode == TagMatchAll && !has {
+			return false
+		}
+		if mode != TagMatchAll && has {
+			return true
+		}
+	}
+	return mode == TagMatchAll
+}
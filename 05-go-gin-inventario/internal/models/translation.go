@@ -0,0 +1,123 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale identifica um idioma em que os textos do produto podem ser exibidos
+type Locale string
+
+const (
+	// LocalePortuguese é o idioma de cadastro do produto (nome e descrição originais)
+	LocalePortuguese Locale = "pt-BR"
+	// LocaleSpanish é o espanhol, sem distinção de país
+	LocaleSpanish Locale = "es"
+	// LocaleEnglish é o inglês, sem distinção de país
+	LocaleEnglish Locale = "en"
+
+	// DefaultLocale é o idioma usado quando nenhum idioma suportado é solicitado
+	DefaultLocale = LocalePortuguese
+)
+
+// SupportedLocales lista os idiomas aceitos, começando pelo padrão
+var SupportedLocales = []Locale{LocalePortuguese, LocaleSpanish, LocaleEnglish}
+
+// ProductTranslation contém o nome e a descrição do produto em outro idioma.
+// Campos vazios usam o texto em português.
+type ProductTranslation struct {
+	Nome      string `json:"nome,omitempty"`
+	Descricao string `json:"descricao,omitempty"`
+}
+
+// IsEmpty verifica se a tradução não tem nenhum texto
+func (t ProductTranslation) IsEmpty() bool {
+	return t.Nome == "" && t.Descricao == ""
+}
+
+// IsDefault verifica se o idioma é o de cadastro do produto
+func (l Locale) IsDefault() bool {
+	return l == DefaultLocale
+}
+
+// ParseLocale interpreta uma etiqueta de idioma (BCP 47), como "es-AR" ou
+// "en_US", e retorna o idioma suportado correspondente. Variantes regionais
+// usam o idioma base: "pt-PT" é exibido em pt-BR, "es-MX" em es.
+func ParseLocale(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(tag, "_", "-")))
+	base, _, _ := strings.Cut(tag, "-")
+	switch base {
+	case "pt":
+		return LocalePortuguese, true
+	case "es":
+		return LocaleSpanish, true
+	case "en":
+		return LocaleEnglish, true
+	default:
+		return "", false
+	}
+}
+
+// NegotiateLocale escolhe o idioma da resposta: o parâmetro lang, se
+// suportado, tem prioridade; depois, o idioma suportado de maior peso no
+// cabeçalho Accept-Language; por fim, pt-BR
+func NegotiateLocale(lang, acceptLanguage string) Locale {
+	if locale, ok := ParseLocale(lang); ok {
+		return locale
+	}
+
+	type candidate struct {
+		locale Locale
+		peso   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		locale, ok := ParseLocale(tag)
+		if !ok {
+			continue
+		}
+		peso := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			valor, err := strconv.ParseFloat(strings.TrimSpace(q), 64)
+			if err != nil {
+				continue
+			}
+			peso = valor
+		}
+		if peso > 0 {
+			candidates = append(candidates, candidate{locale: locale, peso: peso})
+		}
+	}
+
+	// Em caso de empate, vale a ordem em que os idiomas aparecem no cabeçalho
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].peso > candidates[j].peso
+	})
+	if len(candidates) > 0 {
+		return candidates[0].locale
+	}
+	return DefaultLocale
+}
+
+// Translation retorna a tradução do produto para o idioma, se houver
+func (p *Product) Translation(locale Locale) (ProductTranslation, bool) {
+	translation, ok := p.Traducoes[locale]
+	return translation, ok
+}
+
+// LocalizedText retorna o nome e a descrição no idioma, usando o texto em
+// português para os campos sem tradução
+func (p *Product) LocalizedText(locale Locale) (nome, descricao string) {
+	nome, descricao = p.Nome, p.Descricao
+	if translation, ok := p.Translation(locale); ok {
+		if translation.Nome != "" {
+			nome = translation.Nome
+		}
+		if translation.Descricao != "" {
+			descricao = translation.Descricao
+		}
+	}
+	return nome, descricao
+}
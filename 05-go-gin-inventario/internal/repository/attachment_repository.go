@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/models"
+)
+
+// AttachmentRepository define a interface para operações de anexos de produtos
+type AttachmentRepository interface {
+	Create(attachment *models.Attachment) error
+	GetByProduct(productID uuid.UUID, tipo *models.AttachmentKind) ([]*models.Attachment, error)
+	Delete(productID, attachmentID uuid.UUID) (*models.Attachment, error)
+}
+
+// InMemoryAttachmentRepository implementa AttachmentRepository usando banco em memória
+type InMemoryAttachmentRepository struct {
+	db *database.InMemoryDatabase
+}
+
+// NewInMemoryAttachmentRepository cria uma nova instância do repository
+func NewInMemoryAttachmentRepository(db *database.InMemoryDatabase) *InMemoryAttachmentRepository {
+	return &InMemoryAttachmentRepository{
+		db: db,
+	}
+}
+
+// Create registra os metadados de um anexo
+func (r *InMemoryAttachmentRepository) Create(attachment *models.Attachment) error {
+	return r.db.CreateAttachment(attachment)
+}
+
+// GetByProduct retorna os anexos do produto, opcionalmente de um só tipo
+func (r *InMemoryAttachmentRepository) GetByProduct(productID uuid.UUID, tipo *models.AttachmentKind) ([]*models.Attachment, error) {
+	return r.db.GetAttachments(productID, tipo)
+}
+
+// Delete remove os metadados de um anexo e retorna o registro removido
+func (r *InMemoryAttachmentRepository) Delete(productID, attachmentID uuid.UUID) (*models.Attachment, error) {
+	return r.db.DeleteAttachment(productID, attachmentID)
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/models"
+)
+
+// InventoryCountRepository define a interface para operações de contagens de inventário
+type InventoryCountRepository interface {
+	Create(count *models.InventoryCount) error
+	GetByID(id uuid.UUID) (*models.InventoryCount, error)
+	List(filter database.InventoryCountFilter) ([]*models.InventoryCount, error)
+	RecordCounts(id uuid.UUID, entries []database.CountEntry) (*models.InventoryCount, error)
+	Close(id uuid.UUID) (*models.InventoryCount, error)
+	Reopen(id uuid.UUID) (*models.InventoryCount, error)
+	Post(id uuid.UUID, aprovadoPor string) (*models.InventoryCount, error)
+	Cancel(id uuid.UUID) (*models.InventoryCount, error)
+}
+
+// InMemoryInventoryCountRepository implementa InventoryCountRepository usando banco em memória
+type InMemoryInventoryCountRepository struct {
+	db *database.InMemoryDatabase
+}
+
+// NewInMemoryInventoryCountRepository cria uma nova instância do repository
+func NewInMemoryInventoryCountRepository(db *database.InMemoryDatabase) *InMemoryInventoryCountRepository {
+	return &InMemoryInventoryCountRepository{
+		db: db,
+	}
+}
+
+// Create abre uma contagem congelando as quantidades esperadas do escopo
+func (r *InMemoryInventoryCountRepository) Create(count *models.InventoryCount) error {
+	return r.db.CreateInventoryCount(count)
+}
+
+// GetByID busca uma contagem por ID
+func (r *InMemoryInventoryCountRepository) GetByID(id uuid.UUID) (*models.InventoryCount, error) {
+	return r.db.GetInventoryCount(id)
+}
+
+// List retorna as contagens que atendem ao filtro
+func (r *InMemoryInventoryCountRepository) List(filter database.InventoryCountFilter) ([]*models.InventoryCount, error) {
+	return r.db.ListInventoryCounts(filter)
+}
+
+// RecordCounts registra quantidades contadas
+func (r *InMemoryInventoryCountRepository) RecordCounts(id uuid.UUID, entries []database.CountEntry) (*models.InventoryCount, error) {
+	return r.db.RecordCounts(id, entries)
+}
+
+// Close encerra a contagem para revisão
+func (r *InMemoryInventoryCountRepository) Close(id uuid.UUID) (*models.InventoryCount, error) {
+	return r.db.CloseInventoryCount(id)
+}
+
+// Reopen reabre uma contagem encerrada para recontagens
+func (r *InMemoryInventoryCountRepository) Reopen(id uuid.UUID) (*models.InventoryCount, error) {
+	return r.db.ReopenInventoryCount(id)
+}
+
+// Post aprova a contagem e lança os ajustes de estoque
+func (r *InMemoryInventoryCountRepository) Post(id uuid.UUID, aprovadoPor string) (*models.InventoryCount, error) {
+	return r.db.PostInventoryCount(id, aprovadoPor)
+}
+
+// Cancel cancela uma contagem em andamento
+func (r *InMemoryInventoryCountRepository) Cancel(id uuid.UUID) (*models.InventoryCount, error) {
+	return r.db.CancelInventoryCount(id)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/models"
+)
+
+// InvoiceImportRepository define a interface para operações de importação de NF-e
+type InvoiceImportRepository interface {
+	Create(invoice *models.InvoiceImport) error
+	GetByID(id uuid.UUID) (*models.InvoiceImport, error)
+	List(filter database.InvoiceImportFilter) ([]*models.InvoiceImport, error)
+	MapLine(id uuid.UUID, item int, produtoID *uuid.UUID) (*models.InvoiceImport, error)
+	Confirm(id uuid.UUID, observacao string) (*models.InvoiceImport, error)
+	Discard(id uuid.UUID, observacao string) (*models.InvoiceImport, error)
+}
+
+// InMemoryInvoiceImportRepository implementa InvoiceImportRepository usando banco em memória
+type InMemoryInvoiceImportRepository struct {
+	db *database.InMemoryDatabase
+}
+
+// NewInMemoryInvoiceImportRepository cria uma nova instância do repository
+func NewInMemoryInvoiceImportRepository(db *database.InMemoryDatabase) *InMemoryInvoiceImportRepository {
+	return &InMemoryInvoiceImportRepository{
+		db: db,
+	}
+}
+
+// Create registra a importação pendente com os vínculos automáticos
+func (r *InMemoryInvoiceImportRepository) Create(invoice *models.InvoiceImport) error {
+	return r.db.CreateInvoiceImport(invoice)
+}
+
+// GetByID busca uma importação por ID
+func (r *InMemoryInvoiceImportRepository) GetByID(id uuid.UUID) (*models.InvoiceImport, error) {
+	return r.db.GetInvoiceImport(id)
+}
+
+// List retorna as importações que atendem ao filtro
+func (r *InMemoryInvoiceImportRepository) List(filter database.InvoiceImportFilter) ([]*models.InvoiceImport, error) {
+	return r.db.ListInvoiceImports(filter)
+}
+
+// MapLine vincula um item a um produto ou o marca como ignorado
+func (r *InMemoryInvoiceImportRepository) MapLine(id uuid.UUID, item int, produtoID *uuid.UUID) (*models.InvoiceImport, error) {
+	return r.db.MapInvoiceLine(id, item, produtoID)
+}
+
+// Confirm lança a entrada de estoque e custos da nota
+func (r *InMemoryInvoiceImportRepository) Confirm(id uuid.UUID, observacao string) (*models.InvoiceImport, error) {
+	return r.db.ConfirmInvoiceImport(id, observacao)
+}
+
+// Discard descarta a importação pendente
+func (r *InMemoryInvoiceImportRepository) Discard(id uuid.UUID, observacao string) (*models.InvoiceImport, error) {
+	return r.db.DiscardInvoiceImport(id, observacao)
+}
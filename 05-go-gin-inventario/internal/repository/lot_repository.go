@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/models"
+)
+
+// LotRepository define a interface para operações de lotes
+type LotRepository interface {
+	Create(lot *models.Lot) error
+	GetByProduct(productID uuid.UUID, apenasComSaldo bool) ([]*models.Lot, error)
+	GetExpiring(until time.Time) ([]*models.Lot, error)
+}
+
+// InMemoryLotRepository implementa LotRepository usando banco em memória
+type InMemoryLotRepository struct {
+	db *database.InMemoryDatabase
+}
+
+// NewInMemoryLotRepository cria uma nova instância do repository
+func NewInMemoryLotRepository(db *database.InMemoryDatabase) *InMemoryLotRepository {
+	return &InMemoryLotRepository{
+		db: db,
+	}
+}
+
+// Create registra a entrada de um lote
+func (r *InMemoryLotRepository) Create(lot *models.Lot) error {
+	return r.db.CreateLot(lot)
+}
+
+// GetByProduct retorna os lotes de um produto na ordem FEFO
+func (r *InMemoryLotRepository) GetByProduct(productID uuid.UUID, apenasComSaldo bool) ([]*models.Lot, error) {
+	return r.db.GetLotsByProduct(productID, apenasComSaldo)
+}
+
+// GetExpiring retorna os lotes com saldo que vencem até o instante informado
+func (r *InMemoryLotRepository) GetExpiring(until time.Time) ([]*models.Lot, error) {
+	return r.db.GetExpiringLots(until)
+}
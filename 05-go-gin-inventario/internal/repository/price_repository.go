@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/models"
+)
+
+// PriceRepository define a interface para histórico, agendamento e reajuste de preços
+type PriceRepository interface {
+	Schedule(scheduled *models.ScheduledPrice) error
+	CancelScheduled(productID, id uuid.UUID) (*models.ScheduledPrice, error)
+	ListScheduled(productID uuid.UUID, apenasPendentes bool) ([]*models.ScheduledPrice, error)
+	ApplyDue(now time.Time) int
+	GetHistory(productID uuid.UUID) ([]models.PriceChange, error)
+	Adjust(adjustment database.PriceAdjustment) ([]database.PriceAdjustmentResult, error)
+}
+
+// InMemoryPriceRepository implementa PriceRepository usando banco em memória
+type InMemoryPriceRepository struct {
+	db *database.InMemoryDatabase
+}
+
+// NewInMemoryPriceRepository cria uma nova instância do repository
+func NewInMemoryPriceRepository(db *database.InMemoryDatabase) *InMemoryPriceRepository {
+	return &InMemoryPriceRepository{
+		db: db,
+	}
+}
+
+// Schedule agenda um preço futuro
+func (r *InMemoryPriceRepository) Schedule(scheduled *models.ScheduledPrice) error {
+	return r.db.SchedulePrice(scheduled)
+}
+
+// CancelScheduled cancela um preço agendado pendente
+func (r *InMemoryPriceRepository) CancelScheduled(productID, id uuid.UUID) (*models.ScheduledPrice, error) {
+	return r.db.CancelScheduledPrice(productID, id)
+}
+
+// ListScheduled retorna os preços agendados de um produto
+func (r *InMemoryPriceRepository) ListScheduled(productID uuid.UUID, apenasPendentes bool) ([]*models.ScheduledPrice, error) {
+	return r.db.ListScheduledPrices(productID, apenasPendentes)
+}
+
+// ApplyDue aplica os preços agendados vigentes até o instante informado
+func (r *InMemoryPriceRepository) ApplyDue(now time.Time) int {
+	return r.db.ApplyDuePrices(now)
+}
+
+// GetHistory retorna o histórico de preços de um produto
+func (r *InMemoryPriceRepository) GetHistory(productID uuid.UUID) ([]models.PriceChange, error) {
+	return r.db.GetPriceHistory(productID)
+}
+
+// Adjust aplica ou simula um reajuste de preços em massa
+func (r *InMemoryPriceRepository) Adjust(adjustment database.PriceAdjustment) ([]database.PriceAdjustmentResult, error) {
+	return r.db.AdjustPrices(adjustment)
+}
@@ -14,13 +14,47 @@ type ProductRepository interface {
 	GetAll() ([]*models.Product, error)
 	Update(id uuid.UUID, product *models.Product) error
 	Delete(id uuid.UUID) error
+	ModifyProduct(id uuid.UUID, modify database.ProductModifier) (*models.Product, error)
+	ApplyProductBatch(operations []database.ProductOperation, atomic bool) []database.ProductOperationResult
 
 	// Consultas especializadas
 	GetByCategory(category models.ProductCategory) ([]*models.Product, error)
 	GetActiveProducts() ([]*models.Product, error)
 	GetInStockProducts() ([]*models.Product, error)
 	GetFiltered(options database.FilterOptions) ([]*models.Product, int, error)
-	
+
+	// Estoque
+	AdjustStock(adjustment database.StockAdjustment) (*models.Product, error)
+	AdjustStockBatch(adjustments []database.StockAdjustment) ([]*models.Product, error)
+	GetStockAlerts(limit int) ([]models.StockAlert, error)
+
+	// Kits
+	SetKitComponents(definition database.KitDefinition) (*models.Product, error)
+	GetKitComponents(kitID uuid.UUID) (*models.Product, []*models.Product, error)
+
+	// Ciclo de vida
+	ChangeStatus(transition database.StatusTransition) (*database.StatusTransitionResult, error)
+	GetStatusHistory(productID uuid.UUID) ([]models.StatusChange, error)
+
+	// Tags
+	UpdateTags(change database.TagChange) ([]*models.Product, error)
+	RemoveTag(productID uuid.UUID, tag string) (*models.Product, error)
+	GetTagCloud() ([]models.TagCount, error)
+
+	// Traduções
+	SetTranslation(productID uuid.UUID, locale models.Locale, translation models.ProductTranslation) (*models.Product, error)
+	DeleteTranslation(productID uuid.UUID, locale models.Locale) (*models.Product, error)
+
+	// Produtos relacionados
+	CreateRelation(relation *models.ProductRelation) (*models.ProductRelation, error)
+	DeleteRelation(productID, relatedID uuid.UUID, tipo *models.RelationType) error
+	GetRelations(productID uuid.UUID, tipo *models.RelationType) ([]database.RelatedProduct, error)
+	GetAlternatives(productID uuid.UUID) (*models.Product, []database.RelatedProduct, error)
+
+	// Custos e valoração
+	GetCostLayers(productID uuid.UUID, apenasComSaldo bool) ([]models.CostLayer, error)
+	GetInventoryValuation() ([]database.InventoryValuation, error)
+
 	// Estatísticas
 	GetStatistics() (map[string]interface{}, error)
 }
@@ -62,6 +96,16 @@ func (r *InMemoryProductRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(id)
 }
 
+// ModifyProduct lê, altera e grava um produto sob o mesmo lock
+func (r *InMemoryProductRepository) ModifyProduct(id uuid.UUID, modify database.ProductModifier) (*models.Product, error) {
+	return r.db.ModifyProduct(id, modify)
+}
+
+// ApplyProductBatch executa um lote de cadastros, atualizações e remoções
+func (r *InMemoryProductRepository) ApplyProductBatch(operations []database.ProductOperation, atomic bool) []database.ProductOperationResult {
+	return r.db.ApplyProductBatch(operations, atomic)
+}
+
 // GetByCategory retorna produtos de uma categoria específica
 func (r *InMemoryProductRepository) GetByCategory(category models.ProductCategory) ([]*models.Product, error) {
 	return r.db.GetByCategory(category)
@@ -82,7 +126,97 @@ func (r *InMemoryProductRepository) GetFiltered(options database.FilterOptions)
 	return r.db.GetFiltered(options)
 }
 
+// AdjustStock aplica uma variação relativa ao estoque de forma atômica
+func (r *InMemoryProductRepository) AdjustStock(adjustment database.StockAdjustment) (*models.Product, error) {
+	return r.db.AdjustStock(adjustment)
+}
+
+// AdjustStockBatch aplica vários ajustes de estoque de forma atômica
+func (r *InMemoryProductRepository) AdjustStockBatch(adjustments []database.StockAdjustment) ([]*models.Product, error) {
+	return r.db.AdjustStockBatch(adjustments)
+}
+
+// GetStockAlerts retorna os alertas de estoque mais recentes
+func (r *InMemoryProductRepository) GetStockAlerts(limit int) ([]models.StockAlert, error) {
+	return r.db.GetStockAlerts(limit)
+}
+
+// SetKitComponents define a composição de um kit
+func (r *InMemoryProductRepository) SetKitComponents(definition database.KitDefinition) (*models.Product, error) {
+	return r.db.SetKitComponents(definition)
+}
+
+// ChangeStatus aplica uma transição do ciclo de vida do produto
+func (r *InMemoryProductRepository) ChangeStatus(transition database.StatusTransition) (*database.StatusTransitionResult, error) {
+	return r.db.ChangeProductStatus(transition)
+}
+
+// GetStatusHistory retorna as transições do ciclo de vida do produto
+func (r *InMemoryProductRepository) GetStatusHistory(productID uuid.UUID) ([]models.StatusChange, error) {
+	return r.db.GetStatusHistory(productID)
+}
+
+// UpdateTags adiciona e remove tags de um ou mais produtos
+func (r *InMemoryProductRepository) UpdateTags(change database.TagChange) ([]*models.Product, error) {
+	return r.db.UpdateProductTags(change)
+}
+
+// RemoveTag remove uma tag de um produto
+func (r *InMemoryProductRepository) RemoveTag(productID uuid.UUID, tag string) (*models.Product, error) {
+	return r.db.RemoveProductTag(productID, tag)
+}
+
+// GetTagCloud retorna as tags em uso com a contagem de produtos
+func (r *InMemoryProductRepository) GetTagCloud() ([]models.TagCount, error) {
+	return r.db.GetTagCloud()
+}
+
+// SetTranslation cria ou substitui a tradução de um produto
+func (r *InMemoryProductRepository) SetTranslation(productID uuid.UUID, locale models.Locale, translation models.ProductTranslation) (*models.Product, error) {
+	return r.db.SetProductTranslation(productID, locale, translation)
+}
+
+// DeleteTranslation remove a tradução de um produto
+func (r *InMemoryProductRepository) DeleteTranslation(productID uuid.UUID, locale models.Locale) (*models.Product, error) {
+	return r.db.DeleteProductTranslation(productID, locale)
+}
+
+// CreateRelation relaciona um produto a outro
+func (r *InMemoryProductRepository) CreateRelation(relation *models.ProductRelation) (*models.ProductRelation, error) {
+	return r.db.CreateRelation(relation)
+}
+
+// DeleteRelation desfaz as relações de um produto com outro
+func (r *InMemoryProductRepository) DeleteRelation(productID, relatedID uuid.UUID, tipo *models.RelationType) error {
+	return r.db.DeleteRelation(productID, relatedID, tipo)
+}
+
+// GetRelations retorna as relações do produto com os produtos relacionados
+func (r *InMemoryProductRepository) GetRelations(productID uuid.UUID, tipo *models.RelationType) ([]database.RelatedProduct, error) {
+	return r.db.GetRelations(productID, tipo)
+}
+
+// GetAlternatives retorna os substitutos do produto disponíveis em estoque
+func (r *InMemoryProductRepository) GetAlternatives(productID uuid.UUID) (*models.Product, []database.RelatedProduct, error) {
+	return r.db.GetAlternatives(productID)
+}
+
+// GetKitComponents retorna um kit e os produtos que o compõem
+func (r *InMemoryProductRepository) GetKitComponents(kitID uuid.UUID) (*models.Product, []*models.Product, error) {
+	return r.db.GetKitComponents(kitID)
+}
+
+// GetCostLayers retorna as camadas de custo de um produto
+func (r *InMemoryProductRepository) GetCostLayers(productID uuid.UUID, apenasComSaldo bool) ([]models.CostLayer, error) {
+	return r.db.GetCostLayers(productID, apenasComSaldo)
+}
+
+// GetInventoryValuation retorna o valor em estoque de cada produto a custo e a preço de venda
+func (r *InMemoryProductRepository) GetInventoryValuation() ([]database.InventoryValuation, error) {
+	return r.db.GetInventoryValuation()
+}
+
 // GetStatistics retorna estatísticas dos produtos
 func (r *InMemoryProductRepository) GetStatistics() (map[string]interface{}, error) {
 	return r.db.GetStatistics()
-}
\ No newline at end of file
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/models"
+)
+
+// PromotionRepository define a interface para operações de promoções
+type PromotionRepository interface {
+	Create(promotion *models.Promotion) error
+	GetByID(id uuid.UUID) (*models.Promotion, error)
+	List(apenasVigentes bool, now time.Time) ([]*models.Promotion, error)
+	Update(id uuid.UUID, promotion *models.Promotion) error
+	Delete(id uuid.UUID) error
+	Simulate(promotion *models.Promotion, at time.Time) ([]database.PromotionSimulation, error)
+}
+
+// InMemoryPromotionRepository implementa PromotionRepository usando banco em memória
+type InMemoryPromotionRepository struct {
+	db *database.InMemoryDatabase
+}
+
+// NewInMemoryPromotionRepository cria uma nova instância do repository
+func NewInMemoryPromotionRepository(db *database.InMemoryDatabase) *InMemoryPromotionRepository {
+	return &InMemoryPromotionRepository{
+		db: db,
+	}
+}
+
+// Create cadastra uma nova promoção
+func (r *InMemoryPromotionRepository) Create(promotion *models.Promotion) error {
+	return r.db.CreatePromotion(promotion)
+}
+
+// GetByID busca uma promoção por ID
+func (r *InMemoryPromotionRepository) GetByID(id uuid.UUID) (*models.Promotion, error) {
+	return r.db.GetPromotion(id)
+}
+
+// List retorna as promoções cadastradas
+func (r *InMemoryPromotionRepository) List(apenasVigentes bool, now time.Time) ([]*models.Promotion, error) {
+	return r.db.ListPromotions(apenasVigentes, now)
+}
+
+// Update substitui os dados de uma promoção
+func (r *InMemoryPromotionRepository) Update(id uuid.UUID, promotion *models.Promotion) error {
+	return r.db.UpdatePromotion(id, promotion)
+}
+
+// Delete remove uma promoção
+func (r *InMemoryPromotionRepository) Delete(id uuid.UUID) error {
+	return r.db.DeletePromotion(id)
+}
+
+// Simulate calcula o efeito de uma promoção sem cadastrá-la
+func (r *InMemoryPromotionRepository) Simulate(promotion *models.Promotion, at time.Time) ([]database.PromotionSimulation, error) {
+	return r.db.SimulatePromotion(promotion, at)
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/models"
+)
+
+// PurchaseOrderRepository define a interface para operações de pedidos de compra
+type PurchaseOrderRepository interface {
+	Create(order *models.PurchaseOrder) error
+	GetByID(id uuid.UUID) (*models.PurchaseOrder, error)
+	List(filter database.PurchaseOrderFilter) ([]*models.PurchaseOrder, error)
+	Update(id uuid.UUID, order *models.PurchaseOrder) error
+	Send(id uuid.UUID) (*models.PurchaseOrder, error)
+	Cancel(id uuid.UUID, motivo string) (*models.PurchaseOrder, error)
+	Receive(id uuid.UUID, receipt *models.PurchaseReceipt) (*models.PurchaseOrder, error)
+}
+
+// InMemoryPurchaseOrderRepository implementa PurchaseOrderRepository usando banco em memória
+type InMemoryPurchaseOrderRepository struct {
+	db *database.InMemoryDatabase
+}
+
+// NewInMemoryPurchaseOrderRepository cria uma nova instância do repository
+func NewInMemoryPurchaseOrderRepository(db *database.InMemoryDatabase) *InMemoryPurchaseOrderRepository {
+	return &InMemoryPurchaseOrderRepository{
+		db: db,
+	}
+}
+
+// Create cadastra um pedido de compra em rascunho
+func (r *InMemoryPurchaseOrderRepository) Create(order *models.PurchaseOrder) error {
+	return r.db.CreatePurchaseOrder(order)
+}
+
+// GetByID busca um pedido de compra por ID
+func (r *InMemoryPurchaseOrderRepository) GetByID(id uuid.UUID) (*models.PurchaseOrder, error) {
+	return r.db.GetPurchaseOrder(id)
+}
+
+// List retorna os pedidos de compra que atendem ao filtro
+func (r *InMemoryPurchaseOrderRepository) List(filter database.PurchaseOrderFilter) ([]*models.PurchaseOrder, error) {
+	return r.db.ListPurchaseOrders(filter)
+}
+
+// Update substitui os dados de um pedido em rascunho
+func (r *InMemoryPurchaseOrderRepository) Update(id uuid.UUID, order *models.PurchaseOrder) error {
+	return r.db.UpdatePurchaseOrder(id, order)
+}
+
+// Send marca o pedido como enviado ao fornecedor
+func (r *InMemoryPurchaseOrderRepository) Send(id uuid.UUID) (*models.PurchaseOrder, error) {
+	return r.db.SendPurchaseOrder(id)
+}
+
+// Cancel cancela o pedido
+func (r *InMemoryPurchaseOrderRepository) Cancel(id uuid.UUID, motivo string) (*models.PurchaseOrder, error) {
+	return r.db.CancelPurchaseOrder(id, motivo)
+}
+
+// Receive registra o recebimento de itens do pedido
+func (r *InMemoryPurchaseOrderRepository) Receive(id uuid.UUID, receipt *models.PurchaseReceipt) (*models.PurchaseOrder, error) {
+	return r.db.ReceivePurchaseOrder(id, receipt)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/models"
+)
+
+// ReservationRepository define a interface para operações de reserva de estoque
+type ReservationRepository interface {
+	Create(reservation *models.Reservation) error
+	GetByID(id uuid.UUID) (*models.Reservation, error)
+	List(filter database.ReservationFilter) ([]*models.Reservation, error)
+	Confirm(id uuid.UUID) (*models.Reservation, error)
+	Release(id uuid.UUID) (*models.Reservation, error)
+	ExpireBefore(now time.Time) int
+}
+
+// InMemoryReservationRepository implementa ReservationRepository usando banco em memória
+type InMemoryReservationRepository struct {
+	db *database.InMemoryDatabase
+}
+
+// NewInMemoryReservationRepository cria uma nova instância do repository
+func NewInMemoryReservationRepository(db *database.InMemoryDatabase) *InMemoryReservationRepository {
+	return &InMemoryReservationRepository{
+		db: db,
+	}
+}
+
+// Create registra uma nova reserva
+func (r *InMemoryReservationRepository) Create(reservation *models.Reservation) error {
+	return r.db.CreateReservation(reservation)
+}
+
+// GetByID busca uma reserva por ID
+func (r *InMemoryReservationRepository) GetByID(id uuid.UUID) (*models.Reservation, error) {
+	return r.db.GetReservation(id)
+}
+
+// List retorna reservas filtradas
+func (r *InMemoryReservationRepository) List(filter database.ReservationFilter) ([]*models.Reservation, error) {
+	return r.db.ListReservations(filter)
+}
+
+// Confirm efetiva uma reserva ativa
+func (r *InMemoryReservationRepository) Confirm(id uuid.UUID) (*models.Reservation, error) {
+	return r.db.ConfirmReservation(id)
+}
+
+// Release libera uma reserva ativa
+func (r *InMemoryReservationRepository) Release(id uuid.UUID) (*models.Reservation, error) {
+	return r.db.ReleaseReservation(id)
+}
+
+// ExpireBefore expira reservas ativas vencidas até o instante informado
+func (r *InMemoryReservationRepository) ExpireBefore(now time.Time) int {
+	return r.db.ExpireReservations(now)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/models"
+)
+
+// ReturnRepository define a interface para operações de devoluções
+type ReturnRepository interface {
+	Create(ret *models.Return) error
+	GetByID(id uuid.UUID) (*models.Return, error)
+	List(filter database.ReturnFilter) ([]*models.Return, error)
+	Inspect(id uuid.UUID, inspections []database.ReturnInspection) (*models.Return, error)
+}
+
+// InMemoryReturnRepository implementa ReturnRepository usando banco em memória
+type InMemoryReturnRepository struct {
+	db *database.InMemoryDatabase
+}
+
+// NewInMemoryReturnRepository cria uma nova instância do repository
+func NewInMemoryReturnRepository(db *database.InMemoryDatabase) *InMemoryReturnRepository {
+	return &InMemoryReturnRepository{
+		db: db,
+	}
+}
+
+// Create registra uma devolução aguardando inspeção
+func (r *InMemoryReturnRepository) Create(ret *models.Return) error {
+	return r.db.CreateReturn(ret)
+}
+
+// GetByID busca uma devolução por ID
+func (r *InMemoryReturnRepository) GetByID(id uuid.UUID) (*models.Return, error) {
+	return r.db.GetReturn(id)
+}
+
+// List retorna as devoluções que atendem ao filtro
+func (r *InMemoryReturnRepository) List(filter database.ReturnFilter) ([]*models.Return, error) {
+	return r.db.ListReturns(filter)
+}
+
+// Inspect registra o destino de cada item e aplica o efeito no estoque
+func (r *InMemoryReturnRepository) Inspect(id uuid.UUID, inspections []database.ReturnInspection) (*models.Return, error) {
+	return r.db.InspectReturn(id, inspections)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/models"
+)
+
+// SalesOrderRepository define a interface para operações de pedidos de venda
+type SalesOrderRepository interface {
+	Create(order *models.SalesOrder) error
+	GetByID(id uuid.UUID) (*models.SalesOrder, error)
+	List(filter database.SalesOrderFilter) ([]*models.SalesOrder, error)
+	Cancel(id uuid.UUID, motivo string) (*models.SalesOrder, error)
+}
+
+// InMemorySalesOrderRepository implementa SalesOrderRepository usando banco em memória
+type InMemorySalesOrderRepository struct {
+	db *database.InMemoryDatabase
+}
+
+// NewInMemorySalesOrderRepository cria uma nova instância do repository
+func NewInMemorySalesOrderRepository(db *database.InMemoryDatabase) *InMemorySalesOrderRepository {
+	return &InMemorySalesOrderRepository{
+		db: db,
+	}
+}
+
+// Create registra a venda baixando o estoque de todos os itens
+func (r *InMemorySalesOrderRepository) Create(order *models.SalesOrder) error {
+	return r.db.CreateSalesOrder(order)
+}
+
+// GetByID busca um pedido de venda por ID
+func (r *InMemorySalesOrderRepository) GetByID(id uuid.UUID) (*models.SalesOrder, error) {
+	return r.db.GetSalesOrder(id)
+}
+
+// List retorna os pedidos de venda que atendem ao filtro
+func (r *InMemorySalesOrderRepository) List(filter database.SalesOrderFilter) ([]*models.SalesOrder, error) {
+	return r.db.ListSalesOrders(filter)
+}
+
+// Cancel cancela o pedido devolvendo o estoque
+func (r *InMemorySalesOrderRepository) Cancel(id uuid.UUID, motivo string) (*models.SalesOrder, error) {
+	return r.db.CancelSalesOrder(id, motivo)
+}
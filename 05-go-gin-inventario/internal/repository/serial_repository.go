@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/models"
+)
+
+// SerialRepository define a interface para operações de números de série
+type SerialRepository interface {
+	Receive(movement database.SerialMovement) ([]*models.SerialNumber, *models.Product, error)
+	Ship(movement database.SerialMovement) ([]*models.SerialNumber, *models.Product, error)
+	GetByNumber(numero string) (*models.SerialNumber, error)
+	GetByProduct(productID uuid.UUID, status *models.SerialStatus) ([]*models.SerialNumber, error)
+}
+
+// InMemorySerialRepository implementa SerialRepository usando banco em memória
+type InMemorySerialRepository struct {
+	db *database.InMemoryDatabase
+}
+
+// NewInMemorySerialRepository cria uma nova instância do repository
+func NewInMemorySerialRepository(db *database.InMemoryDatabase) *InMemorySerialRepository {
+	return &InMemorySerialRepository{
+		db: db,
+	}
+}
+
+// Receive registra a entrada de unidades serializadas
+func (r *InMemorySerialRepository) Receive(movement database.SerialMovement) ([]*models.SerialNumber, *models.Product, error) {
+	return r.db.ReceiveSerials(movement)
+}
+
+// Ship registra a saída de unidades serializadas
+func (r *InMemorySerialRepository) Ship(movement database.SerialMovement) ([]*models.SerialNumber, *models.Product, error) {
+	return r.db.ShipSerials(movement)
+}
+
+// GetByNumber busca um número de série
+func (r *InMemorySerialRepository) GetByNumber(numero string) (*models.SerialNumber, error) {
+	return r.db.GetSerial(numero)
+}
+
+// GetByProduct retorna os números de série de um produto
+func (r *InMemorySerialRepository) GetByProduct(productID uuid.UUID, status *models.SerialStatus) ([]*models.SerialNumber, error) {
+	return r.db.GetSerialsByProduct(productID, status)
+}
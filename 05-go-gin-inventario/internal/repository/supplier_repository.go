@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/models"
+)
+
+// SupplierRepository define a interface para operações de fornecedores
+type SupplierRepository interface {
+	Create(supplier *models.Supplier) error
+	GetByID(id uuid.UUID) (*models.Supplier, error)
+	List(apenasAtivos bool) ([]*models.Supplier, error)
+	Update(id uuid.UUID, supplier *models.Supplier) error
+	Delete(id uuid.UUID) error
+	LinkProduct(link *models.SupplierProduct) (*database.SupplierProductLink, error)
+	UnlinkProduct(supplierID, productID uuid.UUID) error
+	GetProducts(supplierID uuid.UUID) ([]*database.SupplierProductLink, error)
+	GetByProduct(productID uuid.UUID) ([]*database.SupplierProductLink, error)
+}
+
+// InMemorySupplierRepository implementa SupplierRepository usando banco em memória
+type InMemorySupplierRepository struct {
+	db *database.InMemoryDatabase
+}
+
+// NewInMemorySupplierRepository cria uma nova instância do repository
+func NewInMemorySupplierRepository(db *database.InMemoryDatabase) *InMemorySupplierRepository {
+	return &InMemorySupplierRepository{
+		db: db,
+	}
+}
+
+// Create cadastra um novo fornecedor
+func (r *InMemorySupplierRepository) Create(supplier *models.Supplier) error {
+	return r.db.CreateSupplier(supplier)
+}
+
+// GetByID busca um fornecedor por ID
+func (r *InMemorySupplierRepository) GetByID(id uuid.UUID) (*models.Supplier, error) {
+	return r.db.GetSupplier(id)
+}
+
+// List retorna os fornecedores cadastrados
+func (r *InMemorySupplierRepository) List(apenasAtivos bool) ([]*models.Supplier, error) {
+	return r.db.ListSuppliers(apenasAtivos)
+}
+
+// Update substitui os dados cadastrais de um fornecedor
+func (r *InMemorySupplierRepository) Update(id uuid.UUID, supplier *models.Supplier) error {
+	return r.db.UpdateSupplier(id, supplier)
+}
+
+// Delete remove um fornecedor
+func (r *InMemorySupplierRepository) Delete(id uuid.UUID) error {
+	return r.db.DeleteSupplier(id)
+}
+
+// LinkProduct cria ou atualiza o vínculo de um produto com o fornecedor
+func (r *InMemorySupplierRepository) LinkProduct(link *models.SupplierProduct) (*database.SupplierProductLink, error) {
+	return r.db.LinkSupplierProduct(link)
+}
+
+// UnlinkProduct remove o vínculo de um produto com o fornecedor
+func (r *InMemorySupplierRepository) UnlinkProduct(supplierID, productID uuid.UUID) error {
+	return r.db.UnlinkSupplierProduct(supplierID, productID)
+}
+
+// GetProducts retorna os produtos vinculados ao fornecedor
+func (r *InMemorySupplierRepository) GetProducts(supplierID uuid.UUID) ([]*database.SupplierProductLink, error) {
+	return r.db.GetSupplierProducts(supplierID)
+}
+
+// GetByProduct retorna os fornecedores vinculados ao produto
+func (r *InMemorySupplierRepository) GetByProduct(productID uuid.UUID) ([]*database.SupplierProductLink, error) {
+	return r.db.GetProductSuppliers(productID)
+}
@@ -0,0 +1,410 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif" // registra o decodificador GIF usado por image.Decode
+	"image/jpeg"
+	"image/png"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+	"inventario-api/internal/storage"
+)
+
+// AttachmentURLPrefix é o caminho público sob o qual os arquivos gravados são servidos
+const AttachmentURLPrefix = "/arquivos"
+
+// Limites de tamanho dos anexos
+const (
+	MaxImageSize      = 5 << 20
+	MaxDocumentSize   = 10 << 20
+	MaxAttachmentSize = MaxDocumentSize
+
+	// maxImagePixels limita a área das imagens decodificadas, evitando que um
+	// arquivo pequeno e muito comprimido consuma memória demais
+	maxImagePixels = 25_000_000
+)
+
+// thumbnailSizes define as miniaturas geradas, pelo maior lado em pixels.
+// Imagens menores que o tamanho não são ampliadas.
+var thumbnailSizes = []struct {
+	nome string
+	lado int
+}{
+	{"pequena", 150},
+	{"media", 400},
+	{"grande", 800},
+}
+
+// attachmentFormat define o tipo de anexo e a extensão gravada de um content type aceito
+type attachmentFormat struct {
+	tipo     models.AttachmentKind
+	extensao string
+}
+
+// attachmentFormats lista os content types aceitos, detectados pelo conteúdo do arquivo
+var attachmentFormats = map[string]attachmentFormat{
+	"image/jpeg":      {models.AttachmentImagem, ".jpg"},
+	"image/png":       {models.AttachmentImagem, ".png"},
+	"image/gif":       {models.AttachmentImagem, ".gif"},
+	"application/pdf": {models.AttachmentDocumento, ".pdf"},
+	"text/plain":      {models.AttachmentDocumento, ".txt"},
+	"application/zip": {models.AttachmentDocumento, ".zip"},
+}
+
+// officeExtensions são formatos de escritório empacotados em zip que mantêm a
+// extensão original
+var officeExtensions = map[string]bool{
+	".docx": true, ".xlsx": true, ".pptx": true, ".odt": true, ".ods": true,
+}
+
+// AttachmentService implementa a lógica de negócio para imagens e documentos de produtos
+type AttachmentService struct {
+	repo        repository.AttachmentRepository
+	productRepo repository.ProductRepository
+	files       storage.FileStorage
+}
+
+// NewAttachmentService cria uma nova instância do service
+func NewAttachmentService(repo repository.AttachmentRepository, productRepo repository.ProductRepository, files storage.FileStorage) *AttachmentService {
+	return &AttachmentService{
+		repo:        repo,
+		productRepo: productRepo,
+		files:       files,
+	}
+}
+
+// thumbnailFile é uma miniatura gerada e ainda não gravada
+type thumbnailFile struct {
+	models.Thumbnail
+	dados []byte
+}
+
+// UploadAttachment valida e grava um anexo do produto. O content type é
+// detectado pelo conteúdo, nunca pela extensão ou pelo cabeçalho enviado.
+// Imagens têm as dimensões verificadas e recebem miniaturas.
+func (s *AttachmentService) UploadAttachment(productID uuid.UUID, req *dtos.UploadAttachmentRequest) (*dtos.AttachmentResponse, error) {
+	if _, err := s.productRepo.GetByID(productID); err != nil {
+		return nil, fmt.Errorf("produto não encontrado: %w", err)
+	}
+	if len(req.Dados) == 0 {
+		return nil, fmt.Errorf("%w: arquivo vazio", database.ErrAttachmentInvalid)
+	}
+
+	contentType := sniffContentType(req.Dados)
+	format, ok := attachmentFormats[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", database.ErrAttachmentUnsupported, contentType)
+	}
+
+	tipo := format.tipo
+	if req.Tipo != "" {
+		if !req.Tipo.IsValid() {
+			return nil, fmt.Errorf("%w: tipo %q desconhecido", database.ErrAttachmentInvalid, req.Tipo)
+		}
+		if req.Tipo == models.AttachmentImagem && format.tipo != models.AttachmentImagem {
+			return nil, fmt.Errorf("%w: imagens devem ser JPEG, PNG ou GIF, recebido %s",
+				database.ErrAttachmentUnsupported, contentType)
+		}
+		tipo = req.Tipo
+	}
+
+	limite := MaxDocumentSize
+	if tipo == models.AttachmentImagem {
+		limite = MaxImageSize
+	}
+	if len(req.Dados) > limite {
+		return nil, fmt.Errorf("%w: %s aceita até %d MB", database.ErrAttachmentTooLarge, tipo, limite>>20)
+	}
+
+	nomeOriginal := filepath.Base(strings.ReplaceAll(strings.TrimSpace(req.NomeArquivo), `\`, "/"))
+	extensao := format.extensao
+	if contentType == "application/zip" {
+		if original := strings.ToLower(filepath.Ext(nomeOriginal)); officeExtensions[original] {
+			extensao = original
+		}
+	}
+
+	attachment := &models.Attachment{
+		ID:           uuid.New(),
+		ProdutoID:    productID,
+		Tipo:         tipo,
+		NomeOriginal: nomeOriginal,
+		Descricao:    strings.TrimSpace(req.Descricao),
+		ContentType:  contentType,
+		Tamanho:      int64(len(req.Dados)),
+		Principal:    req.Principal,
+	}
+	base := productID.String() + "/" + attachment.ID.String()
+	attachment.Arquivo = base + extensao
+
+	var thumbnails []thumbnailFile
+	if tipo == models.AttachmentImagem {
+		largura, altura, generated, err := generateThumbnails(req.Dados, contentType, base)
+		if err != nil {
+			return nil, err
+		}
+		attachment.Largura = largura
+		attachment.Altura = altura
+		thumbnails = generated
+		for _, thumbnail := range thumbnails {
+			attachment.Miniaturas = append(attachment.Miniaturas, thumbnail.Thumbnail)
+		}
+	}
+
+	// Grava os arquivos antes dos metadados; em caso de falha nada fica para trás
+	if err := s.files.Save(attachment.Arquivo, req.Dados); err != nil {
+		return nil, fmt.Errorf("erro ao gravar anexo: %w", err)
+	}
+	for _, thumbnail := range thumbnails {
+		if err := s.files.Save(thumbnail.Arquivo, thumbnail.dados); err != nil {
+			s.deleteFiles(attachment)
+			return nil, fmt.Errorf("erro ao gravar miniatura: %w", err)
+		}
+	}
+
+	if err := s.repo.Create(attachment); err != nil {
+		s.deleteFiles(attachment)
+		return nil, fmt.Errorf("erro ao registrar anexo: %w", err)
+	}
+
+	return toAttachmentResponse(attachment), nil
+}
+
+// GetProductAttachments retorna os anexos do produto, opcionalmente de um só tipo
+func (s *AttachmentService) GetProductAttachments(productID uuid.UUID, tipo *models.AttachmentKind) (*dtos.AttachmentListResponse, error) {
+	if tipo != nil && !tipo.IsValid() {
+		return nil, fmt.Errorf("%w: tipo %q desconhecido", database.ErrAttachmentInvalid, *tipo)
+	}
+
+	attachments, err := s.repo.GetByProduct(productID, tipo)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar anexos: %w", err)
+	}
+
+	responses := make([]dtos.AttachmentResponse, len(attachments))
+	for i, attachment := range attachments {
+		responses[i] = *toAttachmentResponse(attachment)
+	}
+
+	return &dtos.AttachmentListResponse{
+		ProdutoID: productID,
+		Anexos:    responses,
+		Total:     len(responses),
+	}, nil
+}
+
+// DeleteAttachment remove o anexo e seus arquivos
+func (s *AttachmentService) DeleteAttachment(productID, attachmentID uuid.UUID) error {
+	attachment, err := s.repo.Delete(productID, attachmentID)
+	if err != nil {
+		return fmt.Errorf("erro ao remover anexo: %w", err)
+	}
+
+	// O anexo já não é referenciado; falhas ao apagar os arquivos não o restauram
+	s.deleteFiles(attachment)
+	return nil
+}
+
+// deleteFiles apaga os arquivos gravados de um anexo, ignorando falhas
+func (s *AttachmentService) deleteFiles(attachment *models.Attachment) {
+	for _, name := range attachment.Files() {
+		_ = s.files.Delete(name)
+	}
+}
+
+// sniffContentType detecta o content type pelos primeiros bytes do conteúdo,
+// sem parâmetros como charset
+func sniffContentType(data []byte) string {
+	detected := http.DetectContentType(data)
+	mediaType, _, err := mime.ParseMediaType(detected)
+	if err != nil {
+		return detected
+	}
+	return mediaType
+}
+
+// generateThumbnails decodifica a imagem e gera as miniaturas menores que ela.
+// Miniaturas de JPEG são gravadas em JPEG; as demais em PNG, preservando a
+// transparência.
+func generateThumbnails(data []byte, contentType, base string) (int, int, []thumbnailFile, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("%w: imagem corrompida ou ilegível", database.ErrAttachmentInvalid)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return 0, 0, nil, fmt.Errorf("%w: imagem sem dimensões", database.ErrAttachmentInvalid)
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return 0, 0, nil, fmt.Errorf("%w: imagem de %dx%d pixels excede %d megapixels",
+			database.ErrAttachmentTooLarge, config.Width, config.Height, maxImagePixels/1_000_000)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("%w: imagem corrompida ou ilegível", database.ErrAttachmentInvalid)
+	}
+
+	bounds := src.Bounds()
+	largura, altura := bounds.Dx(), bounds.Dy()
+	thumbnails := make([]thumbnailFile, 0, len(thumbnailSizes))
+	for _, size := range thumbnailSizes {
+		if largura <= size.lado && altura <= size.lado {
+			continue
+		}
+		w, h := fitWithin(largura, altura, size.lado)
+		resized := resizeImage(src, w, h)
+
+		var buf bytes.Buffer
+		extensao := ".png"
+		if contentType == "image/jpeg" {
+			extensao = ".jpg"
+			err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: 85})
+		} else {
+			err = png.Encode(&buf, resized)
+		}
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("erro ao gerar miniatura: %w", err)
+		}
+
+		thumbnails = append(thumbnails, thumbnailFile{
+			Thumbnail: models.Thumbnail{
+				Tamanho: size.nome,
+				Arquivo: base + "_" + size.nome + extensao,
+				Largura: w,
+				Altura:  h,
+			},
+			dados: buf.Bytes(),
+		})
+	}
+
+	return largura, altura, thumbnails, nil
+}
+
+// fitWithin calcula as dimensões que cabem em um quadrado de lado informado,
+// mantendo a proporção
+func fitWithin(largura, altura, lado int) (int, int) {
+	if largura >= altura {
+		h := (altura*lado + largura/2) / largura
+		if h < 1 {
+			h = 1
+		}
+		return lado, h
+	}
+	w := (largura*lado + altura/2) / altura
+	if w < 1 {
+		w = 1
+	}
+	return w, lado
+}
+
+// resizeImage reduz a imagem pela média das áreas de origem (box filter), que
+// para reduções produz resultado suave sem depender de pacotes externos
+func resizeImage(src image.Image, largura, altura int) *image.RGBA {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	rgba := image.NewRGBA(image.Rect(0, 0, sw, sh))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, largura, altura))
+	for y := 0; y < altura; y++ {
+		y0, y1 := y*sh/altura, (y+1)*sh/altura
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < largura; x++ {
+			x0, x1 := x*sw/largura, (x+1)*sw/largura
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				offset := rgba.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(rgba.Pix[offset])
+					g += uint64(rgba.Pix[offset+1])
+					b += uint64(rgba.Pix[offset+2])
+					a += uint64(rgba.Pix[offset+3])
+					offset += 4
+					n++
+				}
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / n)
+			dst.Pix[offset+1] = uint8(g / n)
+			dst.Pix[offset+2] = uint8(b / n)
+			dst.Pix[offset+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// attachmentURL retorna a URL pública de um arquivo gravado
+func attachmentURL(arquivo string) string {
+	return AttachmentURLPrefix + "/" + arquivo
+}
+
+// thumbnailURLs retorna a URL de cada tamanho de miniatura. Tamanhos não
+// gerados, por a imagem já ser menor, apontam para o original.
+func thumbnailURLs(attachment *models.Attachment) map[string]string {
+	urls := make(map[string]string, len(thumbnailSizes))
+	for _, size := range thumbnailSizes {
+		if thumbnail := attachment.Thumbnail(size.nome); thumbnail != nil {
+			urls[size.nome] = attachmentURL(thumbnail.Arquivo)
+		} else {
+			urls[size.nome] = attachmentURL(attachment.Arquivo)
+		}
+	}
+	return urls
+}
+
+func toAttachmentResponse(attachment *models.Attachment) *dtos.AttachmentResponse {
+	response := &dtos.AttachmentResponse{
+		ID:          attachment.ID,
+		ProdutoID:   attachment.ProdutoID,
+		Tipo:        attachment.Tipo,
+		NomeArquivo: attachment.NomeOriginal,
+		Descricao:   attachment.Descricao,
+		ContentType: attachment.ContentType,
+		Tamanho:     attachment.Tamanho,
+		URL:         attachmentURL(attachment.Arquivo),
+		Largura:     attachment.Largura,
+		Altura:      attachment.Altura,
+		Principal:   attachment.Principal,
+		DataCriacao: attachment.DataCriacao,
+	}
+	if attachment.IsImage() {
+		response.Miniaturas = thumbnailURLs(attachment)
+	}
+	return response
+}
+
+// toProductImages converte as imagens do produto para a resposta do produto
+func toProductImages(images []models.Attachment) []dtos.ProductImageResponse {
+	if len(images) == 0 {
+		return nil
+	}
+	responses := make([]dtos.ProductImageResponse, len(images))
+	for i := range images {
+		responses[i] = dtos.ProductImageResponse{
+			ID:         images[i].ID,
+			URL:        attachmentURL(images[i].Arquivo),
+			Miniaturas: thumbnailURLs(&images[i]),
+			Descricao:  images[i].Descricao,
+			Principal:  images[i].Principal,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+)
+
+// StructValidator aplica as regras de binding a requisições montadas fora do
+// handler, como os itens de um lote ou o resultado de um patch. O
+// binding.Validator do gin implementa esta interface.
+type StructValidator interface {
+	ValidateStruct(obj interface{}) error
+}
+
+// BulkProducts executa um lote de cadastros, atualizações e remoções. Cada
+// item passa pelas mesmas validações da operação individual. No modo
+// tudo_ou_nada (padrão) qualquer falha impede todo o lote; em melhor_esforco
+// os itens válidos são aplicados e os demais relatados.
+func (s *ProductService) BulkProducts(req *dtos.BulkProductRequest, validator StructValidator) (*dtos.BulkProductResponse, error) {
+	modo := req.Modo
+	if modo == "" {
+		modo = models.BulkAllOrNothing
+	}
+	if !modo.IsValid() {
+		return nil, fmt.Errorf("%w: modo %q desconhecido", database.ErrBulkInvalid, modo)
+	}
+
+	total := len(req.Criar) + len(req.Atualizar) + len(req.Remover)
+	if total == 0 {
+		return nil, fmt.Errorf("%w: informe ao menos um item em criar, atualizar ou remover", database.ErrBulkInvalid)
+	}
+	if total > models.MaxBulkItems {
+		return nil, fmt.Errorf("%w: o lote aceita até %d itens, recebeu %d", database.ErrBulkInvalid, models.MaxBulkItems, total)
+	}
+
+	// Monta as operações dos itens válidos; pending guarda a posição de cada
+	// operação no relatório
+	items := make([]dtos.BulkItemResult, 0, total)
+	operations := make([]database.ProductOperation, 0, total)
+	pending := make([]int, 0, total)
+	add := func(item dtos.BulkItemResult, operation *database.ProductOperation, err error) {
+		if err != nil {
+			item.Err = err
+		} else {
+			pending = append(pending, len(items))
+			operations = append(operations, *operation)
+		}
+		items = append(items, item)
+	}
+
+	for i := range req.Criar {
+		operation, err := s.bulkCreateOperation(&req.Criar[i], validator)
+		add(dtos.BulkItemResult{Operacao: models.BulkCreate, Indice: i}, operation, err)
+	}
+	for i := range req.Atualizar {
+		id := req.Atualizar[i].ID
+		operation, err := s.bulkUpdateOperation(&req.Atualizar[i], validator)
+		add(dtos.BulkItemResult{Operacao: models.BulkUpdate, Indice: i, ProdutoID: &id}, operation, err)
+	}
+	for i, id := range req.Remover {
+		id := id
+		var err error
+		if id == uuid.Nil {
+			err = fmt.Errorf("%w: ID do produto inválido", database.ErrBulkInvalid)
+		}
+		add(dtos.BulkItemResult{Operacao: models.BulkDelete, Indice: i, ProdutoID: &id},
+			&database.ProductOperation{Tipo: models.BulkDelete, ProdutoID: id}, err)
+	}
+
+	atomic := modo == models.BulkAllOrNothing
+	if atomic && len(pending) < len(items) {
+		for _, index := range pending {
+			items[index].Err = database.ErrBulkAborted
+		}
+	} else if len(operations) > 0 {
+		results := s.repo.ApplyProductBatch(operations, atomic)
+		for i, result := range results {
+			item := &items[pending[i]]
+			item.Err = result.Err
+			if result.Err == nil && result.Produto != nil {
+				id := result.Produto.ID
+				item.ProdutoID = &id
+			}
+		}
+	}
+
+	response := &dtos.BulkProductResponse{
+		Modo:  modo,
+		Total: total,
+		Itens: items,
+	}
+	for i := range items {
+		if items[i].Err != nil {
+			response.Falhas++
+			continue
+		}
+		items[i].Sucesso = true
+		response.Sucessos++
+
+		// Remove os anexos gravados; o produto já foi excluído mesmo que falhe
+		if items[i].Operacao == models.BulkDelete {
+			_ = s.files.DeleteDir(items[i].ProdutoID.String())
+		}
+	}
+
+	return response, nil
+}
+
+// bulkCreateOperation valida um cadastro do lote e monta sua operação
+func (s *ProductService) bulkCreateOperation(req *dtos.CreateProductRequest, validator StructValidator) (*database.ProductOperation, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	product, err := s.newProduct(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &database.ProductOperation{Tipo: models.BulkCreate, Produto: product}, nil
+}
+
+// bulkUpdateOperation valida uma atualização do lote e monta sua operação. A
+// nova versão é montada dentro do lote, sobre o produto gravado.
+func (s *ProductService) bulkUpdateOperation(item *dtos.BulkUpdateItem, validator StructValidator) (*database.ProductOperation, error) {
+	if err := validator.ValidateStruct(item); err != nil {
+		return nil, err
+	}
+
+	return &database.ProductOperation{
+		Tipo:      models.BulkUpdate,
+		ProdutoID: item.ID,
+		Modificar: func(current *models.Product) (*models.Product, *database.StatusTransition, error) {
+			return s.applyUpdateRequest(item.ID, current, &item.UpdateProductRequest)
+		},
+	}, nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+)
+
+// GetCostLayers retorna as camadas de custo de um produto na ordem de consumo (FIFO)
+func (s *ProductService) GetCostLayers(id uuid.UUID, apenasComSaldo bool) (*dtos.CostLayerListResponse, error) {
+	product, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("produto não encontrado: %w", err)
+	}
+
+	layers, err := s.repo.GetCostLayers(id, apenasComSaldo)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar camadas de custo: %w", err)
+	}
+
+	valorFIFO := 0.0
+	for _, layer := range layers {
+		valorFIFO += layer.CustoUnitario * float64(layer.Quantidade)
+	}
+
+	return &dtos.CostLayerListResponse{
+		ProdutoID:       id,
+		Camadas:         layers,
+		Quantidade:      product.Quantidade,
+		ValorCustoFIFO:  valorFIFO,
+		CustoMedio:      product.CustoMedio,
+		ValorCustoMedio: product.CustoMedio * float64(product.Quantidade),
+	}, nil
+}
+
+// GetInventoryValuation valora o inventário a custo pelo método informado,
+// do produto de maior para o de menor valor em estoque
+func (s *ProductService) GetInventoryValuation(metodo models.ValuationMethod) (*dtos.InventoryValuationResponse, error) {
+	if metodo != models.ValuationFIFO && metodo != models.ValuationMediaPonderada {
+		return nil, fmt.Errorf("método de valoração inválido: use %s ou %s",
+			models.ValuationFIFO, models.ValuationMediaPonderada)
+	}
+
+	valuations, err := s.repo.GetInventoryValuation()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao valorar inventário: %w", err)
+	}
+
+	response := &dtos.InventoryValuationResponse{
+		Metodo: metodo,
+		Itens:  make([]dtos.InventoryValuationItem, 0, len(valuations)),
+	}
+
+	for _, valuation := range valuations {
+		product := valuation.Produto
+		if product.Quantidade == 0 {
+			continue
+		}
+
+		valorCusto := valuation.ValorCustoMedio
+		if metodo == models.ValuationFIFO {
+			valorCusto = valuation.ValorCustoFIFO
+		}
+
+		margem := 0.0
+		if valuation.ValorVenda > 0 {
+			margem = (valuation.ValorVenda - valorCusto) / valuation.ValorVenda * 100
+		}
+
+		response.Itens = append(response.Itens, dtos.InventoryValuationItem{
+			ProdutoID:        product.ID,
+			Nome:             product.Nome,
+			Categoria:        product.Categoria,
+			Quantidade:       product.Quantidade,
+			CustoUnitario:    roundMoney(valorCusto / float64(product.Quantidade)),
+			ValorCusto:       valorCusto,
+			ValorVenda:       valuation.ValorVenda,
+			MargemPercentual: roundPercent(margem),
+		})
+		response.ValorCusto += valorCusto
+		response.ValorVenda += valuation.ValorVenda
+	}
+
+	sort.Slice(response.Itens, func(i, j int) bool {
+		return response.Itens[i].ValorCusto > response.Itens[j].ValorCusto
+	})
+
+	response.Total = len(response.Itens)
+	response.LucroBrutoPotencial = response.ValorVenda - response.ValorCusto
+
+	return response, nil
+}
+
+// roundPercent arredonda percentuais em duas casas decimais
+func roundPercent(value float64) float64 {
+	return math.Round(value*100) / 100
+}
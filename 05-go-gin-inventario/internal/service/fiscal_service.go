@@ -0,0 +1,244 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+)
+
+// FiscalService simula os tributos de venda a partir dos dados fiscais do
+// produto e da tabela local de alíquotas
+type FiscalService struct {
+	repo  repository.ProductRepository
+	table models.TaxTable
+}
+
+// NewFiscalService cria uma nova instância do service fiscal
+func NewFiscalService(repo repository.ProductRepository, table models.TaxTable) *FiscalService {
+	return &FiscalService{
+		repo:  repo,
+		table: table,
+	}
+}
+
+// LoadTaxTable lê a tabela de tributos de um arquivo JSON. Sem caminho, usa a
+// tabela padrão.
+func LoadTaxTable(path string) (models.TaxTable, error) {
+	if path == "" {
+		return models.DefaultTaxTable(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.TaxTable{}, fmt.Errorf("erro ao ler tabela de tributos: %w", err)
+	}
+
+	var table models.TaxTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return models.TaxTable{}, fmt.Errorf("erro ao interpretar tabela de tributos: %w", err)
+	}
+	if err := table.Validate(); err != nil {
+		return models.TaxTable{}, fmt.Errorf("tabela de tributos inválida: %w", err)
+	}
+
+	return table, nil
+}
+
+// LoadNCMTable troca a tabela NCM embutida, que cobre apenas os códigos mais
+// comuns, pela tabela completa de um arquivo CSV no mesmo formato (código,
+// descrição e alíquota de IPI separados por ponto e vírgula). Sem caminho,
+// mantém a tabela embutida.
+func LoadNCMTable(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("erro ao ler tabela NCM: %w", err)
+	}
+	if err := models.UseNCMTable(string(data)); err != nil {
+		return fmt.Errorf("tabela NCM inválida: %w", err)
+	}
+
+	return nil
+}
+
+// GetTaxTable retorna a tabela de tributos em uso
+func (s *FiscalService) GetTaxTable() models.TaxTable {
+	return s.table
+}
+
+// SearchNCM busca códigos na tabela NCM pelo prefixo do código ou por parte
+// da descrição
+func (s *FiscalService) SearchNCM(termo string, limite int) *dtos.NCMListResponse {
+	entries := models.SearchNCM(termo)
+	total := len(entries)
+	if limite > 0 && len(entries) > limite {
+		entries = entries[:limite]
+	}
+	return &dtos.NCMListResponse{NCMs: entries, Total: total}
+}
+
+// SimulateTaxes calcula IPI, ICMS (com DIFAL na venda interestadual a
+// consumidor final), PIS e COFINS da venda do produto para a UF de destino.
+// Para consumidor final o IPI integra a base do ICMS; PIS e COFINS são
+// calculados sem o ICMS na base.
+func (s *FiscalService) SimulateTaxes(req *dtos.TaxSimulationRequest) (*dtos.TaxSimulationResponse, error) {
+	ufDestino := strings.ToUpper(strings.TrimSpace(req.UFDestino))
+	aliquotaDestino, ok := s.table.InternalRate(ufDestino)
+	if !ok {
+		return nil, fmt.Errorf("%w: UF %q desconhecida", database.ErrTaxInvalid, req.UFDestino)
+	}
+
+	product, err := s.repo.GetByID(req.ProdutoID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao simular tributos: %w", err)
+	}
+	if product.Fiscal == nil {
+		return nil, fmt.Errorf("%w: %s", database.ErrFiscalMissing, product.Nome)
+	}
+	fiscal := product.Fiscal
+
+	quantidade := req.Quantidade
+	if quantidade == 0 {
+		quantidade = 1
+	}
+	valorUnitario := product.Preco
+	if product.HasPromotion() {
+		valorUnitario = product.PrecoPromocional
+	}
+	if req.ValorUnitario != nil {
+		valorUnitario = *req.ValorUnitario
+	}
+	valorProdutos := roundMoney(valorUnitario * float64(quantidade))
+
+	response := &dtos.TaxSimulationResponse{
+		ProdutoID:       product.ID,
+		Nome:            product.Nome,
+		NCM:             fiscal.NCM,
+		Origem:          fiscal.Origem,
+		UFOrigem:        s.table.UFOrigem,
+		UFDestino:       ufDestino,
+		ConsumidorFinal: req.ConsumidorFinal,
+		Quantidade:      quantidade,
+		ValorUnitario:   valorUnitario,
+		ValorProdutos:   valorProdutos,
+	}
+
+	aliquotaIPI := 0.0
+	if entry, exists := models.LookupNCM(fiscal.NCM); exists {
+		aliquotaIPI = entry.AliquotaIPI
+	}
+	if fiscal.AliquotaIPI != nil {
+		aliquotaIPI = *fiscal.AliquotaIPI
+	}
+	response.IPI = taxLine(valorProdutos, aliquotaIPI)
+
+	baseICMS := valorProdutos
+	if req.ConsumidorFinal {
+		baseICMS = roundMoney(baseICMS + response.IPI.Valor)
+	}
+
+	if ufDestino == s.table.UFOrigem {
+		aliquotaOrigem, _ := s.table.InternalRate(s.table.UFOrigem)
+		response.Operacao = "interna"
+		response.CFOP = fiscal.CFOPEstadual
+		response.ICMS = taxLine(baseICMS, aliquotaOrigem)
+	} else {
+		aliquotaInterestadual := s.table.InterstateRate(ufDestino, fiscal.Origem)
+		response.Operacao = "interestadual"
+		response.CFOP = fiscal.CFOPInterestadual
+		response.ICMS = taxLine(baseICMS, aliquotaInterestadual)
+		if req.ConsumidorFinal {
+			difal := taxLine(baseICMS, math.Max(0, aliquotaDestino-aliquotaInterestadual))
+			response.DIFAL = &difal
+		}
+	}
+
+	aliquotaPIS := s.table.AliquotaPIS
+	if fiscal.AliquotaPIS != nil {
+		aliquotaPIS = *fiscal.AliquotaPIS
+	}
+	aliquotaCOFINS := s.table.AliquotaCOFINS
+	if fiscal.AliquotaCOFINS != nil {
+		aliquotaCOFINS = *fiscal.AliquotaCOFINS
+	}
+	basePISCOFINS := roundMoney(valorProdutos - response.ICMS.Valor)
+	response.PIS = taxLine(basePISCOFINS, aliquotaPIS)
+	response.COFINS = taxLine(basePISCOFINS, aliquotaCOFINS)
+
+	total := response.IPI.Valor + response.ICMS.Valor + response.PIS.Valor + response.COFINS.Valor
+	if response.DIFAL != nil {
+		total += response.DIFAL.Valor
+	}
+	response.TotalTributos = roundMoney(total)
+	response.ValorTotalNota = roundMoney(valorProdutos + response.IPI.Valor)
+	if response.ValorTotalNota > 0 {
+		response.CargaTributaria = roundMoney(response.TotalTributos / response.ValorTotalNota * 100)
+	}
+
+	return response, nil
+}
+
+// taxLine calcula um tributo sobre a base
+func taxLine(base, aliquota float64) dtos.TaxLine {
+	return dtos.TaxLine{Base: base, Aliquota: aliquota, Valor: models.ApplyRate(base, aliquota)}
+}
+
+// roundMoney arredonda valores em centavos
+func roundMoney(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// toFiscalData converte os dados fiscais da requisição para o modelo, com
+// códigos normalizados e CFOPs padrão, e valida o NCM contra a tabela
+func toFiscalData(req *dtos.FiscalRequest) (*models.FiscalData, error) {
+	if req == nil {
+		return nil, nil
+	}
+	fiscal := &models.FiscalData{
+		NCM:               req.NCM,
+		CEST:              req.CEST,
+		CFOPEstadual:      req.CFOPEstadual,
+		CFOPInterestadual: req.CFOPInterestadual,
+		Origem:            req.Origem,
+		AliquotaIPI:       req.AliquotaIPI,
+		AliquotaPIS:       req.AliquotaPIS,
+		AliquotaCOFINS:    req.AliquotaCOFINS,
+	}
+	fiscal.Normalize()
+	if err := fiscal.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrFiscalInvalid, err)
+	}
+	return fiscal.Clone(), nil
+}
+
+// toFiscalResponse converte os dados fiscais do produto para DTO
+func toFiscalResponse(fiscal *models.FiscalData) *dtos.FiscalResponse {
+	if fiscal == nil {
+		return nil
+	}
+	response := &dtos.FiscalResponse{
+		NCM:               fiscal.NCM,
+		CEST:              fiscal.CEST,
+		CFOPEstadual:      fiscal.CFOPEstadual,
+		CFOPInterestadual: fiscal.CFOPInterestadual,
+		Origem:            fiscal.Origem,
+		DescricaoOrigem:   fiscal.Origem.Description(),
+		AliquotaIPI:       fiscal.AliquotaIPI,
+		AliquotaPIS:       fiscal.AliquotaPIS,
+		AliquotaCOFINS:    fiscal.AliquotaCOFINS,
+	}
+	if entry, exists := models.LookupNCM(fiscal.NCM); exists {
+		response.DescricaoNCM = entry.Descricao
+	}
+	return response
+}
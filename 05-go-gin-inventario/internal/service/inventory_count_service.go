@@ -0,0 +1,263 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+)
+
+// InventoryCountService implementa a lógica de negócio para contagens de inventário
+type InventoryCountService struct {
+	repo repository.InventoryCountRepository
+}
+
+// NewInventoryCountService cria uma nova instância do service
+func NewInventoryCountService(repo repository.InventoryCountRepository) *InventoryCountService {
+	return &InventoryCountService{
+		repo: repo,
+	}
+}
+
+// CreateInventoryCount abre uma contagem sobre o escopo informado
+func (s *InventoryCountService) CreateInventoryCount(req *dtos.InventoryCountRequest) (*dtos.InventoryCountResponse, error) {
+	count := &models.InventoryCount{
+		Descricao:   strings.TrimSpace(req.Descricao),
+		Categoria:   req.Categoria,
+		Localizacao: models.NormalizeLocation(req.Localizacao),
+		Cega:        req.Cega,
+	}
+
+	if err := s.repo.Create(count); err != nil {
+		return nil, fmt.Errorf("erro ao abrir contagem de inventário: %w", err)
+	}
+
+	return s.toInventoryCountResponse(count), nil
+}
+
+// GetInventoryCount busca uma contagem por ID
+func (s *InventoryCountService) GetInventoryCount(id uuid.UUID) (*dtos.InventoryCountResponse, error) {
+	count, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar contagem de inventário: %w", err)
+	}
+
+	return s.toInventoryCountResponse(count), nil
+}
+
+// ListInventoryCounts retorna as contagens filtradas
+func (s *InventoryCountService) ListInventoryCounts(filter database.InventoryCountFilter) (*dtos.InventoryCountListResponse, error) {
+	counts, err := s.repo.List(filter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar contagens de inventário: %w", err)
+	}
+
+	responses := make([]dtos.InventoryCountResponse, len(counts))
+	for i, count := range counts {
+		responses[i] = *s.toInventoryCountResponse(count)
+	}
+
+	return &dtos.InventoryCountListResponse{
+		Contagens: responses,
+		Total:     len(responses),
+	}, nil
+}
+
+// RecordCounts registra quantidades contadas em uma contagem aberta
+func (s *InventoryCountService) RecordCounts(id uuid.UUID, req *dtos.RecordCountsRequest) (*dtos.InventoryCountResponse, error) {
+	contadoPor := strings.TrimSpace(req.ContadoPor)
+	entries := make([]database.CountEntry, len(req.Itens))
+	for i, item := range req.Itens {
+		entries[i] = database.CountEntry{
+			ProdutoID:  item.ProdutoID,
+			Quantidade: *item.Quantidade,
+			ContadoPor: contadoPor,
+		}
+	}
+
+	count, err := s.repo.RecordCounts(id, entries)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao registrar contagem: %w", err)
+	}
+
+	return s.toInventoryCountResponse(count), nil
+}
+
+// CloseInventoryCount encerra a contagem para revisão das divergências
+func (s *InventoryCountService) CloseInventoryCount(id uuid.UUID) (*dtos.InventoryCountResponse, error) {
+	count, err := s.repo.Close(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao encerrar contagem de inventário: %w", err)
+	}
+
+	return s.toInventoryCountResponse(count), nil
+}
+
+// ReopenInventoryCount reabre uma contagem encerrada para recontagens
+func (s *InventoryCountService) ReopenInventoryCount(id uuid.UUID) (*dtos.InventoryCountResponse, error) {
+	count, err := s.repo.Reopen(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao reabrir contagem de inventário: %w", err)
+	}
+
+	return s.toInventoryCountResponse(count), nil
+}
+
+// PostInventoryCount aprova a contagem e lança todos os ajustes de estoque
+func (s *InventoryCountService) PostInventoryCount(id uuid.UUID, req *dtos.PostInventoryCountRequest) (*dtos.InventoryCountResponse, error) {
+	count, err := s.repo.Post(id, strings.TrimSpace(req.AprovadoPor))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao postar contagem de inventário: %w", err)
+	}
+
+	return s.toInventoryCountResponse(count), nil
+}
+
+// CancelInventoryCount cancela uma contagem em andamento
+func (s *InventoryCountService) CancelInventoryCount(id uuid.UUID) (*dtos.InventoryCountResponse, error) {
+	count, err := s.repo.Cancel(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao cancelar contagem de inventário: %w", err)
+	}
+
+	return s.toInventoryCountResponse(count), nil
+}
+
+// GetVarianceReport retorna as divergências dos itens contados com o impacto a
+// custo, da maior para a menor em valor absoluto. Em contagens cegas o
+// relatório só é liberado após o encerramento.
+func (s *InventoryCountService) GetVarianceReport(id uuid.UUID) (*dtos.CountVarianceReportResponse, error) {
+	count, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar contagem de inventário: %w", err)
+	}
+
+	if count.HidesExpected() {
+		return nil, fmt.Errorf("%w: divergências de contagem cega só ficam disponíveis após o encerramento",
+			database.ErrCountStatus)
+	}
+
+	report := &dtos.CountVarianceReportResponse{
+		ContagemID: count.ID,
+		Numero:     count.Numero,
+		Status:     count.Status,
+		TotalItens: len(count.Itens),
+		Itens:      make([]dtos.CountVarianceItem, 0),
+	}
+
+	var sobras, faltas float64
+	for _, item := range count.Itens {
+		if !item.IsCounted() {
+			continue
+		}
+		report.Contados++
+
+		divergencia := item.Variance()
+		if divergencia == 0 {
+			continue
+		}
+
+		valor := item.VarianceValue()
+		report.ItensDivergentes++
+		if divergencia > 0 {
+			report.UnidadesSobra += divergencia
+			sobras += valor
+		} else {
+			report.UnidadesFalta -= divergencia
+			faltas -= valor
+		}
+
+		custo := item.CustoUnitario
+		report.Itens = append(report.Itens, dtos.CountVarianceItem{
+			ProdutoID:          item.ProdutoID,
+			Nome:               item.Nome,
+			Categoria:          item.Categoria,
+			Localizacao:        item.Localizacao,
+			QuantidadeEsperada: item.QuantidadeEsperada,
+			QuantidadeContada:  *item.QuantidadeContada,
+			Divergencia:        divergencia,
+			CustoUnitario:      &custo,
+			ValorDivergencia:   &valor,
+			Contagens:          item.Contagens,
+		})
+	}
+
+	if report.Contados > 0 {
+		acertos := report.Contados - report.ItensDivergentes
+		report.AcuracidadePercentual = math.Round(float64(acertos)/float64(report.Contados)*10000) / 100
+	}
+	sobras = math.Round(sobras*100) / 100
+	faltas = math.Round(faltas*100) / 100
+	liquido := math.Round((sobras-faltas)*100) / 100
+	report.ValorSobras = &sobras
+	report.ValorFaltas = &faltas
+	report.ValorLiquido = &liquido
+
+	sort.SliceStable(report.Itens, func(i, j int) bool {
+		return math.Abs(*report.Itens[i].ValorDivergencia) > math.Abs(*report.Itens[j].ValorDivergencia)
+	})
+
+	return report, nil
+}
+
+// toInventoryCountResponse converte o modelo para a resposta, ocultando as
+// quantidades esperadas de contagens cegas abertas
+func (s *InventoryCountService) toInventoryCountResponse(count *models.InventoryCount) *dtos.InventoryCountResponse {
+	ocultar := count.HidesExpected()
+
+	itens := make([]dtos.InventoryCountItemResponse, len(count.Itens))
+	contados := 0
+	for i, item := range count.Itens {
+		itens[i] = dtos.InventoryCountItemResponse{
+			ProdutoID:         item.ProdutoID,
+			Nome:              item.Nome,
+			Categoria:         item.Categoria,
+			Localizacao:       item.Localizacao,
+			QuantidadeContada: item.QuantidadeContada,
+			Contagens:         item.Contagens,
+			ContadoPor:        item.ContadoPor,
+			DataContagem:      item.DataContagem,
+		}
+		if item.IsCounted() {
+			contados++
+		}
+		if ocultar {
+			continue
+		}
+
+		esperada := item.QuantidadeEsperada
+		itens[i].QuantidadeEsperada = &esperada
+		if item.IsCounted() {
+			divergencia := item.Variance()
+			valor := item.VarianceValue()
+			itens[i].Divergencia = &divergencia
+			itens[i].ValorDivergencia = &valor
+		}
+	}
+
+	return &dtos.InventoryCountResponse{
+		ID:               count.ID,
+		Numero:           count.Numero,
+		Descricao:        count.Descricao,
+		Categoria:        count.Categoria,
+		Localizacao:      count.Localizacao,
+		Cega:             count.Cega,
+		Status:           count.Status,
+		Itens:            itens,
+		TotalItens:       len(itens),
+		Contados:         contados,
+		Pendentes:        len(itens) - contados,
+		Ajustes:          count.Ajustes,
+		AprovadoPor:      count.AprovadoPor,
+		DataAbertura:     count.DataAbertura,
+		DataEncerramento: count.DataEncerramento,
+		DataPostagem:     count.DataPostagem,
+		DataCancelamento: count.DataCancelamento,
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+)
+
+// MaxInvoiceSize é o tamanho máximo aceito para o XML de uma NF-e
+const MaxInvoiceSize = 2 << 20
+
+// InvoiceImportService implementa a entrada de mercadorias a partir do XML da NF-e
+type InvoiceImportService struct {
+	repo repository.InvoiceImportRepository
+}
+
+// NewInvoiceImportService cria uma nova instância do service
+func NewInvoiceImportService(repo repository.InvoiceImportRepository) *InvoiceImportService {
+	return &InvoiceImportService{
+		repo: repo,
+	}
+}
+
+// ImportNFe interpreta o XML da nota e registra a importação pendente com os
+// itens vinculados por código de barras ou código do fornecedor
+func (s *InvoiceImportService) ImportNFe(data []byte) (*dtos.InvoiceImportResponse, error) {
+	if len(data) > MaxInvoiceSize {
+		return nil, fmt.Errorf("%w: o XML excede %d MB", database.ErrInvoiceInvalid, MaxInvoiceSize>>20)
+	}
+
+	invoice, err := models.ParseNFe(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvoiceInvalid, err)
+	}
+
+	if err := s.repo.Create(invoice); err != nil {
+		return nil, fmt.Errorf("erro ao importar NF-e: %w", err)
+	}
+
+	return toInvoiceImportResponse(invoice), nil
+}
+
+// GetInvoiceImport busca uma importação por ID
+func (s *InvoiceImportService) GetInvoiceImport(id uuid.UUID) (*dtos.InvoiceImportResponse, error) {
+	invoice, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar importação de NF-e: %w", err)
+	}
+
+	return toInvoiceImportResponse(invoice), nil
+}
+
+// ListInvoiceImports retorna as importações filtradas
+func (s *InvoiceImportService) ListInvoiceImports(filter database.InvoiceImportFilter) (*dtos.InvoiceImportListResponse, error) {
+	invoices, err := s.repo.List(filter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar importações de NF-e: %w", err)
+	}
+
+	responses := make([]dtos.InvoiceImportResponse, len(invoices))
+	for i, invoice := range invoices {
+		responses[i] = *toInvoiceImportResponse(invoice)
+	}
+
+	return &dtos.InvoiceImportListResponse{
+		Importacoes: responses,
+		Total:       len(responses),
+	}, nil
+}
+
+// MapInvoiceLine vincula manualmente um item a um produto ou o ignora
+func (s *InvoiceImportService) MapInvoiceLine(id uuid.UUID, item int, req *dtos.MapInvoiceLineRequest) (*dtos.InvoiceImportResponse, error) {
+	if req.ProdutoID == nil && !req.Ignorar {
+		return nil, fmt.Errorf("%w: informe produto_id ou ignorar", database.ErrInvoiceInvalid)
+	}
+	if req.ProdutoID != nil && req.Ignorar {
+		return nil, fmt.Errorf("%w: informe produto_id ou ignorar, não ambos", database.ErrInvoiceInvalid)
+	}
+
+	invoice, err := s.repo.MapLine(id, item, req.ProdutoID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao vincular item da NF-e: %w", err)
+	}
+
+	return toInvoiceImportResponse(invoice), nil
+}
+
+// ConfirmInvoiceImport lança a entrada de estoque e custos da nota
+func (s *InvoiceImportService) ConfirmInvoiceImport(id uuid.UUID, req *dtos.InvoiceDecisionRequest) (*dtos.InvoiceImportResponse, error) {
+	invoice, err := s.repo.Confirm(id, strings.TrimSpace(req.Observacao))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao confirmar NF-e: %w", err)
+	}
+
+	return toInvoiceImportResponse(invoice), nil
+}
+
+// DiscardInvoiceImport descarta a importação sem alterar o estoque
+func (s *InvoiceImportService) DiscardInvoiceImport(id uuid.UUID, req *dtos.InvoiceDecisionRequest) (*dtos.InvoiceImportResponse, error) {
+	invoice, err := s.repo.Discard(id, strings.TrimSpace(req.Observacao))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao descartar NF-e: %w", err)
+	}
+
+	return toInvoiceImportResponse(invoice), nil
+}
+
+// toInvoiceImportResponse converte a importação para DTO, com os totais de
+// itens vinculados, ignorados e pendentes
+func toInvoiceImportResponse(invoice *models.InvoiceImport) *dtos.InvoiceImportResponse {
+	response := &dtos.InvoiceImportResponse{
+		ID:             invoice.ID,
+		ChaveAcesso:    invoice.ChaveAcesso,
+		Numero:         invoice.Numero,
+		Serie:          invoice.Serie,
+		DataEmissao:    invoice.DataEmissao,
+		EmitenteCNPJ:   invoice.EmitenteCNPJ,
+		EmitenteNome:   invoice.EmitenteNome,
+		FornecedorID:   invoice.FornecedorID,
+		Status:         invoice.Status,
+		Itens:          make([]dtos.InvoiceLineResponse, len(invoice.Itens)),
+		ValorProdutos:  invoice.ValorProdutos,
+		ValorTotal:     invoice.ValorTotal,
+		Observacao:     invoice.Observacao,
+		DataImportacao: invoice.DataImportacao,
+		DataConclusao:  invoice.DataConclusao,
+	}
+
+	for i, line := range invoice.Itens {
+		switch {
+		case line.Ignorado:
+			response.ItensIgnorados++
+		case line.ProdutoID != nil:
+			response.ItensVinculados++
+		default:
+			response.ItensPendentes++
+		}
+		response.Itens[i] = dtos.InvoiceLineResponse{
+			Item:          line.Item,
+			CodigoProduto: line.CodigoProduto,
+			CodigoBarras:  line.CodigoBarras,
+			Descricao:     line.Descricao,
+			NCM:           line.NCM,
+			CFOP:          line.CFOP,
+			Unidade:       line.Unidade,
+			Quantidade:    line.Quantidade,
+			ValorUnitario: line.ValorUnitario,
+			ValorTotal:    line.ValorTotal,
+			Lotes:         line.Lotes,
+			ProdutoID:     line.ProdutoID,
+			NomeProduto:   line.NomeProduto,
+			Vinculo:       line.Vinculo,
+			Ignorado:      line.Ignorado,
+			Pendente:      !line.IsResolved(),
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+)
+
+// SetKitComponents define a composição (lista de materiais) de um kit e a
+// política de preço. Uma lista vazia transforma o kit em produto simples.
+func (s *ProductService) SetKitComponents(kitID uuid.UUID, req *dtos.KitDefinitionRequest) (*dtos.KitCompositionResponse, error) {
+	componentes := make([]models.KitComponent, len(req.Componentes))
+	for i, component := range req.Componentes {
+		componentes[i] = models.KitComponent{
+			ProdutoID:  component.ProdutoID,
+			Quantidade: component.Quantidade,
+		}
+	}
+
+	if len(componentes) == 0 && req.PrecoDerivado {
+		return nil, fmt.Errorf("%w: preço derivado exige ao menos um componente", database.ErrKitInvalid)
+	}
+
+	_, err := s.repo.SetKitComponents(database.KitDefinition{
+		KitID:         kitID,
+		Componentes:   componentes,
+		PrecoDerivado: req.PrecoDerivado,
+		DescontoKit:   req.DescontoPercentual,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao definir composição do kit: %w", err)
+	}
+
+	if len(componentes) == 0 {
+		product, err := s.repo.GetByID(kitID)
+		if err != nil {
+			return nil, fmt.Errorf("produto não encontrado: %w", err)
+		}
+		return &dtos.KitCompositionResponse{
+			Kit:         *s.toProductResponse(product),
+			Componentes: []dtos.KitComponentDetail{},
+			PrecoKit:    product.Preco,
+		}, nil
+	}
+
+	return s.GetKitComposition(kitID)
+}
+
+// GetKitComposition retorna a composição do kit com a disponibilidade calculada
+// a partir do estoque de cada componente e o preço dos componentes
+func (s *ProductService) GetKitComposition(kitID uuid.UUID) (*dtos.KitCompositionResponse, error) {
+	kit, components, err := s.repo.GetKitComponents(kitID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar composição do kit: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*models.Product, len(components))
+	for _, component := range components {
+		byID[component.ID] = component
+	}
+
+	details := make([]dtos.KitComponentDetail, 0, len(kit.Componentes))
+	precoComponentes := 0.0
+	for _, item := range kit.Componentes {
+		product, exists := byID[item.ProdutoID]
+		if !exists {
+			continue
+		}
+
+		kitsPossiveis := 0
+		if product.Ativo {
+			kitsPossiveis = product.AvailableQuantity() / item.Quantidade
+		}
+
+		subtotal := product.Preco * float64(item.Quantidade)
+		precoComponentes += subtotal
+
+		details = append(details, dtos.KitComponentDetail{
+			ProdutoID:            product.ID,
+			Nome:                 product.Nome,
+			QuantidadePorKit:     item.Quantidade,
+			QuantidadeDisponivel: product.AvailableQuantity(),
+			KitsPossiveis:        kitsPossiveis,
+			PrecoUnitario:        product.Preco,
+			Subtotal:             subtotal,
+		})
+	}
+
+	return &dtos.KitCompositionResponse{
+		Kit:              *s.toProductResponse(kit),
+		Componentes:      details,
+		Disponivel:       kit.Quantidade,
+		PrecoComponentes: precoComponentes,
+		PrecoKit:         kit.Preco,
+		Economia:         precoComponentes - kit.Preco,
+	}, nil
+}
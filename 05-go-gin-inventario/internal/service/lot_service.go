@@ -0,0 +1,146 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+)
+
+// LotService implementa a lógica de negócio para lotes e validade
+type LotService struct {
+	repo        repository.LotRepository
+	productRepo repository.ProductRepository
+}
+
+// NewLotService cria uma nova instância do service
+func NewLotService(repo repository.LotRepository, productRepo repository.ProductRepository) *LotService {
+	return &LotService{
+		repo:        repo,
+		productRepo: productRepo,
+	}
+}
+
+// ReceiveLot registra a entrada de um lote no estoque do produto
+func (s *LotService) ReceiveLot(productID uuid.UUID, req *dtos.CreateLotRequest) (*dtos.LotResponse, error) {
+	codigo := strings.TrimSpace(req.Codigo)
+	if codigo == "" {
+		return nil, fmt.Errorf("código do lote é obrigatório")
+	}
+	if req.Quantidade <= 0 {
+		return nil, fmt.Errorf("quantidade do lote deve ser maior que zero")
+	}
+	if !req.DataValidade.After(req.DataFabricacao) {
+		return nil, fmt.Errorf("data de validade deve ser posterior à data de fabricação")
+	}
+	if req.DataFabricacao.After(time.Now()) {
+		return nil, fmt.Errorf("data de fabricação não pode estar no futuro")
+	}
+
+	lot := &models.Lot{
+		ProdutoID:      productID,
+		Codigo:         codigo,
+		DataFabricacao: req.DataFabricacao,
+		DataValidade:   req.DataValidade,
+		Quantidade:     req.Quantidade,
+		CustoUnitario:  req.CustoUnitario,
+	}
+
+	if err := s.repo.Create(lot); err != nil {
+		return nil, fmt.Errorf("erro ao registrar lote: %w", err)
+	}
+
+	return s.toLotResponse(lot, time.Now()), nil
+}
+
+// GetProductLots retorna os lotes de um produto na ordem de consumo (FEFO)
+func (s *LotService) GetProductLots(productID uuid.UUID, apenasComSaldo bool) (*dtos.LotListResponse, error) {
+	lots, err := s.repo.GetByProduct(productID, apenasComSaldo)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar lotes: %w", err)
+	}
+
+	now := time.Now()
+	responses := make([]dtos.LotResponse, len(lots))
+	quantidadeTotal := 0
+	for i, lot := range lots {
+		responses[i] = *s.toLotResponse(lot, now)
+		quantidadeTotal += lot.Quantidade
+	}
+
+	return &dtos.LotListResponse{
+		ProdutoID:       productID,
+		Lotes:           responses,
+		Total:           len(responses),
+		QuantidadeTotal: quantidadeTotal,
+	}, nil
+}
+
+// GetExpiringLots retorna os lotes com saldo que vencem nos próximos N dias
+// (incluindo os já vencidos) com o valor do estoque comprometido
+func (s *LotService) GetExpiringLots(dias int) (*dtos.ExpiringLotsResponse, error) {
+	if dias < 0 {
+		return nil, fmt.Errorf("dias deve ser maior ou igual a zero")
+	}
+
+	now := time.Now()
+	lots, err := s.repo.GetExpiring(now.AddDate(0, 0, dias))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar lotes a vencer: %w", err)
+	}
+
+	products := make(map[uuid.UUID]*models.Product)
+	items := make([]dtos.ExpiringLotItem, 0, len(lots))
+	quantidadeTotal := 0
+	valorTotal := 0.0
+
+	for _, lot := range lots {
+		product, cached := products[lot.ProdutoID]
+		if !cached {
+			product, err = s.productRepo.GetByID(lot.ProdutoID)
+			if err != nil {
+				continue
+			}
+			products[lot.ProdutoID] = product
+		}
+
+		valor := float64(lot.Quantidade) * product.Preco
+		items = append(items, dtos.ExpiringLotItem{
+			LotResponse: *s.toLotResponse(lot, now),
+			NomeProduto: product.Nome,
+			Categoria:   product.Categoria,
+			Valor:       valor,
+		})
+		quantidadeTotal += lot.Quantidade
+		valorTotal += valor
+	}
+
+	return &dtos.ExpiringLotsResponse{
+		Dias:            dias,
+		Lotes:           items,
+		TotalLotes:      len(items),
+		QuantidadeTotal: quantidadeTotal,
+		ValorTotal:      valorTotal,
+	}, nil
+}
+
+// Métodos auxiliares privados
+
+func (s *LotService) toLotResponse(lot *models.Lot, now time.Time) *dtos.LotResponse {
+	return &dtos.LotResponse{
+		ID:                lot.ID,
+		ProdutoID:         lot.ProdutoID,
+		Codigo:            lot.Codigo,
+		DataFabricacao:    lot.DataFabricacao,
+		DataValidade:      lot.DataValidade,
+		QuantidadeInicial: lot.QuantidadeInicial,
+		Quantidade:        lot.Quantidade,
+		Vencido:           lot.IsExpired(now),
+		DiasParaVencer:    lot.DaysToExpire(now),
+		DataCriacao:       lot.DataCriacao,
+	}
+}
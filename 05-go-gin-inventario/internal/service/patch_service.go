@@ -0,0 +1,181 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/jsonpatch"
+	"inventario-api/internal/models"
+)
+
+// ReplaceProduct substitui a representação editável do produto. O estoque
+// físico é mantido e campos derivados de kits (custo e preço derivado) são
+// ignorados.
+func (s *ProductService) ReplaceProduct(id uuid.UUID, req *dtos.ReplaceProductRequest) (*dtos.ProductResponse, error) {
+	product, err := s.repo.ModifyProduct(id, func(current *models.Product) (*models.Product, *database.StatusTransition, error) {
+		return s.replaceUpdate(id, current, req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar produto: %w", err)
+	}
+
+	return s.toProductResponse(product), nil
+}
+
+// PatchProduct aplica um patch à representação editável do produto, valida o
+// resultado com as regras do PUT e o grava. Leitura, patch e gravação ocorrem
+// sob o mesmo lock, então operações test valem para a versão gravada.
+// Aceita JSON Merge Patch (RFC 7396), em que null limpa textos e tags e remove
+// dimensões e dados fiscais, e JSON Patch (RFC 6902), em que uma operação test
+// que não confere impede todo o patch. Membros obrigatórios removidos
+// tornam o resultado inválido.
+func (s *ProductService) PatchProduct(id uuid.UUID, mediaType string, patch []byte, validator StructValidator) (*dtos.ProductResponse, error) {
+	var apply func(document, patch []byte) ([]byte, error)
+	switch mediaType {
+	case jsonpatch.MergePatchMediaType:
+		apply = jsonpatch.MergePatch
+	case jsonpatch.JSONPatchMediaType:
+		apply = jsonpatch.Apply
+	default:
+		return nil, fmt.Errorf("%w: %q", database.ErrPatchUnsupported, mediaType)
+	}
+
+	product, err := s.repo.ModifyProduct(id, func(current *models.Product) (*models.Product, *database.StatusTransition, error) {
+		req, err := patchDocument(current, patch, apply)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := validator.ValidateStruct(req); err != nil {
+			return nil, nil, err
+		}
+		return s.replaceUpdate(id, current, req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar produto: %w", err)
+	}
+
+	return s.toProductResponse(product), nil
+}
+
+// replaceUpdate monta a nova versão do produto a partir da representação
+// completa
+func (s *ProductService) replaceUpdate(id uuid.UUID, current *models.Product, req *dtos.ReplaceProductRequest) (*models.Product, *database.StatusTransition, error) {
+	// Dimensões e dados fiscais ausentes na representação são removidos
+	base := current.Clone()
+	if req.Dimensoes == nil {
+		base.Dimensoes = nil
+	}
+	if req.Fiscal == nil {
+		base.Fiscal = nil
+	}
+
+	tags := req.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	update := &dtos.UpdateProductRequest{
+		Nome:                &req.Nome,
+		Descricao:           &req.Descricao,
+		EstoqueMinimo:       req.EstoqueMinimo,
+		QuantidadeReposicao: req.QuantidadeReposicao,
+		Categoria:           &req.Categoria,
+		Localizacao:         &req.Localizacao,
+		CodigoBarras:        &req.CodigoBarras,
+		Tags:                &tags,
+		Dimensoes:           req.Dimensoes,
+		Fiscal:              req.Fiscal,
+		Ativo:               req.Ativo,
+		ControlaLote:        &req.ControlaLote,
+		Serializado:         &req.Serializado,
+	}
+	if !current.IsKit() {
+		update.PrecoCusto = req.PrecoCusto
+	}
+	if !current.PrecoDerivado {
+		update.Preco = &req.Preco
+	}
+
+	return s.applyUpdateRequest(id, base, update)
+}
+
+// patchDocument aplica o patch à representação editável do produto
+func patchDocument(product *models.Product, patch []byte, apply func(document, patch []byte) ([]byte, error)) (*dtos.ReplaceProductRequest, error) {
+	document, err := json.Marshal(toReplaceRequest(product))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao montar documento do produto: %w", err)
+	}
+
+	patched, err := apply(document, patch)
+	switch {
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		return nil, fmt.Errorf("%w: %v", database.ErrPatchTestFailed, err)
+	case errors.Is(err, jsonpatch.ErrPathNotFound):
+		return nil, fmt.Errorf("%w: %v", database.ErrPatchUnprocessable, err)
+	case err != nil:
+		return nil, fmt.Errorf("%w: %v", database.ErrPatchInvalid, err)
+	}
+
+	// O resultado precisa continuar sendo um produto: campos desconhecidos ou
+	// de tipo errado tornam o patch inaplicável
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	var req dtos.ReplaceProductRequest
+	if err := decoder.Decode(&req); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrPatchUnprocessable, err)
+	}
+	return &req, nil
+}
+
+// toReplaceRequest monta a representação editável do produto
+func toReplaceRequest(product *models.Product) *dtos.ReplaceProductRequest {
+	ativo := product.Ativo
+	precoCusto := product.PrecoCusto
+	estoqueMinimo := product.EstoqueMinimo
+	quantidadeReposicao := product.QuantidadeReposicao
+	tags := product.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	req := &dtos.ReplaceProductRequest{
+		Nome:                product.Nome,
+		Descricao:           product.Descricao,
+		Preco:               product.Preco,
+		PrecoCusto:          &precoCusto,
+		EstoqueMinimo:       &estoqueMinimo,
+		QuantidadeReposicao: &quantidadeReposicao,
+		Categoria:           product.Categoria,
+		Localizacao:         product.Localizacao,
+		CodigoBarras:        product.CodigoBarras,
+		Tags:                tags,
+		Ativo:               &ativo,
+		ControlaLote:        product.ControlaLote,
+		Serializado:         product.Serializado,
+	}
+	if dimensoes := product.Dimensoes; dimensoes != nil {
+		req.Dimensoes = &dtos.DimensionsRequest{
+			PesoKg:        dimensoes.PesoKg,
+			ComprimentoCm: dimensoes.ComprimentoCm,
+			LarguraCm:     dimensoes.LarguraCm,
+			AlturaCm:      dimensoes.AlturaCm,
+			Embalagem:     dimensoes.Embalagem,
+		}
+	}
+	if fiscal := product.Fiscal; fiscal != nil {
+		req.Fiscal = &dtos.FiscalRequest{
+			NCM:               fiscal.NCM,
+			CEST:              fiscal.CEST,
+			CFOPEstadual:      fiscal.CFOPEstadual,
+			CFOPInterestadual: fiscal.CFOPInterestadual,
+			Origem:            fiscal.Origem,
+			AliquotaIPI:       fiscal.AliquotaIPI,
+			AliquotaPIS:       fiscal.AliquotaPIS,
+			AliquotaCOFINS:    fiscal.AliquotaCOFINS,
+		}
+	}
+	return req
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+)
+
+// PriceService implementa a lógica de negócio para histórico, agendamento e reajuste de preços
+type PriceService struct {
+	repo        repository.PriceRepository
+	productRepo repository.ProductRepository
+}
+
+// NewPriceService cria uma nova instância do service
+func NewPriceService(repo repository.PriceRepository, productRepo repository.ProductRepository) *PriceService {
+	return &PriceService{
+		repo:        repo,
+		productRepo: productRepo,
+	}
+}
+
+// SchedulePrice agenda um novo preço para entrar em vigor no instante informado
+func (s *PriceService) SchedulePrice(productID uuid.UUID, req *dtos.SchedulePriceRequest) (*models.ScheduledPrice, error) {
+	if req.Preco < 0 {
+		return nil, fmt.Errorf("preço deve ser maior ou igual a zero")
+	}
+	if !req.VigenteEm.After(time.Now()) {
+		return nil, fmt.Errorf("vigente_em deve estar no futuro")
+	}
+
+	scheduled := &models.ScheduledPrice{
+		ProdutoID: productID,
+		Preco:     req.Preco,
+		VigenteEm: req.VigenteEm,
+		Motivo:    strings.TrimSpace(req.Motivo),
+	}
+
+	if err := s.repo.Schedule(scheduled); err != nil {
+		return nil, fmt.Errorf("erro ao agendar preço: %w", err)
+	}
+
+	return scheduled, nil
+}
+
+// CancelScheduledPrice cancela um preço agendado que ainda não entrou em vigor
+func (s *PriceService) CancelScheduledPrice(productID, id uuid.UUID) (*models.ScheduledPrice, error) {
+	scheduled, err := s.repo.CancelScheduled(productID, id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao cancelar agendamento: %w", err)
+	}
+
+	return scheduled, nil
+}
+
+// GetPriceHistory retorna o histórico completo de preços e os agendamentos pendentes
+func (s *PriceService) GetPriceHistory(productID uuid.UUID) (*dtos.PriceHistoryResponse, error) {
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("produto não encontrado: %w", err)
+	}
+
+	history, err := s.repo.GetHistory(productID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar histórico de preços: %w", err)
+	}
+
+	pending, err := s.repo.ListScheduled(productID, true)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar preços agendados: %w", err)
+	}
+
+	agendamentos := make([]models.ScheduledPrice, len(pending))
+	for i, scheduled := range pending {
+		agendamentos[i] = *scheduled
+	}
+
+	return &dtos.PriceHistoryResponse{
+		ProdutoID:    productID,
+		PrecoAtual:   product.Preco,
+		Historico:    history,
+		Agendamentos: agendamentos,
+	}, nil
+}
+
+// AdjustPrices aplica (ou apenas simula) um reajuste percentual aos produtos
+// que atendem aos filtros informados
+func (s *PriceService) AdjustPrices(req *dtos.PriceAdjustmentRequest, simular bool) (*dtos.PriceAdjustmentResponse, error) {
+	if req.Percentual <= -100 {
+		return nil, fmt.Errorf("percentual de reajuste deve ser maior que -100")
+	}
+	if req.PrecoMinimo != nil && req.PrecoMaximo != nil && *req.PrecoMinimo > *req.PrecoMaximo {
+		return nil, fmt.Errorf("preco_minimo não pode ser maior que preco_maximo")
+	}
+
+	results, err := s.repo.Adjust(database.PriceAdjustment{
+		Filtro: database.FilterOptions{
+			Categoria:    req.Categoria,
+			PrecoMinimo:  req.PrecoMinimo,
+			PrecoMaximo:  req.PrecoMaximo,
+			ApenasAtivos: req.ApenasAtivos,
+			Nome:         req.Nome,
+		},
+		Percentual: req.Percentual,
+		Motivo:     strings.TrimSpace(req.Motivo),
+		Simular:    simular,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao reajustar preços: %w", err)
+	}
+
+	items := make([]dtos.PriceAdjustmentItem, len(results))
+	for i, result := range results {
+		items[i] = dtos.PriceAdjustmentItem{
+			ProdutoID:     result.Produto.ID,
+			Nome:          result.Produto.Nome,
+			Categoria:     result.Produto.Categoria,
+			PrecoAnterior: result.PrecoAnterior,
+			PrecoNovo:     result.PrecoNovo,
+			Diferenca:     math.Round((result.PrecoNovo-result.PrecoAnterior)*100) / 100,
+		}
+	}
+
+	return &dtos.PriceAdjustmentResponse{
+		Simulacao:  simular,
+		Percentual: req.Percentual,
+		Itens:      items,
+		Total:      len(items),
+	}, nil
+}
+
+// StartScheduler inicia uma goroutine que aplica periodicamente os preços
+// agendados que entraram em vigor até que o contexto seja cancelado
+func (s *PriceService) StartScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if applied := s.repo.ApplyDue(now); applied > 0 {
+					log.Printf("💲 %d preço(s) agendado(s) aplicado(s)", applied)
+				}
+			}
+		}
+	}()
+}
@@ -10,49 +10,79 @@ import (
 	"inventario-api/internal/dtos"
 	"inventario-api/internal/models"
 	"inventario-api/internal/repository"
+	"inventario-api/internal/storage"
 )
 
 // ProductService implementa a lógica de negócio para produtos
 type ProductService struct {
-	repo repository.ProductRepository
+	repo  repository.ProductRepository
+	files storage.FileStorage
 }
 
 // NewProductService cria uma nova instância do service
-func NewProductService(repo repository.ProductRepository) *ProductService {
+func NewProductService(repo repository.ProductRepository, files storage.FileStorage) *ProductService {
 	return &ProductService{
-		repo: repo,
+		repo:  repo,
+		files: files,
 	}
 }
 
 // CreateProduct cria um novo produto com validações de negócio
 func (s *ProductService) CreateProduct(req *dtos.CreateProductRequest) (*dtos.ProductResponse, error) {
+	product, err := s.newProduct(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Salva no repositório
+	if err := s.repo.Create(product); err != nil {
+		return nil, fmt.Errorf("erro ao criar produto: %w", err)
+	}
+
+	// Retorna o produto criado
+	return s.toProductResponse(product), nil
+}
+
+// newProduct valida a requisição de cadastro e monta o modelo do produto
+func (s *ProductService) newProduct(req *dtos.CreateProductRequest) (*models.Product, error) {
 	// Validações de negócio
 	if err := s.validateCreateRequest(req); err != nil {
 		return nil, err
 	}
 
-	// Cria o modelo
-	product := &models.Product{
-		Nome:       strings.TrimSpace(req.Nome),
-		Descricao:  strings.TrimSpace(req.Descricao),
-		Preco:      req.Preco,
-		Quantidade: req.Quantidade,
-		Categoria:  req.Categoria,
-		Ativo:      true, // Padrão é ativo
+	fiscal, err := toFiscalData(req.Fiscal)
+	if err != nil {
+		return nil, err
 	}
 
-	// Se ativo foi especificado na requisição, usa o valor
+	// Cria o modelo
+	product := &models.Product{
+		Nome:                strings.TrimSpace(req.Nome),
+		Descricao:           strings.TrimSpace(req.Descricao),
+		Preco:               req.Preco,
+		PrecoCusto:          req.PrecoCusto,
+		Quantidade:          req.Quantidade,
+		EstoqueMinimo:       req.EstoqueMinimo,
+		QuantidadeReposicao: req.QuantidadeReposicao,
+		Categoria:           req.Categoria,
+		Localizacao:         models.NormalizeLocation(req.Localizacao),
+		CodigoBarras:        models.NormalizeBarcode(req.CodigoBarras),
+		Tags:                models.NormalizeTags(req.Tags),
+		Dimensoes:           toDimensions(req.Dimensoes),
+		Fiscal:              fiscal,
+		Ativo:               true, // Padrão é ativo
+		Status:              req.Status,
+		ControlaLote:        req.ControlaLote,
+		Serializado:         req.Serializado,
+	}
+
+	// Se ativo foi especificado na requisição, usa o valor; a situação
+	// informada prevalece e ativo passa a ser derivado dela
 	if req.Ativo != nil {
 		product.Ativo = *req.Ativo
 	}
 
-	// Salva no repositório
-	if err := s.repo.Create(product); err != nil {
-		return nil, fmt.Errorf("erro ao criar produto: %w", err)
-	}
-
-	// Retorna o produto criado
-	return s.toProductResponse(product), nil
+	return product, nil
 }
 
 // GetProductByID busca um produto por ID
@@ -95,7 +125,11 @@ func (s *ProductService) GetProductsFiltered(
 	categoria *models.ProductCategory,
 	precoMin, precoMax *float64,
 	apenasAtivos, apenasEstoque *bool,
+	status *models.ProductStatus,
+	tags []string,
+	tagsModo models.TagMatch,
 	nome *string,
+	idioma models.Locale,
 	page, size int,
 ) (*dtos.ProductListResponse, error) {
 
@@ -106,6 +140,12 @@ func (s *ProductService) GetProductsFiltered(
 	if size <= 0 || size > 100 {
 		size = 10
 	}
+	tags = models.NormalizeTags(tags)
+	if len(tags) == 0 {
+		tagsModo = ""
+	} else if tagsModo == "" {
+		tagsModo = models.TagMatchAny
+	}
 
 	options := database.FilterOptions{
 		Categoria:     categoria,
@@ -113,7 +153,11 @@ func (s *ProductService) GetProductsFiltered(
 		PrecoMaximo:   precoMax,
 		ApenasAtivos:  apenasAtivos,
 		ApenasEstoque: apenasEstoque,
+		Status:        status,
+		Tags:          tags,
+		TagsModo:      tagsModo,
 		Nome:          nome,
+		Idioma:        idioma,
 		Page:          page,
 		Size:          size,
 	}
@@ -148,64 +192,134 @@ func (s *ProductService) GetProductsFiltered(
 			PrecoMaximo:   precoMax,
 			ApenasAtivos:  apenasAtivos,
 			ApenasEstoque: apenasEstoque,
+			Status:        status,
+			Tags:          tags,
+			TagsModo:      tagsModo,
 			Nome:          nome,
+			Idioma:        idioma,
 		},
 	}, nil
 }
 
-// UpdateProduct atualiza um produto existente
-func (s *ProductService) UpdateProduct(id uuid.UUID, req *dtos.UpdateProductRequest) (*dtos.ProductResponse, error) {
-	// Busca o produto existente
-	existing, err := s.repo.GetByID(id)
-	if err != nil {
-		return nil, fmt.Errorf("produto não encontrado: %w", err)
+// applyUpdateRequest valida os campos informados e monta a nova versão do
+// produto e, se ativo mudou, a transição de situação correspondente
+func (s *ProductService) applyUpdateRequest(id uuid.UUID, existing *models.Product, req *dtos.UpdateProductRequest) (*models.Product, *database.StatusTransition, error) {
+	// Kits não têm estoque próprio e podem ter o preço derivado dos componentes
+	if existing.IsKit() && req.Quantidade != nil {
+		return nil, nil, fmt.Errorf("%w: kits não possuem estoque próprio", database.ErrKitOperation)
+	}
+	if existing.PrecoDerivado && req.Preco != nil {
+		return nil, nil, fmt.Errorf("%w: o preço deste kit é derivado dos componentes", database.ErrKitOperation)
+	}
+	if existing.IsKit() && req.PrecoCusto != nil {
+		return nil, nil, fmt.Errorf("%w: o custo de kits é derivado dos componentes", database.ErrKitOperation)
 	}
 
 	// Aplica as atualizações
-	updated := *existing
-	
+	updated := *existing.Clone()
+
 	if req.Nome != nil {
 		if err := s.validateNome(*req.Nome); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		updated.Nome = strings.TrimSpace(*req.Nome)
 	}
-	
+
 	if req.Descricao != nil {
 		if err := s.validateDescricao(*req.Descricao); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		updated.Descricao = strings.TrimSpace(*req.Descricao)
 	}
-	
+
 	if req.Preco != nil {
 		if err := s.validatePreco(*req.Preco); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		updated.Preco = *req.Preco
 	}
-	
+
+	if req.PrecoCusto != nil {
+		if err := s.validatePreco(*req.PrecoCusto); err != nil {
+			return nil, nil, err
+		}
+		updated.PrecoCusto = *req.PrecoCusto
+	}
+
 	if req.Quantidade != nil {
 		if err := s.validateQuantidade(*req.Quantidade); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		updated.Quantidade = *req.Quantidade
 	}
-	
+
+	if req.EstoqueMinimo != nil {
+		if err := s.validateQuantidade(*req.EstoqueMinimo); err != nil {
+			return nil, nil, err
+		}
+		updated.EstoqueMinimo = *req.EstoqueMinimo
+	}
+
+	if req.QuantidadeReposicao != nil {
+		if err := s.validateQuantidade(*req.QuantidadeReposicao); err != nil {
+			return nil, nil, err
+		}
+		updated.QuantidadeReposicao = *req.QuantidadeReposicao
+	}
+
 	if req.Categoria != nil {
 		updated.Categoria = *req.Categoria
 	}
-	
-	if req.Ativo != nil {
-		updated.Ativo = *req.Ativo
+
+	// O antigo campo ativo vira uma transição entre ativo e inativo
+	var transition *database.StatusTransition
+	if req.Ativo != nil && *req.Ativo != existing.Ativo {
+		target := models.StatusFromActive(*req.Ativo)
+		if existing.Status == models.StatusBloqueado || !existing.Status.CanTransitionTo(target) {
+			return nil, nil, fmt.Errorf("%w: %s está %s; use POST /api/produtos/%s/status",
+				database.ErrProductStatus, existing.Nome, existing.Status, id)
+		}
+		transition = &database.StatusTransition{
+			ProdutoID: id,
+			Para:      target,
+			Motivo:    "alteração do campo ativo",
+		}
 	}
 
-	// Salva as alterações
-	if err := s.repo.Update(id, &updated); err != nil {
-		return nil, fmt.Errorf("erro ao atualizar produto: %w", err)
+	if req.ControlaLote != nil {
+		updated.ControlaLote = *req.ControlaLote
+	}
+
+	if req.Serializado != nil {
+		updated.Serializado = *req.Serializado
+	}
+
+	if req.Localizacao != nil {
+		updated.Localizacao = models.NormalizeLocation(*req.Localizacao)
+	}
+
+	if req.CodigoBarras != nil {
+		updated.CodigoBarras = models.NormalizeBarcode(*req.CodigoBarras)
+	}
+
+	if req.Dimensoes != nil {
+		updated.Dimensoes = toDimensions(req.Dimensoes)
+	}
+
+	if req.Fiscal != nil {
+		fiscal, err := toFiscalData(req.Fiscal)
+		if err != nil {
+			return nil, nil, err
+		}
+		updated.Fiscal = fiscal
+	}
+
+	// Tags informadas na atualização substituem as atuais
+	if req.Tags != nil {
+		updated.Tags = models.NormalizeTags(*req.Tags)
 	}
 
-	return s.toProductResponse(&updated), nil
+	return &updated, transition, nil
 }
 
 // DeleteProduct remove um produto
@@ -221,6 +335,9 @@ func (s *ProductService) DeleteProduct(id uuid.UUID) error {
 		return fmt.Errorf("erro ao deletar produto: %w", err)
 	}
 
+	// Remove os anexos gravados; o produto já foi excluído mesmo que falhe
+	_ = s.files.DeleteDir(id.String())
+
 	return nil
 }
 
@@ -322,6 +439,10 @@ func (s *ProductService) UpdateStock(id uuid.UUID, novaQuantidade int) (*dtos.Pr
 		return nil, fmt.Errorf("produto não encontrado: %w", err)
 	}
 
+	if existing.IsKit() {
+		return nil, fmt.Errorf("%w: kits não possuem estoque próprio", database.ErrKitOperation)
+	}
+
 	// Atualiza apenas a quantidade
 	updated := *existing
 	updated.Quantidade = novaQuantidade
@@ -331,7 +452,106 @@ func (s *ProductService) UpdateStock(id uuid.UUID, novaQuantidade int) (*dtos.Pr
 		return nil, fmt.Errorf("erro ao atualizar estoque: %w", err)
 	}
 
-	return s.toProductResponse(&updated), nil
+	return s.GetProductByID(id)
+}
+
+// AdjustStock aplica uma variação relativa ao estoque de um produto
+func (s *ProductService) AdjustStock(id uuid.UUID, req *dtos.StockAdjustmentRequest) (*dtos.ProductResponse, error) {
+	if err := s.validateDelta(req.Delta); err != nil {
+		return nil, err
+	}
+
+	product, err := s.repo.AdjustStock(database.StockAdjustment{
+		ProductID:     id,
+		Delta:         req.Delta,
+		CustoUnitario: req.CustoUnitario,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ajustar estoque: %w", err)
+	}
+
+	return s.toProductResponse(product), nil
+}
+
+// AdjustStockBatch aplica vários ajustes de estoque em uma única operação atômica
+func (s *ProductService) AdjustStockBatch(req *dtos.StockAdjustmentBatchRequest) (*dtos.StockAdjustmentBatchResponse, error) {
+	adjustments := make([]database.StockAdjustment, len(req.Ajustes))
+	for i, item := range req.Ajustes {
+		if err := s.validateDelta(item.Delta); err != nil {
+			return nil, fmt.Errorf("ajuste %d: %w", i, err)
+		}
+		adjustments[i] = database.StockAdjustment{
+			ProductID:     item.ProdutoID,
+			Delta:         item.Delta,
+			CustoUnitario: item.CustoUnitario,
+		}
+	}
+
+	products, err := s.repo.AdjustStockBatch(adjustments)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ajustar estoque em lote: %w", err)
+	}
+
+	responses := make([]dtos.ProductResponse, len(products))
+	for i, product := range products {
+		responses[i] = *s.toProductResponse(product)
+	}
+
+	return &dtos.StockAdjustmentBatchResponse{
+		TotalAjustes: len(responses),
+		Produtos:     responses,
+	}, nil
+}
+
+// GetReorderList retorna os produtos ativos no ponto de reposição, do mais
+// crítico (menor disponível em relação ao mínimo) para o menos crítico
+func (s *ProductService) GetReorderList() (*dtos.ReorderListResponse, error) {
+	// GetActiveProducts é paginado; a lista de reposição precisa de todos
+	products, err := s.repo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar produtos para reposição: %w", err)
+	}
+
+	items := make([]dtos.ReorderItem, 0)
+	for _, product := range products {
+		if !product.Ativo || product.IsKit() || !product.NeedsReorder() {
+			continue
+		}
+		items = append(items, dtos.ReorderItem{
+			ProdutoID:            product.ID,
+			Nome:                 product.Nome,
+			Categoria:            product.Categoria,
+			Quantidade:           product.Quantidade,
+			QuantidadeDisponivel: product.AvailableQuantity(),
+			EstoqueMinimo:        product.EstoqueMinimo,
+			QuantidadeReposicao:  product.QuantidadeReposicao,
+			QuantidadeSugerida:   product.SuggestedReorderQuantity(),
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		ratioI := float64(items[i].QuantidadeDisponivel) / float64(items[i].EstoqueMinimo)
+		ratioJ := float64(items[j].QuantidadeDisponivel) / float64(items[j].EstoqueMinimo)
+		return ratioI < ratioJ
+	})
+
+	return &dtos.ReorderListResponse{
+		Produtos: items,
+		Total:    len(items),
+	}, nil
+}
+
+// GetStockAlerts retorna os alertas de cruzamento do ponto de reposição
+func (s *ProductService) GetStockAlerts(limit int) (*dtos.StockAlertListResponse, error) {
+	alerts, err := s.repo.GetStockAlerts(limit)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar alertas de estoque: %w", err)
+	}
+
+	return &dtos.StockAlertListResponse{
+		Alertas: alerts,
+		Total:   len(alerts),
+	}, nil
 }
 
 // GetStatistics retorna estatísticas dos produtos
@@ -368,27 +588,78 @@ func (s *ProductService) GetStatistics() (*dtos.ProductStatistics, error) {
 	categoryStats := make([]dtos.CategoryStatistics, 0, len(categoryStatsRaw))
 	for _, catStat := range categoryStatsRaw {
 		categoryStats = append(categoryStats, dtos.CategoryStatistics{
-			Categoria:       catStat.Categoria,
-			TotalProdutos:   catStat.TotalProdutos,
-			ProdutosAtivos:  catStat.ProdutosAtivos,
-			ValorTotal:      catStat.ValorTotal,
+			Categoria:      catStat.Categoria,
+			TotalProdutos:  catStat.TotalProdutos,
+			ProdutosAtivos: catStat.ProdutosAtivos,
+			ValorTotal:     catStat.ValorTotal,
+			Custos: &dtos.CategoryCostStatistics{
+				ValorCustoFIFO:  catStat.ValorCustoFIFO,
+				ValorCustoMedio: catStat.ValorCustoMedio,
+			},
 			PrecoMedio:      catStat.PrecoMedio,
 			QuantidadeTotal: catStat.QuantidadeTotal,
 		})
 	}
 
+	// Converte estatísticas por situação na ordem do ciclo de vida
+	statusStatsRaw := stats["por_status"].(map[models.ProductStatus]*database.StatusStats)
+	statusStats := make([]dtos.StatusStatistics, 0, len(statusStatsRaw))
+	for _, status := range models.ProductStatuses {
+		if st, exists := statusStatsRaw[status]; exists {
+			statusStats = append(statusStats, dtos.StatusStatistics{
+				Status:          st.Status,
+				TotalProdutos:   st.TotalProdutos,
+				QuantidadeTotal: st.QuantidadeTotal,
+				ValorTotal:      st.ValorTotal,
+			})
+		}
+	}
+
+	// Converte estatísticas por tag, das mais usadas para as menos usadas
+	tagStatsRaw := stats["por_tag"].(map[string]*database.TagStats)
+	tagStats := make([]dtos.TagStatistics, 0, len(tagStatsRaw))
+	for _, tg := range tagStatsRaw {
+		tagStats = append(tagStats, dtos.TagStatistics{
+			Tag:             tg.Tag,
+			TotalProdutos:   tg.TotalProdutos,
+			ProdutosAtivos:  tg.ProdutosAtivos,
+			QuantidadeTotal: tg.QuantidadeTotal,
+			ValorTotal:      tg.ValorTotal,
+		})
+	}
+	sort.Slice(tagStats, func(i, j int) bool {
+		if tagStats[i].TotalProdutos != tagStats[j].TotalProdutos {
+			return tagStats[i].TotalProdutos > tagStats[j].TotalProdutos
+		}
+		return tagStats[i].Tag < tagStats[j].Tag
+	})
+
+	// Inventário a custo lado a lado com o valor a preço de venda
+	valorVenda := stats["valor_total_inventario"].(float64)
+	valorCustoMedio := stats["valor_custo_medio"].(float64)
+	valoracao := &dtos.InventoryValuationSummary{
+		ValorVenda:          valorVenda,
+		ValorCustoFIFO:      stats["valor_custo_fifo"].(float64),
+		ValorCustoMedio:     valorCustoMedio,
+		LucroBrutoPotencial: valorVenda - valorCustoMedio,
+	}
+
 	return &dtos.ProductStatistics{
 		TotalProdutos:        stats["total_produtos"].(int),
 		ProdutosAtivos:       stats["produtos_ativos"].(int),
 		ProdutosInativos:     stats["produtos_inativos"].(int),
 		ProdutosEmEstoque:    stats["produtos_em_estoque"].(int),
 		ProdutosSemEstoque:   stats["produtos_sem_estoque"].(int),
-		ValorTotalInventario: stats["valor_total_inventario"].(float64),
+		ProdutosReposicao:    stats["produtos_reposicao"].(int),
+		ValorTotalInventario: valorVenda,
+		Valoracao:            valoracao,
 		PrecoMedio:           stats["preco_medio"].(float64),
 		PrecoMinimo:          stats["preco_minimo"].(float64),
 		PrecoMaximo:          stats["preco_maximo"].(float64),
 		QuantidadeTotal:      stats["quantidade_total"].(int),
 		PorCategoria:         categoryStats,
+		PorStatus:            statusStats,
+		PorTag:               tagStats,
 		Top5MaisCaros:        top5Caros,
 		Top5MaisBaratos:      top5Baratos,
 		Top5MaisEstoque:      top5Estoque,
@@ -398,18 +669,52 @@ func (s *ProductService) GetStatistics() (*dtos.ProductStatistics, error) {
 // Métodos auxiliares privados
 
 func (s *ProductService) toProductResponse(product *models.Product) *dtos.ProductResponse {
+	var precoPromocional *float64
+	if product.HasPromotion() {
+		preco := product.PrecoPromocional
+		precoPromocional = &preco
+	}
+
 	return &dtos.ProductResponse{
-		ID:              product.ID,
-		Nome:            product.Nome,
-		Descricao:       product.Descricao,
-		Preco:           product.Preco,
-		PrecoFormatado:  product.GetDisplayPrice(),
-		Quantidade:      product.Quantidade,
-		Categoria:       product.Categoria,
-		Ativo:           product.Ativo,
-		EmEstoque:       product.IsInStock(),
-		DataCriacao:     product.DataCriacao,
-		DataAtualizacao: product.DataAtualizacao,
+		ID:               product.ID,
+		Nome:             product.Nome,
+		Descricao:        product.Descricao,
+		Preco:            product.Preco,
+		PrecoFormatado:   product.GetDisplayPrice(),
+		PrecoPromocional: precoPromocional,
+		PromocoesAtivas:  product.Promocoes,
+		Custos: &dtos.ProductCostInfo{
+			PrecoCusto:       product.PrecoCusto,
+			CustoMedio:       product.UnitCost(),
+			MargemPercentual: roundPercent(product.Margin()),
+			MarkupPercentual: roundPercent(product.Markup()),
+		},
+		Quantidade:            product.Quantidade,
+		QuantidadeReservada:   product.QuantidadeReservada,
+		QuantidadeDisponivel:  product.AvailableQuantity(),
+		QuantidadeCaixaAberta: product.QuantidadeCaixaAberta,
+		EstoqueMinimo:         product.EstoqueMinimo,
+		QuantidadeReposicao:   product.QuantidadeReposicao,
+		PrecisaReposicao:      product.NeedsReorder(),
+		Categoria:             product.Categoria,
+		Localizacao:           product.Localizacao,
+		CodigoBarras:          product.CodigoBarras,
+		Tags:                  append([]string{}, product.Tags...),
+		Traducoes:             product.Traducoes,
+		Dimensoes:             toDimensionsResponse(product.Dimensoes),
+		Fiscal:                toFiscalResponse(product.Fiscal),
+		Imagens:               toProductImages(product.Imagens),
+		Ativo:                 product.Ativo,
+		Status:                product.Status,
+		EmEstoque:             product.IsInStock(),
+		ControlaLote:          product.ControlaLote,
+		Serializado:           product.Serializado,
+		Kit:                   product.IsKit(),
+		Componentes:           product.Componentes,
+		PrecoDerivado:         product.PrecoDerivado,
+		DescontoKit:           product.DescontoKit,
+		DataCriacao:           product.DataCriacao,
+		DataAtualizacao:       product.DataAtualizacao,
 	}
 }
 
@@ -475,4 +780,11 @@ func (s *ProductService) validateQuantidade(quantidade int) error {
 		return fmt.Errorf("quantidade deve ser maior ou igual a zero")
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+func (s *ProductService) validateDelta(delta int) error {
+	if delta == 0 {
+		return fmt.Errorf("delta deve ser diferente de zero")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+)
+
+// ChangeProductStatus aplica uma transição do ciclo de vida do produto
+func (s *ProductService) ChangeProductStatus(id uuid.UUID, req *dtos.StatusTransitionRequest) (*dtos.StatusTransitionResponse, error) {
+	result, err := s.repo.ChangeStatus(database.StatusTransition{
+		ProdutoID: id,
+		Para:      req.Status,
+		Motivo:    req.Motivo,
+		Usuario:   req.Usuario,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao alterar situação do produto: %w", err)
+	}
+
+	history, err := s.repo.GetStatusHistory(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar histórico de situação: %w", err)
+	}
+
+	return &dtos.StatusTransitionResponse{
+		ProductStatusResponse: toProductStatusResponse(result.Produto, history),
+		Transicao:             result.Transicao,
+		ReservasLiberadas:     result.ReservasLiberadas,
+	}, nil
+}
+
+// GetProductStatus retorna a situação atual do produto, as transições
+// permitidas e o histórico de mudanças
+func (s *ProductService) GetProductStatus(id uuid.UUID) (*dtos.ProductStatusResponse, error) {
+	product, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("produto não encontrado: %w", err)
+	}
+
+	history, err := s.repo.GetStatusHistory(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar histórico de situação: %w", err)
+	}
+
+	response := toProductStatusResponse(product, history)
+	return &response, nil
+}
+
+// toProductStatusResponse converte o produto e seu histórico para o DTO de situação
+func toProductStatusResponse(product *models.Product, history []models.StatusChange) dtos.ProductStatusResponse {
+	return dtos.ProductStatusResponse{
+		ProdutoID:            product.ID,
+		Nome:                 product.Nome,
+		Status:               product.Status,
+		Ativo:                product.Ativo,
+		PermiteVenda:         product.Status.IsSellable(),
+		PermiteReposicao:     product.Status.AllowsRestock(),
+		TransicoesPermitidas: product.Status.Transitions(),
+		Historico:            history,
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+)
+
+// PromotionService implementa a lógica de negócio para promoções e regras de desconto
+type PromotionService struct {
+	repo repository.PromotionRepository
+}
+
+// NewPromotionService cria uma nova instância do service
+func NewPromotionService(repo repository.PromotionRepository) *PromotionService {
+	return &PromotionService{
+		repo: repo,
+	}
+}
+
+// CreatePromotion cadastra uma nova promoção
+func (s *PromotionService) CreatePromotion(req *dtos.PromotionRequest) (*dtos.PromotionResponse, error) {
+	promotion, err := s.toPromotion(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(promotion); err != nil {
+		return nil, fmt.Errorf("erro ao criar promoção: %w", err)
+	}
+
+	return s.toPromotionResponse(promotion, time.Now()), nil
+}
+
+// GetPromotion busca uma promoção por ID
+func (s *PromotionService) GetPromotion(id uuid.UUID) (*dtos.PromotionResponse, error) {
+	promotion, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar promoção: %w", err)
+	}
+
+	return s.toPromotionResponse(promotion, time.Now()), nil
+}
+
+// ListPromotions retorna as promoções cadastradas, opcionalmente só as vigentes
+func (s *PromotionService) ListPromotions(apenasVigentes bool) (*dtos.PromotionListResponse, error) {
+	now := time.Now()
+	promotions, err := s.repo.List(apenasVigentes, now)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar promoções: %w", err)
+	}
+
+	responses := make([]dtos.PromotionResponse, len(promotions))
+	for i, promotion := range promotions {
+		responses[i] = *s.toPromotionResponse(promotion, now)
+	}
+
+	return &dtos.PromotionListResponse{
+		Promocoes: responses,
+		Total:     len(responses),
+	}, nil
+}
+
+// UpdatePromotion substitui todos os dados de uma promoção
+func (s *PromotionService) UpdatePromotion(id uuid.UUID, req *dtos.PromotionRequest) (*dtos.PromotionResponse, error) {
+	promotion, err := s.toPromotion(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(id, promotion); err != nil {
+		return nil, fmt.Errorf("erro ao atualizar promoção: %w", err)
+	}
+
+	return s.toPromotionResponse(promotion, time.Now()), nil
+}
+
+// DeletePromotion remove uma promoção
+func (s *PromotionService) DeletePromotion(id uuid.UUID) error {
+	if err := s.repo.Delete(id); err != nil {
+		return fmt.Errorf("erro ao deletar promoção: %w", err)
+	}
+	return nil
+}
+
+// SimulatePromotion lista os produtos alcançados pela promoção e o preço
+// final de cada um, sem cadastrá-la. A simulação considera o instante atual
+// ou, se a promoção ainda não começou, o início da vigência.
+func (s *PromotionService) SimulatePromotion(req *dtos.PromotionRequest) (*dtos.PromotionSimulationResponse, error) {
+	promotion, err := s.toPromotion(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// A simulação avalia a regra mesmo que ela seja cadastrada desabilitada
+	promotion.Ativa = true
+
+	at := time.Now()
+	if promotion.Inicio.After(at) {
+		at = promotion.Inicio
+	}
+
+	simulations, err := s.repo.Simulate(promotion, at)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao simular promoção: %w", err)
+	}
+
+	itens := make([]dtos.PromotionSimulationItem, len(simulations))
+	for i, simulation := range simulations {
+		itens[i] = dtos.PromotionSimulationItem{
+			ProdutoID:             simulation.Produto.ID,
+			Nome:                  simulation.Produto.Nome,
+			Categoria:             simulation.Produto.Categoria,
+			Preco:                 simulation.Produto.Preco,
+			PrecoPromocionalAtual: simulation.PrecoAtual,
+			PrecoPromocional:      simulation.PrecoFinal,
+			PromocoesAplicadas:    simulation.PromocoesAplicadas,
+		}
+	}
+
+	return &dtos.PromotionSimulationResponse{
+		Em:    at,
+		Itens: itens,
+		Total: len(itens),
+	}, nil
+}
+
+// toPromotion valida as regras da promoção e converte a requisição para o modelo
+func (s *PromotionService) toPromotion(req *dtos.PromotionRequest) (*models.Promotion, error) {
+	if !req.Fim.After(req.Inicio) {
+		return nil, fmt.Errorf("%w: fim deve ser posterior ao início", database.ErrPromotionInvalid)
+	}
+	if req.Tipo == models.DiscountPercentual && req.Valor > 100 {
+		return nil, fmt.Errorf("%w: desconto percentual não pode exceder 100%%", database.ErrPromotionInvalid)
+	}
+	if req.PrecoMinimo != nil && req.PrecoMaximo != nil && *req.PrecoMinimo > *req.PrecoMaximo {
+		return nil, fmt.Errorf("%w: preco_minimo maior que preco_maximo", database.ErrPromotionInvalid)
+	}
+
+	nomeContem := strings.TrimSpace(req.NomeContem)
+	if !req.TodosProdutos && len(req.Produtos) == 0 && len(req.Categorias) == 0 &&
+		nomeContem == "" && req.PrecoMinimo == nil && req.PrecoMaximo == nil {
+		return nil, fmt.Errorf("%w: informe ao menos um alvo (todos_produtos, produtos, categorias ou filtro)", database.ErrPromotionInvalid)
+	}
+
+	ativa := true
+	if req.Ativa != nil {
+		ativa = *req.Ativa
+	}
+
+	return &models.Promotion{
+		Nome:      strings.TrimSpace(req.Nome),
+		Descricao: strings.TrimSpace(req.Descricao),
+		Tipo:      req.Tipo,
+		Valor:     req.Valor,
+		Alvo: models.PromotionTarget{
+			TodosProdutos: req.TodosProdutos,
+			Produtos:      req.Produtos,
+			Categorias:    req.Categorias,
+			NomeContem:    nomeContem,
+			PrecoMinimo:   req.PrecoMinimo,
+			PrecoMaximo:   req.PrecoMaximo,
+		},
+		Inicio:     req.Inicio,
+		Fim:        req.Fim,
+		Prioridade: req.Prioridade,
+		Cumulativa: req.Cumulativa,
+		Ativa:      ativa,
+	}, nil
+}
+
+// toPromotionResponse converte o modelo para a resposta, indicando a vigência atual
+func (s *PromotionService) toPromotionResponse(promotion *models.Promotion, now time.Time) *dtos.PromotionResponse {
+	return &dtos.PromotionResponse{
+		Promotion: *promotion,
+		Vigente:   promotion.IsActiveAt(now),
+	}
+}
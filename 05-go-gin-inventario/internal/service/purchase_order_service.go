@@ -0,0 +1,202 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+)
+
+// PurchaseOrderService implementa a lógica de negócio para pedidos de compra e recebimento
+type PurchaseOrderService struct {
+	repo         repository.PurchaseOrderRepository
+	supplierRepo repository.SupplierRepository
+	productRepo  repository.ProductRepository
+}
+
+// NewPurchaseOrderService cria uma nova instância do service
+func NewPurchaseOrderService(repo repository.PurchaseOrderRepository, supplierRepo repository.SupplierRepository, productRepo repository.ProductRepository) *PurchaseOrderService {
+	return &PurchaseOrderService{
+		repo:         repo,
+		supplierRepo: supplierRepo,
+		productRepo:  productRepo,
+	}
+}
+
+// CreatePurchaseOrder cadastra um pedido de compra em rascunho
+func (s *PurchaseOrderService) CreatePurchaseOrder(req *dtos.PurchaseOrderRequest) (*dtos.PurchaseOrderResponse, error) {
+	order := s.toPurchaseOrder(req)
+	if err := s.repo.Create(order); err != nil {
+		return nil, fmt.Errorf("erro ao criar pedido de compra: %w", err)
+	}
+
+	return s.toPurchaseOrderResponse(order), nil
+}
+
+// GetPurchaseOrder busca um pedido de compra por ID
+func (s *PurchaseOrderService) GetPurchaseOrder(id uuid.UUID) (*dtos.PurchaseOrderResponse, error) {
+	order, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar pedido de compra: %w", err)
+	}
+
+	return s.toPurchaseOrderResponse(order), nil
+}
+
+// ListPurchaseOrders retorna os pedidos de compra filtrados por fornecedor, produto e situação
+func (s *PurchaseOrderService) ListPurchaseOrders(filter database.PurchaseOrderFilter) (*dtos.PurchaseOrderListResponse, error) {
+	orders, err := s.repo.List(filter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar pedidos de compra: %w", err)
+	}
+
+	responses := make([]dtos.PurchaseOrderResponse, len(orders))
+	for i, order := range orders {
+		responses[i] = *s.toPurchaseOrderResponse(order)
+	}
+
+	return &dtos.PurchaseOrderListResponse{
+		Pedidos: responses,
+		Total:   len(responses),
+	}, nil
+}
+
+// UpdatePurchaseOrder substitui fornecedor, itens e observações de um pedido em rascunho
+func (s *PurchaseOrderService) UpdatePurchaseOrder(id uuid.UUID, req *dtos.PurchaseOrderRequest) (*dtos.PurchaseOrderResponse, error) {
+	order := s.toPurchaseOrder(req)
+	if err := s.repo.Update(id, order); err != nil {
+		return nil, fmt.Errorf("erro ao atualizar pedido de compra: %w", err)
+	}
+
+	return s.toPurchaseOrderResponse(order), nil
+}
+
+// SendPurchaseOrder marca o pedido como enviado ao fornecedor
+func (s *PurchaseOrderService) SendPurchaseOrder(id uuid.UUID) (*dtos.PurchaseOrderResponse, error) {
+	order, err := s.repo.Send(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao enviar pedido de compra: %w", err)
+	}
+
+	return s.toPurchaseOrderResponse(order), nil
+}
+
+// CancelPurchaseOrder cancela o pedido; quantidades já recebidas permanecem no estoque
+func (s *PurchaseOrderService) CancelPurchaseOrder(id uuid.UUID, req *dtos.CancelPurchaseOrderRequest) (*dtos.PurchaseOrderResponse, error) {
+	order, err := s.repo.Cancel(id, strings.TrimSpace(req.Motivo))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao cancelar pedido de compra: %w", err)
+	}
+
+	return s.toPurchaseOrderResponse(order), nil
+}
+
+// ReceivePurchaseOrder registra o recebimento de itens do pedido, dando entrada no estoque
+func (s *PurchaseOrderService) ReceivePurchaseOrder(id uuid.UUID, req *dtos.PurchaseReceiptRequest) (*dtos.PurchaseOrderResponse, error) {
+	receipt := &models.PurchaseReceipt{
+		Itens:      make([]models.PurchaseReceiptLine, len(req.Itens)),
+		Observacao: strings.TrimSpace(req.Observacao),
+	}
+
+	for i, item := range req.Itens {
+		line := models.PurchaseReceiptLine{
+			LinhaID:       item.LinhaID,
+			Quantidade:    item.Quantidade,
+			CustoUnitario: item.CustoUnitario,
+			NumerosSerie:  item.NumerosSerie,
+		}
+
+		if item.Lote != nil {
+			codigo := strings.TrimSpace(item.Lote.Codigo)
+			if codigo == "" {
+				return nil, fmt.Errorf("código do lote é obrigatório")
+			}
+			if !item.Lote.DataValidade.After(item.Lote.DataFabricacao) {
+				return nil, fmt.Errorf("data de validade deve ser posterior à data de fabricação")
+			}
+			if item.Lote.DataFabricacao.After(time.Now()) {
+				return nil, fmt.Errorf("data de fabricação não pode estar no futuro")
+			}
+			line.Lote = &models.PurchaseReceiptLot{
+				Codigo:         codigo,
+				DataFabricacao: item.Lote.DataFabricacao,
+				DataValidade:   item.Lote.DataValidade,
+			}
+		}
+
+		receipt.Itens[i] = line
+	}
+
+	order, err := s.repo.Receive(id, receipt)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao receber pedido de compra: %w", err)
+	}
+
+	return s.toPurchaseOrderResponse(order), nil
+}
+
+// toPurchaseOrder converte a requisição para o modelo
+func (s *PurchaseOrderService) toPurchaseOrder(req *dtos.PurchaseOrderRequest) *models.PurchaseOrder {
+	lines := make([]models.PurchaseOrderLine, len(req.Itens))
+	for i, item := range req.Itens {
+		lines[i] = models.PurchaseOrderLine{
+			ProdutoID:        item.ProdutoID,
+			CodigoFornecedor: strings.TrimSpace(item.CodigoFornecedor),
+			Quantidade:       item.Quantidade,
+			CustoUnitario:    item.CustoUnitario,
+		}
+	}
+
+	return &models.PurchaseOrder{
+		FornecedorID:    req.FornecedorID,
+		Itens:           lines,
+		Observacoes:     strings.TrimSpace(req.Observacoes),
+		PrevisaoEntrega: req.PrevisaoEntrega,
+	}
+}
+
+// toPurchaseOrderResponse converte o modelo para a resposta com nomes e totais
+func (s *PurchaseOrderService) toPurchaseOrderResponse(order *models.PurchaseOrder) *dtos.PurchaseOrderResponse {
+	fornecedorNome := ""
+	if supplier, err := s.supplierRepo.GetByID(order.FornecedorID); err == nil {
+		fornecedorNome = supplier.Nome
+	}
+
+	itens := make([]dtos.PurchaseOrderLineResponse, len(order.Itens))
+	for i, line := range order.Itens {
+		produtoNome := ""
+		if product, err := s.productRepo.GetByID(line.ProdutoID); err == nil {
+			produtoNome = product.Nome
+		}
+		itens[i] = dtos.PurchaseOrderLineResponse{
+			PurchaseOrderLine:  line,
+			ProdutoNome:        produtoNome,
+			QuantidadePendente: line.Pending(),
+			Subtotal:           float64(line.Quantidade) * line.CustoUnitario,
+		}
+	}
+
+	return &dtos.PurchaseOrderResponse{
+		ID:                 order.ID,
+		Numero:             order.Numero,
+		FornecedorID:       order.FornecedorID,
+		FornecedorNome:     fornecedorNome,
+		Status:             order.Status,
+		Itens:              itens,
+		Recebimentos:       order.Recebimentos,
+		ValorTotal:         order.Total(),
+		ValorRecebido:      order.ReceivedTotal(),
+		Observacoes:        order.Observacoes,
+		MotivoCancelamento: order.MotivoCancelamento,
+		PrevisaoEntrega:    order.PrevisaoEntrega,
+		DataEnvio:          order.DataEnvio,
+		DataConclusao:      order.DataConclusao,
+		DataCriacao:        order.DataCriacao,
+		DataAtualizacao:    order.DataAtualizacao,
+	}
+}
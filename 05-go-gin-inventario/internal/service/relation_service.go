@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+)
+
+// CreateProductRelation relaciona o produto a outro como substituto,
+// acessório ou upsell
+func (s *ProductService) CreateProductRelation(id uuid.UUID, req *dtos.CreateRelationRequest) (*dtos.RelatedProductResponse, error) {
+	relation, err := s.repo.CreateRelation(&models.ProductRelation{
+		ProdutoID:     id,
+		RelacionadoID: req.RelacionadoID,
+		Tipo:          req.Tipo,
+		Observacao:    req.Observacao,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao relacionar produtos: %w", err)
+	}
+
+	related, err := s.repo.GetByID(relation.RelacionadoID)
+	if err != nil {
+		return nil, fmt.Errorf("produto relacionado não encontrado: %w", err)
+	}
+
+	response := toRelatedProductResponse(database.RelatedProduct{Relacao: *relation, Produto: related})
+	return &response, nil
+}
+
+// DeleteProductRelation desfaz as relações do produto com outro; sem tipo,
+// remove todas as relações entre os dois
+func (s *ProductService) DeleteProductRelation(id, relatedID uuid.UUID, tipo *models.RelationType) error {
+	if err := s.repo.DeleteRelation(id, relatedID, tipo); err != nil {
+		return fmt.Errorf("erro ao remover relação: %w", err)
+	}
+	return nil
+}
+
+// GetProductRelations retorna os produtos relacionados, opcionalmente de um tipo
+func (s *ProductService) GetProductRelations(id uuid.UUID, tipo *models.RelationType) (*dtos.RelationListResponse, error) {
+	related, err := s.repo.GetRelations(id, tipo)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar produtos relacionados: %w", err)
+	}
+
+	return &dtos.RelationListResponse{
+		ProdutoID:    id,
+		Relacionados: toRelatedProductResponses(related),
+		Total:        len(related),
+	}, nil
+}
+
+// GetProductWithRelations retorna o produto com os produtos relacionados
+func (s *ProductService) GetProductWithRelations(id uuid.UUID) (*dtos.ProductResponse, error) {
+	response, err := s.GetProductByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	related, err := s.repo.GetRelations(id, nil)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar produtos relacionados: %w", err)
+	}
+	response.Relacionados = toRelatedProductResponses(related)
+
+	return response, nil
+}
+
+// GetProductAlternatives retorna os substitutos ativos com disponível em
+// estoque, do preço mais próximo ao mais distante do produto
+func (s *ProductService) GetProductAlternatives(id uuid.UUID) (*dtos.AlternativesResponse, error) {
+	product, alternatives, err := s.repo.GetAlternatives(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar alternativas: %w", err)
+	}
+
+	responses := make([]dtos.AlternativeResponse, len(alternatives))
+	for i, alternative := range alternatives {
+		responses[i] = dtos.AlternativeResponse{
+			RelatedProductResponse: toRelatedProductResponse(alternative),
+			DiferencaPreco:         math.Round((alternative.Produto.Preco-product.Preco)*100) / 100,
+		}
+	}
+
+	return &dtos.AlternativesResponse{
+		ProdutoID:    product.ID,
+		Nome:         product.Nome,
+		Preco:        product.Preco,
+		Disponivel:   product.AvailableQuantity(),
+		Alternativas: responses,
+		Total:        len(responses),
+	}, nil
+}
+
+// toRelatedProductResponses converte as relações para DTOs
+func toRelatedProductResponses(related []database.RelatedProduct) []dtos.RelatedProductResponse {
+	responses := make([]dtos.RelatedProductResponse, len(related))
+	for i, item := range related {
+		responses[i] = toRelatedProductResponse(item)
+	}
+	return responses
+}
+
+// toRelatedProductResponse converte uma relação e o produto relacionado para DTO
+func toRelatedProductResponse(item database.RelatedProduct) dtos.RelatedProductResponse {
+	return dtos.RelatedProductResponse{
+		ProdutoID:        item.Produto.ID,
+		Nome:             item.Produto.Nome,
+		Categoria:        item.Produto.Categoria,
+		Tipo:             item.Relacao.Tipo,
+		Observacao:       item.Relacao.Observacao,
+		Preco:            item.Produto.Preco,
+		PrecoPromocional: item.Produto.PrecoPromocional,
+		Disponivel:       item.Produto.AvailableQuantity(),
+		Ativo:            item.Produto.Ativo,
+		Status:           item.Produto.Status,
+	}
+}
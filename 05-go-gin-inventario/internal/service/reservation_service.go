@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+)
+
+// DefaultReservationTTL é a validade aplicada quando a requisição não informa ttl_segundos
+const DefaultReservationTTL = 15 * time.Minute
+
+// ReservationService implementa a lógica de negócio para reservas de estoque
+type ReservationService struct {
+	repo repository.ReservationRepository
+}
+
+// NewReservationService cria uma nova instância do service
+func NewReservationService(repo repository.ReservationRepository) *ReservationService {
+	return &ReservationService{
+		repo: repo,
+	}
+}
+
+// CreateReservation reserva unidades de um produto por um tempo limitado
+func (s *ReservationService) CreateReservation(req *dtos.CreateReservationRequest) (*dtos.ReservationResponse, error) {
+	referencia := strings.TrimSpace(req.Referencia)
+	if referencia == "" {
+		return nil, fmt.Errorf("referência é obrigatória")
+	}
+	if req.Quantidade <= 0 {
+		return nil, fmt.Errorf("quantidade deve ser maior que zero")
+	}
+
+	ttl := DefaultReservationTTL
+	if req.TTLSegundos > 0 {
+		ttl = time.Duration(req.TTLSegundos) * time.Second
+	}
+
+	reservation := &models.Reservation{
+		ProdutoID:  req.ProdutoID,
+		Quantidade: req.Quantidade,
+		Referencia: referencia,
+		ExpiraEm:   time.Now().Add(ttl),
+	}
+
+	if err := s.repo.Create(reservation); err != nil {
+		return nil, fmt.Errorf("erro ao criar reserva: %w", err)
+	}
+
+	return s.toReservationResponse(reservation), nil
+}
+
+// GetReservation busca uma reserva por ID
+func (s *ReservationService) GetReservation(id uuid.UUID) (*dtos.ReservationResponse, error) {
+	reservation, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toReservationResponse(reservation), nil
+}
+
+// ListReservations retorna reservas filtradas por produto, status e referência
+func (s *ReservationService) ListReservations(filter database.ReservationFilter) (*dtos.ReservationListResponse, error) {
+	reservations, err := s.repo.List(filter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar reservas: %w", err)
+	}
+
+	responses := make([]dtos.ReservationResponse, len(reservations))
+	for i, reservation := range reservations {
+		responses[i] = *s.toReservationResponse(reservation)
+	}
+
+	return &dtos.ReservationListResponse{
+		Reservas: responses,
+		Total:    len(responses),
+	}, nil
+}
+
+// ConfirmReservation efetiva a reserva, baixando as unidades do estoque físico
+func (s *ReservationService) ConfirmReservation(id uuid.UUID) (*dtos.ReservationResponse, error) {
+	reservation, err := s.repo.Confirm(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao confirmar reserva: %w", err)
+	}
+
+	return s.toReservationResponse(reservation), nil
+}
+
+// ReleaseReservation libera a reserva, devolvendo as unidades ao disponível
+func (s *ReservationService) ReleaseReservation(id uuid.UUID) (*dtos.ReservationResponse, error) {
+	reservation, err := s.repo.Release(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao liberar reserva: %w", err)
+	}
+
+	return s.toReservationResponse(reservation), nil
+}
+
+// StartExpirationSweeper inicia uma goroutine que expira periodicamente as
+// reservas vencidas até que o contexto seja cancelado
+func (s *ReservationService) StartExpirationSweeper(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if expired := s.repo.ExpireBefore(now); expired > 0 {
+					log.Printf("⏰ %d reserva(s) expirada(s)", expired)
+				}
+			}
+		}
+	}()
+}
+
+// Métodos auxiliares privados
+
+func (s *ReservationService) toReservationResponse(reservation *models.Reservation) *dtos.ReservationResponse {
+	return &dtos.ReservationResponse{
+		ID:              reservation.ID,
+		ProdutoID:       reservation.ProdutoID,
+		Quantidade:      reservation.Quantidade,
+		Referencia:      reservation.Referencia,
+		Status:          reservation.Status,
+		ExpiraEm:        reservation.ExpiraEm,
+		DataCriacao:     reservation.DataCriacao,
+		DataAtualizacao: reservation.DataAtualizacao,
+	}
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+)
+
+// ReturnService implementa a lógica de negócio para devoluções de clientes
+type ReturnService struct {
+	repo repository.ReturnRepository
+}
+
+// NewReturnService cria uma nova instância do service
+func NewReturnService(repo repository.ReturnRepository) *ReturnService {
+	return &ReturnService{
+		repo: repo,
+	}
+}
+
+// CreateReturn registra a devolução de itens de um pedido de venda. Os itens
+// aguardam inspeção, sem efeito no estoque.
+func (s *ReturnService) CreateReturn(req *dtos.ReturnRequest) (*dtos.ReturnResponse, error) {
+	ret := &models.Return{
+		PedidoVendaID: req.PedidoVendaID,
+		Itens:         make([]models.ReturnLine, len(req.Itens)),
+	}
+	for i, item := range req.Itens {
+		ret.Itens[i] = models.ReturnLine{
+			ItemPedidoID: item.ItemPedidoID,
+			Quantidade:   item.Quantidade,
+			NumerosSerie: item.NumerosSerie,
+			Motivo:       models.ReturnReason(item.Motivo),
+			Observacao:   strings.TrimSpace(item.Observacao),
+		}
+	}
+
+	if err := s.repo.Create(ret); err != nil {
+		return nil, fmt.Errorf("erro ao registrar devolução: %w", err)
+	}
+
+	return s.toReturnResponse(ret), nil
+}
+
+// GetReturn busca uma devolução por ID
+func (s *ReturnService) GetReturn(id uuid.UUID) (*dtos.ReturnResponse, error) {
+	ret, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar devolução: %w", err)
+	}
+
+	return s.toReturnResponse(ret), nil
+}
+
+// ListReturns retorna as devoluções filtradas
+func (s *ReturnService) ListReturns(filter database.ReturnFilter) (*dtos.ReturnListResponse, error) {
+	returns, err := s.repo.List(filter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar devoluções: %w", err)
+	}
+
+	responses := make([]dtos.ReturnResponse, len(returns))
+	for i, ret := range returns {
+		responses[i] = *s.toReturnResponse(ret)
+	}
+
+	return &dtos.ReturnListResponse{
+		Devolucoes: responses,
+		Total:      len(responses),
+	}, nil
+}
+
+// InspectReturn registra o destino de cada item devolvido e aplica o efeito no estoque
+func (s *ReturnService) InspectReturn(id uuid.UUID, req *dtos.ReturnInspectionRequest) (*dtos.ReturnResponse, error) {
+	inspections := make([]database.ReturnInspection, len(req.Itens))
+	for i, item := range req.Itens {
+		inspections[i] = database.ReturnInspection{
+			ItemID:       item.ItemID,
+			Destino:      models.ReturnOutcome(item.Destino),
+			FornecedorID: item.FornecedorID,
+			Parecer:      strings.TrimSpace(item.Parecer),
+		}
+	}
+
+	ret, err := s.repo.Inspect(id, inspections)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao inspecionar devolução: %w", err)
+	}
+
+	return s.toReturnResponse(ret), nil
+}
+
+// GetReturnReport totaliza as devoluções registradas no período por motivo,
+// categoria e destino, do agrupamento com mais unidades para o com menos
+func (s *ReturnService) GetReturnReport(inicio, fim *time.Time) (*dtos.ReturnReportResponse, error) {
+	returns, err := s.repo.List(database.ReturnFilter{Inicio: inicio, Fim: fim})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar relatório de devoluções: %w", err)
+	}
+
+	report := &dtos.ReturnReportResponse{
+		Inicio:          inicio,
+		Fim:             fim,
+		TotalDevolucoes: len(returns),
+	}
+
+	porMotivo := make(map[string]*dtos.ReturnReportGroup)
+	porCategoria := make(map[string]*dtos.ReturnReportGroup)
+	porDestino := make(map[string]*dtos.ReturnReportGroup)
+	for _, ret := range returns {
+		if ret.IsPending() {
+			report.Pendentes++
+		}
+		for _, line := range ret.Itens {
+			destino := string(line.Destino)
+			if destino == "" {
+				destino = string(models.ReturnStatusAguardandoInspecao)
+			}
+			addReturnReportLine(porMotivo, string(line.Motivo), line)
+			addReturnReportLine(porCategoria, string(line.Categoria), line)
+			addReturnReportLine(porDestino, destino, line)
+
+			report.Quantidade += line.Quantidade
+			report.ValorReembolso += line.ValorReembolso
+			if line.Destino == models.ReturnOutcomeWriteOff {
+				report.ValorPerdas += line.ValorCusto
+			}
+		}
+	}
+
+	report.ValorReembolso = math.Round(report.ValorReembolso*100) / 100
+	report.ValorPerdas = math.Round(report.ValorPerdas*100) / 100
+	report.PorMotivo = sortedReturnReportGroups(porMotivo)
+	report.PorCategoria = sortedReturnReportGroups(porCategoria)
+	report.PorDestino = sortedReturnReportGroups(porDestino)
+
+	return report, nil
+}
+
+// addReturnReportLine soma um item devolvido ao agrupamento da chave informada
+func addReturnReportLine(groups map[string]*dtos.ReturnReportGroup, chave string, line models.ReturnLine) {
+	group, exists := groups[chave]
+	if !exists {
+		group = &dtos.ReturnReportGroup{Chave: chave}
+		groups[chave] = group
+	}
+	group.Quantidade += line.Quantidade
+	group.ValorReembolso += line.ValorReembolso
+	group.ValorCusto += line.ValorCusto
+}
+
+// sortedReturnReportGroups ordena os agrupamentos por quantidade devolvida
+func sortedReturnReportGroups(groups map[string]*dtos.ReturnReportGroup) []dtos.ReturnReportGroup {
+	result := make([]dtos.ReturnReportGroup, 0, len(groups))
+	for _, group := range groups {
+		group.ValorReembolso = math.Round(group.ValorReembolso*100) / 100
+		group.ValorCusto = math.Round(group.ValorCusto*100) / 100
+		result = append(result, *group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Quantidade != result[j].Quantidade {
+			return result[i].Quantidade > result[j].Quantidade
+		}
+		return result[i].Chave < result[j].Chave
+	})
+
+	return result
+}
+
+// toReturnResponse converte o modelo para a resposta
+func (s *ReturnService) toReturnResponse(ret *models.Return) *dtos.ReturnResponse {
+	quantidade := 0
+	for _, line := range ret.Itens {
+		quantidade += line.Quantidade
+	}
+
+	return &dtos.ReturnResponse{
+		Return:          *ret,
+		QuantidadeTotal: quantidade,
+	}
+}
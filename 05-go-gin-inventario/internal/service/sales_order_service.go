@@ -0,0 +1,105 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+)
+
+// SalesOrderService implementa a lógica de negócio para pedidos de venda
+type SalesOrderService struct {
+	repo repository.SalesOrderRepository
+}
+
+// NewSalesOrderService cria uma nova instância do service
+func NewSalesOrderService(repo repository.SalesOrderRepository) *SalesOrderService {
+	return &SalesOrderService{
+		repo: repo,
+	}
+}
+
+// CreateSalesOrder registra uma venda baixando o estoque de todos os itens de
+// uma só vez. Uma venda recusada retorna um *database.SalesOrderRejection com
+// o erro de cada item.
+func (s *SalesOrderService) CreateSalesOrder(req *dtos.SalesOrderRequest) (*dtos.SalesOrderResponse, error) {
+	order := &models.SalesOrder{
+		Canal:      strings.ToLower(strings.TrimSpace(req.Canal)),
+		Referencia: strings.TrimSpace(req.Referencia),
+		Itens:      make([]models.SalesOrderLine, len(req.Itens)),
+	}
+	for i, item := range req.Itens {
+		order.Itens[i] = models.SalesOrderLine{
+			ProdutoID:    item.ProdutoID,
+			Quantidade:   item.Quantidade,
+			NumerosSerie: item.NumerosSerie,
+			CaixaAberta:  item.CaixaAberta,
+		}
+	}
+
+	if err := s.repo.Create(order); err != nil {
+		return nil, fmt.Errorf("erro ao registrar pedido de venda: %w", err)
+	}
+
+	return s.toSalesOrderResponse(order), nil
+}
+
+// GetSalesOrder busca um pedido de venda por ID
+func (s *SalesOrderService) GetSalesOrder(id uuid.UUID) (*dtos.SalesOrderResponse, error) {
+	order, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar pedido de venda: %w", err)
+	}
+
+	return s.toSalesOrderResponse(order), nil
+}
+
+// ListSalesOrders retorna os pedidos de venda filtrados
+func (s *SalesOrderService) ListSalesOrders(filter database.SalesOrderFilter) (*dtos.SalesOrderListResponse, error) {
+	if filter.Canal != nil {
+		canal := strings.ToLower(strings.TrimSpace(*filter.Canal))
+		filter.Canal = &canal
+	}
+
+	orders, err := s.repo.List(filter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar pedidos de venda: %w", err)
+	}
+
+	responses := make([]dtos.SalesOrderResponse, len(orders))
+	for i, order := range orders {
+		responses[i] = *s.toSalesOrderResponse(order)
+	}
+
+	return &dtos.SalesOrderListResponse{
+		Pedidos: responses,
+		Total:   len(responses),
+	}, nil
+}
+
+// CancelSalesOrder cancela uma venda confirmada devolvendo o estoque
+func (s *SalesOrderService) CancelSalesOrder(id uuid.UUID, req *dtos.CancelSalesOrderRequest) (*dtos.SalesOrderResponse, error) {
+	order, err := s.repo.Cancel(id, strings.TrimSpace(req.Motivo))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao cancelar pedido de venda: %w", err)
+	}
+
+	return s.toSalesOrderResponse(order), nil
+}
+
+// toSalesOrderResponse converte o modelo para a resposta
+func (s *SalesOrderService) toSalesOrderResponse(order *models.SalesOrder) *dtos.SalesOrderResponse {
+	quantidade := 0
+	for _, line := range order.Itens {
+		quantidade += line.Quantidade
+	}
+
+	return &dtos.SalesOrderResponse{
+		SalesOrder:      *order,
+		QuantidadeTotal: quantidade,
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+)
+
+// SerialService implementa a lógica de negócio para rastreio por número de série
+type SerialService struct {
+	repo        repository.SerialRepository
+	productRepo repository.ProductRepository
+}
+
+// NewSerialService cria uma nova instância do service
+func NewSerialService(repo repository.SerialRepository, productRepo repository.ProductRepository) *SerialService {
+	return &SerialService{
+		repo:        repo,
+		productRepo: productRepo,
+	}
+}
+
+// ReceiveSerials registra a entrada de unidades informando seus números de série
+func (s *SerialService) ReceiveSerials(productID uuid.UUID, req *dtos.SerialMovementRequest) (*dtos.SerialMovementResponse, error) {
+	if req.ReservaID != nil {
+		return nil, fmt.Errorf("reserva só pode ser informada em saídas")
+	}
+
+	serials, product, err := s.repo.Receive(s.toMovement(productID, req))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao registrar entrada de números de série: %w", err)
+	}
+
+	return s.toMovementResponse(product, serials), nil
+}
+
+// ShipSerials registra a saída (venda) de unidades informando seus números de série
+func (s *SerialService) ShipSerials(productID uuid.UUID, req *dtos.SerialMovementRequest) (*dtos.SerialMovementResponse, error) {
+	if req.CustoUnitario != 0 {
+		return nil, fmt.Errorf("custo unitário só pode ser informado em entradas")
+	}
+
+	serials, product, err := s.repo.Ship(s.toMovement(productID, req))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao registrar saída de números de série: %w", err)
+	}
+
+	return s.toMovementResponse(product, serials), nil
+}
+
+// GetSerial retorna a situação atual e o histórico de um número de série
+func (s *SerialService) GetSerial(numero string) (*dtos.SerialResponse, error) {
+	serial, err := s.repo.GetByNumber(numero)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toSerialResponse(serial), nil
+}
+
+// GetProductSerials lista os números de série de um produto e verifica se a
+// quantidade em estoque do produto confere com os números em estoque
+func (s *SerialService) GetProductSerials(productID uuid.UUID, status *models.SerialStatus) (*dtos.SerialListResponse, error) {
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("produto não encontrado: %w", err)
+	}
+
+	emEstoque := models.SerialEmEstoque
+	inStock, err := s.repo.GetByProduct(productID, &emEstoque)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar números de série: %w", err)
+	}
+
+	serials := inStock
+	if status == nil || *status != emEstoque {
+		serials, err = s.repo.GetByProduct(productID, status)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao buscar números de série: %w", err)
+		}
+	}
+
+	responses := make([]dtos.SerialResponse, len(serials))
+	for i, serial := range serials {
+		responses[i] = *s.toSerialResponse(serial)
+	}
+
+	return &dtos.SerialListResponse{
+		ProdutoID:         productID,
+		Seriais:           responses,
+		Total:             len(responses),
+		QuantidadeProduto: product.Quantidade,
+		SeriaisEmEstoque:  len(inStock),
+		Consistente:       !product.Serializado || product.Quantidade == len(inStock),
+	}, nil
+}
+
+// Métodos auxiliares privados
+
+func (s *SerialService) toMovement(productID uuid.UUID, req *dtos.SerialMovementRequest) database.SerialMovement {
+	return database.SerialMovement{
+		ProductID:     productID,
+		Numeros:       req.Seriais,
+		Referencia:    strings.TrimSpace(req.Referencia),
+		ReservationID: req.ReservaID,
+		CustoUnitario: req.CustoUnitario,
+	}
+}
+
+func (s *SerialService) toMovementResponse(product *models.Product, serials []*models.SerialNumber) *dtos.SerialMovementResponse {
+	responses := make([]dtos.SerialResponse, len(serials))
+	for i, serial := range serials {
+		responses[i] = *s.toSerialResponse(serial)
+	}
+
+	return &dtos.SerialMovementResponse{
+		ProdutoID:  product.ID,
+		Quantidade: product.Quantidade,
+		Seriais:    responses,
+	}
+}
+
+func (s *SerialService) toSerialResponse(serial *models.SerialNumber) *dtos.SerialResponse {
+	return &dtos.SerialResponse{
+		Numero:          serial.Numero,
+		ProdutoID:       serial.ProdutoID,
+		Status:          serial.Status,
+		Historico:       serial.Historico,
+		DataCriacao:     serial.DataCriacao,
+		DataAtualizacao: serial.DataAtualizacao,
+	}
+}
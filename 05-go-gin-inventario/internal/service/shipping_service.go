@@ -0,0 +1,220 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+)
+
+// ShippingService calcula cotações de frete a partir da tabela local de preços
+type ShippingService struct {
+	repo  repository.ProductRepository
+	table models.RateTable
+}
+
+// NewShippingService cria uma nova instância do service de frete
+func NewShippingService(repo repository.ProductRepository, table models.RateTable) *ShippingService {
+	return &ShippingService{
+		repo:  repo,
+		table: table,
+	}
+}
+
+// LoadRateTable lê a tabela de frete de um arquivo JSON. Sem caminho, usa a
+// tabela padrão.
+func LoadRateTable(path string) (models.RateTable, error) {
+	if path == "" {
+		return models.DefaultRateTable(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.RateTable{}, fmt.Errorf("erro ao ler tabela de frete: %w", err)
+	}
+
+	var table models.RateTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return models.RateTable{}, fmt.Errorf("erro ao interpretar tabela de frete: %w", err)
+	}
+	if err := table.Validate(); err != nil {
+		return models.RateTable{}, fmt.Errorf("tabela de frete inválida: %w", err)
+	}
+
+	return table, nil
+}
+
+// GetRateTable retorna a tabela de frete em uso
+func (s *ShippingService) GetRateTable() models.RateTable {
+	return s.table
+}
+
+// QuoteShipping calcula o frete de um carrinho para o CEP ou a zona de
+// destino. O frete é cobrado pelo maior entre o peso real e o cubado do
+// carrinho, mais o ad valorem sobre o valor das mercadorias.
+func (s *ShippingService) QuoteShipping(req *dtos.ShippingQuoteRequest) (*dtos.ShippingQuoteResponse, error) {
+	zone, cep, err := s.resolveZone(req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dtos.ShippingQuoteResponse{
+		CEP:          cep,
+		Zona:         zone.Zona,
+		Descricao:    zone.Descricao,
+		PrazoDias:    zone.PrazoDias,
+		FatorCubagem: s.table.FatorCubagem,
+		Itens:        make([]dtos.ShippingQuoteItem, 0, len(req.Itens)),
+	}
+
+	var semMedidas []string
+	for _, item := range req.Itens {
+		product, err := s.repo.GetByID(item.ProdutoID)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao cotar frete: %w", err)
+		}
+
+		dimensoes, ok := s.shippingDimensions(product)
+		if !ok {
+			semMedidas = append(semMedidas, product.Nome)
+			continue
+		}
+
+		preco := product.Preco
+		if product.HasPromotion() {
+			preco = product.PrecoPromocional
+		}
+
+		quantidade := float64(item.Quantidade)
+		line := dtos.ShippingQuoteItem{
+			ProdutoID:         product.ID,
+			Nome:              product.Nome,
+			Quantidade:        item.Quantidade,
+			PesoUnitarioKg:    roundWeight(dimensoes.PesoKg),
+			VolumeUnitarioCm3: math.Round(dimensoes.VolumeCm3()),
+			PesoRealKg:        roundWeight(dimensoes.PesoKg * quantidade),
+			PesoCubadoKg:      roundWeight(dimensoes.CubedWeight(s.table.FatorCubagem) * quantidade),
+			ValorMercadorias:  math.Round(preco*quantidade*100) / 100,
+		}
+		response.Itens = append(response.Itens, line)
+
+		response.PesoRealKg += dimensoes.PesoKg * quantidade
+		response.VolumeCm3 += dimensoes.VolumeCm3() * quantidade
+		response.ValorMercadorias += line.ValorMercadorias
+	}
+
+	if len(semMedidas) > 0 {
+		return nil, fmt.Errorf("%w: %s", database.ErrDimensionsMissing, strings.Join(semMedidas, ", "))
+	}
+
+	cubado := response.VolumeCm3 / s.table.FatorCubagem
+	taxado := math.Max(response.PesoRealKg, cubado)
+
+	response.PesoRealKg = roundWeight(response.PesoRealKg)
+	response.VolumeCm3 = math.Round(response.VolumeCm3)
+	response.PesoCubadoKg = roundWeight(cubado)
+	response.PesoTaxadoKg = roundWeight(taxado)
+	response.ValorMercadorias = math.Round(response.ValorMercadorias*100) / 100
+	response.ValorFrete = zone.Freight(taxado)
+	response.ValorSeguro = zone.Insurance(response.ValorMercadorias)
+	response.ValorTotal = math.Round((response.ValorFrete+response.ValorSeguro)*100) / 100
+
+	return response, nil
+}
+
+// resolveZone encontra a zona de destino pelo CEP ou pelo nome informado
+func (s *ShippingService) resolveZone(req *dtos.ShippingQuoteRequest) (*models.ShippingZone, string, error) {
+	if req.CEP != "" {
+		cep := models.NormalizeCEP(req.CEP)
+		if cep == "" {
+			return nil, "", fmt.Errorf("%w: CEP %q deve ter 8 dígitos", database.ErrShippingInvalid, req.CEP)
+		}
+		zone := s.table.ZoneByCEP(cep)
+		if zone == nil {
+			return nil, "", fmt.Errorf("%w: CEP %s", database.ErrShippingUnavailable, cep)
+		}
+		return zone, cep, nil
+	}
+
+	if req.Zona != "" {
+		zone := s.table.ZoneByName(req.Zona)
+		if zone == nil {
+			return nil, "", fmt.Errorf("%w: zona %q desconhecida", database.ErrShippingUnavailable, req.Zona)
+		}
+		return zone, "", nil
+	}
+
+	return nil, "", fmt.Errorf("%w: informe o CEP ou a zona de destino", database.ErrShippingInvalid)
+}
+
+// shippingDimensions retorna as medidas de envio do produto. Kits sem medidas
+// próprias são enviados como a soma do peso e do volume dos componentes.
+func (s *ShippingService) shippingDimensions(product *models.Product) (*models.Dimensions, bool) {
+	if product.Dimensoes != nil {
+		return product.Dimensoes, true
+	}
+	if !product.IsKit() {
+		return nil, false
+	}
+
+	_, components, err := s.repo.GetKitComponents(product.ID)
+	if err != nil || len(components) != len(product.Componentes) {
+		return nil, false
+	}
+
+	var peso, volume float64
+	for i, component := range components {
+		if component.Dimensoes == nil {
+			return nil, false
+		}
+		quantidade := float64(product.Componentes[i].Quantidade)
+		peso += component.Dimensoes.PesoKg * quantidade
+		volume += component.Dimensoes.VolumeCm3() * quantidade
+	}
+
+	// Representa o volume somado como um cubo equivalente
+	lado := math.Cbrt(volume)
+	return &models.Dimensions{PesoKg: peso, ComprimentoCm: lado, LarguraCm: lado, AlturaCm: lado}, true
+}
+
+// roundWeight arredonda pesos para gramas
+func roundWeight(kg float64) float64 {
+	return math.Round(kg*1000) / 1000
+}
+
+// toDimensions converte as medidas da requisição para o modelo
+func toDimensions(req *dtos.DimensionsRequest) *models.Dimensions {
+	if req == nil {
+		return nil
+	}
+	return &models.Dimensions{
+		PesoKg:        req.PesoKg,
+		ComprimentoCm: req.ComprimentoCm,
+		LarguraCm:     req.LarguraCm,
+		AlturaCm:      req.AlturaCm,
+		Embalagem:     strings.TrimSpace(req.Embalagem),
+	}
+}
+
+// toDimensionsResponse converte as medidas do produto para DTO, com o peso
+// cubado pelo fator padrão
+func toDimensionsResponse(dimensoes *models.Dimensions) *dtos.DimensionsResponse {
+	if dimensoes == nil {
+		return nil
+	}
+	return &dtos.DimensionsResponse{
+		PesoKg:        dimensoes.PesoKg,
+		ComprimentoCm: dimensoes.ComprimentoCm,
+		LarguraCm:     dimensoes.LarguraCm,
+		AlturaCm:      dimensoes.AlturaCm,
+		Embalagem:     dimensoes.Embalagem,
+		VolumeCm3:     math.Round(dimensoes.VolumeCm3()),
+		PesoCubadoKg:  roundWeight(dimensoes.CubedWeight(models.DefaultCubingFactor)),
+	}
+}
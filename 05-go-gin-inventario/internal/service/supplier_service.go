@@ -0,0 +1,211 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+	"inventario-api/internal/repository"
+)
+
+// SupplierService implementa a lógica de negócio para fornecedores e seus produtos
+type SupplierService struct {
+	repo repository.SupplierRepository
+}
+
+// NewSupplierService cria uma nova instância do service
+func NewSupplierService(repo repository.SupplierRepository) *SupplierService {
+	return &SupplierService{
+		repo: repo,
+	}
+}
+
+// CreateSupplier cadastra um novo fornecedor
+func (s *SupplierService) CreateSupplier(req *dtos.SupplierRequest) (*dtos.SupplierResponse, error) {
+	supplier, err := s.toSupplier(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(supplier); err != nil {
+		return nil, fmt.Errorf("erro ao criar fornecedor: %w", err)
+	}
+
+	return &dtos.SupplierResponse{Supplier: *supplier}, nil
+}
+
+// GetSupplier busca um fornecedor por ID
+func (s *SupplierService) GetSupplier(id uuid.UUID) (*dtos.SupplierResponse, error) {
+	supplier, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar fornecedor: %w", err)
+	}
+
+	return s.toSupplierResponse(supplier)
+}
+
+// ListSuppliers retorna os fornecedores cadastrados, opcionalmente só os ativos
+func (s *SupplierService) ListSuppliers(apenasAtivos bool) (*dtos.SupplierListResponse, error) {
+	suppliers, err := s.repo.List(apenasAtivos)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar fornecedores: %w", err)
+	}
+
+	responses := make([]dtos.SupplierResponse, 0, len(suppliers))
+	for _, supplier := range suppliers {
+		response, err := s.toSupplierResponse(supplier)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *response)
+	}
+
+	return &dtos.SupplierListResponse{
+		Fornecedores: responses,
+		Total:        len(responses),
+	}, nil
+}
+
+// UpdateSupplier substitui os dados cadastrais de um fornecedor
+func (s *SupplierService) UpdateSupplier(id uuid.UUID, req *dtos.SupplierRequest) (*dtos.SupplierResponse, error) {
+	supplier, err := s.toSupplier(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(id, supplier); err != nil {
+		return nil, fmt.Errorf("erro ao atualizar fornecedor: %w", err)
+	}
+
+	return s.toSupplierResponse(supplier)
+}
+
+// DeleteSupplier remove um fornecedor sem pedidos de compra em aberto
+func (s *SupplierService) DeleteSupplier(id uuid.UUID) error {
+	if err := s.repo.Delete(id); err != nil {
+		return fmt.Errorf("erro ao deletar fornecedor: %w", err)
+	}
+	return nil
+}
+
+// LinkProduct vincula um produto ao fornecedor ou atualiza o vínculo existente
+func (s *SupplierService) LinkProduct(supplierID, productID uuid.UUID, req *dtos.SupplierProductRequest) (*dtos.SupplierProductResponse, error) {
+	codigo := strings.TrimSpace(req.CodigoFornecedor)
+	if codigo == "" {
+		return nil, fmt.Errorf("código do fornecedor é obrigatório")
+	}
+
+	link, err := s.repo.LinkProduct(&models.SupplierProduct{
+		FornecedorID:     supplierID,
+		ProdutoID:        productID,
+		CodigoFornecedor: codigo,
+		PrazoEntregaDias: req.PrazoEntregaDias,
+		UltimoCusto:      req.UltimoCusto,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao vincular produto: %w", err)
+	}
+
+	response := s.toSupplierProductResponse(link)
+	return &response, nil
+}
+
+// UnlinkProduct remove o vínculo de um produto com o fornecedor
+func (s *SupplierService) UnlinkProduct(supplierID, productID uuid.UUID) error {
+	if err := s.repo.UnlinkProduct(supplierID, productID); err != nil {
+		return fmt.Errorf("erro ao desvincular produto: %w", err)
+	}
+	return nil
+}
+
+// GetSupplierProducts retorna os produtos vinculados ao fornecedor
+func (s *SupplierService) GetSupplierProducts(supplierID uuid.UUID) (*dtos.SupplierProductListResponse, error) {
+	links, err := s.repo.GetProducts(supplierID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar produtos do fornecedor: %w", err)
+	}
+
+	return s.toSupplierProductListResponse(supplierID, links), nil
+}
+
+// GetProductSuppliers retorna os fornecedores do produto, do menor prazo de entrega para o maior
+func (s *SupplierService) GetProductSuppliers(productID uuid.UUID) (*dtos.SupplierProductListResponse, error) {
+	links, err := s.repo.GetByProduct(productID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar fornecedores do produto: %w", err)
+	}
+
+	return s.toSupplierProductListResponse(productID, links), nil
+}
+
+// toSupplier valida e converte a requisição para o modelo
+func (s *SupplierService) toSupplier(req *dtos.SupplierRequest) (*models.Supplier, error) {
+	nome := strings.TrimSpace(req.Nome)
+	if len(nome) < 2 {
+		return nil, fmt.Errorf("nome do fornecedor deve ter ao menos 2 caracteres")
+	}
+
+	// O CNPJ é armazenado apenas com os dígitos
+	cnpj := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, req.CNPJ)
+	if cnpj != "" && len(cnpj) != 14 {
+		return nil, fmt.Errorf("CNPJ deve ter 14 dígitos")
+	}
+
+	ativo := true
+	if req.Ativo != nil {
+		ativo = *req.Ativo
+	}
+
+	return &models.Supplier{
+		Nome:     nome,
+		CNPJ:     cnpj,
+		Email:    strings.TrimSpace(req.Email),
+		Telefone: strings.TrimSpace(req.Telefone),
+		Ativo:    ativo,
+	}, nil
+}
+
+// toSupplierResponse converte o modelo para a resposta com a contagem de produtos
+func (s *SupplierService) toSupplierResponse(supplier *models.Supplier) (*dtos.SupplierResponse, error) {
+	links, err := s.repo.GetProducts(supplier.ID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar produtos do fornecedor: %w", err)
+	}
+
+	return &dtos.SupplierResponse{
+		Supplier:      *supplier,
+		TotalProdutos: len(links),
+	}, nil
+}
+
+// toSupplierProductListResponse monta a lista de vínculos
+func (s *SupplierService) toSupplierProductListResponse(id uuid.UUID, links []*database.SupplierProductLink) *dtos.SupplierProductListResponse {
+	itens := make([]dtos.SupplierProductResponse, len(links))
+	for i, link := range links {
+		itens[i] = s.toSupplierProductResponse(link)
+	}
+
+	return &dtos.SupplierProductListResponse{
+		ID:    id,
+		Itens: itens,
+		Total: len(itens),
+	}
+}
+
+// toSupplierProductResponse converte um vínculo para a resposta
+func (s *SupplierService) toSupplierProductResponse(link *database.SupplierProductLink) dtos.SupplierProductResponse {
+	return dtos.SupplierProductResponse{
+		SupplierProduct: *link.Vinculo,
+		FornecedorNome:  link.Fornecedor.Nome,
+		ProdutoNome:     link.Produto.Nome,
+	}
+}
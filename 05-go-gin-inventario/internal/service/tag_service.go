@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+)
+
+// AddProductTags adiciona tags a um produto, mantendo as já existentes
+func (s *ProductService) AddProductTags(id uuid.UUID, req *dtos.AddTagsRequest) (*dtos.ProductTagsResponse, error) {
+	products, err := s.repo.UpdateTags(database.TagChange{
+		ProdutoIDs: []uuid.UUID{id},
+		Adicionar:  req.Tags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao adicionar tags: %w", err)
+	}
+
+	response := toProductTagsResponse(products[0])
+	return &response, nil
+}
+
+// RemoveProductTag remove uma tag de um produto
+func (s *ProductService) RemoveProductTag(id uuid.UUID, tag string) (*dtos.ProductTagsResponse, error) {
+	product, err := s.repo.RemoveTag(id, tag)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao remover tag: %w", err)
+	}
+
+	response := toProductTagsResponse(product)
+	return &response, nil
+}
+
+// UpdateTagsBatch adiciona e remove tags de vários produtos de uma vez; se
+// algum produto não puder ser alterado, nenhum é
+func (s *ProductService) UpdateTagsBatch(req *dtos.BulkTagRequest) (*dtos.BulkTagResponse, error) {
+	products, err := s.repo.UpdateTags(database.TagChange{
+		ProdutoIDs: req.ProdutoIDs,
+		Adicionar:  req.Adicionar,
+		Remover:    req.Remover,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao alterar tags em lote: %w", err)
+	}
+
+	responses := make([]dtos.ProductTagsResponse, len(products))
+	for i, product := range products {
+		responses[i] = toProductTagsResponse(product)
+	}
+
+	return &dtos.BulkTagResponse{
+		Produtos: responses,
+		Total:    len(responses),
+	}, nil
+}
+
+// GetTagCloud retorna as tags em uso com a contagem de produtos e um peso
+// de 1 a 5 proporcional ao uso. minimo descarta tags com menos produtos e
+// limite, quando positivo, mantém apenas as mais usadas.
+func (s *ProductService) GetTagCloud(minimo, limite int) (*dtos.TagCloudResponse, error) {
+	counts, err := s.repo.GetTagCloud()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar tags: %w", err)
+	}
+
+	filtered := make([]models.TagCount, 0, len(counts))
+	for _, count := range counts {
+		if count.TotalProdutos >= minimo {
+			filtered = append(filtered, count)
+		}
+	}
+	if limite > 0 && len(filtered) > limite {
+		filtered = filtered[:limite]
+	}
+
+	// As contagens vêm ordenadas da mais usada para a menos usada
+	var maior, menor int
+	if len(filtered) > 0 {
+		maior = filtered[0].TotalProdutos
+		menor = filtered[len(filtered)-1].TotalProdutos
+	}
+
+	items := make([]dtos.TagCloudItem, len(filtered))
+	for i, count := range filtered {
+		peso := 1
+		if maior > menor {
+			peso = 1 + (count.TotalProdutos-menor)*4/(maior-menor)
+		}
+		items[i] = dtos.TagCloudItem{
+			Tag:            count.Tag,
+			TotalProdutos:  count.TotalProdutos,
+			ProdutosAtivos: count.ProdutosAtivos,
+			Peso:           peso,
+		}
+	}
+
+	return &dtos.TagCloudResponse{
+		Tags:  items,
+		Total: len(items),
+	}, nil
+}
+
+// toProductTagsResponse converte o produto para o DTO de tags
+func toProductTagsResponse(product *models.Product) dtos.ProductTagsResponse {
+	return dtos.ProductTagsResponse{
+		ProdutoID: product.ID,
+		Nome:      product.Nome,
+		Tags:      append([]string{}, product.Tags...),
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventario-api/internal/database"
+	"inventario-api/internal/dtos"
+	"inventario-api/internal/models"
+)
+
+// GetProductTranslations retorna os textos do produto em todos os idiomas
+func (s *ProductService) GetProductTranslations(id uuid.UUID) (*dtos.ProductTranslationsResponse, error) {
+	product, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar traduções: %w", err)
+	}
+
+	return toProductTranslationsResponse(product), nil
+}
+
+// SetProductTranslation cria ou substitui a tradução do produto para o idioma
+func (s *ProductService) SetProductTranslation(id uuid.UUID, idioma string, req *dtos.TranslationRequest) (*dtos.ProductTranslationsResponse, error) {
+	locale, err := parseTranslationLocale(idioma)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.repo.SetTranslation(id, locale, models.ProductTranslation{
+		Nome:      req.Nome,
+		Descricao: req.Descricao,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao salvar tradução: %w", err)
+	}
+
+	return toProductTranslationsResponse(product), nil
+}
+
+// DeleteProductTranslation remove a tradução do produto para o idioma
+func (s *ProductService) DeleteProductTranslation(id uuid.UUID, idioma string) (*dtos.ProductTranslationsResponse, error) {
+	locale, err := parseTranslationLocale(idioma)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.repo.DeleteTranslation(id, locale)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao remover tradução: %w", err)
+	}
+
+	return toProductTranslationsResponse(product), nil
+}
+
+// parseTranslationLocale interpreta o idioma informado na rota; variantes
+// regionais, como "es-AR", usam o idioma base
+func parseTranslationLocale(idioma string) (models.Locale, error) {
+	locale, ok := models.ParseLocale(idioma)
+	if !ok {
+		return "", fmt.Errorf("%w: idioma %q não suportado (use %v)", database.ErrTranslationInvalid, idioma, models.SupportedLocales)
+	}
+	return locale, nil
+}
+
+func toProductTranslationsResponse(product *models.Product) *dtos.ProductTranslationsResponse {
+	response := &dtos.ProductTranslationsResponse{
+		ProdutoID:         product.ID,
+		IdiomaPadrao:      models.DefaultLocale,
+		Nome:              product.Nome,
+		Descricao:         product.Descricao,
+		Traducoes:         make(map[models.Locale]models.ProductTranslation, len(product.Traducoes)),
+		IdiomasSuportados: models.SupportedLocales,
+		IdiomasPendentes:  []models.Locale{},
+	}
+
+	for _, locale := range models.SupportedLocales {
+		if locale.IsDefault() {
+			continue
+		}
+		if translation, ok := product.Translation(locale); ok {
+			response.Traducoes[locale] = translation
+		} else {
+			response.IdiomasPendentes = append(response.IdiomasPendentes, locale)
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileStorage define a interface para gravação de arquivos enviados
+type FileStorage interface {
+	Save(name string, data []byte) error
+	Delete(name string) error
+	DeleteDir(dir string) error
+}
+
+// LocalStorage implementa FileStorage gravando os arquivos em um diretório
+// local. Os nomes são caminhos relativos ao diretório raiz, separados por "/".
+type LocalStorage struct {
+	root string
+}
+
+// NewLocalStorage cria o armazenamento, criando o diretório raiz se necessário
+func NewLocalStorage(root string) (*LocalStorage, error) {
+	absolute, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("diretório de arquivos inválido: %w", err)
+	}
+	if err := os.MkdirAll(absolute, 0o755); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório de arquivos: %w", err)
+	}
+
+	return &LocalStorage{
+		root: absolute,
+	}, nil
+}
+
+// Root retorna o caminho absoluto do diretório raiz
+func (s *LocalStorage) Root() string {
+	return s.root
+}
+
+// Save grava o arquivo de forma atômica: o conteúdo vai para um arquivo
+// temporário no mesmo diretório, renomeado ao final da escrita
+func (s *LocalStorage) Save(name string, data []byte) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("erro ao criar diretório de arquivos: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("erro ao gravar arquivo: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("erro ao gravar arquivo: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("erro ao gravar arquivo: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("erro ao gravar arquivo: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("erro ao gravar arquivo: %w", err)
+	}
+
+	return nil
+}
+
+// Delete remove um arquivo; arquivos inexistentes são ignorados
+func (s *LocalStorage) Delete(name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("erro ao remover arquivo: %w", err)
+	}
+	return nil
+}
+
+// DeleteDir remove um subdiretório e todo o seu conteúdo
+func (s *LocalStorage) DeleteDir(dir string) error {
+	path, err := s.path(dir)
+	if err != nil {
+		return err
+	}
+	if path == s.root {
+		return fmt.Errorf("o diretório raiz de arquivos não pode ser removido")
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("erro ao remover diretório: %w", err)
+	}
+	return nil
+}
+
+// path resolve o nome relativo dentro do diretório raiz, recusando nomes
+// absolutos ou que escapem dele
+func (s *LocalStorage) path(name string) (string, error) {
+	if name == "" || filepath.IsAbs(name) {
+		return "", fmt.Errorf("nome de arquivo inválido: %q", name)
+	}
+	path := filepath.Join(s.root, filepath.FromSlash(name))
+	if path != s.root && !strings.HasPrefix(path, s.root+string(filepath.Separator)) {
+		return "", fmt.Errorf("nome de arquivo inválido: %q", name)
+	}
+	return path, nil
+}
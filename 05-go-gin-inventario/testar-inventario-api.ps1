@@ -115,11 +115,16 @@ if ($produtoCriado1) {
     }
 }
 
-# 5. Teste PUT - Atualizar produto
+# 5. Teste PUT - Substituir produto (representação completa)
 if ($produtoCriado1) {
     $atualizacao = @{
+        nome = $produtoCriado1.nome
+        categoria = $produtoCriado1.categoria
+        ativo = $true
         preco = 7599.99
-        quantidade = 20
+        preco_custo = 5899.90
+        estoque_minimo = $produtoCriado1.estoque_minimo
+        quantidade_reposicao = $produtoCriado1.quantidade_reposicao
         descricao = "Tablet profissional com chip M2, tela Liquid Retina XDR e WiFi 6E"
     }
     
@@ -127,7 +132,7 @@ if ($produtoCriado1) {
     
     if ($produtoAtualizado) {
         Write-Host "Novo preço: $($produtoAtualizado.preco_formatado)" -ForegroundColor Cyan
-        Write-Host "Nova quantidade: $($produtoAtualizado.quantidade)" -ForegroundColor Cyan
+        Write-Host "Quantidade mantida: $($produtoAtualizado.quantidade)" -ForegroundColor Cyan
     }
 }
 